@@ -9,9 +9,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -20,27 +23,32 @@ import (
 
 	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/provider"
+	"github.com/gardener/diki/pkg/provider/registry"
 	"github.com/gardener/diki/pkg/report"
+	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
 )
 
 // NewDikiCommand creates a new command that is used to start Diki.
-func NewDikiCommand(ctx context.Context, providerCreateFuncs map[string]provider.ProviderFromConfigFunc) *cobra.Command {
-	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})
-	logger := slog.New(handler)
-	slog.SetDefault(logger)
-
+func NewDikiCommand(ctx context.Context, providers *registry.ProviderRegistry) *cobra.Command {
+	var logOpts logOptions
 	rootCmd := &cobra.Command{
 		Use:   "diki",
 		Short: "Diki a \"compliance checker\" or sorts, a detective control framework.",
 		Long: `Diki a "compliance checker" or sorts, a detective control framework. 
 It is part of the Gardener family, but can be used also on other Kubernetes distros or even on non-Kubernetes environments, 
 e.g. to check compliance of your hyperscaler accounts.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return setDefaultLogger(os.Stderr, logOpts)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
+	addLogFlags(rootCmd, &logOpts)
+
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Show version details.",
@@ -62,7 +70,7 @@ e.g. to check compliance of your hyperscaler accounts.`,
 		Short: "Run some rulesets and rules.",
 		Long:  `Run allows running rulesets and rules for the given provider(s).`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCmd(ctx, providerCreateFuncs, opts)
+			return runCmd(ctx, providers, opts)
 		},
 	}
 
@@ -80,83 +88,357 @@ e.g. to check compliance of your hyperscaler accounts.`,
 	}
 
 	addReportFlags(reportCmd, &reportOpts)
+
+	var trendOpts trendOptions
+	trendCmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Trend shows the development of multiple output files over time.",
+		Long:  `Trend orders output files by their time and shows the rule status counts, the rule status changes and the persistent failures over time.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return trendCmd(args, reportOpts, trendOpts)
+		},
+	}
+
+	addTrendFlags(trendCmd, &trendOpts)
+	reportCmd.AddCommand(trendCmd)
 	rootCmd.AddCommand(reportCmd)
+
+	rulesetCmd := &cobra.Command{
+		Use:   "ruleset",
+		Short: "Show information about the versions of rulesets.",
+		Long:  `Show information about the versions of rulesets.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	var diffOpts diffOptions
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Diff shows the rule level differences between two ruleset versions.",
+		Long:  `Diff shows the rules which were added, removed or changed between two versions of a ruleset.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffCmd(cmd.OutOrStdout(), providers, diffOpts)
+		},
+	}
+
+	addDiffFlags(diffCmd, &diffOpts)
+	rulesetCmd.AddCommand(diffCmd)
+
+	var changelogRulesetID string
+	changelogCmd := &cobra.Command{
+		Use:   "changelog",
+		Short: "Changelog shows the changes of all versions of a ruleset.",
+		Long:  `Changelog prints a markdown changelog with the rules which were added, removed or changed by every version of a ruleset.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return changelogCmd(cmd.OutOrStdout(), providers, changelogRulesetID)
+		},
+	}
+
+	changelogCmd.Flags().StringVar(&changelogRulesetID, "ruleset-id", "", "The id of the ruleset.")
+	rulesetCmd.AddCommand(changelogCmd)
+
+	var migrateOpts migrateOptions
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate rewrites the configuration of a ruleset for another ruleset version.",
+		Long: `Migrate prints the configuration file with all rulesets of the --from version changed to the --to version.
+Rule options of retired rules are removed with a warning.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrateCmd(cmd.OutOrStdout(), providers, migrateOpts)
+		},
+	}
+
+	addMigrateFlags(migrateCmd, &migrateOpts)
+	rulesetCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(rulesetCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	return rootCmd
 }
 
+func addLogFlags(cmd *cobra.Command, opts *logOptions) {
+	cmd.PersistentFlags().StringVar(&opts.format, "log-format", "json", "Format of the log messages. One of: json, text.")
+	cmd.PersistentFlags().StringVar(&opts.level, "log-level", "info", "Minimal level of the log messages. One of: debug, info, warn, error.")
+}
+
+func setDefaultLogger(w io.Writer, opts logOptions) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(opts.level)); err != nil {
+		return fmt.Errorf("unsupported log level: %s", opts.level)
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch opts.format {
+	case "json":
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	case "text":
+		handler = slog.NewTextHandler(w, handlerOpts)
+	default:
+		return fmt.Errorf("unsupported log format: %s", opts.format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
 func addRunFlags(cmd *cobra.Command, opts *runOptions) {
 	cmd.PersistentFlags().StringVar(&opts.configFile, "config", "", "Configuration file for diki containing info about providers and rulesets.")
 	cmd.PersistentFlags().BoolVar(&opts.all, "all", false, "If set to true diki will run all rulesets for all known providers.")
 	cmd.PersistentFlags().StringVar(&opts.provider, "provider", "", "The provider that should be used to run checks.")
 	cmd.PersistentFlags().StringVar(&opts.rulesetID, "ruleset-id", "", "The id of the ruleset that should be run. If provided --ruleset-version should also be set. If both flags are empty all rulesets for the provider will be run.")
 	cmd.PersistentFlags().StringVar(&opts.rulesetVersion, "ruleset-version", "", "The version of the ruleset that should be run. If provided --ruleset-id should also be set. If both flags are empty all rulesets for the provider will be run.")
-	cmd.PersistentFlags().StringVar(&opts.ruleID, "rule-id", "", "If set only the rule with the provided id will be run.")
+	cmd.PersistentFlags().StringSliceVar(&opts.ruleIDs, "rule-id", nil, "If set only the rules with the provided ids will be run. Can be repeated or passed as a comma separated list.")
 }
 
 func addReportFlags(cmd *cobra.Command, opts *reportOptions) {
-	cmd.PersistentFlags().StringVar(&opts.output, "output", "html", "Output type.")
-	cmd.PersistentFlags().Var(cliflag.NewMapStringString(&opts.distinctBy), "distinct-by", "If set generates a merged report. The keys are the IDs for the providers which the merged report will include and the values are distinct metadata attributes to be used as IDs for the different reports.")
+	cmd.PersistentFlags().StringVar(&opts.output, "output", "html", "Output type. One of: html, json.")
+	cmd.PersistentFlags().Var(cliflag.NewMapStringString(&opts.distinctBy), "distinct-by", "If set generates a merged report. The keys are the IDs for the providers which the merged report will include and the values are distinct metadata attributes to be used as IDs for the different reports. Providers of already merged reports are always included and keep their distinct attributes.")
 }
 
-func reportCmd(args []string, opts reportOptions) error {
+func addTrendFlags(cmd *cobra.Command, opts *trendOptions) {
+	cmd.Flags().IntVar(&opts.maxPersistentFailures, "max-persistent-failures", 10, "The maximum number of persistent failures included in the trend.")
+}
+
+func addDiffFlags(cmd *cobra.Command, opts *diffOptions) {
+	cmd.Flags().StringVar(&opts.rulesetID, "ruleset-id", "", "The id of the ruleset.")
+	cmd.Flags().StringVar(&opts.from, "from", "", "The ruleset version which is compared.")
+	cmd.Flags().StringVar(&opts.to, "to", "", "The ruleset version which the --from version is compared to.")
+	cmd.Flags().StringVar(&opts.output, "output", "text", "Output type. One of: text, json.")
+}
+
+func diffCmd(w io.Writer, providerRegistry *registry.ProviderRegistry, opts diffOptions) error {
+	if len(opts.rulesetID) == 0 || len(opts.from) == 0 || len(opts.to) == 0 {
+		return errors.New("diff command requires the --ruleset-id, --from and --to flags")
+	}
+
+	h, err := providerRegistry.History(opts.rulesetID)
+	if err != nil {
+		return err
+	}
+
+	diff, err := h.Diff(opts.from, opts.to)
+	if err != nil {
+		return err
+	}
+
+	switch opts.output {
+	case "json":
+		return json.NewEncoder(w).Encode(diff)
+	case "text":
+		fmt.Fprintf(w, "Ruleset %s %s -> %s\n", diff.RulesetID, diff.From, diff.To)
+		for _, section := range []struct {
+			title string
+			rules []string
+		}{
+			{"Added", diff.Added},
+			{"Removed", diff.Removed},
+			{"Changed", diff.Changed},
+		} {
+			fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.rules))
+			for _, id := range section.rules {
+				fmt.Fprintf(w, "  %s\n", id)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output type: %s", opts.output)
+	}
+}
+
+func addMigrateFlags(cmd *cobra.Command, opts *migrateOptions) {
+	cmd.Flags().StringVar(&opts.configFile, "config", "", "Configuration file for diki which should be migrated.")
+	cmd.Flags().StringVar(&opts.rulesetID, "ruleset-id", "", "The id of the ruleset.")
+	cmd.Flags().StringVar(&opts.from, "from", "", "The ruleset version of the current configuration.")
+	cmd.Flags().StringVar(&opts.to, "to", "", "The ruleset version which the configuration is migrated to.")
+}
+
+func migrateCmd(w io.Writer, providerRegistry *registry.ProviderRegistry, opts migrateOptions) error {
+	if len(opts.configFile) == 0 || len(opts.rulesetID) == 0 || len(opts.from) == 0 || len(opts.to) == 0 {
+		return errors.New("migrate command requires the --config, --ruleset-id, --from and --to flags")
+	}
+
+	h, err := providerRegistry.History(opts.rulesetID)
+	if err != nil {
+		return err
+	}
+
+	diff, err := h.Diff(opts.from, opts.to)
+	if err != nil {
+		return err
+	}
+
+	c, err := readConfig(opts.configFile)
+	if err != nil {
+		return err
+	}
+
+	var migrated int
+	for _, providerConfig := range c.Providers {
+		for i, rulesetConfig := range providerConfig.Rulesets {
+			if rulesetConfig.ID != opts.rulesetID || rulesetConfig.Version != opts.from {
+				continue
+			}
+
+			ruleOptions, retired := diff.MigrateRuleOptions(rulesetConfig.RuleOptions)
+			for _, opt := range retired {
+				slog.Warn("removed rule options of retired rule", "provider", providerConfig.ID, "rule", opt.RuleID)
+			}
+			if len(rulesetConfig.Tailoring) > 0 {
+				slog.Warn("tailoring profile has to be migrated manually", "provider", providerConfig.ID, "tailoring", rulesetConfig.Tailoring)
+			}
+
+			providerConfig.Rulesets[i].Version = opts.to
+			providerConfig.Rulesets[i].RuleOptions = ruleOptions
+			migrated++
+		}
+	}
+
+	if migrated == 0 {
+		return fmt.Errorf("configuration does not contain ruleset %s version %s", opts.rulesetID, opts.from)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func changelogCmd(w io.Writer, providerRegistry *registry.ProviderRegistry, rulesetID string) error {
+	if len(rulesetID) == 0 {
+		return errors.New("changelog command requires the --ruleset-id flag")
+	}
+
+	h, err := providerRegistry.History(rulesetID)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, h.Changelog())
+	return err
+}
+
+func trendCmd(args []string, rOpts reportOptions, opts trendOptions) error {
 	if len(args) == 0 {
-		return errors.New("report command requires a minimum of one filepath argument")
+		return errors.New("trend command requires a minimum of one filepath argument")
 	}
 
-	if len(args) > 1 && len(opts.distinctBy) == 0 {
-		return errors.New("report command requires a single filepath argument when the distinct-by flag is not set")
+	if len(rOpts.distinctBy) > 0 {
+		return errors.New("trend command does not support the distinct-by flag")
 	}
 
-	if opts.output != "html" {
-		return fmt.Errorf("unsuported output format: %s", opts.output)
+	if rOpts.output != "html" && rOpts.output != "json" {
+		return fmt.Errorf("unsuported output format: %s", rOpts.output)
+	}
+
+	reports, mergedReports, err := readReports(args)
+	if err != nil {
+		return err
 	}
 
-	reports := []*report.Report{}
-	for _, arg := range args {
-		fileData, err := os.ReadFile(filepath.Clean(arg))
+	if len(mergedReports) > 0 {
+		return errors.New("trend command does not support merged reports")
+	}
+
+	trend, err := report.NewTrend(reports, report.MaxPersistentFailures(opts.maxPersistentFailures))
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(os.Stdout, rOpts.output, trend)
+}
+
+// readReports reads report files and separates
+// them into plain reports and merged reports.
+func readReports(filePaths []string) ([]*report.Report, []*report.MergedReport, error) {
+	var (
+		reports       []*report.Report
+		mergedReports []*report.MergedReport
+	)
+	for _, filePath := range filePaths {
+		fileData, err := os.ReadFile(filepath.Clean(filePath))
 		if err != nil {
-			return fmt.Errorf("failed to read file %s: %w", arg, err)
+			return nil, nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 		}
 
-		// TODO: handle report types
-		rep := &report.Report{}
-		if err := json.Unmarshal(fileData, rep); err != nil {
-			return fmt.Errorf("failed to unmarshal data: %w", err)
+		rep, err := report.Parse(fileData)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+
+		switch r := rep.(type) {
+		case *report.Report:
+			reports = append(reports, r)
+		case *report.MergedReport:
+			mergedReports = append(mergedReports, r)
 		}
+	}
+	return reports, mergedReports, nil
+}
 
-		reports = append(reports, rep)
+// writeOutput writes a report, merged report or trend
+// into the passed writer in the given output format.
+func writeOutput(w io.Writer, output string, rep any) error {
+	if output == "json" {
+		return json.NewEncoder(w).Encode(rep)
 	}
 
 	htlmRenderer, err := report.NewHTMLRenderer()
 	if err != nil {
 		return fmt.Errorf("failed to initialize renderer: %w", err)
 	}
+	return htlmRenderer.Render(w, rep)
+}
+
+func reportCmd(args []string, opts reportOptions) error {
+	if len(args) == 0 {
+		return errors.New("report command requires a minimum of one filepath argument")
+	}
 
-	if len(opts.distinctBy) > 0 {
-		mergedReport, err := report.MergeReport(reports, opts.distinctBy)
-		if err != nil {
-			return err
+	if opts.output != "html" && opts.output != "json" {
+		return fmt.Errorf("unsuported output format: %s", opts.output)
+	}
+
+	reports, mergedReports, err := readReports(args)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.distinctBy) == 0 {
+		switch {
+		case len(reports) == 1 && len(mergedReports) == 0:
+			return writeOutput(os.Stdout, opts.output, reports[0])
+		case len(reports) == 0 && len(mergedReports) == 1:
+			return writeOutput(os.Stdout, opts.output, mergedReports[0])
+		case len(mergedReports) == 0:
+			return errors.New("report command requires a single filepath argument when the distinct-by flag is not set")
 		}
-		return htlmRenderer.Render(os.Stdout, mergedReport)
 	}
 
-	return htlmRenderer.Render(os.Stdout, reports[0])
+	mergedReport, err := report.Merge(reports, mergedReports, opts.distinctBy)
+	if err != nil {
+		return err
+	}
+	return writeOutput(os.Stdout, opts.output, mergedReport)
 }
 
-func runCmd(ctx context.Context, providerCreateFuncs map[string]provider.ProviderFromConfigFunc, opts runOptions) error {
+func runCmd(ctx context.Context, providerRegistry *registry.ProviderRegistry, opts runOptions) error {
 	dikiConfig, err := readConfig(opts.configFile)
 	if err != nil {
 		return err
 	}
 
-	providers, err := getProvidersFromConfig(dikiConfig, providerCreateFuncs)
-	if err != nil {
+	if err := setPluginPaths(dikiConfig); err != nil {
 		return err
 	}
 
-	// outputPath := dikiConfig.Output.Path
+	providers, err := getProvidersFromConfig(ctx, dikiConfig, providerRegistry)
+	if err != nil {
+		return err
+	}
 
 	if opts.all {
 		providerResults := []provider.ProviderResult{}
@@ -168,15 +450,7 @@ func runCmd(ctx context.Context, providerCreateFuncs map[string]provider.Provide
 			providerResults = append(providerResults, res)
 		}
 
-		if dikiConfig.Output != nil && dikiConfig.Output.Path != "" {
-			opts := []report.ReportOption{}
-			if dikiConfig.Output.MinStatus != "" {
-				opts = append(opts, report.MinStatus(dikiConfig.Output.MinStatus))
-			}
-			rep := report.FromProviderResults(providerResults, opts...)
-			return rep.WriteToFile(dikiConfig.Output.Path)
-		}
-		return nil
+		return writeReport(dikiConfig.Output, providerResults, nil)
 	}
 
 	p, ok := providers[opts.provider]
@@ -186,63 +460,153 @@ func runCmd(ctx context.Context, providerCreateFuncs map[string]provider.Provide
 
 	switch {
 	case opts.rulesetID == "" && opts.rulesetVersion == "":
+		if len(opts.ruleIDs) > 0 {
+			return errors.New("--ruleset-id and --ruleset-version should be set along with --rule-id")
+		}
 		// run all rulesets for the provider
 		res, err := p.RunAll(ctx)
 		if err != nil {
 			return err
 		}
-		providerResults := []provider.ProviderResult{res}
 
-		if dikiConfig.Output != nil && dikiConfig.Output.Path != "" {
-			opts := []report.ReportOption{}
-			if dikiConfig.Output.MinStatus != "" {
-				opts = append(opts, report.MinStatus(dikiConfig.Output.MinStatus))
-			}
-			rep := report.FromProviderResults(providerResults, opts...)
-			return rep.WriteToFile(dikiConfig.Output.Path)
-		}
-		return nil
+		return writeReport(dikiConfig.Output, []provider.ProviderResult{res}, nil)
 	case opts.rulesetID != "" && opts.rulesetVersion == "":
 		return errors.New("--ruleset-version should be set along with --ruleset-id")
 	case opts.rulesetID == "" && opts.rulesetVersion != "":
 		return errors.New("--ruleset-id should be set along with --ruleset-version")
 	}
 
-	if opts.ruleID == "" {
+	if len(opts.ruleIDs) == 0 {
 		// run the whole ruleset
 		res, err := p.RunRuleset(ctx, opts.rulesetID, opts.rulesetVersion)
 		if err != nil {
 			return err
 		}
-		providerResults := []provider.ProviderResult{{ProviderID: p.ID(), ProviderName: p.Name(), RulesetResults: []ruleset.RulesetResult{res}}}
 
-		if dikiConfig.Output != nil && dikiConfig.Output.Path != "" {
-			opts := []report.ReportOption{}
-			if dikiConfig.Output.MinStatus != "" {
-				opts = append(opts, report.MinStatus(dikiConfig.Output.MinStatus))
+		return writeReport(dikiConfig.Output, []provider.ProviderResult{providerResult(p, res)}, nil)
+	}
+
+	res, err := runRules(ctx, p, opts.rulesetID, opts.rulesetVersion, opts.ruleIDs)
+	if err != nil {
+		return err
+	}
+	if rulesetConfig, ok := findRulesetConfig(dikiConfig, p.ID(), opts.rulesetID, opts.rulesetVersion); ok {
+		res.RulesetName = rulesetConfig.Name
+		if len(rulesetConfig.Tailoring) > 0 {
+			if res.Tailoring, err = tailoring.Load(rulesetConfig.Tailoring); err != nil {
+				return err
 			}
-			rep := report.FromProviderResults(providerResults, opts...)
-			return rep.WriteToFile(dikiConfig.Output.Path)
 		}
-		return nil
 	}
 
-	return runRule(ctx, p, opts.rulesetID, opts.rulesetVersion, opts.ruleID)
+	// single rule runs are usually used for debugging purposes
+	// so the report is printed when no output path is configured
+	return writeReport(dikiConfig.Output, []provider.ProviderResult{providerResult(p, res)}, os.Stdout)
 }
 
-func runRule(ctx context.Context, p provider.Provider, rulesetID, rulesetVersion, ruleID string) error {
-	res, err := p.RunRule(ctx, rulesetID, rulesetVersion, ruleID)
-	if err != nil {
-		return err
+// runRules runs the rules with the given ids and
+// collects their results into a single [ruleset.RulesetResult].
+func runRules(ctx context.Context, p provider.Provider, rulesetID, rulesetVersion string, ruleIDs []string) (ruleset.RulesetResult, error) {
+	result := ruleset.RulesetResult{
+		RulesetID:      rulesetID,
+		RulesetVersion: rulesetVersion,
+		RuleResults:    make([]rule.RuleResult, 0, len(ruleIDs)),
 	}
 
-	j, err := json.Marshal(res)
-	if err != nil {
-		return err
+	ruleIDs = slices.Clone(ruleIDs)
+	slices.Sort(ruleIDs)
+	for _, ruleID := range slices.Compact(ruleIDs) {
+		res, err := p.RunRule(ctx, rulesetID, rulesetVersion, ruleID)
+		if err != nil {
+			return ruleset.RulesetResult{}, err
+		}
+		result.RuleResults = append(result.RuleResults, res)
 	}
 
-	fmt.Print(string(j))
-	return nil
+	return result, nil
+}
+
+// findRulesetConfig returns the configuration of a ruleset for the given provider.
+func findRulesetConfig(c *config.DikiConfig, providerID, rulesetID, rulesetVersion string) (config.RulesetConfig, bool) {
+	for _, providerConfig := range c.Providers {
+		if providerConfig.ID != providerID {
+			continue
+		}
+		for _, rulesetConfig := range providerConfig.Rulesets {
+			if rulesetConfig.ID == rulesetID && rulesetConfig.Version == rulesetVersion {
+				return rulesetConfig, true
+			}
+		}
+	}
+	return config.RulesetConfig{}, false
+}
+
+func providerResult(p provider.Provider, rulesetResult ruleset.RulesetResult) provider.ProviderResult {
+	return provider.ProviderResult{
+		ProviderID:     p.ID(),
+		ProviderName:   p.Name(),
+		Metadata:       maps.Clone(p.Metadata()),
+		RulesetResults: []ruleset.RulesetResult{rulesetResult},
+	}
+}
+
+// writeReport creates a [report.Report] from the provider results and writes it to the
+// configured output path. If no output path is configured the report is written to fallback.
+// Nothing is written when both are missing.
+func writeReport(outputConfig *config.OutputConfig, providerResults []provider.ProviderResult, fallback io.Writer) error {
+	reportOpts := []report.ReportOption{}
+	if outputConfig != nil && outputConfig.MinStatus != "" {
+		reportOpts = append(reportOpts, report.MinStatus(outputConfig.MinStatus))
+	}
+	if outputConfig != nil && outputConfig.Score != nil {
+		weights, err := scoreWeights(outputConfig.Score)
+		if err != nil {
+			return err
+		}
+		reportOpts = append(reportOpts, weights)
+	}
+	rep := report.FromProviderResults(providerResults, reportOpts...)
+
+	switch {
+	case outputConfig != nil && outputConfig.Path != "":
+		return rep.WriteToFile(outputConfig.Path)
+	case fallback != nil:
+		return rep.Write(fallback)
+	default:
+		return nil
+	}
+}
+
+// scoreWeights returns the default [report.ScoreWeights]
+// with the weights from the score configuration applied.
+func scoreWeights(scoreConfig *config.ScoreConfig) (report.ScoreWeights, error) {
+	weights := report.DefaultScoreWeights()
+	if scoreConfig.SeverityWeights != nil {
+		weights.Severities = make(map[report.Severity]float64, len(scoreConfig.SeverityWeights))
+		for severity, weight := range scoreConfig.SeverityWeights {
+			if !slices.Contains(report.Severities(), report.Severity(severity)) {
+				return report.ScoreWeights{}, fmt.Errorf("unknown severity in score weights: %s", severity)
+			}
+			if weight < 0 {
+				return report.ScoreWeights{}, fmt.Errorf("score weight of severity %s must not be negative", severity)
+			}
+			weights.Severities[report.Severity(severity)] = weight
+		}
+	}
+
+	if scoreConfig.StatusWeights != nil {
+		weights.Statuses = make(map[rule.Status]float64, len(scoreConfig.StatusWeights))
+		for status, weight := range scoreConfig.StatusWeights {
+			if !slices.Contains(rule.Statuses(), rule.Status(status)) {
+				return report.ScoreWeights{}, fmt.Errorf("unknown status in score weights: %s", status)
+			}
+			if weight < 0 || weight > 1 {
+				return report.ScoreWeights{}, fmt.Errorf("score weight of status %s must be between 0 and 1", status)
+			}
+			weights.Statuses[rule.Status(status)] = weight
+		}
+	}
+	return weights, nil
 }
 
 type runOptions struct {
@@ -251,7 +615,30 @@ type runOptions struct {
 	provider       string
 	rulesetID      string
 	rulesetVersion string
-	ruleID         string
+	ruleIDs        []string
+}
+
+type trendOptions struct {
+	maxPersistentFailures int
+}
+
+type diffOptions struct {
+	rulesetID string
+	from      string
+	to        string
+	output    string
+}
+
+type migrateOptions struct {
+	configFile string
+	rulesetID  string
+	from       string
+	to         string
+}
+
+type logOptions struct {
+	format string
+	level  string
 }
 
 type reportOptions struct {
@@ -275,11 +662,28 @@ func readConfig(filePath string) (*config.DikiConfig, error) {
 	return c, nil
 }
 
-func getProvidersFromConfig(c *config.DikiConfig, providerCreateFuncs map[string]provider.ProviderFromConfigFunc) (map[string]provider.Provider, error) {
+// setPluginPaths sets the executable path of plugin rulesets without a configured
+// path to the executable diki-ruleset-<ruleset id> in the plugin directory.
+func setPluginPaths(c *config.DikiConfig) error {
+	for _, providerConfig := range c.Providers {
+		for _, rulesetConfig := range providerConfig.Rulesets {
+			if rulesetConfig.Plugin == nil || len(rulesetConfig.Plugin.Path) > 0 {
+				continue
+			}
+			if c.Plugins == nil || len(c.Plugins.Directory) == 0 {
+				return fmt.Errorf("plugin of ruleset %s does not have a path and no plugin directory is configured", rulesetConfig.ID)
+			}
+			rulesetConfig.Plugin.Path = filepath.Join(c.Plugins.Directory, "diki-ruleset-"+rulesetConfig.ID)
+		}
+	}
+	return nil
+}
+
+func getProvidersFromConfig(ctx context.Context, c *config.DikiConfig, providerRegistry *registry.ProviderRegistry) (map[string]provider.Provider, error) {
 	providers := map[string]provider.Provider{}
 	for _, providerConfig := range c.Providers {
-		if providerFunc, ok := providerCreateFuncs[providerConfig.ID]; ok {
-			p, err := providerFunc(providerConfig)
+		if providerFunc, ok := providerRegistry.Get(providerConfig.ID); ok {
+			p, err := providerFunc(ctx, providerConfig)
 			if err != nil {
 				return nil, err
 			}
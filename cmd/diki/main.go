@@ -9,16 +9,11 @@ import (
 	"log"
 
 	"github.com/gardener/diki/cmd/diki/app"
-	"github.com/gardener/diki/pkg/provider"
 	"github.com/gardener/diki/pkg/provider/builder"
 )
 
 func main() {
-	cmd := app.NewDikiCommand(context.Background(), map[string]provider.ProviderFromConfigFunc{
-		"gardener":      builder.GardenerProviderFromConfig,
-		"managedk8s":    builder.ManagedK8SProviderFromConfig,
-		"virtualgarden": builder.VirtualGardenProviderFromConfig,
-	})
+	cmd := app.NewDikiCommand(context.Background(), builder.Providers())
 
 	if err := cmd.Execute(); err != nil {
 		log.Fatal(err)
@@ -33,3 +33,7 @@ cp "${old_ruleset_file}" "${new_ruleset_file}"
 find "${new_version_dir}" -name '*.go' -exec sed -i -e "s/${old_version}/${new_version}/g" -e "s/${old_version_uppercase}/${new_version_uppercase}/g" {} \;
 sed -i -e "s/${old_version}/${new_version}/g" -e "s/${old_version_uppercase}/${new_version_uppercase}/g" "${new_ruleset_file}"
 find "${new_version_dir}" -name '*.go' -exec bash -c 'mv "$0" "$(echo "$0" | sed s/'"${old_version}/${new_version}"'/)" 2>/dev/null' {} \;
+
+# the shared rules are not copied, the new version keeps using the shared rules of the old version
+find "${new_version_dir}" -name '*.go' -exec sed -i -e "s#shared/ruleset/disak8sstig/${new_version}\"#shared/ruleset/disak8sstig/${old_version}\"#g" {} \;
+sed -i -e "s#shared/ruleset/disak8sstig/${new_version}\"#shared/ruleset/disak8sstig/${old_version}\"#g" "${new_ruleset_file}"
@@ -10,6 +10,8 @@ type DikiConfig struct {
 	Providers []ProviderConfig `yaml:"providers"`
 	// Output describes options related to diki's output configuration.
 	Output *OutputConfig `yaml:"output,omitempty"`
+	// Plugins describes options related to external ruleset plugins.
+	Plugins *PluginsConfig `yaml:"plugins,omitempty"`
 }
 
 // ProviderConfig is used to describe and configure a provider.
@@ -36,6 +38,24 @@ type RulesetConfig struct {
 	Version string `yaml:"version"`
 	// RuleOptions is used to provide per rule configurations.
 	RuleOptions []RuleOptionsConfig `yaml:"ruleOptions"`
+	// Tailoring is the path to a tailoring profile which sets
+	// organisation defined values of rule parameters.
+	Tailoring string `yaml:"tailoring,omitempty"`
+	// Args are ruleset specific arguments that each ruleset should be able to parse.
+	Args any `yaml:"args,omitempty"`
+	// Plugin marks the ruleset as implemented by an external plugin.
+	Plugin *PluginConfig `yaml:"plugin,omitempty"`
+}
+
+// PluginConfig describes an external ruleset plugin.
+type PluginConfig struct {
+	// Path is the path to the plugin executable. When empty the executable
+	// diki-ruleset-<ruleset id> is looked up in the plugin directory.
+	Path string `yaml:"path,omitempty"`
+	// Args are command line arguments passed to the plugin executable.
+	Args []string `yaml:"args,omitempty"`
+	// Timeout is the maximal duration of a single plugin invocation, e.g. 10m.
+	Timeout string `yaml:"timeout,omitempty"`
 }
 
 // RuleOptionsConfig represents per rule options.
@@ -56,10 +76,28 @@ type RuleOptionSkipConfig struct {
 	Justification string `yaml:"justification"`
 }
 
+// PluginsConfig represents options of external ruleset plugins.
+type PluginsConfig struct {
+	// Directory is the directory which contains the plugin executables.
+	Directory string `yaml:"directory,omitempty"`
+}
+
 // OutputConfig represents output configurations.
 type OutputConfig struct {
 	// Path is the location which will be used to write a diki report.
 	Path string `yaml:"path"`
 	// MinStatus is the minimal status that diki will report.
 	MinStatus string `yaml:"minStatus"`
+	// Score describes the weights used to calculate the compliance scores of a report.
+	Score *ScoreConfig `yaml:"score,omitempty"`
+}
+
+// ScoreConfig represents the weights used to calculate compliance scores.
+// Each set map replaces the corresponding default weights.
+type ScoreConfig struct {
+	// SeverityWeights maps rule severities (HIGH, MEDIUM, LOW) to the weight of the rules.
+	SeverityWeights map[string]float64 `yaml:"severityWeights,omitempty"`
+	// StatusWeights maps rule statuses to the fraction of the rule weight that is achieved.
+	// Rules with statuses that are not present are excluded from the score.
+	StatusWeights map[string]float64 `yaml:"statusWeights,omitempty"`
 }
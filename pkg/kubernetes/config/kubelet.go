@@ -22,6 +22,9 @@ type KubeletConfig struct {
 	FeatureGates                   map[string]bool       `yaml:"featureGates" json:"featureGates"`
 	ProtectKernelDefaults          *bool                 `yaml:"protectKernelDefaults" json:"protectKernelDefaults"`
 	StreamingConnectionIdleTimeout *string               `yaml:"streamingConnectionIdleTimeout" json:"streamingConnectionIdleTimeout"`
+	MakeIPTablesUtilChains         *bool                 `yaml:"makeIPTablesUtilChains" json:"makeIPTablesUtilChains"`
+	RotateCertificates             *bool                 `yaml:"rotateCertificates" json:"rotateCertificates"`
+	PodPidsLimit                   *int64                `yaml:"podPidsLimit" json:"podPidsLimit"`
 }
 
 // KubeletAuthentication describes kubelet configuration values for authentication mechanisms.
@@ -18,7 +18,9 @@ import (
 
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -91,6 +93,215 @@ func GetReplicaSets(ctx context.Context, c client.Client, namespace string, sele
 	}
 }
 
+// GetDeployments returns all deployments for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves deployments by portions set by limit.
+func GetDeployments(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]appsv1.Deployment, error) {
+	deploymentList := &appsv1.DeploymentList{}
+	deployments := []appsv1.Deployment{}
+
+	for {
+		if err := c.List(ctx, deploymentList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(deploymentList.Continue)); err != nil {
+			return nil, err
+		}
+
+		deployments = append(deployments, deploymentList.Items...)
+
+		if len(deploymentList.Continue) == 0 {
+			return deployments, nil
+		}
+	}
+}
+
+// GetStatefulSets returns all statefulSets for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves statefulSets by portions set by limit.
+func GetStatefulSets(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]appsv1.StatefulSet, error) {
+	statefulSetList := &appsv1.StatefulSetList{}
+	statefulSets := []appsv1.StatefulSet{}
+
+	for {
+		if err := c.List(ctx, statefulSetList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(statefulSetList.Continue)); err != nil {
+			return nil, err
+		}
+
+		statefulSets = append(statefulSets, statefulSetList.Items...)
+
+		if len(statefulSetList.Continue) == 0 {
+			return statefulSets, nil
+		}
+	}
+}
+
+// GetDaemonSets returns all daemonSets for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves daemonSets by portions set by limit.
+func GetDaemonSets(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]appsv1.DaemonSet, error) {
+	daemonSetList := &appsv1.DaemonSetList{}
+	daemonSets := []appsv1.DaemonSet{}
+
+	for {
+		if err := c.List(ctx, daemonSetList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(daemonSetList.Continue)); err != nil {
+			return nil, err
+		}
+
+		daemonSets = append(daemonSets, daemonSetList.Items...)
+
+		if len(daemonSetList.Continue) == 0 {
+			return daemonSets, nil
+		}
+	}
+}
+
+// GetJobs returns all jobs for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves jobs by portions set by limit.
+func GetJobs(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]batchv1.Job, error) {
+	jobList := &batchv1.JobList{}
+	jobs := []batchv1.Job{}
+
+	for {
+		if err := c.List(ctx, jobList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(jobList.Continue)); err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, jobList.Items...)
+
+		if len(jobList.Continue) == 0 {
+			return jobs, nil
+		}
+	}
+}
+
+// GetCronJobs returns all cronJobs for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves cronJobs by portions set by limit.
+func GetCronJobs(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]batchv1.CronJob, error) {
+	cronJobList := &batchv1.CronJobList{}
+	cronJobs := []batchv1.CronJob{}
+
+	for {
+		if err := c.List(ctx, cronJobList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(cronJobList.Continue)); err != nil {
+			return nil, err
+		}
+
+		cronJobs = append(cronJobs, cronJobList.Items...)
+
+		if len(cronJobList.Continue) == 0 {
+			return cronJobs, nil
+		}
+	}
+}
+
+// GetNetworkPolicies returns all networkPolicies for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves networkPolicies by portions set by limit.
+func GetNetworkPolicies(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]networkingv1.NetworkPolicy, error) {
+	networkPolicyList := &networkingv1.NetworkPolicyList{}
+	networkPolicies := []networkingv1.NetworkPolicy{}
+
+	for {
+		if err := c.List(ctx, networkPolicyList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(networkPolicyList.Continue)); err != nil {
+			return nil, err
+		}
+
+		networkPolicies = append(networkPolicies, networkPolicyList.Items...)
+
+		if len(networkPolicyList.Continue) == 0 {
+			return networkPolicies, nil
+		}
+	}
+}
+
+// DefaultDenyNetworkPolicy returns whether the network policy denies all ingress
+// and whether it denies all egress traffic of the pods in its namespace.
+func DefaultDenyNetworkPolicy(networkPolicy networkingv1.NetworkPolicy) (bool, bool) {
+	selector := networkPolicy.Spec.PodSelector
+	if len(selector.MatchLabels) > 0 || len(selector.MatchExpressions) > 0 {
+		return false, false
+	}
+
+	policyTypes := networkPolicy.Spec.PolicyTypes
+	if len(policyTypes) == 0 {
+		// policies without policy types always affect ingress and only affect egress if they have egress rules
+		policyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	}
+
+	ingress := slices.Contains(policyTypes, networkingv1.PolicyTypeIngress) && len(networkPolicy.Spec.Ingress) == 0
+	egress := slices.Contains(policyTypes, networkingv1.PolicyTypeEgress) && len(networkPolicy.Spec.Egress) == 0
+	return ingress, egress
+}
+
+// GetServices returns all services for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves services by portions set by limit.
+func GetServices(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]corev1.Service, error) {
+	serviceList := &corev1.ServiceList{}
+	services := []corev1.Service{}
+
+	for {
+		if err := c.List(ctx, serviceList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(serviceList.Continue)); err != nil {
+			return nil, err
+		}
+
+		services = append(services, serviceList.Items...)
+
+		if len(serviceList.Continue) == 0 {
+			return services, nil
+		}
+	}
+}
+
+// GetSecrets returns all secrets for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves secrets by portions set by limit.
+func GetSecrets(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]corev1.Secret, error) {
+	secretList := &corev1.SecretList{}
+	secrets := []corev1.Secret{}
+
+	for {
+		if err := c.List(ctx, secretList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(secretList.Continue)); err != nil {
+			return nil, err
+		}
+
+		secrets = append(secrets, secretList.Items...)
+
+		if len(secretList.Continue) == 0 {
+			return secrets, nil
+		}
+	}
+}
+
+// GetIngresses returns all ingresses for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves ingresses by portions set by limit.
+func GetIngresses(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]networkingv1.Ingress, error) {
+	ingressList := &networkingv1.IngressList{}
+	ingresses := []networkingv1.Ingress{}
+
+	for {
+		if err := c.List(ctx, ingressList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(ingressList.Continue)); err != nil {
+			return nil, err
+		}
+
+		ingresses = append(ingresses, ingressList.Items...)
+
+		if len(ingressList.Continue) == 0 {
+			return ingresses, nil
+		}
+	}
+}
+
+// GetResourceQuotas returns all resourceQuotas for a given namespace, or all namespaces if it's set to empty string "".
+// It retrieves resourceQuotas by portions set by limit.
+func GetResourceQuotas(ctx context.Context, c client.Client, namespace string, selector labels.Selector, limit int64) ([]corev1.ResourceQuota, error) {
+	resourceQuotaList := &corev1.ResourceQuotaList{}
+	resourceQuotas := []corev1.ResourceQuota{}
+
+	for {
+		if err := c.List(ctx, resourceQuotaList, client.InNamespace(namespace), client.Limit(limit), client.MatchingLabelsSelector{Selector: selector}, client.Continue(resourceQuotaList.Continue)); err != nil {
+			return nil, err
+		}
+
+		resourceQuotas = append(resourceQuotas, resourceQuotaList.Items...)
+
+		if len(resourceQuotaList.Continue) == 0 {
+			return resourceQuotas, nil
+		}
+	}
+}
+
 // GetDeploymentPods returns all pods of a given deployment.
 func GetDeploymentPods(ctx context.Context, c client.Client, name, namespace string) ([]corev1.Pod, error) {
 	deployment := &appsv1.Deployment{
@@ -14,7 +14,9 @@ import (
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -231,6 +233,126 @@ var _ = Describe("utils", func() {
 
 	})
 
+	Describe("#GetDeployments", func() {
+		var (
+			fakeClient client.Client
+			ctx        = context.TODO()
+		)
+
+		BeforeEach(func() {
+			fakeClient = fakeclient.NewClientBuilder().Build()
+			for i := 0; i < 5; i++ {
+				deployment := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      strconv.Itoa(i),
+						Namespace: "default",
+						Labels: map[string]string{
+							"foo": strconv.Itoa(i % 2),
+						},
+					},
+				}
+				Expect(fakeClient.Create(ctx, deployment)).To(Succeed())
+			}
+			Expect(fakeClient.Create(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "foo"}})).To(Succeed())
+		})
+
+		It("should return all deployments of a namespace by portions", func() {
+			deployments, err := utils.GetDeployments(ctx, fakeClient, "default", labels.NewSelector(), 2)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deployments).To(HaveLen(5))
+		})
+
+		It("should return the labeled deployments of all namespaces", func() {
+			deployments, err := utils.GetDeployments(ctx, fakeClient, "", labels.SelectorFromSet(labels.Set{"foo": "0"}), 2)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deployments).To(HaveLen(3))
+		})
+	})
+
+	Describe("#GetCronJobs", func() {
+		It("should return all cronJobs of all namespaces", func() {
+			ctx := context.TODO()
+			fakeClient := fakeclient.NewClientBuilder().Build()
+			Expect(fakeClient.Create(ctx, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "foo"}})).To(Succeed())
+
+			cronJobs, err := utils.GetCronJobs(ctx, fakeClient, "", labels.NewSelector(), 1)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cronJobs).To(HaveLen(2))
+		})
+	})
+
+	Describe("#GetNetworkPolicies", func() {
+		It("should return all networkPolicies of a namespace", func() {
+			ctx := context.TODO()
+			fakeClient := fakeclient.NewClientBuilder().Build()
+			Expect(fakeClient.Create(ctx, &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "default"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "foo"}})).To(Succeed())
+
+			networkPolicies, err := utils.GetNetworkPolicies(ctx, fakeClient, "default", labels.NewSelector(), 1)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(networkPolicies).To(HaveLen(2))
+		})
+	})
+
+	Describe("#DefaultDenyNetworkPolicy", func() {
+		DescribeTable("Run cases",
+			func(spec networkingv1.NetworkPolicySpec, expectedIngress, expectedEgress bool) {
+				ingress, egress := utils.DefaultDenyNetworkPolicy(networkingv1.NetworkPolicy{Spec: spec})
+				Expect(ingress).To(Equal(expectedIngress))
+				Expect(egress).To(Equal(expectedEgress))
+			},
+			Entry("should deny ingress by default when policy types are not set",
+				networkingv1.NetworkPolicySpec{}, true, false),
+			Entry("should deny ingress and egress",
+				networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}}, true, true),
+			Entry("should not deny traffic which is allowed by rules",
+				networkingv1.NetworkPolicySpec{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+					Egress:      []networkingv1.NetworkPolicyEgressRule{{}},
+				}, true, false),
+			Entry("should not deny traffic of policies which select specific pods",
+				networkingv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+				}, false, false),
+		)
+	})
+
+	Describe("#GetResourceQuotas", func() {
+		It("should return all resourceQuotas of all namespaces", func() {
+			ctx := context.TODO()
+			fakeClient := fakeclient.NewClientBuilder().Build()
+			Expect(fakeClient.Create(ctx, &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "foo"}})).To(Succeed())
+
+			resourceQuotas, err := utils.GetResourceQuotas(ctx, fakeClient, "", labels.NewSelector(), 1)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceQuotas).To(HaveLen(2))
+		})
+	})
+
+	Describe("#GetSecrets", func() {
+		It("should return all secrets of a namespace", func() {
+			ctx := context.TODO()
+			fakeClient := fakeclient.NewClientBuilder().Build()
+			Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "default"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "foo"}})).To(Succeed())
+
+			secrets, err := utils.GetSecrets(ctx, fakeClient, "default", labels.NewSelector(), 1)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secrets).To(HaveLen(2))
+		})
+	})
+
 	Describe("#GetReplicaSets", func() {
 		var (
 			fakeClient       client.Client
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/registry"
+	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+	"github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+	"github.com/gardener/diki/pkg/shared/ruleset/certificates"
+	"github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+	"github.com/gardener/diki/pkg/shared/ruleset/custom"
+	"github.com/gardener/diki/pkg/shared/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+	"github.com/gardener/diki/pkg/shared/ruleset/images"
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+	"github.com/gardener/diki/pkg/shared/ruleset/network"
+	"github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+	"github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+	"github.com/gardener/diki/pkg/shared/ruleset/oshardening"
+	"github.com/gardener/diki/pkg/shared/ruleset/pss"
+	"github.com/gardener/diki/pkg/shared/ruleset/rbac"
+)
+
+// Providers returns a new [registry.ProviderRegistry] containing all providers
+// implemented in diki. The providers create their rulesets with the
+// [GardenerRulesets], [ManagedK8SRulesets] and [VirtualGardenRulesets] registries.
+// The registry also contains the version histories of the rulesets, except
+// for the custom ruleset whose rules are declared in the configuration.
+func Providers() *registry.ProviderRegistry {
+	providers := registry.NewProviderRegistry(
+		registry.ProviderFactory{ID: "gardener", FromConfig: GardenerProviderFromConfig},
+		registry.ProviderFactory{ID: "managedk8s", FromConfig: ManagedK8SProviderFromConfig},
+		registry.ProviderFactory{ID: "virtualgarden", FromConfig: VirtualGardenProviderFromConfig},
+	)
+	if err := providers.RegisterHistory(disak8sstig.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(cisk8s.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(nsacisa.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(rbac.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(network.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(images.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(certificates.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(auditpolicy.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(encryption.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(admission.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(lifecycle.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(oshardening.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(nodedrift.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterHistory(pss.History); err != nil {
+		panic(err)
+	}
+	if err := providers.RegisterUnversioned(custom.RulesetID, "its rules are declared in the configuration"); err != nil {
+		panic(err)
+	}
+	return providers
+}
+
+// ruleOptionsTypes contains the types of the rule options of the shared rulesets which are
+// validated before the rulesets are created. The args of all other rules are rejected.
+var ruleOptionsTypes = map[string][]registry.RuleOptionsType{
+	admission.RulesetID: {
+		registry.NewRuleOptionsType[admission.FailurePolicyOptions]("adm-001"),
+		registry.NewRuleOptionsType[admission.NamespaceSelectorOptions]("adm-002"),
+		registry.NewRuleOptionsType[admission.TimeoutOptions]("adm-003"),
+		registry.NewRuleOptionsType[admission.Options]("adm-004"),
+		registry.NewRuleOptionsType[admission.Options]("adm-005"),
+	},
+	auditpolicy.RulesetID: {
+		registry.NewRuleOptionsType[auditpolicy.SensitiveResourceOptions]("audit-001"),
+		registry.NewRuleOptionsType[auditpolicy.WriteOptions]("audit-002"),
+		registry.NewRuleOptionsType[auditpolicy.StageOptions]("audit-003"),
+	},
+	certificates.RulesetID: {
+		registry.NewRuleOptionsType[certificates.ExpiryOptions]("cert-001"),
+		registry.NewRuleOptionsType[certificates.KeyOptions]("cert-002"),
+		registry.NewRuleOptionsType[certificates.Options]("cert-003"),
+		registry.NewRuleOptionsType[certificates.Options]("cert-004"),
+	},
+	encryption.RulesetID: {
+		registry.NewRuleOptionsType[encryption.ResourceOptions]("enc-001"),
+		registry.NewRuleOptionsType[encryption.ProviderOptions]("enc-003"),
+		registry.NewRuleOptionsType[encryption.RotationOptions]("enc-004"),
+	},
+	images.RulesetID: {
+		registry.NewRuleOptionsType[images.Options]("img-001"),
+		registry.NewRuleOptionsType[images.Options]("img-002"),
+		registry.NewRuleOptionsType[images.RegistryOptions]("img-003"),
+		registry.NewRuleOptionsType[images.Options]("img-004"),
+		registry.NewRuleOptionsType[images.SignatureOptions]("img-005"),
+	},
+	lifecycle.RulesetID: {
+		registry.NewRuleOptionsType[lifecycle.EndOfLifeOptions]("lc-001"),
+		registry.NewRuleOptionsType[lifecycle.PatchOptions]("lc-002"),
+		registry.NewRuleOptionsType[lifecycle.SkewOptions]("lc-003"),
+	},
+	network.RulesetID: {
+		registry.NewRuleOptionsType[network.NamespaceOptions]("net-001"),
+		registry.NewRuleOptionsType[network.NamespaceOptions]("net-002"),
+		registry.NewRuleOptionsType[network.ExposureOptions]("net-003"),
+		registry.NewRuleOptionsType[network.ExposureOptions]("net-004"),
+	},
+	nodedrift.RulesetID: {
+		registry.NewRuleOptionsType[nodedrift.IgnoreOptions]("drift-001"),
+		registry.NewRuleOptionsType[nodedrift.IgnoreOptions]("drift-002"),
+		registry.NewRuleOptionsType[nodedrift.IgnoreOptions]("drift-003"),
+	},
+	// recommendations which do not overlap with the DISA Kubernetes STIG, see [nsacisaRuleOptionsTypes]
+	nsacisa.RulesetID: {
+		registry.NewRuleOptionsType[pss.Options]("non-root-containers"),
+		registry.NewRuleOptionsType[pss.Options]("immutable-container-filesystems"),
+		registry.NewRuleOptionsType[pss.Options]("privileged-containers"),
+		registry.NewRuleOptionsType[nsacisa.NamespaceOptions]("default-deny-network-policies"),
+		registry.NewRuleOptionsType[nsacisa.NamespaceOptions]("resource-quotas"),
+		registry.NewRuleOptionsType[nsacisa.EncryptionOptions]("secrets-encryption"),
+		registry.NewRuleOptionsType[rbac.Options]("rbac-least-privilege"),
+	},
+	oshardening.RulesetID: {
+		registry.NewRuleOptionsType[oshardening.SysctlOptions]("os-001"),
+		registry.NewRuleOptionsType[oshardening.ModuleOptions]("os-002"),
+		registry.NewRuleOptionsType[oshardening.MountPointOptions]("os-003"),
+		registry.NewRuleOptionsType[oshardening.FileOptions]("os-004"),
+		registry.NewRuleOptionsType[oshardening.SUIDOptions]("os-005"),
+	},
+	pss.RulesetID:  newRuleOptionsTypes[pss.Options](pss.RuleIDs...),
+	rbac.RulesetID: newRuleOptionsTypes[rbac.Options](rbac.RuleIDs...),
+}
+
+// newRuleOptionsTypes creates rule options types of the same type for several rules.
+func newRuleOptionsTypes[O any](ruleIDs ...string) []registry.RuleOptionsType {
+	types := make([]registry.RuleOptionsType, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		types = append(types, registry.NewRuleOptionsType[O](id))
+	}
+	return types
+}
+
+// forVersions restricts rule options types to ruleset versions.
+func forVersions(versions []string, types ...registry.RuleOptionsType) []registry.RuleOptionsType {
+	for i := range types {
+		types[i].Versions = versions
+	}
+	return types
+}
+
+// cisk8sRuleOptionsTypes returns the rule options types of the CIS controls of a benchmark version. The args
+// of the controls are passed to the DISA Kubernetes STIG rules of the provider which check the controls.
+func cisk8sRuleOptionsTypes(version string, disaRuleOptionsTypes []registry.RuleOptionsType) []registry.RuleOptionsType {
+	controls, err := cisk8s.Controls(version)
+	if err != nil {
+		panic(err)
+	}
+
+	var types []registry.RuleOptionsType
+	for _, control := range controls {
+		if t, ok := requirementRuleOptionsType(control.ID, control.DISARuleIDs, cisk8s.DISAVersion, disaRuleOptionsTypes); ok {
+			types = append(types, forVersions([]string{version}, t)...)
+		}
+	}
+	return types
+}
+
+// nsacisaRuleOptionsTypes returns the rule options types of the recommendations of a NSA/CISA Kubernetes
+// Hardening Guidance version. The args of recommendations which overlap with the DISA Kubernetes STIG
+// are passed to its rules of the provider, all other recommendations have a type of their own.
+func nsacisaRuleOptionsTypes(version string, disaRuleOptionsTypes []registry.RuleOptionsType) []registry.RuleOptionsType {
+	recommendations, err := nsacisa.Recommendations(version)
+	if err != nil {
+		panic(err)
+	}
+
+	var types []registry.RuleOptionsType
+	for _, recommendation := range recommendations {
+		if t, ok := requirementRuleOptionsType(recommendation.ID, recommendation.DISARuleIDs, nsacisa.DISAVersion, disaRuleOptionsTypes); ok {
+			types = append(types, forVersions([]string{version}, t)...)
+		}
+	}
+	return append(types, ruleOptionsTypes[nsacisa.RulesetID]...)
+}
+
+// requirementRuleOptionsType returns the rule options type of a requirement which is checked by DISA Kubernetes
+// STIG rules. Its args are valid if they are valid args of all rules of the DISA version which accept args.
+func requirementRuleOptionsType(requirementID string, disaRuleIDs []string, disaVersion string, disaRuleOptionsTypes []registry.RuleOptionsType) (registry.RuleOptionsType, bool) {
+	var validate []func(args any) error
+	for _, t := range disaRuleOptionsTypes {
+		if slices.Contains(disaRuleIDs, t.RuleID) && t.AppliesTo(disaVersion) {
+			validate = append(validate, t.Validate)
+		}
+	}
+	if len(validate) == 0 {
+		return registry.RuleOptionsType{}, false
+	}
+
+	return registry.RuleOptionsType{
+		RuleID: requirementID,
+		Validate: func(args any) error {
+			for _, v := range validate {
+				if err := v(args); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, true
+}
+
+// rulesetsFromConfig creates the rulesets of the provider config. Rulesets implemented by
+// plugins are created directly, all other rulesets are created with the registry.
+func rulesetsFromConfig[P any](ctx context.Context, conf config.ProviderConfig, rulesets *registry.RulesetRegistry[P], p P, providerLogger *slog.Logger) ([]ruleset.Ruleset, error) {
+	result := make([]ruleset.Ruleset, 0, len(conf.Rulesets))
+	for _, rulesetConfig := range conf.Rulesets {
+		if rulesetConfig.Plugin != nil {
+			ruleset, err := pluginRulesetFromConfig(ctx, rulesetConfig, conf, providerLogger)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, ruleset)
+			continue
+		}
+
+		ruleset, err := rulesets.FromConfig(rulesetConfig, p, providerLogger)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ruleset)
+	}
+	return result, nil
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package builder_test
+
+import (
+	"os"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/builder"
+	"github.com/gardener/diki/pkg/provider/registry"
+	"github.com/gardener/diki/pkg/shared/ruleset/custom"
+)
+
+// rulesetInfo describes a registered ruleset independently of the type of its provider.
+type rulesetInfo struct {
+	id          string
+	versions    []string
+	ruleOptions []registry.RuleOptionsType
+}
+
+func rulesetInfos[P any](rulesets *registry.RulesetRegistry[P]) []rulesetInfo {
+	var infos []rulesetInfo
+	for _, factory := range rulesets.Rulesets() {
+		infos = append(infos, rulesetInfo{id: factory.ID, versions: factory.Versions, ruleOptions: factory.RuleOptions})
+	}
+	return infos
+}
+
+var _ = Describe("Builder", func() {
+	var (
+		providers = builder.Providers()
+		rulesets  = map[string][]rulesetInfo{
+			"gardener":      rulesetInfos(builder.GardenerRulesets),
+			"managedk8s":    rulesetInfos(builder.ManagedK8SRulesets),
+			"virtualgarden": rulesetInfos(builder.VirtualGardenRulesets),
+		}
+	)
+
+	It("should declare the versions and history of all rulesets", func() {
+		for providerID, infos := range rulesets {
+			for _, info := range infos {
+				Expect(info.versions).ToNot(BeEmpty(), "ruleset %s of provider %s", info.id, providerID)
+
+				h, err := providers.History(info.id)
+				if info.id == custom.RulesetID {
+					Expect(err).To(MatchError("ruleset custom does not have a version history: its rules are declared in the configuration"))
+					continue
+				}
+				Expect(err).ToNot(HaveOccurred(), "ruleset %s of provider %s", info.id, providerID)
+				for _, version := range info.versions {
+					_, err := h.RuleIDs(version)
+					Expect(err).ToNot(HaveOccurred(), "ruleset %s of provider %s", info.id, providerID)
+				}
+			}
+		}
+	})
+
+	It("should only declare rule options types of rules of the rulesets", func() {
+		for providerID, infos := range rulesets {
+			for _, info := range infos {
+				if info.id == custom.RulesetID {
+					Expect(info.ruleOptions).To(BeEmpty())
+					continue
+				}
+
+				h, err := providers.History(info.id)
+				Expect(err).ToNot(HaveOccurred())
+				for _, t := range info.ruleOptions {
+					// the file owners of the pods of the gardener provider are configured with a pseudo rule
+					if t.RuleID == "pod-files" {
+						continue
+					}
+
+					known := slices.ContainsFunc(info.versions, func(version string) bool {
+						ids, err := h.RuleIDs(version)
+						Expect(err).ToNot(HaveOccurred())
+						return t.AppliesTo(version) && slices.Contains(ids, t.RuleID)
+					})
+					Expect(known).To(BeTrue(), "rule %s of ruleset %s of provider %s", t.RuleID, info.id, providerID)
+				}
+			}
+		}
+	})
+
+	DescribeTable("should accept the example configuration",
+		func(path string, validate func(config.RulesetConfig) error) {
+			data, err := os.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+
+			c := &config.DikiConfig{}
+			Expect(yaml.Unmarshal(data, c)).To(Succeed())
+			Expect(c.Providers).ToNot(BeEmpty())
+			for _, providerConfig := range c.Providers {
+				for _, rulesetConfig := range providerConfig.Rulesets {
+					Expect(validate(rulesetConfig)).To(Succeed(), "ruleset %s", rulesetConfig.ID)
+				}
+			}
+		},
+		Entry("gardener", "../../../example/config/gardener.yaml", builder.GardenerRulesets.Validate),
+		Entry("managedk8s", "../../../example/config/managedk8s.yaml", builder.ManagedK8SRulesets.Validate),
+		Entry("virtualgarden", "../../../example/config/virtualgarden.yaml", builder.VirtualGardenRulesets.Validate),
+	)
+
+	DescribeTable("should validate the rule options of the rulesets",
+		func(validate func(config.RulesetConfig) error, rulesetConfig config.RulesetConfig, expectedErr string) {
+			err := validate(rulesetConfig)
+			if len(expectedErr) == 0 {
+				Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("valid DISA Kubernetes STIG rule options of the version", builder.GardenerRulesets.Validate,
+			config.RulesetConfig{ID: "disa-kubernetes-stig", Version: "v1r10", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "pod-files", Args: map[string]any{"expectedFileOwner": map[string]any{"users": []any{"0"}}}},
+			}}, ""),
+		Entry("unknown field of a DISA Kubernetes STIG rule", builder.GardenerRulesets.Validate,
+			config.RulesetConfig{ID: "disa-kubernetes-stig", Version: "v1r11", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "254800", Args: map[string]any{"minPodSecurity": "baseline"}},
+			}}, `invalid options of rule 254800 of ruleset disa-kubernetes-stig: json: unknown field "minPodSecurity"`),
+		Entry("args of a DISA Kubernetes STIG rule without options", builder.ManagedK8SRulesets.Validate,
+			config.RulesetConfig{ID: "disa-kubernetes-stig", Version: "v1r11", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "242445", Args: map[string]any{"expectedFileOwner": map[string]any{}}},
+			}}, "invalid options of rule 242445 of ruleset disa-kubernetes-stig: rule does not accept args in version v1r11"),
+		Entry("args of a CIS control which are passed to a DISA Kubernetes STIG rule", builder.GardenerRulesets.Validate,
+			config.RulesetConfig{ID: "cis-kubernetes-benchmark", Version: "v1.8", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "1.1.12", Args: map[string]any{"expectedFileOwner": map[string]any{"users": []any{"0"}}}},
+			}}, ""),
+		Entry("invalid args of a CIS control", builder.VirtualGardenRulesets.Validate,
+			config.RulesetConfig{ID: "cis-kubernetes-benchmark", Version: "v1.8", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "1.1.12", Args: map[string]any{"owner": "root"}},
+			}}, `invalid options of rule 1.1.12 of ruleset cis-kubernetes-benchmark: json: unknown field "owner"`),
+		Entry("args of a CIS control without options", builder.GardenerRulesets.Validate,
+			config.RulesetConfig{ID: "cis-kubernetes-benchmark", Version: "v1.8", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "1.2.1", Args: map[string]any{"foo": "bar"}},
+			}}, "invalid options of rule 1.2.1 of ruleset cis-kubernetes-benchmark: rule does not accept args in version v1.8"),
+		Entry("args of the NSA/CISA secrets encryption recommendation", builder.GardenerRulesets.Validate,
+			config.RulesetConfig{ID: "nsa-cisa-kubernetes-hardening", Version: "v1.2", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "secrets-encryption", Args: map[string]any{"resources": []any{"configmaps"}, "maxKeyAge": "720h"}},
+			}}, ""),
+		Entry("invalid args of a NSA/CISA recommendation", builder.ManagedK8SRulesets.Validate,
+			config.RulesetConfig{ID: "nsa-cisa-kubernetes-hardening", Version: "v1.2", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "resource-quotas", Args: map[string]any{"acceptedPods": []any{}}},
+			}}, `invalid options of rule resource-quotas of ruleset nsa-cisa-kubernetes-hardening: json: unknown field "acceptedPods"`),
+		Entry("args of a Pod Security Standards control", builder.ManagedK8SRulesets.Validate,
+			config.RulesetConfig{ID: "pod-security-standards", Version: "v1.28", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "privileged", Args: map[string]any{"acceptedPods": []any{}}},
+			}}, ""),
+		Entry("unknown Pod Security Standards version", builder.GardenerRulesets.Validate,
+			config.RulesetConfig{ID: "pod-security-standards", Version: "v2.0"},
+			"unknown ruleset pod-security-standards version: v2.0, supported versions are [latest v1.0 v1.1 v1.2 v1.3 v1.4 v1.5 v1.6 v1.7 v1.8 v1.9 v1.10 v1.11 v1.12 v1.13 v1.14 v1.15 v1.16 v1.17 v1.18 v1.19 v1.20 v1.21 v1.22 v1.23 v1.24 v1.25 v1.26 v1.27 v1.28]"),
+		Entry("invalid args of a RBAC Analysis rule", builder.VirtualGardenRulesets.Validate,
+			config.RulesetConfig{ID: "rbac-analysis", Version: "v1", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "rbac-001", Args: map[string]any{"acceptedSubjects": "system:masters"}},
+			}}, "invalid options of rule rbac-001 of ruleset rbac-analysis: json: cannot unmarshal string into Go struct field Options.acceptedSubjects of type []rbac.AcceptedSubject"),
+		Entry("args of a custom rule", builder.ManagedK8SRulesets.Validate,
+			config.RulesetConfig{ID: "custom", Version: "v1", RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "ns-001", Args: map[string]any{"foo": "bar"}},
+			}}, "invalid options of rule ns-001 of ruleset custom: rule does not accept args in version v1"),
+	)
+})
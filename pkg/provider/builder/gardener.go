@@ -5,7 +5,7 @@
 package builder
 
 import (
-	"fmt"
+	"context"
 	"log/slog"
 
 	"k8s.io/client-go/rest"
@@ -13,12 +13,282 @@ import (
 	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/provider"
 	"github.com/gardener/diki/pkg/provider/gardener"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/auditpolicy"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/certificates"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/cisk8s"
 	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig/v1r10"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig/v1r11"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/encryption"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/nodedrift"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/nsacisa"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/oshardening"
+	"github.com/gardener/diki/pkg/provider/registry"
+	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+	sharedauditpolicy "github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+	sharedcertificates "github.com/gardener/diki/pkg/shared/ruleset/certificates"
+	sharedcisk8s "github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+	"github.com/gardener/diki/pkg/shared/ruleset/custom"
+	"github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/option"
+	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
+	sharedencryption "github.com/gardener/diki/pkg/shared/ruleset/encryption"
+	"github.com/gardener/diki/pkg/shared/ruleset/images"
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+	"github.com/gardener/diki/pkg/shared/ruleset/network"
+	sharednodedrift "github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+	sharednsacisa "github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+	sharedoshardening "github.com/gardener/diki/pkg/shared/ruleset/oshardening"
+	"github.com/gardener/diki/pkg/shared/ruleset/pss"
+	"github.com/gardener/diki/pkg/shared/ruleset/rbac"
+)
+
+// GardenerRulesets contains the rulesets of the gardener provider.
+// Additional rulesets can be registered before the provider is created.
+var GardenerRulesets = registry.NewRulesetRegistry(
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          disak8sstig.RulesetID,
+		RuleOptions: gardenerDISARuleOptionsTypes,
+		Versions:    []string{"v1r10", "v1r11"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := disak8sstig.FromGenericConfig(rulesetConfig, p.ShootConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := disak8sstig.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          sharedcisk8s.RulesetID,
+		RuleOptions: cisk8sRuleOptionsTypes("v1.8", gardenerDISARuleOptionsTypes),
+		Versions:    []string{"v1.8"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := cisk8s.FromGenericConfig(rulesetConfig, p.ShootConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedcisk8s.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          sharednsacisa.RulesetID,
+		RuleOptions: nsacisaRuleOptionsTypes("v1.2", gardenerDISARuleOptionsTypes),
+		Versions:    []string{"v1.2"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := nsacisa.FromGenericConfig(rulesetConfig, p.ShootConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharednsacisa.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          pss.RulesetID,
+		RuleOptions: ruleOptionsTypes[pss.RulesetID],
+		Versions:    pss.Versions,
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := pss.FromGenericConfig(rulesetConfig, p.ShootConfig, rule.NewTarget("cluster", "shoot"))
+			if err != nil {
+				return nil, err
+			}
+			setLogger := pss.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          rbac.RulesetID,
+		RuleOptions: ruleOptionsTypes[rbac.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := rbac.FromGenericConfig(rulesetConfig, p.ShootConfig, rule.NewTarget("cluster", "shoot"))
+			if err != nil {
+				return nil, err
+			}
+			setLogger := rbac.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          network.RulesetID,
+		RuleOptions: ruleOptionsTypes[network.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := network.FromGenericConfig(rulesetConfig, p.ShootConfig, rule.NewTarget("cluster", "shoot"))
+			if err != nil {
+				return nil, err
+			}
+			setLogger := network.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          images.RulesetID,
+		RuleOptions: ruleOptionsTypes[images.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := images.FromGenericConfig(rulesetConfig, p.ShootConfig, rule.NewTarget("cluster", "shoot"))
+			if err != nil {
+				return nil, err
+			}
+			setLogger := images.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          sharedcertificates.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedcertificates.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := certificates.FromGenericConfig(rulesetConfig, p.ShootConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedcertificates.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          sharedauditpolicy.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedauditpolicy.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := auditpolicy.FromGenericConfig(rulesetConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedauditpolicy.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          sharedencryption.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedencryption.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := encryption.FromGenericConfig(rulesetConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedencryption.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          admission.RulesetID,
+		RuleOptions: ruleOptionsTypes[admission.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := admission.FromGenericConfig(rulesetConfig, p.ShootConfig, rule.NewTarget("cluster", "shoot"))
+			if err != nil {
+				return nil, err
+			}
+			setLogger := admission.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          lifecycle.RulesetID,
+		RuleOptions: ruleOptionsTypes[lifecycle.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := lifecycle.FromGenericConfig(rulesetConfig, p.ShootConfig, rule.NewTarget("cluster", "shoot"))
+			if err != nil {
+				return nil, err
+			}
+			setLogger := lifecycle.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          sharedoshardening.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedoshardening.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := oshardening.FromGenericConfig(rulesetConfig, p.ShootConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedoshardening.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:          sharednodedrift.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharednodedrift.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := nodedrift.FromGenericConfig(rulesetConfig, p.ShootConfig, p.SeedConfig, p.Args.ShootNamespace)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharednodedrift.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*gardener.Provider]{
+		ID:       custom.RulesetID,
+		Versions: []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *gardener.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := custom.FromGenericConfig(rulesetConfig, map[string]*rest.Config{"shoot": p.ShootConfig, "seed": p.SeedConfig}, "shoot")
+			if err != nil {
+				return nil, err
+			}
+			setLogger := custom.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+)
+
+// gardenerDISARuleOptionsTypes contains the types of the rule options
+// of the DISA Kubernetes STIG rules of the gardener provider.
+var gardenerDISARuleOptionsTypes = append(
+	forVersions([]string{"v1r10"},
+		registry.NewRuleOptionsType[v1r10.Options242414](v1r10.ID242414),
+		registry.NewRuleOptionsType[v1r10.Options242415](v1r10.ID242415),
+		registry.NewRuleOptionsType[v1r10.Options245543](v1r10.ID245543),
+		registry.NewRuleOptionsType[v1r10.Options254800](v1r10.ID254800),
+		registry.NewRuleOptionsType[v1r10.OptionsPodFiles](v1r10.IDPodFiles),
+	),
+	forVersions([]string{"v1r11"},
+		registry.NewRuleOptionsType[v1r11.Options242414](v1r11.ID242414),
+		registry.NewRuleOptionsType[v1r11.Options242415](v1r11.ID242415),
+		registry.NewRuleOptionsType[sharedv1r11.Options242376](sharedv1r11.ID242376),
+		registry.NewRuleOptionsType[sharedv1r11.Options242378](sharedv1r11.ID242378),
+		registry.NewRuleOptionsType[sharedv1r11.Options242418](sharedv1r11.ID242418),
+		registry.NewRuleOptionsType[sharedv1r11.Options242443](sharedv1r11.ID242443),
+		registry.NewRuleOptionsType[option.FileOwnerOptions](sharedv1r11.ID242445),
+		registry.NewRuleOptionsType[option.FileOwnerOptions](sharedv1r11.ID242446),
+		registry.NewRuleOptionsType[option.FileOwnerOptions](sharedv1r11.ID242451),
+		registry.NewRuleOptionsType[sharedv1r11.Options242462](sharedv1r11.ID242462),
+		registry.NewRuleOptionsType[sharedv1r11.Options242463](sharedv1r11.ID242463),
+		registry.NewRuleOptionsType[sharedv1r11.Options242464](sharedv1r11.ID242464),
+		registry.NewRuleOptionsType[sharedv1r11.Options245543](v1r11.ID245543),
+		registry.NewRuleOptionsType[sharedv1r11.Options254800](v1r11.ID254800),
+		registry.NewRuleOptionsType[option.FileOwnerOptions](v1r11.IDPodFiles),
+	)...,
 )
 
 // GardenerProviderFromConfig retuns a Provider from a ProviderConfig.
-func GardenerProviderFromConfig(conf config.ProviderConfig) (provider.Provider, error) {
+func GardenerProviderFromConfig(ctx context.Context, conf config.ProviderConfig) (provider.Provider, error) {
 	p, err := gardener.FromGenericConfig(conf)
 	if err != nil {
 		return nil, err
@@ -29,20 +299,9 @@ func GardenerProviderFromConfig(conf config.ProviderConfig) (provider.Provider,
 	providerLogger := slog.Default().With("provider", p.ID())
 	setLoggerFunc := gardener.WithLogger(providerLogger)
 	setLoggerFunc(p)
-	rulesets := make([]ruleset.Ruleset, 0, len(conf.Rulesets))
-	for _, rulesetConfig := range conf.Rulesets {
-		switch rulesetConfig.ID {
-		case disak8sstig.RulesetID:
-			ruleset, err := disak8sstig.FromGenericConfig(rulesetConfig, p.ShootConfig, p.SeedConfig, p.Args.ShootNamespace)
-			if err != nil {
-				return nil, err
-			}
-			setLoggerDISA := disak8sstig.WithLogger(providerLogger.With("ruleset", ruleset.ID(), "version", ruleset.Version()))
-			setLoggerDISA(ruleset)
-			rulesets = append(rulesets, ruleset)
-		default:
-			return nil, fmt.Errorf("unknown ruleset identifier: %s", rulesetConfig.ID)
-		}
+	rulesets, err := rulesetsFromConfig(ctx, conf, GardenerRulesets, p, providerLogger)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := p.AddRulesets(rulesets...); err != nil {
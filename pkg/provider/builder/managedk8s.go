@@ -5,18 +5,217 @@
 package builder
 
 import (
-	"fmt"
+	"context"
 	"log/slog"
 
+	"k8s.io/client-go/rest"
+
 	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/provider"
 	"github.com/gardener/diki/pkg/provider/managedk8s"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/certificates"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/cisk8s"
 	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig/v1r11"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/nsacisa"
+	"github.com/gardener/diki/pkg/provider/registry"
+	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+	sharedcertificates "github.com/gardener/diki/pkg/shared/ruleset/certificates"
+	sharedcisk8s "github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+	"github.com/gardener/diki/pkg/shared/ruleset/custom"
+	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
+	sharedencryption "github.com/gardener/diki/pkg/shared/ruleset/encryption"
+	"github.com/gardener/diki/pkg/shared/ruleset/images"
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+	"github.com/gardener/diki/pkg/shared/ruleset/network"
+	sharednsacisa "github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+	"github.com/gardener/diki/pkg/shared/ruleset/pss"
+	"github.com/gardener/diki/pkg/shared/ruleset/rbac"
+)
+
+// managedK8SDISARuleOptionsTypes contains the types of the rule options
+// of the DISA Kubernetes STIG rules of the managedk8s provider.
+var managedK8SDISARuleOptionsTypes = forVersions([]string{"v1r11"},
+	registry.NewRuleOptionsType[v1r11.Options242415](sharedv1r11.ID242415),
+	registry.NewRuleOptionsType[sharedv1r11.Options242443](sharedv1r11.ID242443),
+)
+
+// ManagedK8SRulesets contains the rulesets of the managedk8s provider.
+// Additional rulesets can be registered before the provider is created.
+var ManagedK8SRulesets = registry.NewRulesetRegistry(
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          disak8sstig.RulesetID,
+		RuleOptions: managedK8SDISARuleOptionsTypes,
+		Versions:    []string{"v1r11"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := disak8sstig.FromGenericConfig(rulesetConfig, p.Config)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := disak8sstig.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          sharedcisk8s.RulesetID,
+		RuleOptions: cisk8sRuleOptionsTypes("v1.8", managedK8SDISARuleOptionsTypes),
+		Versions:    []string{"v1.8"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := cisk8s.FromGenericConfig(rulesetConfig, p.Config)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedcisk8s.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          sharednsacisa.RulesetID,
+		RuleOptions: nsacisaRuleOptionsTypes("v1.2", managedK8SDISARuleOptionsTypes),
+		Versions:    []string{"v1.2"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := nsacisa.FromGenericConfig(rulesetConfig, p.Config)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharednsacisa.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          pss.RulesetID,
+		RuleOptions: ruleOptionsTypes[pss.RulesetID],
+		Versions:    pss.Versions,
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := pss.FromGenericConfig(rulesetConfig, p.Config, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := pss.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          rbac.RulesetID,
+		RuleOptions: ruleOptionsTypes[rbac.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := rbac.FromGenericConfig(rulesetConfig, p.Config, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := rbac.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          network.RulesetID,
+		RuleOptions: ruleOptionsTypes[network.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := network.FromGenericConfig(rulesetConfig, p.Config, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := network.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          images.RulesetID,
+		RuleOptions: ruleOptionsTypes[images.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := images.FromGenericConfig(rulesetConfig, p.Config, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := images.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          sharedcertificates.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedcertificates.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := certificates.FromGenericConfig(rulesetConfig, p.Config)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedcertificates.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          sharedencryption.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedencryption.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := sharedencryption.FromGenericConfig(rulesetConfig, nil)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedencryption.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          admission.RulesetID,
+		RuleOptions: ruleOptionsTypes[admission.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := admission.FromGenericConfig(rulesetConfig, p.Config, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := admission.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:          lifecycle.RulesetID,
+		RuleOptions: ruleOptionsTypes[lifecycle.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := lifecycle.FromGenericConfig(rulesetConfig, p.Config, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := lifecycle.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*managedk8s.Provider]{
+		ID:       custom.RulesetID,
+		Versions: []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *managedk8s.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := custom.FromGenericConfig(rulesetConfig, map[string]*rest.Config{"cluster": p.Config}, "cluster")
+			if err != nil {
+				return nil, err
+			}
+			setLogger := custom.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
 )
 
 // ManagedK8SProviderFromConfig retuns a Provider from a [ProviderConfig].
-func ManagedK8SProviderFromConfig(conf config.ProviderConfig) (provider.Provider, error) {
+func ManagedK8SProviderFromConfig(ctx context.Context, conf config.ProviderConfig) (provider.Provider, error) {
 	p, err := managedk8s.FromGenericConfig(conf)
 	if err != nil {
 		return nil, err
@@ -26,20 +225,9 @@ func ManagedK8SProviderFromConfig(conf config.ProviderConfig) (provider.Provider
 	providerLogger := slog.Default().With("provider", p.ID())
 	setLoggerFunc := managedk8s.WithLogger(providerLogger)
 	setLoggerFunc(p)
-	rulesets := make([]ruleset.Ruleset, 0, len(conf.Rulesets))
-	for _, rulesetConfig := range conf.Rulesets {
-		switch rulesetConfig.ID {
-		case disak8sstig.RulesetID:
-			ruleset, err := disak8sstig.FromGenericConfig(rulesetConfig, p.Config)
-			if err != nil {
-				return nil, err
-			}
-			setLoggerDISA := disak8sstig.WithLogger(providerLogger.With("ruleset", ruleset.ID(), "version", ruleset.Version()))
-			setLoggerDISA(ruleset)
-			rulesets = append(rulesets, ruleset)
-		default:
-			return nil, fmt.Errorf("unknown ruleset identifier: %s", rulesetConfig.ID)
-		}
+	rulesets, err := rulesetsFromConfig(ctx, conf, ManagedK8SRulesets, p, providerLogger)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := p.AddRulesets(rulesets...); err != nil {
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/plugin"
+)
+
+// pluginRulesetFromConfig returns a [ruleset.Ruleset] implemented by the plugin of the ruleset config.
+func pluginRulesetFromConfig(ctx context.Context, rulesetConfig config.RulesetConfig, conf config.ProviderConfig, providerLogger *slog.Logger) (ruleset.Ruleset, error) {
+	ruleset, err := plugin.FromGenericConfig(ctx, rulesetConfig, conf)
+	if err != nil {
+		return nil, err
+	}
+	setLoggerPlugin := plugin.WithLogger(providerLogger.With("ruleset", ruleset.ID(), "version", ruleset.Version()))
+	setLoggerPlugin(ruleset)
+	return ruleset, nil
+}
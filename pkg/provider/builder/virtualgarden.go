@@ -5,18 +5,198 @@
 package builder
 
 import (
-	"fmt"
+	"context"
 	"log/slog"
 
+	"k8s.io/client-go/rest"
+
 	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/provider"
+	"github.com/gardener/diki/pkg/provider/registry"
 	"github.com/gardener/diki/pkg/provider/virtualgarden"
+	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/auditpolicy"
+	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/certificates"
+	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/cisk8s"
 	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/encryption"
+	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/nsacisa"
+	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+	sharedauditpolicy "github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+	sharedcertificates "github.com/gardener/diki/pkg/shared/ruleset/certificates"
+	sharedcisk8s "github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+	"github.com/gardener/diki/pkg/shared/ruleset/custom"
+	"github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/option"
+	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
+	sharedencryption "github.com/gardener/diki/pkg/shared/ruleset/encryption"
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+	sharednsacisa "github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+	"github.com/gardener/diki/pkg/shared/ruleset/rbac"
+)
+
+// virtualGardenDISARuleOptionsTypes contains the types of the rule options
+// of the DISA Kubernetes STIG rules of the virtualgarden provider.
+var virtualGardenDISARuleOptionsTypes = forVersions([]string{"v1r11"},
+	registry.NewRuleOptionsType[sharedv1r11.Options242376](sharedv1r11.ID242376),
+	registry.NewRuleOptionsType[sharedv1r11.Options242378](sharedv1r11.ID242378),
+	registry.NewRuleOptionsType[sharedv1r11.Options242418](sharedv1r11.ID242418),
+	registry.NewRuleOptionsType[sharedv1r11.Options242443](sharedv1r11.ID242443),
+	registry.NewRuleOptionsType[option.FileOwnerOptions](sharedv1r11.ID242445),
+	registry.NewRuleOptionsType[option.FileOwnerOptions](sharedv1r11.ID242446),
+	registry.NewRuleOptionsType[option.FileOwnerOptions](sharedv1r11.ID242451),
+	registry.NewRuleOptionsType[sharedv1r11.Options242462](sharedv1r11.ID242462),
+	registry.NewRuleOptionsType[sharedv1r11.Options242463](sharedv1r11.ID242463),
+	registry.NewRuleOptionsType[sharedv1r11.Options242464](sharedv1r11.ID242464),
+	registry.NewRuleOptionsType[sharedv1r11.Options245543](sharedv1r11.ID245543),
+)
+
+// VirtualGardenRulesets contains the rulesets of the virtualgarden provider.
+// Additional rulesets can be registered before the provider is created.
+var VirtualGardenRulesets = registry.NewRulesetRegistry(
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          disak8sstig.RulesetID,
+		RuleOptions: virtualGardenDISARuleOptionsTypes,
+		Versions:    []string{"v1r11"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := disak8sstig.FromGenericConfig(rulesetConfig, p.GardenConfig, p.RuntimeConfig)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := disak8sstig.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          sharedcisk8s.RulesetID,
+		RuleOptions: cisk8sRuleOptionsTypes("v1.8", virtualGardenDISARuleOptionsTypes),
+		Versions:    []string{"v1.8"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := cisk8s.FromGenericConfig(rulesetConfig, p.GardenConfig, p.RuntimeConfig)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedcisk8s.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          sharednsacisa.RulesetID,
+		RuleOptions: nsacisaRuleOptionsTypes("v1.2", virtualGardenDISARuleOptionsTypes),
+		Versions:    []string{"v1.2"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := nsacisa.FromGenericConfig(rulesetConfig, p.GardenConfig, p.RuntimeConfig)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharednsacisa.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          rbac.RulesetID,
+		RuleOptions: ruleOptionsTypes[rbac.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := rbac.FromGenericConfig(rulesetConfig, p.GardenConfig, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := rbac.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          sharedcertificates.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedcertificates.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := certificates.FromGenericConfig(rulesetConfig, p.GardenConfig, p.RuntimeConfig)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedcertificates.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          sharedauditpolicy.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedauditpolicy.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := auditpolicy.FromGenericConfig(rulesetConfig, p.RuntimeConfig)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedauditpolicy.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          sharedencryption.RulesetID,
+		RuleOptions: ruleOptionsTypes[sharedencryption.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := encryption.FromGenericConfig(rulesetConfig, p.RuntimeConfig)
+			if err != nil {
+				return nil, err
+			}
+			setLogger := sharedencryption.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          admission.RulesetID,
+		RuleOptions: ruleOptionsTypes[admission.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := admission.FromGenericConfig(rulesetConfig, p.GardenConfig, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := admission.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:          lifecycle.RulesetID,
+		RuleOptions: ruleOptionsTypes[lifecycle.RulesetID],
+		Versions:    []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := lifecycle.FromGenericConfig(rulesetConfig, p.GardenConfig, rule.NewTarget())
+			if err != nil {
+				return nil, err
+			}
+			setLogger := lifecycle.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
+	registry.RulesetFactory[*virtualgarden.Provider]{
+		ID:       custom.RulesetID,
+		Versions: []string{"v1"},
+		FromConfig: func(rulesetConfig config.RulesetConfig, p *virtualgarden.Provider, logger *slog.Logger) (ruleset.Ruleset, error) {
+			ruleset, err := custom.FromGenericConfig(rulesetConfig, map[string]*rest.Config{"garden": p.GardenConfig, "runtime": p.RuntimeConfig}, "garden")
+			if err != nil {
+				return nil, err
+			}
+			setLogger := custom.WithLogger(logger)
+			setLogger(ruleset)
+			return ruleset, nil
+		},
+	},
 )
 
 // VirtualGardenProviderFromConfig retuns a Provider from a [ProviderConfig].
-func VirtualGardenProviderFromConfig(conf config.ProviderConfig) (provider.Provider, error) {
+func VirtualGardenProviderFromConfig(ctx context.Context, conf config.ProviderConfig) (provider.Provider, error) {
 	p, err := virtualgarden.FromGenericConfig(conf)
 	if err != nil {
 		return nil, err
@@ -27,20 +207,9 @@ func VirtualGardenProviderFromConfig(conf config.ProviderConfig) (provider.Provi
 	providerLogger := slog.Default().With("provider", p.ID())
 	setLoggerFunc := virtualgarden.WithLogger(providerLogger)
 	setLoggerFunc(p)
-	rulesets := make([]ruleset.Ruleset, 0, len(conf.Rulesets))
-	for _, rulesetConfig := range conf.Rulesets {
-		switch rulesetConfig.ID {
-		case disak8sstig.RulesetID:
-			ruleset, err := disak8sstig.FromGenericConfig(rulesetConfig, p.GardenConfig, p.RuntimeConfig)
-			if err != nil {
-				return nil, err
-			}
-			setLoggerDISA := disak8sstig.WithLogger(providerLogger.With("ruleset", ruleset.ID(), "version", ruleset.Version()))
-			setLoggerDISA(ruleset)
-			rulesets = append(rulesets, ruleset)
-		default:
-			return nil, fmt.Errorf("unknown ruleset identifier: %s", rulesetConfig.ID)
-		}
+	rulesets, err := rulesetsFromConfig(ctx, conf, VirtualGardenRulesets, p, providerLogger)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := p.AddRulesets(rulesets...); err != nil {
@@ -77,6 +77,31 @@ func GetSingleAllocatableNodePerWorker(workers []extensionsv1alpha1.Worker, node
 	return result
 }
 
+// GetAllocatableNodesPerWorker returns a map where the keys are the names of the worker pools and the values are
+// all allocatable nodes of the worker pool. If no allocatable nodes are present for a given worker pool the value is empty.
+func GetAllocatableNodesPerWorker(workers []extensionsv1alpha1.Worker, nodes []corev1.Node, nodesAllocatablePods map[string]int) map[string][]corev1.Node {
+	result := map[string][]corev1.Node{}
+
+	for _, worker := range workers {
+		for _, workerGroup := range worker.Spec.Pools {
+			if workerGroup.Minimum == 0 && !anyNodesForWorkerGroup(workerGroup.Name, nodes) {
+				continue
+			}
+
+			allocatableNodes := []corev1.Node{}
+			for _, node := range nodes {
+				if node.ObjectMeta.Labels[v1beta1constants.LabelWorkerPool] == workerGroup.Name && kubeutils.NodeReadyStatus(node) &&
+					nodesAllocatablePods[node.Name] > 0 {
+					allocatableNodes = append(allocatableNodes, node)
+				}
+			}
+			result[workerGroup.Name] = allocatableNodes
+		}
+	}
+
+	return result
+}
+
 // ReadyNode contains a single Node and whether it is in Ready state or not
 type ReadyNode struct {
 	Node  *corev1.Node
@@ -259,6 +259,87 @@ var _ = Describe("utils", func() {
 		})
 	})
 
+	Describe("#GetAllocatableNodesPerWorker", func() {
+		var (
+			nodes     []corev1.Node
+			node      corev1.Node
+			workers   []extensionsv1alpha1.Worker
+			namespace = "foo"
+		)
+
+		BeforeEach(func() {
+			workers = []extensionsv1alpha1.Worker{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "worker1",
+						Namespace: namespace,
+					},
+					Spec: extensionsv1alpha1.WorkerSpec{
+						Pools: []extensionsv1alpha1.WorkerPool{
+							{
+								Name: "pool1",
+							},
+							{
+								Name: "pool2",
+							},
+							{
+								Name: "pool3",
+							},
+						},
+					},
+				},
+			}
+			node = corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{},
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			}
+			nodes = []corev1.Node{}
+		})
+
+		It("should return all allocatable nodes of the worker groups", func() {
+			pool1Node1 := node.DeepCopy()
+			pool1Node1.ObjectMeta.Name = "pool1Node1"
+			pool1Node1.Labels["worker.gardener.cloud/pool"] = "pool1"
+
+			pool1Node2 := node.DeepCopy()
+			pool1Node2.ObjectMeta.Name = "pool1Node2"
+			pool1Node2.Labels["worker.gardener.cloud/pool"] = "pool1"
+
+			pool1Node3 := node.DeepCopy()
+			pool1Node3.ObjectMeta.Name = "pool1Node3"
+			pool1Node3.Labels["worker.gardener.cloud/pool"] = "pool1"
+			pool1Node3.Status.Conditions[0].Status = corev1.ConditionFalse
+
+			pool2Node := node.DeepCopy()
+			pool2Node.ObjectMeta.Name = "pool2Node"
+			pool2Node.Labels["worker.gardener.cloud/pool"] = "pool2"
+
+			nodes = append(nodes, *pool1Node1, *pool1Node2, *pool1Node3, *pool2Node)
+			nodesAllocatablePods := map[string]int{
+				"pool1Node1": 1,
+				"pool1Node2": 3,
+				"pool1Node3": 1,
+				"pool2Node":  0,
+			}
+
+			nodesPerWorker := utils.GetAllocatableNodesPerWorker(workers, nodes, nodesAllocatablePods)
+
+			Expect(nodesPerWorker).To(Equal(map[string][]corev1.Node{
+				"pool1": {*pool1Node1, *pool1Node2},
+				"pool2": {},
+			}))
+		})
+	})
+
 	Describe("#GetSingleRunningNodePerWorker", func() {
 		var (
 			nodes     []corev1.Node
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy
+
+import (
+	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedauditpolicy "github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+)
+
+// FromGenericConfig creates an Audit Policy Validation Ruleset for a shoot cluster from a RulesetConfig.
+// The audit policy of the kube-apiserver in the shoot namespace of the seed cluster is checked.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, seedConfig *rest.Config, shootNamespace string) (*sharedauditpolicy.Ruleset, error) {
+	seedClient, err := client.New(seedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedauditpolicy.FromGenericConfig(rulesetConfig, sharedauditpolicy.APIServer{
+		Client:         seedClient,
+		Namespace:      shootNamespace,
+		DeploymentName: "kube-apiserver",
+		ContainerName:  "kube-apiserver",
+		Target:         rule.NewTarget("cluster", "seed"),
+	})
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedcertificates "github.com/gardener/diki/pkg/shared/ruleset/certificates"
+)
+
+// FromGenericConfig creates a Certificate Inventory and Expiry Ruleset for a shoot cluster from a RulesetConfig.
+// The TLS secrets of the shoot cluster as well as the TLS secrets and the control plane components
+// in the shoot namespace of the seed cluster are checked.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, shootConfig, seedConfig *rest.Config, shootNamespace string) (*sharedcertificates.Ruleset, error) {
+	shootClient, err := client.New(shootConfig, client.Options{Scheme: kubernetesgardener.ShootScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	seedClient, err := client.New(seedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	shootTarget := rule.NewTarget("cluster", "shoot")
+	seedTarget := rule.NewTarget("cluster", "seed")
+	sources := sharedcertificates.Sources{
+		Secrets: []sharedcertificates.SecretSource{
+			{Client: shootClient, Target: shootTarget},
+			{Client: seedClient, Namespace: shootNamespace, Target: seedTarget},
+		},
+	}
+	for _, name := range []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler"} {
+		sources.Components = append(sources.Components, sharedcertificates.ComponentSource{
+			Client:         seedClient,
+			Namespace:      shootNamespace,
+			DeploymentName: name,
+			ContainerName:  name,
+			Target:         seedTarget,
+		})
+	}
+
+	return sharedcertificates.FromGenericConfig(rulesetConfig, sources)
+}
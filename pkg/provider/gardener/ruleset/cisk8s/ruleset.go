@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"fmt"
+	"slices"
+
+	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig/v1r11"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	sharedcisk8s "github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
+)
+
+// nodeFilesRuleIDs are the ids of the DISA Kubernetes STIG rules which are implemented by the rule node-files.
+var nodeFilesRuleIDs = []string{
+	sharedv1r11.ID242406,
+	sharedv1r11.ID242407,
+	sharedv1r11.ID242449,
+	sharedv1r11.ID242450,
+	sharedv1r11.ID242452,
+	sharedv1r11.ID242453,
+}
+
+// disaRules returns the rules of the DISA Kubernetes STIG ruleset. The rules which are skipped by the ruleset
+// because they are implemented by the rule node-files are replaced by it, which then runs only once for all controls.
+// Node-files reads the paths of the kubelet files from the kubelet, so its check results are not filtered per control.
+type disaRules struct {
+	sharedruleset.RuleGetter
+	nodeFiles rule.Rule
+}
+
+func newDISARules(rules sharedruleset.RuleGetter) (*disaRules, error) {
+	nodeFiles, ok := rules.Rule(v1r11.IDNodeFiles)
+	if !ok {
+		return nil, fmt.Errorf("rule %s is not registered in the DISA Kubernetes STIG ruleset", v1r11.IDNodeFiles)
+	}
+	return &disaRules{RuleGetter: rules, nodeFiles: nodeFiles}, nil
+}
+
+// Rule returns the rule with the given id.
+func (r *disaRules) Rule(id string) (rule.Rule, bool) {
+	if slices.Contains(nodeFilesRuleIDs, id) {
+		return r.nodeFiles, true
+	}
+	return r.RuleGetter.Rule(id)
+}
+
+// FromGenericConfig creates a CIS Kubernetes Benchmark Ruleset for a shoot cluster from a RulesetConfig.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, shootConfig, seedConfig *rest.Config, shootNamespace string) (*sharedcisk8s.Ruleset, error) {
+	disaRuleset := func(disaRuleOptions []config.RuleOptionsConfig) (sharedruleset.RuleGetter, error) {
+		ruleset, err := disak8sstig.FromGenericConfig(config.RulesetConfig{
+			ID:          disak8sstig.RulesetID,
+			Version:     sharedcisk8s.DISAVersion,
+			RuleOptions: disaRuleOptions,
+		}, shootConfig, seedConfig, shootNamespace)
+		if err != nil {
+			return nil, err
+		}
+		return newDISARules(ruleset)
+	}
+
+	rules := func(controls []sharedcisk8s.Control) ([]rule.Rule, error) {
+		shootClient, err := client.New(shootConfig, client.Options{Scheme: kubernetesgardener.ShootScheme})
+		if err != nil {
+			return nil, err
+		}
+
+		seedClient, err := client.New(seedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+		if err != nil {
+			return nil, err
+		}
+
+		shootClientSet, err := kubernetes.NewForConfig(shootConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		var rules []rule.Rule
+		rules = append(rules, sharedcisk8s.APIServerOptionRules(controls, seedClient, shootNamespace, "kube-apiserver", "kube-apiserver")...)
+		rules = append(rules, sharedcisk8s.SchedulerOptionRules(controls, seedClient, shootNamespace, "kube-scheduler", "kube-scheduler")...)
+		rules = append(rules, sharedcisk8s.KubeletConfigRules(controls, shootClient, shootClientSet.CoreV1().RESTClient(), rule.NewTarget("cluster", "shoot"))...)
+		return rules, nil
+	}
+
+	return sharedcisk8s.FromGenericConfig(rulesetConfig, disaRuleset, rules)
+}
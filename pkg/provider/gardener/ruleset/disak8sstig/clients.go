@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package disak8sstig
+
+import (
+	"github.com/Masterminds/semver/v3"
+	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/kubernetes/pod"
+)
+
+// clients contains the clients and versions of the shoot and seed clusters
+// which are used by the rules of all ruleset versions.
+type clients struct {
+	shootClient, seedClient         client.Client
+	shootPodContext, seedPodContext *pod.SimplePodContext
+	shootClientSet                  kubernetes.Interface
+	shootVersion, seedVersion       *semver.Version
+}
+
+func (r *Ruleset) newClients() (*clients, error) {
+	shootClient, err := client.New(r.ShootConfig, client.Options{Scheme: kubernetesgardener.ShootScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	seedClient, err := client.New(r.SeedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	shootPodContext, err := pod.NewSimplePodContext(shootClient, r.ShootConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	seedPodContext, err := pod.NewSimplePodContext(seedClient, r.SeedConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	shootClientSet, err := kubernetes.NewForConfig(r.ShootConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	shootVersion, err := serverVersion(shootClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	seedClientSet, err := kubernetes.NewForConfig(r.SeedConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	seedVersion, err := serverVersion(seedClientSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients{
+		shootClient:     shootClient,
+		seedClient:      seedClient,
+		shootPodContext: shootPodContext,
+		seedPodContext:  seedPodContext,
+		shootClientSet:  shootClientSet,
+		shootVersion:    shootVersion,
+		seedVersion:     seedVersion,
+	}, nil
+}
+
+func serverVersion(clientSet kubernetes.Interface) (*semver.Version, error) {
+	kubernetesVersion, err := clientSet.Discovery().ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return semver.NewVersion(kubernetesVersion.String())
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
 	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	shareddisak8sstig "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig"
 )
 
 const (
@@ -34,6 +36,7 @@ type Ruleset struct {
 	numWorkers              int
 	instanceID              string
 	logger                  *slog.Logger
+	tailoring               *tailoring.Profile
 }
 
 // New creates a new Ruleset.
@@ -69,7 +72,6 @@ func (r *Ruleset) Version() string {
 
 // FromGenericConfig creates a Ruleset from a RulesetConfig
 func FromGenericConfig(rulesetConfig config.RulesetConfig, shootConfig, seedConfig *rest.Config, shootNamespace string) (*Ruleset, error) {
-	// TODO: add all known rules and validate
 	ruleset, err := New(
 		WithVersion(rulesetConfig.Version),
 		WithShootConfig(shootConfig),
@@ -91,10 +93,17 @@ func FromGenericConfig(rulesetConfig config.RulesetConfig, shootConfig, seedConf
 
 	switch rulesetConfig.Version {
 	case "v1r10":
+		// the rules of v1r10 do not have tailorable parameters
+		if ruleset.tailoring, err = shareddisak8sstig.ApplyTailoring(ruleset.Logger(), rulesetConfig, ruleOptions); err != nil {
+			return nil, err
+		}
 		if err := ruleset.registerV1R10Rules(ruleOptions); err != nil {
 			return nil, err
 		}
 	case "v1r11":
+		if ruleset.tailoring, err = shareddisak8sstig.ApplyTailoring(ruleset.Logger(), rulesetConfig, ruleOptions, v1r11TailorableRuleIDs...); err != nil {
+			return nil, err
+		}
 		if err := ruleset.registerV1R11Rules(ruleOptions); err != nil {
 			return nil, err
 		}
@@ -115,6 +124,12 @@ func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, erro
 	return rr.Run(ctx)
 }
 
+// Rule returns a registered Rule of the Ruleset.
+func (r *Ruleset) Rule(id string) (rule.Rule, bool) {
+	rr, ok := r.rules[id]
+	return rr, ok
+}
+
 // Run executes all known Rules of the Ruleset.
 func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
 	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
@@ -131,6 +146,11 @@ func (r *Ruleset) AddRules(rules ...rule.Rule) error {
 	return nil
 }
 
+// Tailoring returns the tailoring profile applied to the Ruleset.
+func (r *Ruleset) Tailoring() *tailoring.Profile {
+	return r.tailoring
+}
+
 // Logger returns the Ruleset's logger.
 // If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
 func (r *Ruleset) Logger() *slog.Logger {
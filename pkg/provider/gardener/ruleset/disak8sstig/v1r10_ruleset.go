@@ -7,13 +7,7 @@ package disak8sstig
 import (
 	"encoding/json"
 
-	"github.com/Masterminds/semver/v3"
-	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
-	"k8s.io/client-go/kubernetes"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-
 	"github.com/gardener/diki/pkg/config"
-	"github.com/gardener/diki/pkg/kubernetes/pod"
 	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig/v1r10"
 	"github.com/gardener/diki/pkg/rule"
 )
@@ -40,55 +34,14 @@ func getV1R10OptionOrNil[O v1r10.RuleOption](options any) (*O, error) {
 }
 
 func (r *Ruleset) registerV1R10Rules(ruleOptions map[string]config.RuleOptionsConfig) error { // TODO: add to FromGenericConfig
-	shootClient, err := client.New(r.ShootConfig, client.Options{Scheme: kubernetesgardener.ShootScheme})
-	if err != nil {
-		return err
-	}
-
-	seedClient, err := client.New(r.SeedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
-	if err != nil {
-		return err
-	}
-
-	shootPodContext, err := pod.NewSimplePodContext(shootClient, r.ShootConfig)
-	if err != nil {
-		return err
-	}
-
-	seedPodContext, err := pod.NewSimplePodContext(seedClient, r.SeedConfig)
-	if err != nil {
-		return err
-	}
-
-	shootClientSet, err := kubernetes.NewForConfig(r.ShootConfig)
-	if err != nil {
-		return err
-	}
-
-	shootKubernetesVersion, err := shootClientSet.Discovery().ServerVersion()
-	if err != nil {
-		return err
-	}
-
-	semverShootKubernetesVersion, err := semver.NewVersion(shootKubernetesVersion.String())
-	if err != nil {
-		return err
-	}
-
-	seedClientSet, err := kubernetes.NewForConfig(r.SeedConfig)
-	if err != nil {
-		return err
-	}
-
-	seedKubernetesVersion, err := seedClientSet.Discovery().ServerVersion()
-	if err != nil {
-		return err
-	}
-
-	semverSeedKubernetesVersion, err := semver.NewVersion(seedKubernetesVersion.String())
+	c, err := r.newClients()
 	if err != nil {
 		return err
 	}
+	shootClient, seedClient := c.shootClient, c.seedClient
+	shootPodContext, seedPodContext := c.shootPodContext, c.seedPodContext
+	shootClientSet := c.shootClientSet
+	semverShootKubernetesVersion, semverSeedKubernetesVersion := c.shootVersion, c.seedVersion
 
 	opts242414, err := getV1R10OptionOrNil[v1r10.Options242414](ruleOptions[v1r10.ID242414].Args)
 	if err != nil {
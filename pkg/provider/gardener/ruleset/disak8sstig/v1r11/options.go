@@ -106,5 +106,16 @@ const (
 )
 
 type RuleOption interface {
-	Options242414 | Options242415 | sharedv1r11.Options245543 | sharedv1r11.Options254800 | option.FileOwnerOptions
+	Options242414 |
+		Options242415 |
+		sharedv1r11.Options242376 |
+		sharedv1r11.Options242378 |
+		sharedv1r11.Options242418 |
+		sharedv1r11.Options242443 |
+		sharedv1r11.Options242462 |
+		sharedv1r11.Options242463 |
+		sharedv1r11.Options242464 |
+		sharedv1r11.Options245543 |
+		sharedv1r11.Options254800 |
+		option.FileOwnerOptions
 }
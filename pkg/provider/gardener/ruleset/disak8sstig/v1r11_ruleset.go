@@ -6,20 +6,30 @@ package disak8sstig
 
 import (
 	"encoding/json"
+	"fmt"
 
-	"github.com/Masterminds/semver/v3"
-	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
-	"k8s.io/client-go/kubernetes"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/gardener/diki/pkg/config"
-	"github.com/gardener/diki/pkg/kubernetes/pod"
 	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig/v1r11"
 	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
 	option "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/option"
 	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
 )
 
+// v1r11TailorableRuleIDs are the ids of the v1r11 rules whose parameters can be set by a tailoring profile.
+var v1r11TailorableRuleIDs = []string{
+	sharedv1r11.ID242376,
+	sharedv1r11.ID242378,
+	sharedv1r11.ID242418,
+	sharedv1r11.ID242462,
+	sharedv1r11.ID242463,
+	sharedv1r11.ID242464,
+	sharedv1r11.ID254800,
+}
+
 func parseV1R11Options[O v1r11.RuleOption](options any) (*O, error) {
 	optionsByte, err := json.Marshal(options)
 	if err != nil {
@@ -41,95 +51,105 @@ func getV1R11OptionOrNil[O v1r11.RuleOption](options any) (*O, error) {
 	return parseV1R11Options[O](options)
 }
 
-func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsConfig) error { // TODO: add to FromGenericConfig
-	shootClient, err := client.New(r.ShootConfig, client.Options{Scheme: kubernetesgardener.ShootScheme})
+func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsConfig) error {
+	rules, err := r.v1r11Rules(ruleOptions)
 	if err != nil {
 		return err
 	}
+	return r.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...)
+}
 
-	seedClient, err := client.New(r.SeedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+func (r *Ruleset) v1r11Rules(ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	c, err := r.newClients()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	shootClient, seedClient := c.shootClient, c.seedClient
+	shootPodContext, seedPodContext := c.shootPodContext, c.seedPodContext
+	shootClientSet := c.shootClientSet
+	semverShootKubernetesVersion, semverSeedKubernetesVersion := c.shootVersion, c.seedVersion
 
-	shootPodContext, err := pod.NewSimplePodContext(shootClient, r.ShootConfig)
+	opts242414, err := getV1R11OptionOrNil[v1r11.Options242414](ruleOptions[v1r11.ID242414].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	seedPodContext, err := pod.NewSimplePodContext(seedClient, r.SeedConfig)
+	opts242415, err := getV1R11OptionOrNil[v1r11.Options242415](ruleOptions[v1r11.ID242415].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	shootClientSet, err := kubernetes.NewForConfig(r.ShootConfig)
+	opts242376, err := getV1R11OptionOrNil[sharedv1r11.Options242376](ruleOptions[sharedv1r11.ID242376].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	shootKubernetesVersion, err := shootClientSet.Discovery().ServerVersion()
-	if err != nil {
-		return err
+	if opts242376 != nil {
+		if err := opts242376.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of rule %s: %w", sharedv1r11.ID242376, err)
+		}
 	}
-
-	semverShootKubernetesVersion, err := semver.NewVersion(shootKubernetesVersion.String())
+	opts242378, err := getV1R11OptionOrNil[sharedv1r11.Options242378](ruleOptions[sharedv1r11.ID242378].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	seedClientSet, err := kubernetes.NewForConfig(r.SeedConfig)
+	if opts242378 != nil {
+		if err := opts242378.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of rule %s: %w", sharedv1r11.ID242378, err)
+		}
+	}
+	opts242418, err := getV1R11OptionOrNil[sharedv1r11.Options242418](ruleOptions[sharedv1r11.ID242418].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	seedKubernetesVersion, err := seedClientSet.Discovery().ServerVersion()
+	opts242443, err := getV1R11OptionOrNil[sharedv1r11.Options242443](ruleOptions[sharedv1r11.ID242443].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	semverSeedKubernetesVersion, err := semver.NewVersion(seedKubernetesVersion.String())
+	if opts242443 != nil {
+		if err := opts242443.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of rule %s: %w", sharedv1r11.ID242443, err)
+		}
+	}
+	opts242462, err := getV1R11OptionOrNil[sharedv1r11.Options242462](ruleOptions[sharedv1r11.ID242462].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	opts242414, err := getV1R11OptionOrNil[v1r11.Options242414](ruleOptions[v1r11.ID242414].Args)
+	opts242463, err := getV1R11OptionOrNil[sharedv1r11.Options242463](ruleOptions[sharedv1r11.ID242463].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	opts242415, err := getV1R11OptionOrNil[v1r11.Options242415](ruleOptions[v1r11.ID242415].Args)
+	opts242464, err := getV1R11OptionOrNil[sharedv1r11.Options242464](ruleOptions[sharedv1r11.ID242464].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts242445, err := getV1R11OptionOrNil[option.FileOwnerOptions](ruleOptions[sharedv1r11.ID242445].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts242446, err := getV1R11OptionOrNil[option.FileOwnerOptions](ruleOptions[sharedv1r11.ID242446].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts245543, err := getV1R11OptionOrNil[sharedv1r11.Options245543](ruleOptions[v1r11.ID245543].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts242451, err := getV1R11OptionOrNil[option.FileOwnerOptions](ruleOptions[sharedv1r11.ID242451].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts254800, err := getV1R11OptionOrNil[sharedv1r11.Options254800](ruleOptions[v1r11.ID254800].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	optsPodFiles, err := getV1R11OptionOrNil[option.FileOwnerOptions](ruleOptions[v1r11.IDPodFiles].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rules := []rule.Rule{
-		&sharedv1r11.Rule242376{Client: seedClient, Namespace: r.shootNamespace},
+		&sharedv1r11.Rule242376{Client: seedClient, Namespace: r.shootNamespace, Options: opts242376},
 		&v1r11.Rule242377{Logger: r.Logger().With("rule", sharedv1r11.ID242377), Client: seedClient, Namespace: r.shootNamespace},
-		&sharedv1r11.Rule242378{Client: seedClient, Namespace: r.shootNamespace},
+		&sharedv1r11.Rule242378{Client: seedClient, Namespace: r.shootNamespace, Options: opts242378},
 		&sharedv1r11.Rule242379{Client: seedClient, Namespace: r.shootNamespace},
 		&sharedv1r11.Rule242380{Client: seedClient, Namespace: r.shootNamespace},
 		&sharedv1r11.Rule242381{Client: seedClient, Namespace: r.shootNamespace},
@@ -293,7 +313,7 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 			Options:               opts242415,
 		},
 		&v1r11.Rule242417{Logger: r.Logger().With("rule", sharedv1r11.ID242417), Client: shootClient},
-		&sharedv1r11.Rule242418{Client: seedClient, Namespace: r.shootNamespace},
+		&sharedv1r11.Rule242418{Client: seedClient, Namespace: r.shootNamespace, Options: opts242418},
 		&sharedv1r11.Rule242419{Client: seedClient, Namespace: r.shootNamespace},
 		&v1r11.Rule242420{
 			Logger:                  r.Logger().With("rule", sharedv1r11.ID242420),
@@ -353,12 +373,13 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		},
 		&sharedv1r11.Rule242438{Client: seedClient, Namespace: r.shootNamespace},
 		&v1r11.Rule242442{Logger: r.Logger().With("rule", sharedv1r11.ID242442), ClusterClient: shootClient, ControlPlaneClient: seedClient, ControlPlaneNamespace: r.shootNamespace},
-		rule.NewSkipRule(
-			sharedv1r11.ID242443,
-			"Kubernetes must contain the latest updates as authorized by IAVMs, CTOs, DTMs, and STIGs (MEDIUM 242443)",
-			"Scanning/patching security vulnerabilities should be enforced organizationally. Security vulnerability scanning should be automated and maintainers should be informed automatically.",
-			rule.Skipped,
-		),
+		&sharedv1r11.Rule242443{
+			PodSources: []sharedv1r11.PodSource{
+				{Client: seedClient, Namespace: r.shootNamespace, Target: rule.NewTarget("cluster", "seed")},
+				{Client: shootClient, Selector: labels.SelectorFromSet(labels.Set{resourcesv1alpha1.ManagedBy: "gardener"}), Target: rule.NewTarget("cluster", "shoot")},
+			},
+			Options: opts242443,
+		},
 		rule.NewSkipRule(
 			sharedv1r11.ID242444,
 			"Kubernetes component manifests must be owned by root (MEDIUM 242444)",
@@ -464,9 +485,9 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 			Namespace:  r.shootNamespace,
 		},
 		&sharedv1r11.Rule242461{Client: seedClient, Namespace: r.shootNamespace},
-		&sharedv1r11.Rule242462{Client: seedClient, Namespace: r.shootNamespace},
-		&sharedv1r11.Rule242463{Client: seedClient, Namespace: r.shootNamespace},
-		&sharedv1r11.Rule242464{Client: seedClient, Namespace: r.shootNamespace},
+		&sharedv1r11.Rule242462{Client: seedClient, Namespace: r.shootNamespace, Options: opts242462},
+		&sharedv1r11.Rule242463{Client: seedClient, Namespace: r.shootNamespace, Options: opts242463},
+		&sharedv1r11.Rule242464{Client: seedClient, Namespace: r.shootNamespace, Options: opts242464},
 		rule.NewSkipRule(
 			sharedv1r11.ID242465,
 			"Kubernetes API Server audit log path must be set (MEDIUM 242465)",
@@ -529,12 +550,5 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		},
 	}
 
-	for i, r := range rules {
-		opt, found := ruleOptions[r.ID()]
-		if found && opt.Skip != nil && opt.Skip.Enabled {
-			rules[i] = rule.NewSkipRule(r.ID(), r.Name(), opt.Skip.Justification, rule.Accepted)
-		}
-	}
-
-	return r.AddRules(rules...)
+	return rules, nil
 }
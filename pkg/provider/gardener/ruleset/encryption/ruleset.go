@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedencryption "github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+// FromGenericConfig creates an Encryption at Rest Ruleset for a shoot cluster from a RulesetConfig.
+// The encryption configuration of the kube-apiserver in the shoot namespace of the seed cluster is checked.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, seedConfig *rest.Config, shootNamespace string) (*sharedencryption.Ruleset, error) {
+	seedClient, err := client.New(seedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedencryption.FromGenericConfig(rulesetConfig, &sharedencryption.APIServer{
+		Client:         seedClient,
+		Namespace:      shootNamespace,
+		DeploymentName: "kube-apiserver",
+		ContainerName:  "kube-apiserver",
+		Target:         rule.NewTarget("cluster", "seed"),
+	})
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/kubernetes/pod"
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/provider/gardener/internal/utils"
+	"github.com/gardener/diki/pkg/rule"
+	sharednodedrift "github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+)
+
+// FromGenericConfig creates a Node Configuration Drift Ruleset for a shoot cluster from a RulesetConfig.
+// The ready nodes with an allocatable pod spot of every worker group of the shoot are sampled and compared.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, shootConfig, seedConfig *rest.Config, shootNamespace string) (*sharednodedrift.Ruleset, error) {
+	shootClient, err := client.New(shootConfig, client.Options{Scheme: kubernetesgardener.ShootScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	seedClient, err := client.New(seedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+	if err != nil {
+		return nil, err
+	}
+
+	shootPodContext, err := pod.NewSimplePodContext(shootClient, shootConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return sharednodedrift.FromGenericConfig(rulesetConfig, sharednodedrift.Nodes{
+		PodContext: shootPodContext,
+		WorkerGroups: func(ctx context.Context) ([]sharednodedrift.WorkerGroup, error) {
+			return workerGroups(ctx, shootClient, seedClient, shootNamespace)
+		},
+		InstanceID: uuid.New().String(),
+		Target:     rule.NewTarget("cluster", "shoot"),
+	})
+}
+
+func workerGroups(ctx context.Context, shootClient, seedClient client.Client, shootNamespace string) ([]sharednodedrift.WorkerGroup, error) {
+	nodes, err := kubeutils.GetNodes(ctx, shootClient, 300)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := kubeutils.GetPods(ctx, shootClient, "", labels.NewSelector(), 300)
+	if err != nil {
+		return nil, err
+	}
+
+	workers, err := utils.GetWorkers(ctx, seedClient, shootNamespace, 300)
+	if err != nil {
+		return nil, err
+	}
+
+	nodesAllocatablePodsNum := kubeutils.GetNodesAllocatablePodsNum(pods, nodes)
+	workerGroupNodes := utils.GetAllocatableNodesPerWorker(workers, nodes, nodesAllocatablePodsNum)
+
+	workerGroups := make([]sharednodedrift.WorkerGroup, 0, len(workerGroupNodes))
+	for name, nodes := range workerGroupNodes {
+		workerGroup := sharednodedrift.WorkerGroup{Name: name}
+		for _, node := range nodes {
+			workerGroup.NodeNames = append(workerGroup.NodeNames, node.Name)
+		}
+		workerGroups = append(workerGroups, workerGroup)
+	}
+	slices.SortFunc(workerGroups, func(a, b sharednodedrift.WorkerGroup) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return workerGroups, nil
+}
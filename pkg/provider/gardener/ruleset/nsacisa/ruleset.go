@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/gardener/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	sharedencryption "github.com/gardener/diki/pkg/shared/ruleset/encryption"
+	sharednsacisa "github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+// FromGenericConfig creates a NSA/CISA Kubernetes Hardening Guidance Ruleset for a shoot cluster from a RulesetConfig.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, shootConfig, seedConfig *rest.Config, shootNamespace string) (*sharednsacisa.Ruleset, error) {
+	disaRuleset := func(disaRuleOptions []config.RuleOptionsConfig) (sharedruleset.RuleGetter, error) {
+		return disak8sstig.FromGenericConfig(config.RulesetConfig{
+			ID:          disak8sstig.RulesetID,
+			Version:     sharednsacisa.DISAVersion,
+			RuleOptions: disaRuleOptions,
+		}, shootConfig, seedConfig, shootNamespace)
+	}
+
+	rules := func(recommendations []sharednsacisa.Recommendation, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+		shootClient, err := client.New(shootConfig, client.Options{Scheme: kubernetesgardener.ShootScheme})
+		if err != nil {
+			return nil, err
+		}
+
+		seedClient, err := client.New(seedConfig, client.Options{Scheme: kubernetesgardener.SeedScheme})
+		if err != nil {
+			return nil, err
+		}
+
+		shootTarget := rule.NewTarget("cluster", "shoot")
+		podRules, err := sharednsacisa.PodRules(recommendations, shootClient, shootTarget, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaceRules, err := sharednsacisa.NamespaceRules(recommendations, shootClient, shootTarget, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		encryptionRules, err := sharednsacisa.EncryptionRules(recommendations, &sharedencryption.APIServer{
+			Client:         seedClient,
+			Namespace:      shootNamespace,
+			DeploymentName: "kube-apiserver",
+			ContainerName:  "kube-apiserver",
+			Target:         rule.NewTarget("cluster", "seed"),
+		}, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		rbacRules, err := sharednsacisa.RBACRules(recommendations, shootClient, shootTarget, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		var rules []rule.Rule
+		rules = append(rules, podRules...)
+		rules = append(rules, namespaceRules...)
+		rules = append(rules, encryptionRules...)
+		rules = append(rules, rbacRules...)
+		return rules, nil
+	}
+
+	return sharednsacisa.FromGenericConfig(rulesetConfig, disaRuleset, rules)
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	sharedcertificates "github.com/gardener/diki/pkg/shared/ruleset/certificates"
+)
+
+// FromGenericConfig creates a Certificate Inventory and Expiry Ruleset for a managed cluster from a RulesetConfig.
+// Only the TLS secrets are checked since the control plane components of managed clusters are not accessible.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, managedConfig *rest.Config) (*sharedcertificates.Ruleset, error) {
+	managedClient, err := client.New(managedConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedcertificates.FromGenericConfig(rulesetConfig, sharedcertificates.Sources{
+		Secrets: []sharedcertificates.SecretSource{{Client: managedClient}},
+	})
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+	sharedcisk8s "github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+)
+
+// FromGenericConfig creates a CIS Kubernetes Benchmark Ruleset for a managed cluster from a RulesetConfig.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, managedConfig *rest.Config) (*sharedcisk8s.Ruleset, error) {
+	disaRuleset := func(disaRuleOptions []config.RuleOptionsConfig) (sharedruleset.RuleGetter, error) {
+		return disak8sstig.FromGenericConfig(config.RulesetConfig{
+			ID:          disak8sstig.RulesetID,
+			Version:     sharedcisk8s.DISAVersion,
+			RuleOptions: disaRuleOptions,
+		}, managedConfig)
+	}
+
+	rules := func(controls []sharedcisk8s.Control) ([]rule.Rule, error) {
+		c, err := client.New(managedConfig, client.Options{})
+		if err != nil {
+			return nil, err
+		}
+
+		clientSet, err := kubernetes.NewForConfig(managedConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		const (
+			noControlPlaneMsg = "The Managed Kubernetes cluster does not have access to control plane components."
+		)
+		var rules []rule.Rule
+		rules = append(rules, catalog.SkipRules(sharedcisk8s.APIServerOptionRules(controls, nil, "", "", ""), noControlPlaneMsg)...)
+		rules = append(rules, catalog.SkipRules(sharedcisk8s.SchedulerOptionRules(controls, nil, "", "", ""), noControlPlaneMsg)...)
+		rules = append(rules, sharedcisk8s.KubeletConfigRules(controls, c, clientSet.CoreV1().RESTClient(), rule.NewTarget())...)
+		return rules, nil
+	}
+
+	return sharedcisk8s.FromGenericConfig(rulesetConfig, disaRuleset, rules)
+}
@@ -4,6 +4,11 @@
 
 package v1r11
 
+import (
+	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
+)
+
 type RuleOption interface {
-	Options242415
+	Options242415 |
+		sharedv1r11.Options242443
 }
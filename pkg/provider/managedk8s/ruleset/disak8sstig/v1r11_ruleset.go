@@ -6,24 +6,43 @@ package disak8sstig
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig/v1r11"
 	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
 	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
 )
 
-func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsConfig) error { // TODO: add to FromGenericConfig
-	client, err := client.New(r.Config, client.Options{})
+func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsConfig) error {
+	rules, err := r.v1r11Rules(ruleOptions)
 	if err != nil {
 		return err
 	}
+	return r.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...)
+}
+
+func (r *Ruleset) v1r11Rules(ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	client, err := client.New(r.Config, client.Options{})
+	if err != nil {
+		return nil, err
+	}
 
 	opts242415, err := getV1R11OptionOrNil[v1r11.Options242415](ruleOptions[sharedv1r11.ID242415].Args)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	opts242443, err := getV1R11OptionOrNil[sharedv1r11.Options242443](ruleOptions[sharedv1r11.ID242443].Args)
+	if err != nil {
+		return nil, err
+	}
+	if opts242443 != nil {
+		if err := opts242443.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of rule %s: %w", sharedv1r11.ID242443, err)
+		}
 	}
 
 	const (
@@ -396,12 +415,10 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 			"",
 			rule.NotImplemented,
 		),
-		rule.NewSkipRule(
-			sharedv1r11.ID242443,
-			"Kubernetes must contain the latest updates as authorized by IAVMs, CTOs, DTMs, and STIGs (MEDIUM 242443)",
-			"Scanning/patching security vulnerabilities should be enforced organizationally. Security vulnerability scanning should be automated and maintainers should be informed automatically.",
-			rule.Skipped,
-		),
+		&sharedv1r11.Rule242443{
+			PodSources: []sharedv1r11.PodSource{{Client: client}},
+			Options:    opts242443,
+		},
 		rule.NewSkipRule(
 			sharedv1r11.ID242444,
 			"Kubernetes component manifests must be owned by root (MEDIUM 242444)",
@@ -578,14 +595,7 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		),
 	}
 
-	for i, r := range rules {
-		opt, found := ruleOptions[r.ID()]
-		if found && opt.Skip != nil && opt.Skip.Enabled {
-			rules[i] = rule.NewSkipRule(r.ID(), r.Name(), opt.Skip.Justification, rule.Accepted)
-		}
-	}
-
-	return r.AddRules(rules...)
+	return rules, nil
 }
 
 func parseV1R11Options[O v1r11.RuleOption](options any) (*O, error) {
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	sharednsacisa "github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+// FromGenericConfig creates a NSA/CISA Kubernetes Hardening Guidance Ruleset for a managed cluster from a RulesetConfig.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, managedConfig *rest.Config) (*sharednsacisa.Ruleset, error) {
+	disaRuleset := func(disaRuleOptions []config.RuleOptionsConfig) (sharedruleset.RuleGetter, error) {
+		return disak8sstig.FromGenericConfig(config.RulesetConfig{
+			ID:          disak8sstig.RulesetID,
+			Version:     sharednsacisa.DISAVersion,
+			RuleOptions: disaRuleOptions,
+		}, managedConfig)
+	}
+
+	rules := func(recommendations []sharednsacisa.Recommendation, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+		c, err := client.New(managedConfig, client.Options{})
+		if err != nil {
+			return nil, err
+		}
+
+		podRules, err := sharednsacisa.PodRules(recommendations, c, rule.NewTarget(), ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaceRules, err := sharednsacisa.NamespaceRules(recommendations, c, rule.NewTarget(), ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		// the encryption configuration of the kube-apiserver is not visible for managed clusters
+		encryptionRules, err := sharednsacisa.EncryptionRules(recommendations, nil, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		rbacRules, err := sharednsacisa.RBACRules(recommendations, c, rule.NewTarget(), ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		var rules []rule.Rule
+		rules = append(rules, podRules...)
+		rules = append(rules, namespaceRules...)
+		rules = append(rules, encryptionRules...)
+		rules = append(rules, rbacRules...)
+		return rules, nil
+	}
+
+	return sharednsacisa.FromGenericConfig(rulesetConfig, disaRuleset, rules)
+}
@@ -30,5 +30,6 @@ type ProviderResult struct {
 	RulesetResults []ruleset.RulesetResult
 }
 
-// ProviderFromConfigFunc constructs a Provider from ProviderConfig.
-type ProviderFromConfigFunc func(conf config.ProviderConfig) (Provider, error)
+// ProviderFromConfigFunc constructs a Provider from ProviderConfig. The context is used
+// for the setup of the provider, e.g. to start rulesets implemented by plugins.
+type ProviderFromConfigFunc func(ctx context.Context, conf config.ProviderConfig) (Provider, error)
@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider"
+	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// ProviderFactory describes a provider which can be created from a provider config.
+type ProviderFactory struct {
+	// ID is the id of the provider.
+	ID string
+	// FromConfig creates the provider.
+	FromConfig provider.ProviderFromConfigFunc
+}
+
+// ProviderRegistry contains the providers known to diki
+// and the version histories of their rulesets.
+type ProviderRegistry struct {
+	mu          sync.RWMutex
+	factories   map[string]provider.ProviderFromConfigFunc
+	histories   map[string]*history.History
+	unversioned map[string]string
+}
+
+// NewProviderRegistry creates a new ProviderRegistry with the given factories.
+// Panics if a factory is invalid or more than one factory has the same id.
+func NewProviderRegistry(factories ...ProviderFactory) *ProviderRegistry {
+	r := &ProviderRegistry{
+		factories:   map[string]provider.ProviderFromConfigFunc{},
+		histories:   map[string]*history.History{},
+		unversioned: map[string]string{},
+	}
+	for _, factory := range factories {
+		if err := r.Register(factory); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register registers a provider factory.
+func (r *ProviderRegistry) Register(factory ProviderFactory) error {
+	if len(factory.ID) == 0 {
+		return errors.New("provider id must be set")
+	}
+	if factory.FromConfig == nil {
+		return fmt.Errorf("provider %s does not have a FromConfig function", factory.ID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.factories[factory.ID]; ok {
+		return fmt.Errorf("provider with id %s is already registered", factory.ID)
+	}
+	r.factories[factory.ID] = factory.FromConfig
+	return nil
+}
+
+// Get returns the function which creates providers with the given id.
+func (r *ProviderRegistry) Get(id string) (provider.ProviderFromConfigFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fromConfig, ok := r.factories[id]
+	return fromConfig, ok
+}
+
+// IDs returns the sorted ids of all registered providers.
+func (r *ProviderRegistry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.factories))
+	for id := range r.factories {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// RegisterHistory registers the version history of a ruleset.
+func (r *ProviderRegistry) RegisterHistory(h *history.History) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.histories[h.RulesetID()]; ok {
+		return fmt.Errorf("history of ruleset %s is already registered", h.RulesetID())
+	}
+	if _, ok := r.unversioned[h.RulesetID()]; ok {
+		return fmt.Errorf("ruleset %s is already registered without history", h.RulesetID())
+	}
+	r.histories[h.RulesetID()] = h
+	return nil
+}
+
+// RegisterUnversioned declares a ruleset without a version history, e.g. because its rules are
+// declared in the configuration. Its history is rejected with the reason instead of being unknown.
+func (r *ProviderRegistry) RegisterUnversioned(rulesetID, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.histories[rulesetID]; ok {
+		return fmt.Errorf("history of ruleset %s is already registered", rulesetID)
+	}
+	if _, ok := r.unversioned[rulesetID]; ok {
+		return fmt.Errorf("ruleset %s is already registered without history", rulesetID)
+	}
+	r.unversioned[rulesetID] = reason
+	return nil
+}
+
+// History returns the version history of a ruleset. It returns an error
+// for unknown rulesets and for rulesets without a version history.
+func (r *ProviderRegistry) History(rulesetID string) (*history.History, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if reason, ok := r.unversioned[rulesetID]; ok {
+		return nil, fmt.Errorf("ruleset %s does not have a version history: %s", rulesetID, reason)
+	}
+	h, ok := r.histories[rulesetID]
+	if !ok {
+		return nil, fmt.Errorf("unknown ruleset identifier: %s", rulesetID)
+	}
+	return h, nil
+}
+
+// RulesetFromConfigFunc creates a ruleset for a provider of type P.
+// The logger is already scoped to the provider, ruleset and version.
+type RulesetFromConfigFunc[P any] func(rulesetConfig config.RulesetConfig, p P, logger *slog.Logger) (ruleset.Ruleset, error)
+
+// RuleOptionsType describes the type of the args of the rule options of a rule.
+type RuleOptionsType struct {
+	// RuleID is the id of the rule.
+	RuleID string
+	// Versions are the ruleset versions in which the rule accepts args of the type.
+	// The type applies to all versions when empty.
+	Versions []string
+	// Validate returns an error if the args of the rule options cannot be used by the rule.
+	Validate func(args any) error
+}
+
+// AppliesTo returns whether the type describes the args of the rule in a ruleset version.
+func (t RuleOptionsType) AppliesTo(version string) bool {
+	return len(t.Versions) == 0 || slices.Contains(t.Versions, version)
+}
+
+// NewRuleOptionsType creates a RuleOptionsType for a rule whose args are decoded into options
+// of type O. The args must not contain unknown fields. Options which implement
+// `Validate() error` are validated after they are decoded.
+func NewRuleOptionsType[O any](ruleID string) RuleOptionsType {
+	return RuleOptionsType{
+		RuleID: ruleID,
+		Validate: func(args any) error {
+			argsByte, err := json.Marshal(args)
+			if err != nil {
+				return err
+			}
+
+			var options O
+			decoder := json.NewDecoder(bytes.NewReader(argsByte))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&options); err != nil {
+				return err
+			}
+
+			if v, ok := any(&options).(interface{ Validate() error }); ok {
+				return v.Validate()
+			}
+			return nil
+		},
+	}
+}
+
+// RulesetFactory describes a ruleset which can be created for providers of type P.
+type RulesetFactory[P any] struct {
+	// ID is the id of the ruleset.
+	ID string
+	// Versions are the supported versions of the ruleset.
+	// All versions are accepted when empty.
+	Versions []string
+	// RuleOptions are the types of the rule options of the rules of the ruleset. The rule
+	// options of these rules are validated before the ruleset is created and the args
+	// of all other rules are rejected.
+	RuleOptions []RuleOptionsType
+	// FromConfig creates the ruleset.
+	FromConfig RulesetFromConfigFunc[P]
+}
+
+// RulesetRegistry contains the rulesets which can be created for providers of type P.
+type RulesetRegistry[P any] struct {
+	mu        sync.RWMutex
+	factories map[string]RulesetFactory[P]
+}
+
+// NewRulesetRegistry creates a new RulesetRegistry with the given factories.
+// Panics if a factory is invalid or more than one factory has the same id.
+func NewRulesetRegistry[P any](factories ...RulesetFactory[P]) *RulesetRegistry[P] {
+	r := &RulesetRegistry[P]{
+		factories: map[string]RulesetFactory[P]{},
+	}
+	for _, factory := range factories {
+		if err := r.Register(factory); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register registers a ruleset factory.
+func (r *RulesetRegistry[P]) Register(factory RulesetFactory[P]) error {
+	if len(factory.ID) == 0 {
+		return errors.New("ruleset id must be set")
+	}
+	if factory.FromConfig == nil {
+		return fmt.Errorf("ruleset %s does not have a FromConfig function", factory.ID)
+	}
+	for _, ruleOptionsType := range factory.RuleOptions {
+		if len(ruleOptionsType.RuleID) == 0 || ruleOptionsType.Validate == nil {
+			return fmt.Errorf("ruleset %s has an invalid rule options type", factory.ID)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.factories[factory.ID]; ok {
+		return fmt.Errorf("ruleset with id %s is already registered", factory.ID)
+	}
+	r.factories[factory.ID] = factory
+	return nil
+}
+
+// Rulesets returns all registered ruleset factories sorted by their id.
+func (r *RulesetRegistry[P]) Rulesets() []RulesetFactory[P] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factories := make([]RulesetFactory[P], 0, len(r.factories))
+	for _, factory := range r.factories {
+		factories = append(factories, factory)
+	}
+	slices.SortFunc(factories, func(a, b RulesetFactory[P]) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return factories
+}
+
+// Validate validates the version and the rule options of the ruleset described by the ruleset config.
+// Args of rules without a rule options type in the version of the ruleset are rejected.
+func (r *RulesetRegistry[P]) Validate(rulesetConfig config.RulesetConfig) error {
+	r.mu.RLock()
+	factory, ok := r.factories[rulesetConfig.ID]
+	r.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("unknown ruleset identifier: %s", rulesetConfig.ID)
+	}
+	if len(factory.Versions) > 0 && !slices.Contains(factory.Versions, rulesetConfig.Version) {
+		return fmt.Errorf("unknown ruleset %s version: %s, supported versions are %v", rulesetConfig.ID, rulesetConfig.Version, factory.Versions)
+	}
+
+	for _, ruleOptions := range rulesetConfig.RuleOptions {
+		if ruleOptions.Args == nil {
+			continue
+		}
+		idx := slices.IndexFunc(factory.RuleOptions, func(ruleOptionsType RuleOptionsType) bool {
+			return ruleOptionsType.RuleID == ruleOptions.RuleID && ruleOptionsType.AppliesTo(rulesetConfig.Version)
+		})
+		if idx < 0 {
+			return fmt.Errorf("invalid options of rule %s of ruleset %s: rule does not accept args in version %s", ruleOptions.RuleID, rulesetConfig.ID, rulesetConfig.Version)
+		}
+		if err := factory.RuleOptions[idx].Validate(ruleOptions.Args); err != nil {
+			return fmt.Errorf("invalid options of rule %s of ruleset %s: %w", ruleOptions.RuleID, rulesetConfig.ID, err)
+		}
+	}
+	return nil
+}
+
+// FromConfig creates the ruleset described by the ruleset config for the provider.
+// The ruleset config is validated with [RulesetRegistry.Validate] before the ruleset is created.
+func (r *RulesetRegistry[P]) FromConfig(rulesetConfig config.RulesetConfig, p P, providerLogger *slog.Logger) (ruleset.Ruleset, error) {
+	if err := r.Validate(rulesetConfig); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	factory := r.factories[rulesetConfig.ID]
+	r.mu.RUnlock()
+
+	return factory.FromConfig(rulesetConfig, p, providerLogger.With("ruleset", rulesetConfig.ID, "version", rulesetConfig.Version))
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider"
+	"github.com/gardener/diki/pkg/provider/registry"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+type fakeProvider struct {
+	namespace string
+}
+
+type fakeRuleset struct {
+	id, version, namespace string
+}
+
+func (r *fakeRuleset) ID() string      { return r.id }
+func (r *fakeRuleset) Name() string    { return r.id }
+func (r *fakeRuleset) Version() string { return r.version }
+func (r *fakeRuleset) Run(context.Context) (ruleset.RulesetResult, error) {
+	return ruleset.RulesetResult{}, nil
+}
+func (r *fakeRuleset) RunRule(context.Context, string) (rule.RuleResult, error) {
+	return rule.RuleResult{}, nil
+}
+
+type fakeOptions struct {
+	Limit int `json:"limit"`
+}
+
+func (o *fakeOptions) Validate() error {
+	if o.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+	return nil
+}
+
+var _ = Describe("registry", func() {
+	Describe("ProviderRegistry", func() {
+		var fromConfig provider.ProviderFromConfigFunc = func(context.Context, config.ProviderConfig) (provider.Provider, error) {
+			return nil, nil
+		}
+
+		It("should register providers", func() {
+			providers := registry.NewProviderRegistry(registry.ProviderFactory{ID: "foo", FromConfig: fromConfig})
+			Expect(providers.Register(registry.ProviderFactory{ID: "bar", FromConfig: fromConfig})).To(Succeed())
+
+			Expect(providers.IDs()).To(Equal([]string{"bar", "foo"}))
+			_, ok := providers.Get("foo")
+			Expect(ok).To(BeTrue())
+			_, ok = providers.Get("baz")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not register a provider twice", func() {
+			providers := registry.NewProviderRegistry(registry.ProviderFactory{ID: "foo", FromConfig: fromConfig})
+
+			Expect(providers.Register(registry.ProviderFactory{ID: "foo", FromConfig: fromConfig})).To(MatchError("provider with id foo is already registered"))
+			Expect(func() {
+				registry.NewProviderRegistry(registry.ProviderFactory{ID: "foo", FromConfig: fromConfig}, registry.ProviderFactory{ID: "foo", FromConfig: fromConfig})
+			}).To(Panic())
+		})
+
+		It("should register ruleset histories", func() {
+			providers := registry.NewProviderRegistry()
+			h := history.MustNew("foo", history.Version{Version: "v1", Rules: []string{"1"}})
+
+			Expect(providers.RegisterHistory(h)).To(Succeed())
+			Expect(providers.RegisterHistory(h)).To(MatchError("history of ruleset foo is already registered"))
+
+			registered, err := providers.History("foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registered).To(BeIdenticalTo(h))
+			_, err = providers.History("bar")
+			Expect(err).To(MatchError("unknown ruleset identifier: bar"))
+		})
+
+		It("should reject the history of rulesets without history", func() {
+			providers := registry.NewProviderRegistry()
+
+			Expect(providers.RegisterUnversioned("foo", "its rules are declared in the configuration")).To(Succeed())
+			Expect(providers.RegisterUnversioned("foo", "other reason")).To(MatchError("ruleset foo is already registered without history"))
+			Expect(providers.RegisterHistory(history.MustNew("foo", history.Version{Version: "v1", Rules: []string{"1"}}))).To(MatchError("ruleset foo is already registered without history"))
+
+			_, err := providers.History("foo")
+			Expect(err).To(MatchError("ruleset foo does not have a version history: its rules are declared in the configuration"))
+		})
+	})
+
+	Describe("RulesetRegistry", func() {
+		var (
+			logger  = slog.Default()
+			p       = &fakeProvider{namespace: "ns"}
+			factory = registry.RulesetFactory[*fakeProvider]{
+				ID:       "foo",
+				Versions: []string{"v1", "v2"},
+				FromConfig: func(rulesetConfig config.RulesetConfig, p *fakeProvider, _ *slog.Logger) (ruleset.Ruleset, error) {
+					return &fakeRuleset{id: rulesetConfig.ID, version: rulesetConfig.Version, namespace: p.namespace}, nil
+				},
+			}
+		)
+
+		It("should create registered rulesets for the provider", func() {
+			rulesets := registry.NewRulesetRegistry(factory)
+
+			r, err := rulesets.FromConfig(config.RulesetConfig{ID: "foo", Version: "v2"}, p, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r).To(Equal(&fakeRuleset{id: "foo", version: "v2", namespace: "ns"}))
+		})
+
+		It("should accept all versions when no versions are declared", func() {
+			rulesets := registry.NewRulesetRegistry[*fakeProvider]()
+			factory := factory
+			factory.Versions = nil
+			Expect(rulesets.Register(factory)).To(Succeed())
+
+			r, err := rulesets.FromConfig(config.RulesetConfig{ID: "foo", Version: "v3"}, p, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.Version()).To(Equal("v3"))
+		})
+
+		It("should return an error for unknown rulesets and versions", func() {
+			rulesets := registry.NewRulesetRegistry(factory)
+
+			_, err := rulesets.FromConfig(config.RulesetConfig{ID: "bar", Version: "v1"}, p, logger)
+			Expect(err).To(MatchError("unknown ruleset identifier: bar"))
+
+			_, err = rulesets.FromConfig(config.RulesetConfig{ID: "foo", Version: "v3"}, p, logger)
+			Expect(err).To(MatchError("unknown ruleset foo version: v3, supported versions are [v1 v2]"))
+		})
+
+		It("should not register invalid or duplicate rulesets", func() {
+			rulesets := registry.NewRulesetRegistry(factory)
+
+			Expect(rulesets.Register(factory)).To(MatchError("ruleset with id foo is already registered"))
+			Expect(rulesets.Register(registry.RulesetFactory[*fakeProvider]{ID: "bar"})).To(MatchError("ruleset bar does not have a FromConfig function"))
+			invalid := factory
+			invalid.ID = "baz"
+			invalid.RuleOptions = []registry.RuleOptionsType{{RuleID: "1"}}
+			Expect(rulesets.Register(invalid)).To(MatchError("ruleset baz has an invalid rule options type"))
+			Expect(rulesets.Rulesets()).To(HaveLen(1))
+		})
+
+		Describe("#RuleOptions", func() {
+			var rulesets *registry.RulesetRegistry[*fakeProvider]
+
+			BeforeEach(func() {
+				factory := factory
+				factory.RuleOptions = []registry.RuleOptionsType{
+					registry.NewRuleOptionsType[fakeOptions]("1"),
+				}
+				rulesets = registry.NewRulesetRegistry(factory)
+			})
+
+			It("should create the ruleset when the rule options are valid", func() {
+				_, err := rulesets.FromConfig(config.RulesetConfig{ID: "foo", Version: "v1", RuleOptions: []config.RuleOptionsConfig{
+					{RuleID: "1", Args: map[string]any{"limit": 1}},
+					{RuleID: "2", Skip: &config.RuleOptionSkipConfig{Enabled: true}},
+					{RuleID: "1", Skip: &config.RuleOptionSkipConfig{Enabled: true}},
+				}}, p, logger)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should reject args of rules without rule options type", func() {
+				_, err := rulesets.FromConfig(config.RulesetConfig{ID: "foo", Version: "v1", RuleOptions: []config.RuleOptionsConfig{
+					{RuleID: "2", Args: map[string]any{"bar": "baz"}},
+				}}, p, logger)
+				Expect(err).To(MatchError("invalid options of rule 2 of ruleset foo: rule does not accept args in version v1"))
+			})
+
+			It("should only apply rule options types to their versions", func() {
+				factory := factory
+				factory.ID = "bar"
+				factory.RuleOptions = []registry.RuleOptionsType{
+					registry.NewRuleOptionsType[fakeOptions]("1"),
+				}
+				factory.RuleOptions[0].Versions = []string{"v2"}
+				Expect(rulesets.Register(factory)).To(Succeed())
+
+				ruleOptions := []config.RuleOptionsConfig{{RuleID: "1", Args: map[string]any{"limit": 1}}}
+				Expect(rulesets.Validate(config.RulesetConfig{ID: "bar", Version: "v2", RuleOptions: ruleOptions})).To(Succeed())
+				Expect(rulesets.Validate(config.RulesetConfig{ID: "bar", Version: "v1", RuleOptions: ruleOptions})).To(MatchError("invalid options of rule 1 of ruleset bar: rule does not accept args in version v1"))
+			})
+
+			DescribeTable("should not create the ruleset when the rule options are invalid",
+				func(args any, expectedErr string) {
+					_, err := rulesets.FromConfig(config.RulesetConfig{ID: "foo", Version: "v1", RuleOptions: []config.RuleOptionsConfig{
+						{RuleID: "1", Args: args},
+					}}, p, logger)
+					Expect(err).To(MatchError(expectedErr))
+				},
+				Entry("unknown field", map[string]any{"foo": 1}, `invalid options of rule 1 of ruleset foo: json: unknown field "foo"`),
+				Entry("wrong type", map[string]any{"limit": "1"}, "invalid options of rule 1 of ruleset foo: json: cannot unmarshal string into Go struct field fakeOptions.limit of type int"),
+				Entry("failed validation", map[string]any{"limit": -1}, "invalid options of rule 1 of ruleset foo: limit must not be negative"),
+			)
+		})
+	})
+})
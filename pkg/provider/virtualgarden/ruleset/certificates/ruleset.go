@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedcertificates "github.com/gardener/diki/pkg/shared/ruleset/certificates"
+)
+
+// FromGenericConfig creates a Certificate Inventory and Expiry Ruleset for a virtual garden cluster from a RulesetConfig.
+// The TLS secrets of the virtual garden cluster as well as the TLS secrets and the control plane components
+// in the garden namespace of the runtime cluster are checked.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, gardenConfig, runtimeConfig *rest.Config) (*sharedcertificates.Ruleset, error) {
+	gardenClient, err := client.New(gardenConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeClient, err := client.New(runtimeConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	const ns = "garden"
+	runtimeTarget := rule.NewTarget("cluster", "runtime")
+	return sharedcertificates.FromGenericConfig(rulesetConfig, sharedcertificates.Sources{
+		Secrets: []sharedcertificates.SecretSource{
+			{Client: gardenClient, Target: rule.NewTarget("cluster", "garden")},
+			{Client: runtimeClient, Namespace: ns, Target: runtimeTarget},
+		},
+		Components: []sharedcertificates.ComponentSource{
+			{Client: runtimeClient, Namespace: ns, DeploymentName: "virtual-garden-kube-apiserver", ContainerName: "kube-apiserver", Target: runtimeTarget},
+			{Client: runtimeClient, Namespace: ns, DeploymentName: "virtual-garden-kube-controller-manager", ContainerName: "kube-controller-manager", Target: runtimeTarget},
+		},
+	})
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+	sharedcisk8s "github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+)
+
+// FromGenericConfig creates a CIS Kubernetes Benchmark Ruleset for a virtual garden cluster from a RulesetConfig.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, gardenConfig, runtimeConfig *rest.Config) (*sharedcisk8s.Ruleset, error) {
+	disaRuleset := func(disaRuleOptions []config.RuleOptionsConfig) (sharedruleset.RuleGetter, error) {
+		return disak8sstig.FromGenericConfig(config.RulesetConfig{
+			ID:          disak8sstig.RulesetID,
+			Version:     sharedcisk8s.DISAVersion,
+			RuleOptions: disaRuleOptions,
+		}, gardenConfig, runtimeConfig)
+	}
+
+	rules := func(controls []sharedcisk8s.Control) ([]rule.Rule, error) {
+		runtimeClient, err := client.New(runtimeConfig, client.Options{})
+		if err != nil {
+			return nil, err
+		}
+
+		const (
+			ns                      = "garden"
+			apiserverDeploymentName = "virtual-garden-kube-apiserver"
+			apiserverContainerName  = "kube-apiserver"
+			noSchedulerMsg          = "The Virtual Garden cluster does not make use of a Kubernetes Scheduler."
+			noKubeletsMsg           = "The Virtual Garden cluster does not have any nodes therefore there are no kubelets to check."
+		)
+		var rules []rule.Rule
+		rules = append(rules, sharedcisk8s.APIServerOptionRules(controls, runtimeClient, ns, apiserverDeploymentName, apiserverContainerName)...)
+		rules = append(rules, catalog.SkipRules(sharedcisk8s.SchedulerOptionRules(controls, nil, "", "", ""), noSchedulerMsg)...)
+		rules = append(rules, catalog.SkipRules(sharedcisk8s.KubeletConfigRules(controls, nil, nil, nil), noKubeletsMsg)...)
+		return rules, nil
+	}
+
+	return sharedcisk8s.FromGenericConfig(rulesetConfig, disaRuleset, rules)
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
 	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	shareddisak8sstig "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig"
 )
 
 const (
@@ -33,6 +35,7 @@ type Ruleset struct {
 	numWorkers                  int
 	instanceID                  string
 	logger                      *slog.Logger
+	tailoring                   *tailoring.Profile
 }
 
 // New creates a new Ruleset.
@@ -87,6 +90,9 @@ func FromGenericConfig(rulesetConfig config.RulesetConfig, gardenConfig, runtime
 
 	switch rulesetConfig.Version {
 	case "v1r11":
+		if ruleset.tailoring, err = shareddisak8sstig.ApplyTailoring(ruleset.Logger(), rulesetConfig, ruleOptions, v1r11TailorableRuleIDs...); err != nil {
+			return nil, err
+		}
 		if err := ruleset.registerV1R11Rules(ruleOptions); err != nil {
 			return nil, err
 		}
@@ -107,6 +113,12 @@ func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, erro
 	return rr.Run(ctx)
 }
 
+// Rule returns a registered Rule of the Ruleset.
+func (r *Ruleset) Rule(id string) (rule.Rule, bool) {
+	rr, ok := r.rules[id]
+	return rr, ok
+}
+
 // Run executes all known Rules of the Ruleset.
 func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
 	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
@@ -123,6 +135,11 @@ func (r *Ruleset) AddRules(rules ...rule.Rule) error {
 	return nil
 }
 
+// Tailoring returns the tailoring profile applied to the Ruleset.
+func (r *Ruleset) Tailoring() *tailoring.Profile {
+	return r.tailoring
+}
+
 // Logger returns the Ruleset's logger.
 // If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
 func (r *Ruleset) Logger() *slog.Logger {
@@ -6,6 +6,7 @@ package disak8sstig
 
 import (
 	"encoding/json"
+	"fmt"
 
 	kubernetesgardener "github.com/gardener/gardener/pkg/client/kubernetes"
 	"k8s.io/apimachinery/pkg/labels"
@@ -15,40 +16,102 @@ import (
 	"github.com/gardener/diki/pkg/kubernetes/pod"
 	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/disak8sstig/v1r11"
 	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
 	"github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/option"
 	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
 )
 
-func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsConfig) error { // TODO: add to FromGenericConfig
-	runtimeClient, err := client.New(r.RuntimeConfig, client.Options{})
+// v1r11TailorableRuleIDs are the ids of the v1r11 rules whose parameters can be set by a tailoring profile.
+var v1r11TailorableRuleIDs = []string{
+	sharedv1r11.ID242376,
+	sharedv1r11.ID242378,
+	sharedv1r11.ID242418,
+	sharedv1r11.ID242462,
+	sharedv1r11.ID242463,
+	sharedv1r11.ID242464,
+}
+
+func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsConfig) error {
+	rules, err := r.v1r11Rules(ruleOptions)
 	if err != nil {
 		return err
 	}
+	return r.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...)
+}
+
+func (r *Ruleset) v1r11Rules(ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	runtimeClient, err := client.New(r.RuntimeConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
 
 	_, err = client.New(r.GardenConfig, client.Options{Scheme: kubernetesgardener.GardenScheme})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	runtimePodContext, err := pod.NewSimplePodContext(runtimeClient, r.RuntimeConfig)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	opts242376, err := getV1R11OptionOrNil[sharedv1r11.Options242376](ruleOptions[sharedv1r11.ID242376].Args)
+	if err != nil {
+		return nil, err
+	}
+	if opts242376 != nil {
+		if err := opts242376.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of rule %s: %w", sharedv1r11.ID242376, err)
+		}
+	}
+	opts242378, err := getV1R11OptionOrNil[sharedv1r11.Options242378](ruleOptions[sharedv1r11.ID242378].Args)
+	if err != nil {
+		return nil, err
+	}
+	if opts242378 != nil {
+		if err := opts242378.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of rule %s: %w", sharedv1r11.ID242378, err)
+		}
+	}
+	opts242418, err := getV1R11OptionOrNil[sharedv1r11.Options242418](ruleOptions[sharedv1r11.ID242418].Args)
+	if err != nil {
+		return nil, err
+	}
+	opts242443, err := getV1R11OptionOrNil[sharedv1r11.Options242443](ruleOptions[sharedv1r11.ID242443].Args)
+	if err != nil {
+		return nil, err
+	}
+	if opts242443 != nil {
+		if err := opts242443.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of rule %s: %w", sharedv1r11.ID242443, err)
+		}
+	}
+	opts242462, err := getV1R11OptionOrNil[sharedv1r11.Options242462](ruleOptions[sharedv1r11.ID242462].Args)
+	if err != nil {
+		return nil, err
+	}
+	opts242463, err := getV1R11OptionOrNil[sharedv1r11.Options242463](ruleOptions[sharedv1r11.ID242463].Args)
+	if err != nil {
+		return nil, err
+	}
+	opts242464, err := getV1R11OptionOrNil[sharedv1r11.Options242464](ruleOptions[sharedv1r11.ID242464].Args)
+	if err != nil {
+		return nil, err
 	}
 	opts242445, err := getV1R11OptionOrNil[option.FileOwnerOptions](ruleOptions[sharedv1r11.ID242445].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts242446, err := getV1R11OptionOrNil[option.FileOwnerOptions](ruleOptions[sharedv1r11.ID242446].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts245543, err := getV1R11OptionOrNil[sharedv1r11.Options245543](ruleOptions[sharedv1r11.ID245543].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	opts242451, err := getV1R11OptionOrNil[option.FileOwnerOptions](ruleOptions[sharedv1r11.ID242451].Args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	const (
@@ -66,6 +129,7 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		&sharedv1r11.Rule242376{
 			Client:         runtimeClient,
 			Namespace:      ns,
+			Options:        opts242376,
 			DeploymentName: kcmDeploymentName,
 			ContainerName:  kcmContainerName,
 		},
@@ -78,6 +142,7 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		&sharedv1r11.Rule242378{
 			Client:         runtimeClient,
 			Namespace:      ns,
+			Options:        opts242378,
 			DeploymentName: apiserverDeploymentName,
 			ContainerName:  apiserverContainerName,
 		},
@@ -307,6 +372,7 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		&sharedv1r11.Rule242418{
 			Client:         runtimeClient,
 			Namespace:      ns,
+			Options:        opts242418,
 			DeploymentName: apiserverDeploymentName,
 			ContainerName:  apiserverContainerName,
 		},
@@ -428,12 +494,10 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 			Client:    runtimeClient,
 			Namespace: ns,
 		},
-		rule.NewSkipRule(
-			sharedv1r11.ID242443,
-			"Kubernetes must contain the latest updates as authorized by IAVMs, CTOs, DTMs, and STIGs (MEDIUM 242443)",
-			"Scanning/patching security vulnerabilities should be enforced organizationally. Security vulnerability scanning should be automated and maintainers should be informed automatically.",
-			rule.Skipped,
-		),
+		&sharedv1r11.Rule242443{
+			PodSources: []sharedv1r11.PodSource{{Client: runtimeClient, Namespace: ns}},
+			Options:    opts242443,
+		},
 		rule.NewSkipRule(
 			sharedv1r11.ID242444,
 			"Kubernetes component manifests must be owned by root (MEDIUM 242444)",
@@ -556,18 +620,21 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		&sharedv1r11.Rule242462{
 			Client:         runtimeClient,
 			Namespace:      ns,
+			Options:        opts242462,
 			DeploymentName: apiserverDeploymentName,
 			ContainerName:  apiserverContainerName,
 		},
 		&sharedv1r11.Rule242463{
 			Client:         runtimeClient,
 			Namespace:      ns,
+			Options:        opts242463,
 			DeploymentName: apiserverDeploymentName,
 			ContainerName:  apiserverContainerName,
 		},
 		&sharedv1r11.Rule242464{
 			Client:         runtimeClient,
 			Namespace:      ns,
+			Options:        opts242464,
 			DeploymentName: apiserverDeploymentName,
 			ContainerName:  apiserverContainerName,
 		},
@@ -636,14 +703,7 @@ func (r *Ruleset) registerV1R11Rules(ruleOptions map[string]config.RuleOptionsCo
 		),
 	}
 
-	for i, r := range rules {
-		opt, found := ruleOptions[r.ID()]
-		if found && opt.Skip != nil && opt.Skip.Enabled {
-			rules[i] = rule.NewSkipRule(r.ID(), r.Name(), opt.Skip.Justification, rule.Accepted)
-		}
-	}
-
-	return r.AddRules(rules...)
+	return rules, nil
 }
 
 func parseV1R11Options[O v1r11.RuleOption](options any) (*O, error) {
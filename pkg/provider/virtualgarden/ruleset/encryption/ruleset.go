@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedencryption "github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+// FromGenericConfig creates an Encryption at Rest Ruleset for a virtual garden cluster from a RulesetConfig.
+// The encryption configuration of the virtual garden kube-apiserver in the garden namespace of the runtime cluster is checked.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, runtimeConfig *rest.Config) (*sharedencryption.Ruleset, error) {
+	runtimeClient, err := client.New(runtimeConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedencryption.FromGenericConfig(rulesetConfig, &sharedencryption.APIServer{
+		Client:         runtimeClient,
+		Namespace:      "garden",
+		DeploymentName: "virtual-garden-kube-apiserver",
+		ContainerName:  "kube-apiserver",
+		Target:         rule.NewTarget("cluster", "runtime"),
+	})
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/virtualgarden/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+	sharedencryption "github.com/gardener/diki/pkg/shared/ruleset/encryption"
+	sharednsacisa "github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+// FromGenericConfig creates a NSA/CISA Kubernetes Hardening Guidance Ruleset for a virtual garden cluster from a RulesetConfig.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, gardenConfig, runtimeConfig *rest.Config) (*sharednsacisa.Ruleset, error) {
+	disaRuleset := func(disaRuleOptions []config.RuleOptionsConfig) (sharedruleset.RuleGetter, error) {
+		return disak8sstig.FromGenericConfig(config.RulesetConfig{
+			ID:          disak8sstig.RulesetID,
+			Version:     sharednsacisa.DISAVersion,
+			RuleOptions: disaRuleOptions,
+		}, gardenConfig, runtimeConfig)
+	}
+
+	rules := func(recommendations []sharednsacisa.Recommendation, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+		runtimeClient, err := client.New(runtimeConfig, client.Options{})
+		if err != nil {
+			return nil, err
+		}
+
+		gardenClient, err := client.New(gardenConfig, client.Options{})
+		if err != nil {
+			return nil, err
+		}
+
+		podRules, err := sharednsacisa.PodRules(recommendations, nil, nil, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaceRules, err := sharednsacisa.NamespaceRules(recommendations, nil, nil, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		const (
+			ns                      = "garden"
+			apiserverDeploymentName = "virtual-garden-kube-apiserver"
+			apiserverContainerName  = "kube-apiserver"
+			noPodsMsg               = "The Virtual Garden cluster does not have any nodes therefore there are no pods to check."
+			noWorkloadsMsg          = "The Virtual Garden cluster does not run any workloads."
+		)
+		encryptionRules, err := sharednsacisa.EncryptionRules(recommendations, &sharedencryption.APIServer{
+			Client:         runtimeClient,
+			Namespace:      ns,
+			DeploymentName: apiserverDeploymentName,
+			ContainerName:  apiserverContainerName,
+			Target:         rule.NewTarget("cluster", "runtime"),
+		}, ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		rbacRules, err := sharednsacisa.RBACRules(recommendations, gardenClient, rule.NewTarget(), ruleOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		var rules []rule.Rule
+		rules = append(rules, catalog.SkipRules(podRules, noPodsMsg)...)
+		rules = append(rules, catalog.SkipRules(namespaceRules, noWorkloadsMsg)...)
+		rules = append(rules, encryptionRules...)
+		rules = append(rules, rbacRules...)
+		return rules, nil
+	}
+
+	return sharednsacisa.FromGenericConfig(rulesetConfig, disaRuleset, rules)
+}
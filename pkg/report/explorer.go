@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// explorerData is the data embedded into html reports
+// and used for client-side filtering, searching and sorting.
+type explorerData struct {
+	Statuses []rule.Status          `json:"statuses"`
+	Icons    map[rule.Status]string `json:"icons"`
+	Rows     []explorerRow          `json:"rows"`
+}
+
+// explorerRow is a single target of a check. Checks without
+// targets are represented by a single row without target.
+type explorerRow struct {
+	Provider       string      `json:"provider"`
+	Cluster        string      `json:"cluster,omitempty"`
+	RulesetID      string      `json:"rulesetID"`
+	RulesetName    string      `json:"rulesetName"`
+	RulesetVersion string      `json:"rulesetVersion"`
+	RuleID         string      `json:"ruleID"`
+	RuleName       string      `json:"ruleName"`
+	Severity       Severity    `json:"severity,omitempty"`
+	Status         rule.Status `json:"status"`
+	Message        string      `json:"message"`
+	Namespace      string      `json:"namespace,omitempty"`
+	Target         rule.Target `json:"target,omitempty"`
+}
+
+// newExplorerData flattens a report or a merged report into explorer rows.
+func newExplorerData(report any) explorerData {
+	data := explorerData{
+		Statuses: rule.Statuses(),
+		Icons:    make(map[rule.Status]string, len(rule.Statuses())),
+		Rows:     []explorerRow{},
+	}
+	for _, status := range rule.Statuses() {
+		data.Icons[status] = string(rule.GetStatusIcon(status))
+	}
+
+	switch rep := report.(type) {
+	case *Report:
+		for _, p := range rep.Providers {
+			for _, rs := range p.Rulesets {
+				for _, r := range rs.Rules {
+					for _, check := range r.Checks {
+						row := explorerRow{
+							Provider:       p.Name,
+							RulesetID:      rs.ID,
+							RulesetName:    rs.Name,
+							RulesetVersion: rs.Version,
+							RuleID:         r.ID,
+							RuleName:       r.Name,
+							Severity:       r.Severity,
+							Status:         check.Status,
+							Message:        check.Message,
+						}
+						data.Rows = appendTargetRows(data.Rows, row, check.Targets)
+					}
+				}
+			}
+		}
+	case *MergedReport:
+		for _, p := range rep.Providers {
+			for _, rs := range p.Rulesets {
+				for _, r := range rs.Rules {
+					for _, check := range r.Checks {
+						for _, cluster := range sortedKeys(check.ReportsTargets) {
+							row := explorerRow{
+								Provider:       p.Name,
+								Cluster:        cluster,
+								RulesetID:      rs.ID,
+								RulesetName:    rs.Name,
+								RulesetVersion: rs.Version,
+								RuleID:         r.ID,
+								RuleName:       r.Name,
+								Severity:       r.Severity,
+								Status:         check.Status,
+								Message:        check.Message,
+							}
+							data.Rows = appendTargetRows(data.Rows, row, check.ReportsTargets[cluster])
+						}
+					}
+				}
+			}
+		}
+	}
+	return data
+}
+
+func appendTargetRows(rows []explorerRow, row explorerRow, targets []rule.Target) []explorerRow {
+	if len(targets) == 0 {
+		return append(rows, row)
+	}
+
+	for _, target := range targets {
+		targetRow := row
+		targetRow.Target = target
+		targetRow.Namespace = target["namespace"]
+		rows = append(rows, targetRow)
+	}
+	return rows
+}
@@ -5,20 +5,30 @@
 package report
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
 )
 
+// MergedReportKind is the kind of merged reports. It distinguishes
+// merged reports from reports of a single Diki run.
+const MergedReportKind = "MergedReport"
+
 // MergedReport contains information about multiple Diki
 // runs in a suitable for reporting format.
 type MergedReport struct {
+	Kind      string           `json:"kind"`
 	Time      time.Time        `json:"time"`
 	MinStatus rule.Status      `json:"minStatus,omitempty"`
+	Score     *Score           `json:"score,omitempty"`
 	Providers []MergedProvider `json:"providers"`
 }
 
@@ -29,27 +39,52 @@ type MergedProvider struct {
 	Name       string                       `json:"name"`
 	DistinctBy string                       `json:"distinctBy"`
 	Metadata   map[string]map[string]string `json:"metadata,omitempty"`
+	Score      *Score                       `json:"score,omitempty"`
+	Scores     map[string]*Score            `json:"scores,omitempty"`
 	Rulesets   []MergedRuleset              `json:"rulesets"`
 }
 
-// mergeRulesets traverses the rulesets from a single report
-// and merges them into the already existing ones.
-func (mp *MergedProvider) mergeRulesets(uniqueAttrVal string, rulesets []Ruleset) {
+// merge merges another merged provider with the same
+// distinct attribute into the merged provider.
+func (mp *MergedProvider) merge(other MergedProvider) error {
+	if mp.DistinctBy != other.DistinctBy {
+		return fmt.Errorf("provider %s can not be merged by different distinct attributes: %s and %s", mp.ID, mp.DistinctBy, other.DistinctBy)
+	}
+
+	if mp.Name == "" {
+		mp.Name = other.Name
+	}
+
+	for _, uniqueAttrVal := range sortedKeys(other.Metadata) {
+		if _, ok := mp.Metadata[uniqueAttrVal]; ok {
+			return fmt.Errorf("distinct attribute %s is not unique", mp.DistinctBy)
+		}
+		mp.Metadata[uniqueAttrVal] = maps.Clone(other.Metadata[uniqueAttrVal])
+	}
+	maps.Copy(mp.Scores, other.Scores)
+	mp.mergeRulesets(other.Rulesets)
+	return nil
+}
+
+// mergeRulesets traverses the rulesets from a single merged
+// provider and merges them into the already existing ones.
+func (mp *MergedProvider) mergeRulesets(rulesets []MergedRuleset) {
 	for _, ruleset := range rulesets {
 		idx := slices.IndexFunc(mp.Rulesets, func(mr MergedRuleset) bool {
 			return ruleset.ID == mr.ID && ruleset.Version == mr.Version
 		})
 
 		if idx >= 0 {
-			mp.Rulesets[idx].mergeRules(uniqueAttrVal, ruleset.Rules)
+			mp.Rulesets[idx].merge(ruleset)
 		} else {
 			mergedRuleset := MergedRuleset{
 				ID:      ruleset.ID,
 				Name:    ruleset.Name,
 				Version: ruleset.Version,
+				Scores:  map[string]*Score{},
 				Rules:   []MergedRule{},
 			}
-			mergedRuleset.mergeRules(uniqueAttrVal, ruleset.Rules)
+			mergedRuleset.merge(ruleset)
 			mp.Rulesets = append(mp.Rulesets, mergedRuleset)
 		}
 	}
@@ -57,29 +92,46 @@ func (mp *MergedProvider) mergeRulesets(uniqueAttrVal string, rulesets []Ruleset
 
 // MergedRuleset contains information from multiple reports about a ruleset and its rules.
 type MergedRuleset struct {
-	ID      string       `json:"id"`
-	Name    string       `json:"name"`
-	Version string       `json:"version"`
-	Rules   []MergedRule `json:"rules"`
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Scores  map[string]*Score `json:"scores,omitempty"`
+	// Tailorings contains the tailoring profiles applied to the
+	// ruleset per distinct attribute value of the tailored reports.
+	Tailorings map[string]*tailoring.Profile `json:"tailorings,omitempty"`
+	Rules      []MergedRule                  `json:"rules"`
 }
 
-// mergeRules traverses the rules from a single ruleset
-// and merges them into the already existing ones.
-func (mr *MergedRuleset) mergeRules(uniqueAttrVal string, rules []Rule) {
+// merge merges another merged ruleset with the same id and version into the merged ruleset.
+func (mr *MergedRuleset) merge(other MergedRuleset) {
+	maps.Copy(mr.Scores, other.Scores)
+	if len(other.Tailorings) > 0 {
+		if mr.Tailorings == nil {
+			mr.Tailorings = map[string]*tailoring.Profile{}
+		}
+		maps.Copy(mr.Tailorings, other.Tailorings)
+	}
+	mr.mergeRules(other.Rules)
+}
+
+// mergeRules traverses the rules from a single merged
+// ruleset and merges them into the already existing ones.
+func (mr *MergedRuleset) mergeRules(rules []MergedRule) {
 	for _, rule := range rules {
 		idx := slices.IndexFunc(mr.Rules, func(mr MergedRule) bool {
 			return rule.ID == mr.ID
 		})
 
 		if idx >= 0 {
-			mr.Rules[idx].mergeChecks(uniqueAttrVal, rule.Checks)
+			mr.Rules[idx].mergeChecks(rule.Checks)
 		} else {
 			mergedRule := MergedRule{
-				ID:     rule.ID,
-				Name:   rule.Name,
-				Checks: []MergedCheck{},
+				ID:       rule.ID,
+				Name:     rule.Name,
+				Severity: rule.Severity,
+				Checks:   []MergedCheck{},
 			}
-			mergedRule.mergeChecks(uniqueAttrVal, rule.Checks)
+			mergedRule.mergeChecks(rule.Checks)
 			mr.Rules = append(mr.Rules, mergedRule)
 		}
 	}
@@ -87,28 +139,31 @@ func (mr *MergedRuleset) mergeRules(uniqueAttrVal string, rules []Rule) {
 
 // MergedRule contains information about a ran rule for multiple reports.
 type MergedRule struct {
-	ID     string        `json:"id"`
-	Name   string        `json:"name"`
-	Checks []MergedCheck `json:"checks"`
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Severity Severity      `json:"severity,omitempty"`
+	Checks   []MergedCheck `json:"checks"`
 }
 
-// mergeChecks traverses the checks from a single rule
-// and merges them into the already existing ones.
-func (mr *MergedRule) mergeChecks(uniqueAttrVal string, checks []Check) {
+// mergeChecks traverses the checks from a single merged
+// rule and merges them into the already existing ones.
+func (mr *MergedRule) mergeChecks(checks []MergedCheck) {
 	for _, check := range checks {
 		idx := slices.IndexFunc(mr.Checks, func(mr MergedCheck) bool {
 			return check.Message == mr.Message && check.Status == mr.Status
 		})
 
 		if idx >= 0 {
-			mr.Checks[idx].ReportsTargets[uniqueAttrVal] = check.Targets
+			maps.Copy(mr.Checks[idx].ReportsTargets, check.ReportsTargets)
 		} else {
 			mergedCheck := MergedCheck{
 				Message:        check.Message,
 				Status:         check.Status,
-				ReportsTargets: map[string][]rule.Target{},
+				ReportsTargets: maps.Clone(check.ReportsTargets),
+			}
+			if mergedCheck.ReportsTargets == nil {
+				mergedCheck.ReportsTargets = map[string][]rule.Target{}
 			}
-			mergedCheck.ReportsTargets[uniqueAttrVal] = check.Targets
 			mr.Checks = append(mr.Checks, mergedCheck)
 		}
 	}
@@ -123,91 +178,222 @@ type MergedCheck struct {
 
 // MergeReport merges given reports by specified providers and unique metadata attribute.
 func MergeReport(reports []*Report, distinctByAttrs map[string]string) (*MergedReport, error) {
-	if len(reports) == 0 {
+	return Merge(reports, nil, distinctByAttrs)
+}
+
+// Merge merges given reports and previously merged reports into a single merged report.
+// The providers of the reports are merged by the providers specified in distinctByAttrs and
+// their unique metadata attribute. All providers of the merged reports are included and
+// their unique metadata attributes are also used for the providers of the reports.
+// Each selected provider has to be present in at least one of the reports
+// and each report has to contain at least one of the selected providers.
+func Merge(reports []*Report, mergedReports []*MergedReport, distinctByAttrs map[string]string) (*MergedReport, error) {
+	if len(reports) == 0 && len(mergedReports) == 0 {
 		return nil, errors.New("zero reports provided for merging")
 	}
+
 	mergedReport := &MergedReport{
+		Kind:      MergedReportKind,
 		Time:      time.Now(),
-		MinStatus: reports[0].MinStatus,
 		Providers: []MergedProvider{},
 	}
+	if len(reports) > 0 {
+		mergedReport.MinStatus = reports[0].MinStatus
+	} else {
+		mergedReport.MinStatus = mergedReports[0].MinStatus
+	}
 
-	distinctByAttrsProviders := []string{}
-	for key := range distinctByAttrs {
-		distinctByAttrsProviders = append(distinctByAttrsProviders, key)
+	mergedProviders := map[string]*MergedProvider{}
+	mergeProvider := func(mp MergedProvider) error {
+		if _, ok := mergedProviders[mp.ID]; !ok {
+			mergedProviders[mp.ID] = &MergedProvider{
+				ID:         mp.ID,
+				Name:       mp.Name,
+				DistinctBy: mp.DistinctBy,
+				Metadata:   map[string]map[string]string{},
+				Scores:     map[string]*Score{},
+				Rulesets:   []MergedRuleset{},
+			}
+		}
+		return mergedProviders[mp.ID].merge(mp)
 	}
-	slices.Sort(distinctByAttrsProviders)
-
-	for _, selectedProvider := range distinctByAttrsProviders {
-		mergedReport.Providers = append(mergedReport.Providers, MergedProvider{
-			ID:         selectedProvider,
-			Name:       "",
-			DistinctBy: distinctByAttrs[selectedProvider],
-			Metadata:   map[string]map[string]string{},
-			Rulesets:   []MergedRuleset{},
-		})
+
+	selectedDistinctByAttrs := maps.Clone(distinctByAttrs)
+	if selectedDistinctByAttrs == nil {
+		selectedDistinctByAttrs = map[string]string{}
 	}
+	for _, mr := range mergedReports {
+		if mr.MinStatus != mergedReport.MinStatus {
+			return nil, errors.New("reports must have equal minStatus in order to be merged")
+		}
+
+		for _, mp := range mr.Providers {
+			if distinctBy, ok := selectedDistinctByAttrs[mp.ID]; ok && distinctBy != mp.DistinctBy {
+				return nil, fmt.Errorf("provider %s is merged by distinct attribute %s instead of %s", mp.ID, mp.DistinctBy, distinctBy)
+			}
+			selectedDistinctByAttrs[mp.ID] = mp.DistinctBy
 
+			if err := mergeProvider(mp); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	selectedProviders := sortedKeys(selectedDistinctByAttrs)
 	for _, report := range reports {
 		if report.MinStatus != mergedReport.MinStatus {
 			return nil, errors.New("reports must have equal minStatus in order to be merged")
 		}
 
-		for key, mergedProvider := range mergedReport.Providers {
+		found := false
+		for _, selectedProvider := range selectedProviders {
 			idx := slices.IndexFunc(report.Providers, func(p Provider) bool {
-				return p.ID == mergedProvider.ID
+				return p.ID == selectedProvider
 			})
 
 			if idx == -1 {
-				return nil, fmt.Errorf("provider %s not found in at least 1 of the selected reports", mergedProvider.ID)
+				continue
 			}
+			found = true
 
-			if mergedReport.Providers[key].Name == "" {
-				mergedReport.Providers[key].Name = report.Providers[idx].Name
+			mp, err := mergedProviderFromProvider(report.Providers[idx], selectedDistinctByAttrs[selectedProvider], report.Time, report.scoreWeights())
+			if err != nil {
+				return nil, err
 			}
 
-			uniqueAttr := report.Providers[idx].Metadata[mergedProvider.DistinctBy]
-			if uniqueAttr == "" {
-				return nil, fmt.Errorf("distinct attribute %s is empty in at least 1 of the selected reports", mergedProvider.DistinctBy)
+			if err := mergeProvider(mp); err != nil {
+				return nil, err
 			}
+		}
 
-			if _, ok := mergedProvider.Metadata[uniqueAttr]; ok {
-				return nil, fmt.Errorf("distinct attribute %s is not unique", mergedProvider.DistinctBy)
-			}
+		if !found {
+			return nil, errors.New("at least 1 of the selected reports does not contain any of the selected providers")
+		}
+	}
 
-			mergedProvider.Metadata[uniqueAttr] = report.Providers[idx].Metadata
-			mergedProvider.Metadata[uniqueAttr]["time"] = report.Time.Format("01-02-2006 15:04:05")
+	for _, selectedProvider := range selectedProviders {
+		if _, ok := mergedProviders[selectedProvider]; !ok {
+			return nil, fmt.Errorf("provider %s not found in any of the selected reports", selectedProvider)
 		}
 	}
-	for _, report := range reports {
-		for idx, mergedProvider := range mergedReport.Providers {
-			for _, provider := range report.Providers {
-				if provider.ID == mergedProvider.ID {
-					uniqueAttr := provider.Metadata[mergedProvider.DistinctBy]
-					mergedProvider.mergeRulesets(uniqueAttr, provider.Rulesets)
-					mergedReport.Providers[idx] = mergedProvider
-				}
-			}
+
+	providerScores := make([]*Score, 0, len(mergedProviders))
+	for _, providerID := range sortedKeys(mergedProviders) {
+		mergedProvider := mergedProviders[providerID]
+		scores := make([]*Score, 0, len(mergedProvider.Scores))
+		for _, key := range sortedKeys(mergedProvider.Scores) {
+			scores = append(scores, mergedProvider.Scores[key])
 		}
+		mergedProvider.Score = sumScores(scores...)
+		providerScores = append(providerScores, mergedProvider.Score)
+		mergedReport.Providers = append(mergedReport.Providers, *mergedProvider)
 	}
+	mergedReport.Score = sumScores(providerScores...)
 	return mergedReport, nil
 }
 
-// rulesWithStatus return all rules that have results with a given status.
-func mergedRulesWithStatus(ruleset *MergedRuleset, status rule.Status) []MergedRule {
-	result := []MergedRule{}
-	for _, rule := range ruleset.Rules {
-		ruleWithStatus := MergedRule{ID: rule.ID, Name: rule.Name}
-		for _, check := range rule.Checks {
-			if check.Status == status {
-				ruleWithStatus.Checks = append(ruleWithStatus.Checks, check)
+// mergedProviderFromProvider converts a provider of a single report to a merged provider distinguished
+// by the given metadata attribute. Missing scores are calculated with the weights of the report.
+func mergedProviderFromProvider(p Provider, distinctBy string, reportTime time.Time, weights ScoreWeights) (MergedProvider, error) {
+	uniqueAttrVal := p.Metadata[distinctBy]
+	if uniqueAttrVal == "" {
+		return MergedProvider{}, fmt.Errorf("distinct attribute %s is empty in at least 1 of the selected reports", distinctBy)
+	}
+
+	metadata := maps.Clone(p.Metadata)
+	metadata["time"] = reportTime.Format("01-02-2006 15:04:05")
+	mp := MergedProvider{
+		ID:         p.ID,
+		Name:       p.Name,
+		DistinctBy: distinctBy,
+		Metadata:   map[string]map[string]string{uniqueAttrVal: metadata},
+		Scores:     map[string]*Score{uniqueAttrVal: providerScore(p, weights)},
+		Rulesets:   make([]MergedRuleset, 0, len(p.Rulesets)),
+	}
+
+	for _, ruleset := range p.Rulesets {
+		mergedRuleset := MergedRuleset{
+			ID:      ruleset.ID,
+			Name:    ruleset.Name,
+			Version: ruleset.Version,
+			Scores:  map[string]*Score{uniqueAttrVal: rulesetScoreOrCalculate(ruleset, weights)},
+			Rules:   make([]MergedRule, 0, len(ruleset.Rules)),
+		}
+		if ruleset.Tailoring != nil {
+			mergedRuleset.Tailorings = map[string]*tailoring.Profile{uniqueAttrVal: ruleset.Tailoring}
+		}
+		for _, r := range ruleset.Rules {
+			mergedRule := MergedRule{
+				ID:       r.ID,
+				Name:     r.Name,
+				Severity: r.Severity,
+				Checks:   make([]MergedCheck, 0, len(r.Checks)),
+			}
+			for _, check := range r.Checks {
+				mergedRule.Checks = append(mergedRule.Checks, MergedCheck{
+					Status:         check.Status,
+					Message:        check.Message,
+					ReportsTargets: map[string][]rule.Target{uniqueAttrVal: check.Targets},
+				})
 			}
+			mergedRuleset.Rules = append(mergedRuleset.Rules, mergedRule)
+		}
+		mp.Rulesets = append(mp.Rulesets, mergedRuleset)
+	}
+	return mp, nil
+}
+
+// Write writes a Diki merged report in json format into the passed writer.
+func (mr *MergedReport) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(mr)
+}
+
+// rulesetsAcrossProviders groups the rulesets of all providers of a merged report
+// by their id and version. The scores and targets of the returned rulesets
+// are identified by the provider id and the distinct attribute value.
+func rulesetsAcrossProviders(mr *MergedReport) []MergedRuleset {
+	acrossProviders := &MergedProvider{}
+	for _, mp := range mr.Providers {
+		key := func(uniqueAttrVal string) string {
+			return mp.ID + "/" + uniqueAttrVal
 		}
-		if len(ruleWithStatus.Checks) > 0 {
-			result = append(result, ruleWithStatus)
+		rulesets := make([]MergedRuleset, 0, len(mp.Rulesets))
+		for _, ruleset := range mp.Rulesets {
+			rs := MergedRuleset{
+				ID:      ruleset.ID,
+				Name:    ruleset.Name,
+				Version: ruleset.Version,
+				Scores:  make(map[string]*Score, len(ruleset.Scores)),
+				Rules:   make([]MergedRule, 0, len(ruleset.Rules)),
+			}
+			for uniqueAttrVal, score := range ruleset.Scores {
+				rs.Scores[key(uniqueAttrVal)] = score
+			}
+			for _, r := range ruleset.Rules {
+				mergedRule := MergedRule{
+					ID:       r.ID,
+					Name:     r.Name,
+					Severity: r.Severity,
+					Checks:   make([]MergedCheck, 0, len(r.Checks)),
+				}
+				for _, check := range r.Checks {
+					reportsTargets := make(map[string][]rule.Target, len(check.ReportsTargets))
+					for uniqueAttrVal, targets := range check.ReportsTargets {
+						reportsTargets[key(uniqueAttrVal)] = targets
+					}
+					mergedRule.Checks = append(mergedRule.Checks, MergedCheck{
+						Status:         check.Status,
+						Message:        check.Message,
+						ReportsTargets: reportsTargets,
+					})
+				}
+				rs.Rules = append(rs.Rules, mergedRule)
+			}
+			rulesets = append(rulesets, rs)
 		}
+		acrossProviders.mergeRulesets(rulesets)
 	}
-	return result
+	return acrossProviders.Rulesets
 }
 
 // mergedRulesetSummaryText returns a summary string with the number of merged rules with results per status.
@@ -226,6 +412,26 @@ func mergedRulesetSummaryText(ruleset *MergedRuleset) string {
 	return summaryText
 }
 
+// mergedRulesetScore returns the aggregated score of a merged ruleset.
+func mergedRulesetScore(ruleset *MergedRuleset) *Score {
+	scores := make([]*Score, 0, len(ruleset.Scores))
+	for _, key := range sortedKeys(ruleset.Scores) {
+		scores = append(scores, ruleset.Scores[key])
+	}
+	return sumScores(scores...)
+}
+
+// mergedRuleStatus returns the most severe status of the checks of a merged rule.
+func mergedRuleStatus(r MergedRule) rule.Status {
+	var status rule.Status
+	for _, check := range r.Checks {
+		if status == "" || status.Less(check.Status) {
+			status = check.Status
+		}
+	}
+	return status
+}
+
 func numOfMergedRulesWithStatus(ruleset *MergedRuleset, status rule.Status) int {
 	num := 0
 	for _, rule := range ruleset.Rules {
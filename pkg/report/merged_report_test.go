@@ -143,13 +143,32 @@ var _ = Describe("merged report", func() {
 			Expect(err).To(MatchError("reports must have equal minStatus in order to be merged"))
 		})
 
-		It("should return error when at least 1 report does not contain the selected provider", func() {
+		It("should return error when no report contains the selected provider", func() {
+			reports := []*report.Report{&simpleReport1, &simpleReport2}
+			mergedReport, err := report.MergeReport(reports, map[string]string{providerID: "id", "provider-bar": "id"})
+
+			Expect(mergedReport).To(BeNil())
+			Expect(err).To(MatchError("provider provider-bar not found in any of the selected reports"))
+		})
+
+		It("should return error when at least 1 report does not contain any selected provider", func() {
 			simpleReport2.Providers[0].ID = "not-provider-foo"
 			reports := []*report.Report{&simpleReport1, &simpleReport2}
 			mergedReport, err := report.MergeReport(reports, map[string]string{providerID: "id"})
 
 			Expect(mergedReport).To(BeNil())
-			Expect(err).To(MatchError("provider provider-foo not found in at least 1 of the selected reports"))
+			Expect(err).To(MatchError("at least 1 of the selected reports does not contain any of the selected providers"))
+		})
+
+		It("should skip reports which do not contain one of the selected providers", func() {
+			simpleReport2.Providers[0].ID = "not-provider-foo"
+			reports := []*report.Report{&simpleReport1, &simpleReport2}
+			mergedReport, err := report.MergeReport(reports, map[string]string{providerID: "id", "not-provider-foo": "id"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mergedReport.Providers).To(HaveLen(2))
+			Expect(mergedReport.Providers[0].Metadata).To(HaveKey("bar"))
+			Expect(mergedReport.Providers[1].Metadata).To(HaveKey("foo"))
 		})
 
 		It("should return error when at distinct attribute is missing from at least 1 provider run", func() {
@@ -175,8 +194,10 @@ var _ = Describe("merged report", func() {
 			mergedReport, err := report.MergeReport(reports, map[string]string{providerID: "id"})
 
 			expectedMergedReport := &report.MergedReport{
+				Kind:      report.MergedReportKind,
 				Time:      mergedReport.Time,
 				MinStatus: rule.Passed,
+				Score:     &report.Score{Value: 75, Achieved: 3, Total: 4},
 				Providers: []report.MergedProvider{
 					{
 						ID:         "provider-foo",
@@ -194,11 +215,20 @@ var _ = Describe("merged report", func() {
 								"time": "01-01-2000 00:00:00",
 							},
 						},
+						Score: &report.Score{Value: 75, Achieved: 3, Total: 4},
+						Scores: map[string]*report.Score{
+							"foo": {Value: 50, Achieved: 1, Total: 2},
+							"bar": {Value: 100, Achieved: 2, Total: 2},
+						},
 						Rulesets: []report.MergedRuleset{
 							{
 								ID:      "ruleset-foo",
 								Name:    "Ruleset Foo",
 								Version: "v1",
+								Scores: map[string]*report.Score{
+									"foo": {Value: 50, Achieved: 1, Total: 2},
+									"bar": {Value: 100, Achieved: 2, Total: 2},
+								},
 								Rules: []report.MergedRule{
 									{
 										ID:   "1",
@@ -264,8 +294,10 @@ var _ = Describe("merged report", func() {
 			mergedReport, err := report.MergeReport(reports, map[string]string{providerID: "id"})
 
 			expectedMergedReport := &report.MergedReport{
+				Kind:      report.MergedReportKind,
 				Time:      mergedReport.Time,
 				MinStatus: rule.Passed,
+				Score:     &report.Score{Value: 75, Achieved: 3, Total: 4},
 				Providers: []report.MergedProvider{
 					{
 						ID:         "provider-foo",
@@ -283,11 +315,19 @@ var _ = Describe("merged report", func() {
 								"time": "01-01-2000 00:00:00",
 							},
 						},
+						Score: &report.Score{Value: 75, Achieved: 3, Total: 4},
+						Scores: map[string]*report.Score{
+							"foo": {Value: 50, Achieved: 1, Total: 2},
+							"bar": {Value: 100, Achieved: 2, Total: 2},
+						},
 						Rulesets: []report.MergedRuleset{
 							{
 								ID:      "ruleset-foo",
 								Name:    "Ruleset Foo",
 								Version: "v1",
+								Scores: map[string]*report.Score{
+									"foo": {Value: 50, Achieved: 1, Total: 2},
+								},
 								Rules: []report.MergedRule{
 									{
 										ID:   "1",
@@ -328,6 +368,9 @@ var _ = Describe("merged report", func() {
 								ID:      "ruleset-bar",
 								Name:    "Ruleset Bar",
 								Version: "v1",
+								Scores: map[string]*report.Score{
+									"bar": {Value: 100, Achieved: 2, Total: 2},
+								},
 								Rules: []report.MergedRule{
 									{
 										ID:   "2",
@@ -427,8 +470,10 @@ var _ = Describe("merged report", func() {
 			mergedReport, err := report.MergeReport(reports, map[string]string{providerID: "id", "new-provider": "key"})
 
 			expectedMergedReport := &report.MergedReport{
+				Kind:      report.MergedReportKind,
 				Time:      mergedReport.Time,
 				MinStatus: rule.Passed,
+				Score:     &report.Score{Value: 83.33, Achieved: 5, Total: 6},
 				Providers: []report.MergedProvider{
 					{
 						ID:         "new-provider",
@@ -444,11 +489,20 @@ var _ = Describe("merged report", func() {
 								"time": "01-01-2000 00:00:00",
 							},
 						},
+						Score: &report.Score{Value: 100, Achieved: 2, Total: 2},
+						Scores: map[string]*report.Score{
+							"value1": {Value: 100, Achieved: 1, Total: 1},
+							"value2": {Value: 100, Achieved: 1, Total: 1},
+						},
 						Rulesets: []report.MergedRuleset{
 							{
 								ID:      "ruleset-foo",
 								Name:    "Ruleset Foo",
 								Version: "v1",
+								Scores: map[string]*report.Score{
+									"value1": {Value: 100, Achieved: 1, Total: 1},
+									"value2": {Value: 100, Achieved: 1, Total: 1},
+								},
 								Rules: []report.MergedRule{
 									{
 										ID:   "1",
@@ -484,11 +538,20 @@ var _ = Describe("merged report", func() {
 								"time": "01-01-2000 00:00:00",
 							},
 						},
+						Score: &report.Score{Value: 75, Achieved: 3, Total: 4},
+						Scores: map[string]*report.Score{
+							"foo": {Value: 50, Achieved: 1, Total: 2},
+							"bar": {Value: 100, Achieved: 2, Total: 2},
+						},
 						Rulesets: []report.MergedRuleset{
 							{
 								ID:      "ruleset-foo",
 								Name:    "Ruleset Foo",
 								Version: "v1",
+								Scores: map[string]*report.Score{
+									"foo": {Value: 50, Achieved: 1, Total: 2},
+									"bar": {Value: 100, Achieved: 2, Total: 2},
+								},
 								Rules: []report.MergedRule{
 									{
 										ID:   "1",
@@ -547,4 +610,121 @@ var _ = Describe("merged report", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Describe("#Merge", func() {
+		var (
+			reportTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+			newReport  = func(providerID, distinctAttr string, status rule.Status) *report.Report {
+				return &report.Report{
+					Time: reportTime,
+					Providers: []report.Provider{
+						{
+							ID:       providerID,
+							Name:     providerID,
+							Metadata: map[string]string{"id": distinctAttr},
+							Rulesets: []report.Ruleset{
+								{
+									ID:      "ruleset-foo",
+									Name:    "Ruleset Foo",
+									Version: "v1",
+									Rules: []report.Rule{
+										{
+											ID:     "1",
+											Name:   "1",
+											Checks: []report.Check{{Status: status, Message: "foo", Targets: []rule.Target{rule.NewTarget("name", distinctAttr)}}},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+		)
+
+		It("should merge reports of different providers", func() {
+			reports := []*report.Report{
+				newReport("gardener", "shoot1", rule.Passed),
+				newReport("virtualgarden", "garden1", rule.Failed),
+			}
+			mergedReport, err := report.Merge(reports, nil, map[string]string{"gardener": "id", "virtualgarden": "id"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mergedReport.Providers).To(HaveLen(2))
+			Expect(mergedReport.Providers[0].ID).To(Equal("gardener"))
+			Expect(mergedReport.Providers[1].ID).To(Equal("virtualgarden"))
+			Expect(mergedReport.Score).To(Equal(&report.Score{Value: 50, Achieved: 1, Total: 2}))
+		})
+
+		It("should merge previously merged reports incrementally", func() {
+			mergedReport1, err := report.Merge([]*report.Report{newReport("gardener", "shoot1", rule.Passed)}, nil, map[string]string{"gardener": "id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			mergedReport2, err := report.Merge(
+				[]*report.Report{newReport("gardener", "shoot3", rule.Passed)},
+				[]*report.MergedReport{mergedReport1},
+				map[string]string{"gardener": "id"},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the distinct attribute is taken from the merged report
+			mergedReport3, err := report.Merge(
+				[]*report.Report{newReport("gardener", "shoot2", rule.Failed)},
+				[]*report.MergedReport{mergedReport2},
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(mergedReport3.Providers).To(HaveLen(1))
+			Expect(mergedReport3.Providers[0].Metadata).To(HaveLen(3))
+			Expect(mergedReport3.Providers[0].Score).To(Equal(&report.Score{Value: 66.67, Achieved: 2, Total: 3}))
+			Expect(mergedReport3.Providers[0].Rulesets[0].Rules[0].Checks).To(Equal([]report.MergedCheck{
+				{
+					Status:  rule.Passed,
+					Message: "foo",
+					ReportsTargets: map[string][]rule.Target{
+						"shoot1": {rule.NewTarget("name", "shoot1")},
+						"shoot3": {rule.NewTarget("name", "shoot3")},
+					},
+				},
+				{
+					Status:  rule.Failed,
+					Message: "foo",
+					ReportsTargets: map[string][]rule.Target{
+						"shoot2": {rule.NewTarget("name", "shoot2")},
+					},
+				},
+			}))
+			Expect(mergedReport1.Providers[0].Metadata).To(HaveLen(1))
+		})
+
+		It("should merge only merged reports", func() {
+			mergedReport1, err := report.Merge([]*report.Report{newReport("gardener", "shoot1", rule.Passed)}, nil, map[string]string{"gardener": "id"})
+			Expect(err).ToNot(HaveOccurred())
+			mergedReport2, err := report.Merge([]*report.Report{newReport("managedk8s", "cluster1", rule.Passed)}, nil, map[string]string{"managedk8s": "id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			mergedReport, err := report.Merge(nil, []*report.MergedReport{mergedReport1, mergedReport2}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mergedReport.Providers).To(HaveLen(2))
+		})
+
+		It("should return error when merged reports contain the same distinct attribute", func() {
+			mergedReport1, err := report.Merge([]*report.Report{newReport("gardener", "shoot1", rule.Passed)}, nil, map[string]string{"gardener": "id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			mergedReport, err := report.Merge([]*report.Report{newReport("gardener", "shoot1", rule.Passed)}, []*report.MergedReport{mergedReport1}, map[string]string{"gardener": "id"})
+			Expect(err).To(MatchError("distinct attribute id is not unique"))
+			Expect(mergedReport).To(BeNil())
+		})
+
+		It("should return error when merged reports are distinct by another attribute", func() {
+			mergedReport1, err := report.Merge([]*report.Report{newReport("gardener", "shoot1", rule.Passed)}, nil, map[string]string{"gardener": "id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			mergedReport, err := report.Merge(nil, []*report.MergedReport{mergedReport1}, map[string]string{"gardener": "name"})
+			Expect(err).To(MatchError("provider gardener is merged by distinct attribute id instead of name"))
+			Expect(mergedReport).To(BeNil())
+		})
+	})
 })
@@ -19,7 +19,10 @@ const (
 	tmplReportPath       = "templates/html/report.html"
 	tmplMergedReportName = "merged_report"
 	tmplMergedReportPath = "templates/html/merged_report.html"
+	tmplTrendName        = "trend"
+	tmplTrendPath        = "templates/html/trend.html"
 	tmplStylesPath       = "templates/html/_styles.tpl"
+	tmplExplorerPath     = "templates/html/_explorer.tpl"
 )
 
 var (
@@ -37,6 +40,9 @@ func NewHTMLRenderer() (*HTMLRenderer, error) {
 	convTimeFunc := func(time time.Time) string {
 		return time.Format("01-02-2006")
 	}
+	convDateTimeFunc := func(time time.Time) string {
+		return time.Format("01-02-2006 15:04:05")
+	}
 	templates := make(map[string]*template.Template)
 
 	parsedReport, err := template.New(tmplReportName+".html").Funcs(template.FuncMap{
@@ -44,9 +50,10 @@ func NewHTMLRenderer() (*HTMLRenderer, error) {
 		"Icon":               rule.GetStatusIcon,
 		"Time":               convTimeFunc,
 		"RulesetSummaryText": rulesetSummaryText,
-		"RulesWithStatus":    rulesWithStatus,
 		"SortedMapKeys":      sortedKeys[string],
-	}).ParseFS(files, tmplReportPath, tmplStylesPath)
+		"ScoreText":          scoreText,
+		"ExplorerData":       newExplorerData,
+	}).ParseFS(files, tmplReportPath, tmplStylesPath, tmplExplorerPath)
 	if err != nil {
 		return nil, err
 	}
@@ -58,14 +65,32 @@ func NewHTMLRenderer() (*HTMLRenderer, error) {
 		"Time":                     convTimeFunc,
 		"MergedMetadataTexts":      metadataTextForMergedProvider,
 		"MergedRulesetSummaryText": mergedRulesetSummaryText,
-		"MergedRulesWithStatus":    mergedRulesWithStatus,
+		"MergedRulesetScore":       mergedRulesetScore,
+		"MergedRuleStatus":         mergedRuleStatus,
+		"RulesetsAcrossProviders":  rulesetsAcrossProviders,
 		"SortedMapKeys":            sortedKeys[string],
-	}).ParseFS(files, tmplMergedReportPath, tmplStylesPath)
+		"ScoreText":                scoreText,
+		"ExplorerData":             newExplorerData,
+	}).ParseFS(files, tmplMergedReportPath, tmplStylesPath, tmplExplorerPath)
 	if err != nil {
 		return nil, err
 	}
 	templates[tmplMergedReportName] = parsedMergedReport
 
+	parsedTrend, err := template.New(tmplTrendName+".html").Funcs(template.FuncMap{
+		"Statuses":         rule.Statuses,
+		"Icon":             rule.GetStatusIcon,
+		"Time":             convTimeFunc,
+		"DateTime":         convDateTimeFunc,
+		"ScoreText":        scoreText,
+		"ChangedRules":     changedRules,
+		"StatusPercentage": statusPercentage,
+	}).ParseFS(files, tmplTrendPath, tmplStylesPath)
+	if err != nil {
+		return nil, err
+	}
+	templates[tmplTrendName] = parsedTrend
+
 	return &HTMLRenderer{
 		templates: templates,
 	}, nil
@@ -78,6 +103,8 @@ func (r *HTMLRenderer) Render(w io.Writer, report any) error {
 		return r.templates[tmplReportName].Execute(w, rep)
 	case *MergedReport:
 		return r.templates[tmplMergedReportName].Execute(w, rep)
+	case *Trend:
+		return r.templates[tmplTrendName].Execute(w, rep)
 	default:
 		return fmt.Errorf("unsupported report type: %T", report)
 	}
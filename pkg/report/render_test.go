@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/provider"
+	"github.com/gardener/diki/pkg/report"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+)
+
+var _ = Describe("render", func() {
+	var (
+		renderer        *report.HTMLRenderer
+		providerResults []provider.ProviderResult
+		dataRegex       = regexp.MustCompile(`const data = (.*);\n`)
+	)
+
+	type row struct {
+		Provider  string      `json:"provider"`
+		Cluster   string      `json:"cluster"`
+		RuleID    string      `json:"ruleID"`
+		Severity  string      `json:"severity"`
+		Status    rule.Status `json:"status"`
+		Message   string      `json:"message"`
+		Namespace string      `json:"namespace"`
+		Target    rule.Target `json:"target"`
+	}
+
+	renderedRows := func(rep any) []row {
+		buf := &bytes.Buffer{}
+		Expect(renderer.Render(buf, rep)).To(Succeed())
+
+		match := dataRegex.FindSubmatch(buf.Bytes())
+		Expect(match).To(HaveLen(2))
+
+		data := struct {
+			Rows []row `json:"rows"`
+		}{}
+		Expect(json.Unmarshal(match[1], &data)).To(Succeed())
+		return data.Rows
+	}
+
+	BeforeEach(func() {
+		var err error
+		renderer, err = report.NewHTMLRenderer()
+		Expect(err).ToNot(HaveOccurred())
+
+		providerResults = []provider.ProviderResult{
+			{
+				ProviderID:   "provider-foo",
+				ProviderName: "Provider Foo",
+				Metadata:     map[string]string{"foo": "bar"},
+				RulesetResults: []ruleset.RulesetResult{
+					{
+						RulesetID:      "ruleset-foo",
+						RulesetName:    "Ruleset Foo",
+						RulesetVersion: "v1",
+						RuleResults: []rule.RuleResult{
+							{
+								RuleID:   "1",
+								RuleName: "Rule 1 (HIGH 1)",
+								CheckResults: []rule.CheckResult{
+									rule.PassedCheckResult("<foo>", rule.NewTarget()),
+									rule.FailedCheckResult("bar", rule.NewTarget("name", "bar", "namespace", "default")),
+									rule.FailedCheckResult("bar", rule.NewTarget("name", "baz", "namespace", "kube-system")),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should embed the check targets of a report", func() {
+		rep := report.FromProviderResults(providerResults)
+
+		Expect(renderedRows(rep)).To(ConsistOf([]row{
+			{Provider: "Provider Foo", RuleID: "1", Severity: "HIGH", Status: rule.Failed, Message: "bar", Namespace: "default", Target: rule.NewTarget("name", "bar", "namespace", "default")},
+			{Provider: "Provider Foo", RuleID: "1", Severity: "HIGH", Status: rule.Failed, Message: "bar", Namespace: "kube-system", Target: rule.NewTarget("name", "baz", "namespace", "kube-system")},
+			{Provider: "Provider Foo", RuleID: "1", Severity: "HIGH", Status: rule.Passed, Message: "<foo>"},
+		}))
+	})
+
+	It("should embed the check targets of a merged report per cluster", func() {
+		rep := report.FromProviderResults(providerResults)
+		rep.Providers[0].Metadata["id"] = "cluster-1"
+		mergedReport, err := report.MergeReport([]*report.Report{rep}, map[string]string{"provider-foo": "id"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(renderedRows(mergedReport)).To(ConsistOf([]row{
+			{Provider: "Provider Foo", Cluster: "cluster-1", RuleID: "1", Severity: "HIGH", Status: rule.Passed, Message: "<foo>"},
+			{Provider: "Provider Foo", Cluster: "cluster-1", RuleID: "1", Severity: "HIGH", Status: rule.Failed, Message: "bar", Namespace: "default", Target: rule.NewTarget("name", "bar", "namespace", "default")},
+			{Provider: "Provider Foo", Cluster: "cluster-1", RuleID: "1", Severity: "HIGH", Status: rule.Failed, Message: "bar", Namespace: "kube-system", Target: rule.NewTarget("name", "baz", "namespace", "kube-system")},
+		}))
+	})
+
+	It("should group the results of all providers by ruleset and rule", func() {
+		providerResults = append(providerResults, provider.ProviderResult{
+			ProviderID:   "provider-bar",
+			ProviderName: "Provider Bar",
+			Metadata:     map[string]string{"id": "cluster-2"},
+			RulesetResults: []ruleset.RulesetResult{
+				{
+					RulesetID:      "ruleset-foo",
+					RulesetName:    "Ruleset Foo",
+					RulesetVersion: "v1",
+					RuleResults: []rule.RuleResult{
+						{
+							RuleID:   "1",
+							RuleName: "Rule 1 (HIGH 1)",
+							CheckResults: []rule.CheckResult{
+								rule.FailedCheckResult("bar", rule.NewTarget("name", "qux")),
+							},
+						},
+						{
+							RuleID:   "2",
+							RuleName: "Rule 2",
+							CheckResults: []rule.CheckResult{
+								rule.WarningCheckResult("baz", rule.NewTarget()),
+							},
+						},
+					},
+				},
+			},
+		})
+		rep := report.FromProviderResults(providerResults)
+		rep.Providers[0].Metadata["id"] = "cluster-1"
+		mergedReport, err := report.MergeReport([]*report.Report{rep}, map[string]string{"provider-foo": "id", "provider-bar": "id"})
+		Expect(err).ToNot(HaveOccurred())
+
+		buf := &bytes.Buffer{}
+		Expect(renderer.Render(buf, mergedReport)).To(Succeed())
+
+		_, allProviders, found := strings.Cut(buf.String(), "All Providers")
+		Expect(found).To(BeTrue())
+		allProviders, _, found = strings.Cut(allProviders, `<div id="explorer">`)
+		Expect(found).To(BeTrue())
+
+		summaries := []string{}
+		for _, match := range regexp.MustCompile(`<summary>(.*)</summary>`).FindAllStringSubmatch(allProviders, -1) {
+			summaries = append(summaries, match[1])
+		}
+		Expect(summaries).To(Equal([]string{
+			`&#128308 <span class="font-semibold">Rule 1 (HIGH 1)</span> [HIGH]`,
+			`&#128308 Failed: <span class="font-medium">bar</span>`,
+			`&#128994 Passed: <span class="font-medium">&lt;foo&gt;</span>`,
+			`&#128992 <span class="font-semibold">Rule 2</span>`,
+			`&#128992 Warning: <span class="font-medium">baz</span>`,
+		}))
+		Expect(allProviders).To(ContainSubstring(`<span class="font-semibold">provider-bar/cluster-2</span>`))
+		Expect(allProviders).To(ContainSubstring(`<span class="font-semibold">provider-foo/cluster-1</span>`))
+	})
+})
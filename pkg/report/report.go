@@ -7,6 +7,7 @@ package report
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"time"
@@ -14,14 +15,17 @@ import (
 	"github.com/gardener/diki/pkg/provider"
 	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
 )
 
 // Report contains information about a Diki run
 // in a suitable for reporting format.
 type Report struct {
-	Time      time.Time   `json:"time"`
-	MinStatus rule.Status `json:"minStatus,omitempty"`
-	Providers []Provider  `json:"providers"`
+	Time         time.Time     `json:"time"`
+	MinStatus    rule.Status   `json:"minStatus,omitempty"`
+	ScoreWeights *ScoreWeights `json:"scoreWeights,omitempty"`
+	Score        *Score        `json:"score,omitempty"`
+	Providers    []Provider    `json:"providers"`
 }
 
 // Provider contains information about a known provider
@@ -30,6 +34,7 @@ type Provider struct {
 	ID       string            `json:"id"`
 	Name     string            `json:"name"`
 	Metadata map[string]string `json:"metadata,omitempty"`
+	Score    *Score            `json:"score,omitempty"`
 	Rulesets []Ruleset         `json:"rulesets"`
 }
 
@@ -38,14 +43,18 @@ type Ruleset struct {
 	ID      string `json:"id"`
 	Name    string `json:"name"`
 	Version string `json:"version"`
-	Rules   []Rule `json:"rules"`
+	Score   *Score `json:"score,omitempty"`
+	// Tailoring is the tailoring profile applied to the ruleset.
+	Tailoring *tailoring.Profile `json:"tailoring,omitempty"`
+	Rules     []Rule             `json:"rules"`
 }
 
 // Rule contains information about a ran rule.
 type Rule struct {
-	ID     string  `json:"id"`
-	Name   string  `json:"name"`
-	Checks []Check `json:"checks"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Severity Severity `json:"severity,omitempty"`
+	Checks   []Check  `json:"checks"`
 }
 
 // Check is the result of a single Rule check.
@@ -57,7 +66,8 @@ type Check struct {
 
 // ReportOptions are options that can be applied to a Report.
 type ReportOptions struct {
-	MinStatus rule.Status
+	MinStatus    rule.Status
+	ScoreWeights *ScoreWeights
 }
 
 // ReportOption defines a single option that can be applied to a Report.
@@ -77,15 +87,18 @@ func (ms MinStatus) ApplyToReport(opts *ReportOptions) {
 
 // FromProviderResults returns a Diki report from ProviderResults.
 func FromProviderResults(results []provider.ProviderResult, options ...ReportOption) *Report {
-	opts := &ReportOptions{}
+	weights := DefaultScoreWeights()
+	opts := &ReportOptions{ScoreWeights: &weights}
 	for _, o := range options {
 		o.ApplyToReport(opts)
 	}
 	report := &Report{
-		Time:      time.Now().UTC(),
-		MinStatus: opts.MinStatus,
-		Providers: make([]Provider, 0, len(results)),
+		Time:         time.Now().UTC(),
+		MinStatus:    opts.MinStatus,
+		ScoreWeights: opts.ScoreWeights,
+		Providers:    make([]Provider, 0, len(results)),
 	}
+	providerScores := make([]*Score, 0, len(results))
 	for _, providerResult := range results {
 		p := Provider{
 			ID:       providerResult.ProviderID,
@@ -93,8 +106,15 @@ func FromProviderResults(results []provider.ProviderResult, options ...ReportOpt
 			Metadata: providerResult.Metadata,
 			Rulesets: getRulesets(providerResult.RulesetResults, opts),
 		}
+		rulesetScores := make([]*Score, 0, len(p.Rulesets))
+		for _, rs := range p.Rulesets {
+			rulesetScores = append(rulesetScores, rs.Score)
+		}
+		p.Score = sumScores(rulesetScores...)
+		providerScores = append(providerScores, p.Score)
 		report.Providers = append(report.Providers, p)
 	}
+	report.Score = sumScores(providerScores...)
 	return report
 }
 
@@ -107,6 +127,42 @@ func (r *Report) WriteToFile(filePath string) error {
 	return os.WriteFile(filePath, data, 0600)
 }
 
+// Parse parses a Diki report in json format. It returns a [*MergedReport]
+// when the data contains a merged report and a [*Report] otherwise.
+func Parse(data []byte) (any, error) {
+	probe := struct {
+		Kind      string                       `json:"kind"`
+		Providers []map[string]json.RawMessage `json:"providers"`
+	}{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	// merged reports written before the kind was introduced are detected by the distinct attribute of their providers
+	if probe.Kind == MergedReportKind || slices.ContainsFunc(probe.Providers, func(p map[string]json.RawMessage) bool {
+		_, ok := p["distinctBy"]
+		return ok
+	}) {
+		mergedReport := &MergedReport{}
+		if err := json.Unmarshal(data, mergedReport); err != nil {
+			return nil, err
+		}
+		mergedReport.Kind = MergedReportKind
+		return mergedReport, nil
+	}
+
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Write writes a Diki report in json format into the passed writer.
+func (r *Report) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
+
 // rulesetSummaryText returns a summary string with the number of rules with results per status.
 func rulesetSummaryText(ruleset *Ruleset) string {
 	statuses := rule.Statuses()
@@ -136,31 +192,18 @@ func numOfRulesWithStatus(ruleset *Ruleset, status rule.Status) int {
 	return num
 }
 
-// rulesWithStatus return all rules that have results with a given status.
-func rulesWithStatus(ruleset *Ruleset, status rule.Status) []Rule {
-	result := []Rule{}
-	for _, rule := range ruleset.Rules {
-		ruleWithStatus := Rule{ID: rule.ID, Name: rule.Name}
-		for _, check := range rule.Checks {
-			if check.Status == status {
-				ruleWithStatus.Checks = append(ruleWithStatus.Checks, check)
-			}
-		}
-		if len(ruleWithStatus.Checks) > 0 {
-			result = append(result, ruleWithStatus)
-		}
-	}
-	return result
-}
-
 func getRulesets(rulesetResults []ruleset.RulesetResult, opts *ReportOptions) []Ruleset {
 	rulesets := make([]Ruleset, 0, len(rulesetResults))
 	for _, rulesetResult := range rulesetResults {
+		// the score is calculated over all checks regardless of the minimal status
+		unfilteredRules := getRules(rulesetResult.RuleResults, &ReportOptions{})
 		rs := Ruleset{
-			ID:      rulesetResult.RulesetID,
-			Name:    rulesetResult.RulesetName,
-			Version: rulesetResult.RulesetVersion,
-			Rules:   getRules(rulesetResult.RuleResults, opts),
+			ID:        rulesetResult.RulesetID,
+			Name:      rulesetResult.RulesetName,
+			Version:   rulesetResult.RulesetVersion,
+			Score:     rulesetScore(Ruleset{Rules: unfilteredRules}, *opts.ScoreWeights),
+			Tailoring: rulesetResult.Tailoring,
+			Rules:     getRules(rulesetResult.RuleResults, opts),
 		}
 		rulesets = append(rulesets, rs)
 	}
@@ -171,9 +214,10 @@ func getRules(ruleResults []rule.RuleResult, opts *ReportOptions) []Rule {
 	rules := make([]Rule, 0, len(ruleResults))
 	for _, ruleResult := range ruleResults {
 		r := Rule{
-			ID:     ruleResult.RuleID,
-			Name:   ruleResult.RuleName,
-			Checks: getChecks(ruleResult.CheckResults, opts),
+			ID:       ruleResult.RuleID,
+			Name:     ruleResult.RuleName,
+			Severity: SeverityFromRuleName(ruleResult.RuleName),
+			Checks:   getChecks(ruleResult.CheckResults, opts),
 		}
 		rules = append(rules, r)
 	}
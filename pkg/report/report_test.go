@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/provider"
+	"github.com/gardener/diki/pkg/report"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
+)
+
+var _ = Describe("report", func() {
+	var providerResults []provider.ProviderResult
+
+	BeforeEach(func() {
+		providerResults = []provider.ProviderResult{
+			{
+				ProviderID:   "provider-foo",
+				ProviderName: "Provider Foo",
+				Metadata:     map[string]string{"foo": "bar"},
+				RulesetResults: []ruleset.RulesetResult{
+					{
+						RulesetID:      "ruleset-foo",
+						RulesetName:    "Ruleset Foo",
+						RulesetVersion: "v1",
+						RuleResults: []rule.RuleResult{
+							{
+								RuleID:   "1",
+								RuleName: "1",
+								CheckResults: []rule.CheckResult{
+									rule.PassedCheckResult("foo", rule.NewTarget("name", "foo")),
+									rule.FailedCheckResult("bar", rule.NewTarget("name", "bar")),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("#FromProviderResults", func() {
+		It("should respect the min status option", func() {
+			rep := report.FromProviderResults(providerResults, report.MinStatus(rule.Failed))
+
+			Expect(rep.MinStatus).To(Equal(rule.Failed))
+			Expect(rep.Providers).To(HaveLen(1))
+			Expect(rep.Providers[0].Rulesets[0].Rules[0].Checks).To(Equal([]report.Check{
+				{
+					Status:  rule.Failed,
+					Message: "bar",
+					Targets: []rule.Target{rule.NewTarget("name", "bar")},
+				},
+			}))
+		})
+
+		It("should record the tailoring profile of the rulesets", func() {
+			profile := &tailoring.Profile{
+				ID:        "acme",
+				Benchmark: tailoring.Benchmark{ID: "ruleset-foo", Version: "v1"},
+				SetValues: []tailoring.SetValue{{RuleID: "1", Parameter: "foo", Value: "bar"}},
+			}
+			providerResults[0].RulesetResults[0].Tailoring = profile
+
+			rep := report.FromProviderResults(providerResults)
+			Expect(rep.Providers[0].Rulesets[0].Tailoring).To(Equal(profile))
+
+			mergedReport, err := report.MergeReport([]*report.Report{rep}, map[string]string{"provider-foo": "foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mergedReport.Providers[0].Rulesets[0].Tailorings).To(Equal(map[string]*tailoring.Profile{"bar": profile}))
+		})
+	})
+
+	Describe("#Write", func() {
+		It("should write the report in json format", func() {
+			rep := report.FromProviderResults(providerResults)
+			buf := &bytes.Buffer{}
+
+			Expect(rep.Write(buf)).To(Succeed())
+
+			parsed := &report.Report{}
+			Expect(json.Unmarshal(buf.Bytes(), parsed)).To(Succeed())
+			Expect(parsed.Time.Equal(rep.Time)).To(BeTrue())
+			parsed.Time = rep.Time
+			Expect(parsed).To(Equal(rep))
+		})
+	})
+
+	Describe("#Parse", func() {
+		It("should parse plain reports", func() {
+			rep := report.FromProviderResults(providerResults)
+			buf := &bytes.Buffer{}
+			Expect(rep.Write(buf)).To(Succeed())
+
+			parsed, err := report.Parse(buf.Bytes())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(BeAssignableToTypeOf(&report.Report{}))
+		})
+
+		It("should parse merged reports", func() {
+			rep := report.FromProviderResults(providerResults)
+			mergedReport, err := report.MergeReport([]*report.Report{rep}, map[string]string{"provider-foo": "foo"})
+			Expect(err).ToNot(HaveOccurred())
+			buf := &bytes.Buffer{}
+			Expect(mergedReport.Write(buf)).To(Succeed())
+
+			parsed, err := report.Parse(buf.Bytes())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(BeAssignableToTypeOf(&report.MergedReport{}))
+			Expect(parsed.(*report.MergedReport).Providers[0].Metadata).To(HaveKey("bar"))
+		})
+
+		It("should parse merged reports without providers", func() {
+			mergedReport := &report.MergedReport{Kind: report.MergedReportKind, Providers: []report.MergedProvider{}}
+			buf := &bytes.Buffer{}
+			Expect(mergedReport.Write(buf)).To(Succeed())
+
+			parsed, err := report.Parse(buf.Bytes())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(BeAssignableToTypeOf(&report.MergedReport{}))
+		})
+
+		It("should return error for invalid data", func() {
+			parsed, err := report.Parse([]byte("foo"))
+			Expect(err).To(HaveOccurred())
+			Expect(parsed).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// Severity is the severity of a rule.
+type Severity string
+
+const (
+	// SeverityHigh is the severity of rules whose violation has a high impact.
+	SeverityHigh Severity = "HIGH"
+	// SeverityMedium is the severity of rules whose violation has a medium impact.
+	SeverityMedium Severity = "MEDIUM"
+	// SeverityLow is the severity of rules whose violation has a low impact.
+	SeverityLow Severity = "LOW"
+)
+
+// Severities returns all known severities.
+func Severities() []Severity {
+	return []Severity{SeverityHigh, SeverityMedium, SeverityLow}
+}
+
+// severityRegex matches the severity suffix of rule names, e.g. "(MEDIUM 242376)".
+var severityRegex = regexp.MustCompile(`\((HIGH|MEDIUM|LOW)\s*[\w.-]*\)\s*$`)
+
+// SeverityFromRuleName returns the severity of a rule based on its name.
+// An empty Severity is returned when the name does not contain one.
+func SeverityFromRuleName(name string) Severity {
+	match := severityRegex.FindStringSubmatch(name)
+	if len(match) < 2 {
+		return ""
+	}
+	return Severity(match[1])
+}
+
+// ScoreWeights are the weights used to calculate a compliance [Score].
+type ScoreWeights struct {
+	// Severities maps rule severities to the weight of the rules in the score.
+	// Rules with severity that is not present are weighted with 1.
+	Severities map[Severity]float64 `json:"severities"`
+	// Statuses maps rule statuses to the fraction of the rule weight that is
+	// achieved when a rule has the given status. Rules with status that is not
+	// present are not taken into account.
+	Statuses map[rule.Status]float64 `json:"statuses"`
+}
+
+// DefaultScoreWeights returns the default [ScoreWeights].
+// Accepted rules count as passed, warnings count as half passed
+// while skipped and not implemented rules are excluded from the score.
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		Severities: map[Severity]float64{
+			SeverityHigh:   10,
+			SeverityMedium: 5,
+			SeverityLow:    1,
+		},
+		Statuses: map[rule.Status]float64{
+			rule.Passed:   1,
+			rule.Accepted: 1,
+			rule.Warning:  0.5,
+			rule.Failed:   0,
+			rule.Errored:  0,
+		},
+	}
+}
+
+// ApplyToReport implements ReportOption.
+func (sw ScoreWeights) ApplyToReport(opts *ReportOptions) {
+	opts.ScoreWeights = &sw
+}
+
+// Score is a weighted compliance posture summary.
+type Score struct {
+	// Value is the achieved percentage of the total weight.
+	Value float64 `json:"value"`
+	// Achieved is the sum of the weights achieved by the scored rules.
+	Achieved float64 `json:"achieved"`
+	// Total is the sum of the weights of the scored rules.
+	Total float64 `json:"total"`
+}
+
+func newScore(achieved, total float64) *Score {
+	if total <= 0 {
+		return nil
+	}
+	return &Score{
+		Value:    math.Round(achieved/total*10000) / 100,
+		Achieved: achieved,
+		Total:    total,
+	}
+}
+
+// sumScores aggregates multiple scores into a single one.
+// Nil scores are ignored.
+func sumScores(scores ...*Score) *Score {
+	var achieved, total float64
+	for _, s := range scores {
+		if s == nil {
+			continue
+		}
+		achieved += s.Achieved
+		total += s.Total
+	}
+	return newScore(achieved, total)
+}
+
+// ruleWeights returns the achieved and the total weight of a rule
+// with the given severity and check statuses.
+func ruleWeights(severity Severity, statuses []rule.Status, weights ScoreWeights) (float64, float64) {
+	if len(statuses) == 0 {
+		return 0, 0
+	}
+
+	status := statuses[0]
+	for _, s := range statuses[1:] {
+		if status.Less(s) {
+			status = s
+		}
+	}
+
+	statusWeight, ok := weights.Statuses[status]
+	if !ok {
+		return 0, 0
+	}
+
+	severityWeight, ok := weights.Severities[severity]
+	if !ok {
+		severityWeight = 1
+	}
+	return severityWeight * statusWeight, severityWeight
+}
+
+// rulesetScore calculates the score of the rules of a ruleset with the severities
+// declared by the rules. Only the checks contained in the rules are scored, i.e. the
+// rules of reports filtered with a minimal status are rescored only over the remaining
+// checks and rules without remaining checks are excluded from the score.
+func rulesetScore(ruleset Ruleset, weights ScoreWeights) *Score {
+	var achieved, total float64
+	for _, r := range ruleset.Rules {
+		statuses := make([]rule.Status, 0, len(r.Checks))
+		for _, check := range r.Checks {
+			statuses = append(statuses, check.Status)
+		}
+		a, t := ruleWeights(r.Severity, statuses, weights)
+		achieved += a
+		total += t
+	}
+	return newScore(achieved, total)
+}
+
+// providerScore returns the score of a provider. If the provider does not
+// have a score it is calculated from its rulesets with the given weights.
+func providerScore(p Provider, weights ScoreWeights) *Score {
+	if p.Score != nil {
+		return p.Score
+	}
+	scores := make([]*Score, 0, len(p.Rulesets))
+	for _, rs := range p.Rulesets {
+		scores = append(scores, rulesetScoreOrCalculate(rs, weights))
+	}
+	return sumScores(scores...)
+}
+
+// rulesetScoreOrCalculate returns the score of a ruleset. If the ruleset
+// does not have a score it is calculated with the given weights.
+func rulesetScoreOrCalculate(rs Ruleset, weights ScoreWeights) *Score {
+	if rs.Score != nil {
+		return rs.Score
+	}
+	return rulesetScore(rs, weights)
+}
+
+// scoreWeights returns the weights the report was scored with.
+// The default weights are returned for reports created without weights.
+func (r *Report) scoreWeights() ScoreWeights {
+	if r.ScoreWeights != nil {
+		return *r.ScoreWeights
+	}
+	return DefaultScoreWeights()
+}
+
+// scoreText returns a human readable representation of a score.
+func scoreText(score *Score) string {
+	if score == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f%%", score.Value)
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/provider"
+	"github.com/gardener/diki/pkg/report"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+)
+
+var _ = Describe("score", func() {
+	DescribeTable("#SeverityFromRuleName",
+		func(name string, expectedSeverity report.Severity) {
+			Expect(report.SeverityFromRuleName(name)).To(Equal(expectedSeverity))
+		},
+		Entry("should parse high severity", "Kubernetes must have a pod security policy set (HIGH 242437)", report.SeverityHigh),
+		Entry("should parse medium severity without space", "The Kubernetes API Server must enable Node,RBAC as the authorization mode (MEDIUM242382)", report.SeverityMedium),
+		Entry("should parse low severity", "foo (LOW)", report.SeverityLow),
+		Entry("should return empty severity when missing", "foo", report.Severity("")),
+		Entry("should return empty severity when not at the end", "foo (HIGH 1) bar", report.Severity("")),
+	)
+
+	Describe("#FromProviderResults", func() {
+		var providerResults []provider.ProviderResult
+
+		BeforeEach(func() {
+			providerResults = []provider.ProviderResult{
+				{
+					ProviderID:   "foo",
+					ProviderName: "Foo",
+					RulesetResults: []ruleset.RulesetResult{
+						{
+							RulesetID:      "bar",
+							RulesetName:    "Bar",
+							RulesetVersion: "v1",
+							RuleResults: []rule.RuleResult{
+								{
+									RuleID:   "1",
+									RuleName: "rule 1 (HIGH 1)",
+									CheckResults: []rule.CheckResult{
+										rule.PassedCheckResult("foo", nil),
+										rule.FailedCheckResult("foo", nil),
+									},
+								},
+								{
+									RuleID:   "2",
+									RuleName: "rule 2 (MEDIUM 2)",
+									CheckResults: []rule.CheckResult{
+										rule.AcceptedCheckResult("foo", nil),
+									},
+								},
+								{
+									RuleID:   "3",
+									RuleName: "rule 3 (LOW 3)",
+									CheckResults: []rule.CheckResult{
+										rule.WarningCheckResult("foo", nil),
+									},
+								},
+								{
+									RuleID:   "4",
+									RuleName: "rule 4 (HIGH 4)",
+									CheckResults: []rule.CheckResult{
+										rule.SkippedCheckResult("foo", nil),
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should calculate scores with the default weights", func() {
+			rep := report.FromProviderResults(providerResults)
+
+			expectedScore := &report.Score{Value: 34.38, Achieved: 5.5, Total: 16}
+			Expect(rep.Score).To(Equal(expectedScore))
+			Expect(rep.Providers[0].Score).To(Equal(expectedScore))
+			Expect(rep.Providers[0].Rulesets[0].Score).To(Equal(expectedScore))
+			Expect(*rep.ScoreWeights).To(Equal(report.DefaultScoreWeights()))
+		})
+
+		It("should calculate scores with custom weights regardless of min status", func() {
+			weights := report.ScoreWeights{
+				Severities: map[report.Severity]float64{
+					report.SeverityHigh: 2,
+				},
+				Statuses: map[rule.Status]float64{
+					rule.Passed:  1,
+					rule.Skipped: 1,
+					rule.Failed:  0,
+				},
+			}
+			rep := report.FromProviderResults(providerResults, weights, report.MinStatus(rule.Failed))
+
+			Expect(rep.Score).To(Equal(&report.Score{Value: 50, Achieved: 2, Total: 4}))
+			Expect(rep.ScoreWeights).To(Equal(&weights))
+		})
+
+		It("should not set a score when no rule is scored", func() {
+			providerResults[0].RulesetResults[0].RuleResults = providerResults[0].RulesetResults[0].RuleResults[3:]
+			rep := report.FromProviderResults(providerResults)
+
+			Expect(rep.Score).To(BeNil())
+			Expect(rep.Providers[0].Score).To(BeNil())
+		})
+	})
+
+	Describe("#MergeReport", func() {
+		It("should calculate missing scores with the weights of the report", func() {
+			rep := &report.Report{
+				ScoreWeights: &report.ScoreWeights{
+					Severities: map[report.Severity]float64{report.SeverityHigh: 4},
+					Statuses:   map[rule.Status]float64{rule.Passed: 1, rule.Failed: 0},
+				},
+				Providers: []report.Provider{
+					{
+						ID:       "foo",
+						Metadata: map[string]string{"id": "1"},
+						Rulesets: []report.Ruleset{
+							{
+								ID: "bar",
+								Rules: []report.Rule{
+									{ID: "1", Name: "rule 1 (HIGH 1)", Severity: report.SeverityHigh, Checks: []report.Check{{Status: rule.Passed}}},
+									{ID: "2", Name: "rule 2 (MEDIUM 2)", Severity: report.SeverityMedium, Checks: []report.Check{{Status: rule.Failed}}},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			mergedReport, err := report.MergeReport([]*report.Report{rep}, map[string]string{"foo": "id"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mergedReport.Score).To(Equal(&report.Score{Value: 80, Achieved: 4, Total: 5}))
+		})
+
+		It("should weight rules with their severity instead of their name", func() {
+			rep := &report.Report{
+				Providers: []report.Provider{
+					{
+						ID:       "foo",
+						Metadata: map[string]string{"id": "1"},
+						Rulesets: []report.Ruleset{
+							{
+								ID: "bar",
+								Rules: []report.Rule{
+									{ID: "1", Name: "renamed rule 1 (LOW 1)", Severity: report.SeverityHigh, Checks: []report.Check{{Status: rule.Passed}}},
+									{ID: "2", Name: "renamed rule 2 (HIGH 2)", Checks: []report.Check{{Status: rule.Failed}}},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			mergedReport, err := report.MergeReport([]*report.Report{rep}, map[string]string{"foo": "id"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mergedReport.Score).To(Equal(&report.Score{Value: 90.91, Achieved: 10, Total: 11}))
+		})
+
+		It("should rescore reports filtered with min status only over the remaining rules", func() {
+			rep := report.FromProviderResults([]provider.ProviderResult{
+				{
+					ProviderID: "foo",
+					Metadata:   map[string]string{"id": "1"},
+					RulesetResults: []ruleset.RulesetResult{
+						{
+							RulesetID: "bar",
+							RuleResults: []rule.RuleResult{
+								{RuleID: "1", RuleName: "rule 1 (HIGH 1)", CheckResults: []rule.CheckResult{rule.PassedCheckResult("foo", nil)}},
+								{RuleID: "2", RuleName: "rule 2 (MEDIUM 2)", CheckResults: []rule.CheckResult{rule.FailedCheckResult("foo", nil)}},
+								{RuleID: "3", RuleName: "rule 3 (LOW 3)", CheckResults: []rule.CheckResult{rule.PassedCheckResult("foo", nil), rule.WarningCheckResult("foo", nil)}},
+							},
+						},
+					},
+				},
+			}, report.MinStatus(rule.Warning))
+			Expect(rep.Score).To(Equal(&report.Score{Value: 65.63, Achieved: 10.5, Total: 16}))
+
+			// reports written without scores are scored only over the checks they contain
+			rep.Score = nil
+			rep.Providers[0].Score = nil
+			rep.Providers[0].Rulesets[0].Score = nil
+
+			mergedReport, err := report.MergeReport([]*report.Report{rep}, map[string]string{"foo": "id"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mergedReport.Score).To(Equal(&report.Score{Value: 8.33, Achieved: 0.5, Total: 6}))
+		})
+	})
+})
@@ -0,0 +1,280 @@
+{{define "_explorer"}}
+<style>
+    .explorer-toolbar {
+        display: flex;
+        flex-wrap: wrap;
+        align-items: center;
+        gap: 0.5rem;
+        padding: 0.5rem 0 1rem 0;
+    }
+
+    .explorer-toolbar input,
+    .explorer-toolbar select,
+    .explorer-toolbar button {
+        border: 1px solid #d1d5db;
+        border-radius: 0.25rem;
+        padding: 0.125rem 0.5rem;
+    }
+
+    #explorer-results details {
+        padding-left: 1.25rem;
+    }
+
+    #explorer-results summary {
+        cursor: pointer;
+    }
+
+    #explorer-results table {
+        margin: 0.25rem 0 0.5rem 1.25rem;
+    }
+
+    #explorer-results th,
+    #explorer-results td {
+        border: 1px solid #e5e7eb;
+        padding: 0.125rem 0.5rem;
+        text-align: left;
+    }
+
+    #explorer-results th {
+        background-color: #f9fafb;
+        cursor: pointer;
+        user-select: none;
+    }
+</style>
+<div id="explorer">
+    <div class="explorer-toolbar">
+        <input id="explorer-search" type="search" placeholder="Search">
+        <select id="explorer-status" data-key="status"></select>
+        <select id="explorer-severity" data-key="severity"></select>
+        <select id="explorer-provider" data-key="provider"></select>
+        <select id="explorer-cluster" data-key="cluster"></select>
+        <select id="explorer-namespace" data-key="namespace"></select>
+        <select id="explorer-rule" data-key="ruleID"></select>
+        <button id="explorer-expand" type="button">Expand all</button>
+        <button id="explorer-collapse" type="button">Collapse all</button>
+        <button id="explorer-csv" type="button">Download CSV</button>
+        <span id="explorer-count"></span>
+    </div>
+    <div id="explorer-results"></div>
+</div>
+<script>
+    (function () {
+        const data = {{ . }};
+        const rows = data.rows;
+        const statuses = data.statuses;
+        const icons = data.icons;
+        const labels = {
+            status: "status",
+            severity: "severity",
+            provider: "provider",
+            cluster: "cluster",
+            namespace: "namespace",
+            ruleID: "rule",
+        };
+        const selects = Array.from(document.querySelectorAll(".explorer-toolbar select"));
+        const search = document.getElementById("explorer-search");
+        const results = document.getElementById("explorer-results");
+        const count = document.getElementById("explorer-count");
+
+        rows.forEach(function (row) {
+            const values = [row.provider, row.cluster, row.rulesetName, row.rulesetVersion, row.ruleID, row.ruleName, row.severity, row.status, row.message];
+            Object.keys(row.target || {}).forEach(function (key) {
+                values.push(key + ": " + row.target[key]);
+            });
+            row.text = values.filter(Boolean).join("\n").toLowerCase();
+        });
+
+        function unique(key) {
+            const values = Array.from(new Set(rows.map(function (row) { return row[key]; }).filter(Boolean)));
+            if (key === "status") {
+                return values.sort(function (a, b) { return statuses.indexOf(a) - statuses.indexOf(b); });
+            }
+            return values.sort();
+        }
+
+        selects.forEach(function (select) {
+            const key = select.dataset.key;
+            const values = unique(key);
+            if (values.length < 2) {
+                select.classList.add("hidden");
+            }
+            select.appendChild(new Option("All " + labels[key], ""));
+            values.forEach(function (value) {
+                select.appendChild(new Option(key === "status" ? icons[value] + " " + value : value, value));
+            });
+            select.addEventListener("change", render);
+        });
+        search.addEventListener("input", render);
+
+        function filtered() {
+            const query = search.value.trim().toLowerCase();
+            return rows.filter(function (row) {
+                const matchesSelects = selects.every(function (select) {
+                    return select.value === "" || row[select.dataset.key] === select.value;
+                });
+                return matchesSelects && (query === "" || row.text.includes(query));
+            });
+        }
+
+        function group(items, keyFunc) {
+            const groups = new Map();
+            items.forEach(function (item) {
+                const key = keyFunc(item);
+                if (!groups.has(key)) {
+                    groups.set(key, []);
+                }
+                groups.get(key).push(item);
+            });
+            return groups;
+        }
+
+        function worstStatus(items) {
+            return items.reduce(function (worst, row) {
+                return statuses.indexOf(row.status) > statuses.indexOf(worst) ? row.status : worst;
+            }, items[0].status);
+        }
+
+        function details(text, open) {
+            const element = document.createElement("details");
+            const summary = document.createElement("summary");
+            summary.textContent = text;
+            element.appendChild(summary);
+            element.open = open;
+            return element;
+        }
+
+        function columns(items) {
+            const result = [];
+            if (unique("provider").length > 1) {
+                result.push({ label: "provider", value: function (row) { return row.provider; } });
+            }
+            if (unique("cluster").length > 0) {
+                result.push({ label: "cluster", value: function (row) { return row.cluster || ""; } });
+            }
+            const keys = new Set();
+            items.forEach(function (row) {
+                Object.keys(row.target || {}).forEach(function (key) { keys.add(key); });
+            });
+            Array.from(keys).sort().forEach(function (key) {
+                result.push({ label: key, value: function (row) { return (row.target || {})[key] || ""; } });
+            });
+            return result;
+        }
+
+        function table(items) {
+            const cols = columns(items);
+            const element = document.createElement("table");
+            const head = element.createTHead().insertRow();
+            const body = element.createTBody();
+            let sortColumn = -1;
+            let ascending = true;
+
+            function fill() {
+                const sorted = items.slice();
+                if (sortColumn >= 0) {
+                    const value = cols[sortColumn].value;
+                    sorted.sort(function (a, b) {
+                        const result = value(a).localeCompare(value(b), undefined, { numeric: true });
+                        return ascending ? result : -result;
+                    });
+                }
+                body.replaceChildren();
+                sorted.forEach(function (row) {
+                    const tr = body.insertRow();
+                    cols.forEach(function (col) {
+                        tr.insertCell().textContent = col.value(row);
+                    });
+                });
+            }
+
+            cols.forEach(function (col, idx) {
+                const th = document.createElement("th");
+                th.textContent = col.label;
+                th.addEventListener("click", function () {
+                    ascending = sortColumn === idx ? !ascending : true;
+                    sortColumn = idx;
+                    head.querySelectorAll("th").forEach(function (cell, cellIdx) {
+                        cell.textContent = cols[cellIdx].label + (cellIdx === sortColumn ? (ascending ? " ▲" : " ▼") : "");
+                    });
+                    fill();
+                });
+                head.appendChild(th);
+            });
+            fill();
+            return element;
+        }
+
+        function render() {
+            const items = filtered();
+            count.textContent = items.length + " of " + rows.length + " results";
+            results.replaceChildren();
+
+            group(items, function (row) { return row.rulesetVersion + " " + row.rulesetName; }).forEach(function (rulesetItems, ruleset) {
+                const rulesetElement = details(ruleset, true);
+                const rules = group(rulesetItems, function (row) { return row.ruleID; });
+                Array.from(rules.keys()).sort(function (a, b) { return a.localeCompare(b, undefined, { numeric: true }); }).forEach(function (ruleID) {
+                    const ruleItems = rules.get(ruleID);
+                    const status = worstStatus(ruleItems);
+                    const severity = ruleItems[0].severity ? " [" + ruleItems[0].severity + "]" : "";
+                    const ruleElement = details(icons[status] + " " + ruleItems[0].ruleName + severity, false);
+                    const checks = group(ruleItems, function (row) { return row.status + "\n" + row.message; });
+                    Array.from(checks.keys()).sort(function (a, b) {
+                        return statuses.indexOf(checks.get(b)[0].status) - statuses.indexOf(checks.get(a)[0].status);
+                    }).forEach(function (key) {
+                        const checkItems = checks.get(key);
+                        const targets = checkItems.filter(function (row) { return row.target || row.cluster; });
+                        const checkElement = details(icons[checkItems[0].status] + " " + checkItems[0].status + ": " + checkItems[0].message + (targets.length > 0 ? " (" + targets.length + ")" : ""), false);
+                        if (targets.length > 0) {
+                            checkElement.addEventListener("toggle", function () {
+                                if (checkElement.open && checkElement.children.length === 1) {
+                                    checkElement.appendChild(table(targets));
+                                }
+                            });
+                        }
+                        ruleElement.appendChild(checkElement);
+                    });
+                    rulesetElement.appendChild(ruleElement);
+                });
+                results.appendChild(rulesetElement);
+            });
+        }
+
+        function setOpen(open) {
+            results.querySelectorAll("details").forEach(function (element) {
+                if (element.open !== open) {
+                    element.open = open;
+                    element.dispatchEvent(new Event("toggle"));
+                }
+            });
+        }
+
+        document.getElementById("explorer-expand").addEventListener("click", function () { setOpen(true); });
+        document.getElementById("explorer-collapse").addEventListener("click", function () { setOpen(false); });
+
+        document.getElementById("explorer-csv").addEventListener("click", function () {
+            const items = filtered();
+            const keys = new Set();
+            items.forEach(function (row) {
+                Object.keys(row.target || {}).forEach(function (key) { keys.add(key); });
+            });
+            const targetKeys = Array.from(keys).sort();
+            const header = ["provider", "cluster", "ruleset", "version", "rule", "name", "severity", "status", "message"].concat(targetKeys);
+            const lines = [header].concat(items.map(function (row) {
+                return [row.provider, row.cluster, row.rulesetID, row.rulesetVersion, row.ruleID, row.ruleName, row.severity, row.status, row.message].concat(
+                    targetKeys.map(function (key) { return (row.target || {})[key]; }));
+            })).map(function (values) {
+                return values.map(function (value) {
+                    return "\"" + String(value || "").replace(/"/g, "\"\"") + "\"";
+                }).join(",");
+            });
+            const link = document.createElement("a");
+            link.href = URL.createObjectURL(new Blob([lines.join("\r\n")], { type: "text/csv" }));
+            link.download = "diki-report.csv";
+            link.click();
+            URL.revokeObjectURL(link.href);
+        });
+
+        render();
+    })();
+</script>
+{{end}}
@@ -0,0 +1,300 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// Trend contains information about the development
+// of multiple Diki runs over time.
+type Trend struct {
+	Time               time.Time           `json:"time"`
+	Points             []TrendPoint        `json:"points"`
+	Rules              []RuleHistory       `json:"rules"`
+	PersistentFailures []PersistentFailure `json:"persistentFailures"`
+}
+
+// TrendPoint summarizes a single report of a [Trend].
+type TrendPoint struct {
+	Time time.Time `json:"time"`
+	// StatusCounts contains the number of rules with results per status.
+	StatusCounts map[rule.Status]int `json:"statusCounts"`
+	Score        *Score              `json:"score,omitempty"`
+}
+
+// RuleHistory contains the status changes of a rule over time.
+type RuleHistory struct {
+	ProviderID     string `json:"providerID"`
+	RulesetID      string `json:"rulesetID"`
+	RulesetVersion string `json:"rulesetVersion"`
+	RuleID         string `json:"ruleID"`
+	RuleName       string `json:"ruleName"`
+	// Statuses contains the most severe status of the rule in every report.
+	// Reports that do not contain the rule have an empty status.
+	Statuses []rule.Status `json:"statuses"`
+	// Changes contains the points in time when the status of the rule changed.
+	Changes []StatusChange `json:"changes,omitempty"`
+}
+
+// StatusChange describes a change of the status of a rule.
+type StatusChange struct {
+	Time time.Time   `json:"time"`
+	From rule.Status `json:"from,omitempty"`
+	To   rule.Status `json:"to,omitempty"`
+}
+
+// PersistentFailure is a rule that failed in the consecutive latest reports.
+type PersistentFailure struct {
+	ProviderID     string      `json:"providerID"`
+	RulesetID      string      `json:"rulesetID"`
+	RulesetVersion string      `json:"rulesetVersion"`
+	RuleID         string      `json:"ruleID"`
+	RuleName       string      `json:"ruleName"`
+	Status         rule.Status `json:"status"`
+	FailingSince   time.Time   `json:"failingSince"`
+	// Reports is the number of consecutive latest reports in which the rule failed.
+	Reports int `json:"reports"`
+}
+
+// TrendOptions are options that can be applied to a Trend.
+type TrendOptions struct {
+	MaxPersistentFailures int
+}
+
+// TrendOption defines a single option that can be applied to a Trend.
+type TrendOption interface {
+	ApplyToTrend(*TrendOptions)
+}
+
+// MaxPersistentFailures is the maximum number of persistent failures in a Trend.
+type MaxPersistentFailures int
+
+// ApplyToTrend implements TrendOption.
+func (m MaxPersistentFailures) ApplyToTrend(opts *TrendOptions) {
+	if m > 0 {
+		opts.MaxPersistentFailures = int(m)
+	}
+}
+
+// NewTrend creates a Trend from reports. The reports are ordered by time. All reports must
+// contain the same providers with the same metadata, which identifies the evaluated clusters,
+// and the same rulesets. The versions of the rulesets can change over time.
+func NewTrend(reports []*Report, options ...TrendOption) (*Trend, error) {
+	if len(reports) == 0 {
+		return nil, errors.New("zero reports provided for trend")
+	}
+
+	opts := &TrendOptions{MaxPersistentFailures: 10}
+	for _, o := range options {
+		o.ApplyToTrend(opts)
+	}
+
+	sortedReports := slices.Clone(reports)
+	slices.SortStableFunc(sortedReports, func(a, b *Report) int {
+		return a.Time.Compare(b.Time)
+	})
+	for _, report := range sortedReports[1:] {
+		if err := checkSameIdentity(sortedReports[0], report); err != nil {
+			return nil, err
+		}
+	}
+
+	trend := &Trend{
+		Time:               time.Now().UTC(),
+		Points:             make([]TrendPoint, 0, len(sortedReports)),
+		Rules:              []RuleHistory{},
+		PersistentFailures: []PersistentFailure{},
+	}
+
+	type ruleKey struct {
+		providerID, rulesetID, rulesetVersion, ruleID string
+	}
+	histories := map[ruleKey]*RuleHistory{}
+	keys := []ruleKey{}
+	for idx, report := range sortedReports {
+		point := TrendPoint{
+			Time:         report.Time,
+			StatusCounts: map[rule.Status]int{},
+		}
+		providerScores := make([]*Score, 0, len(report.Providers))
+		for _, p := range report.Providers {
+			providerScores = append(providerScores, providerScore(p, report.scoreWeights()))
+			for _, rs := range p.Rulesets {
+				for _, status := range rule.Statuses() {
+					if num := numOfRulesWithStatus(&rs, status); num > 0 {
+						point.StatusCounts[status] += num
+					}
+				}
+				for _, r := range rs.Rules {
+					key := ruleKey{p.ID, rs.ID, rs.Version, r.ID}
+					history, ok := histories[key]
+					if !ok {
+						history = &RuleHistory{
+							ProviderID:     p.ID,
+							RulesetID:      rs.ID,
+							RulesetVersion: rs.Version,
+							RuleID:         r.ID,
+							Statuses:       make([]rule.Status, len(sortedReports)),
+						}
+						histories[key] = history
+						keys = append(keys, key)
+					}
+					history.RuleName = r.Name
+					history.Statuses[idx] = ruleStatus(r)
+				}
+			}
+		}
+		point.Score = sumScores(providerScores...)
+		trend.Points = append(trend.Points, point)
+	}
+
+	for _, key := range keys {
+		history := histories[key]
+		for idx := 1; idx < len(history.Statuses); idx++ {
+			if history.Statuses[idx] != history.Statuses[idx-1] {
+				history.Changes = append(history.Changes, StatusChange{
+					Time: sortedReports[idx].Time,
+					From: history.Statuses[idx-1],
+					To:   history.Statuses[idx],
+				})
+			}
+		}
+		trend.Rules = append(trend.Rules, *history)
+
+		last := len(history.Statuses) - 1
+		if !isFailing(history.Statuses[last]) {
+			continue
+		}
+		start := last
+		for start > 0 && isFailing(history.Statuses[start-1]) {
+			start--
+		}
+		trend.PersistentFailures = append(trend.PersistentFailures, PersistentFailure{
+			ProviderID:     history.ProviderID,
+			RulesetID:      history.RulesetID,
+			RulesetVersion: history.RulesetVersion,
+			RuleID:         history.RuleID,
+			RuleName:       history.RuleName,
+			Status:         history.Statuses[last],
+			FailingSince:   sortedReports[start].Time,
+			Reports:        last - start + 1,
+		})
+	}
+
+	slices.SortStableFunc(trend.Rules, func(a, b RuleHistory) int {
+		return slices.Compare(
+			[]string{a.ProviderID, a.RulesetID, a.RulesetVersion, a.RuleID},
+			[]string{b.ProviderID, b.RulesetID, b.RulesetVersion, b.RuleID},
+		)
+	})
+	slices.SortStableFunc(trend.PersistentFailures, func(a, b PersistentFailure) int {
+		if c := cmp.Compare(b.Reports, a.Reports); c != 0 {
+			return c
+		}
+		return slices.Compare(
+			[]string{a.ProviderID, a.RulesetID, a.RulesetVersion, a.RuleID},
+			[]string{b.ProviderID, b.RulesetID, b.RulesetVersion, b.RuleID},
+		)
+	})
+	if len(trend.PersistentFailures) > opts.MaxPersistentFailures {
+		trend.PersistentFailures = trend.PersistentFailures[:opts.MaxPersistentFailures]
+	}
+
+	return trend, nil
+}
+
+// Write writes a Trend in json format into the passed writer.
+func (t *Trend) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t)
+}
+
+// checkSameIdentity returns an error if a report does not contain the same providers, provider
+// metadata and rulesets as the reference report, since they do not describe the same clusters.
+func checkSameIdentity(reference, report *Report) error {
+	reportTime := report.Time.Format(time.RFC3339)
+	if ids, referenceIDs := providerIDs(report), providerIDs(reference); !slices.Equal(ids, referenceIDs) {
+		return fmt.Errorf("report from %s contains providers %v instead of %v", reportTime, ids, referenceIDs)
+	}
+
+	for _, p := range report.Providers {
+		idx := slices.IndexFunc(reference.Providers, func(other Provider) bool { return other.ID == p.ID })
+		referenceProvider := reference.Providers[idx]
+		if !maps.Equal(p.Metadata, referenceProvider.Metadata) {
+			return fmt.Errorf("provider %s of report from %s has metadata %v instead of %v", p.ID, reportTime, p.Metadata, referenceProvider.Metadata)
+		}
+		if ids, referenceIDs := rulesetIDs(p), rulesetIDs(referenceProvider); !slices.Equal(ids, referenceIDs) {
+			return fmt.Errorf("provider %s of report from %s contains rulesets %v instead of %v", p.ID, reportTime, ids, referenceIDs)
+		}
+	}
+	return nil
+}
+
+// providerIDs returns the sorted ids of the providers of a report.
+func providerIDs(report *Report) []string {
+	ids := make([]string, 0, len(report.Providers))
+	for _, p := range report.Providers {
+		ids = append(ids, p.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// rulesetIDs returns the sorted ids of the rulesets of a provider.
+func rulesetIDs(p Provider) []string {
+	ids := make([]string, 0, len(p.Rulesets))
+	for _, rs := range p.Rulesets {
+		ids = append(ids, rs.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// ruleStatus returns the most severe status of the checks of a rule.
+func ruleStatus(r Rule) rule.Status {
+	var status rule.Status
+	for _, check := range r.Checks {
+		if status == "" || status.Less(check.Status) {
+			status = check.Status
+		}
+	}
+	return status
+}
+
+func isFailing(status rule.Status) bool {
+	return status == rule.Failed || status == rule.Errored
+}
+
+// changedRules returns the rule histories that contain at least one status change.
+func changedRules(trend *Trend) []RuleHistory {
+	result := []RuleHistory{}
+	for _, history := range trend.Rules {
+		if len(history.Changes) > 0 {
+			result = append(result, history)
+		}
+	}
+	return result
+}
+
+// statusPercentage returns the percentage of rules with the given status in a trend point.
+func statusPercentage(point TrendPoint, status rule.Status) float64 {
+	total := 0
+	for _, num := range point.StatusCounts {
+		total += num
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(point.StatusCounts[status]) * 100 / float64(total)
+}
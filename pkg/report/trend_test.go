@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/report"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+var _ = Describe("trend", func() {
+	Describe("#NewTrend", func() {
+		var (
+			newReport = func(month time.Month, statuses ...rule.Status) *report.Report {
+				rules := []report.Rule{}
+				for idx, status := range statuses {
+					id := string(rune('1' + idx))
+					rules = append(rules, report.Rule{
+						ID:     id,
+						Name:   id,
+						Checks: []report.Check{{Status: rule.Passed, Message: "foo"}, {Status: status, Message: "bar"}},
+					})
+				}
+				return &report.Report{
+					Time: time.Date(2000, month, 1, 0, 0, 0, 0, time.UTC),
+					Providers: []report.Provider{
+						{
+							ID:   "provider-foo",
+							Name: "Provider Foo",
+							Rulesets: []report.Ruleset{
+								{
+									ID:      "ruleset-foo",
+									Name:    "Ruleset Foo",
+									Version: "v1",
+									Rules:   rules,
+								},
+							},
+						},
+					},
+				}
+			}
+			january  = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+			february = time.Date(2000, time.February, 1, 0, 0, 0, 0, time.UTC)
+			march    = time.Date(2000, time.March, 1, 0, 0, 0, 0, time.UTC)
+		)
+
+		It("should return error when zero reports are provided", func() {
+			trend, err := report.NewTrend([]*report.Report{})
+
+			Expect(trend).To(BeNil())
+			Expect(err).To(MatchError("zero reports provided for trend"))
+		})
+
+		It("should order reports by time and track rule changes", func() {
+			reports := []*report.Report{
+				newReport(time.March, rule.Failed, rule.Errored, rule.Passed),
+				newReport(time.January, rule.Passed, rule.Failed),
+				newReport(time.February, rule.Failed, rule.Failed, rule.Warning),
+			}
+
+			trend, err := report.NewTrend(reports)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(trend.Points).To(Equal([]report.TrendPoint{
+				{
+					Time:         january,
+					StatusCounts: map[rule.Status]int{rule.Passed: 2, rule.Failed: 1},
+					Score:        &report.Score{Value: 50, Achieved: 1, Total: 2},
+				},
+				{
+					Time:         february,
+					StatusCounts: map[rule.Status]int{rule.Passed: 3, rule.Warning: 1, rule.Failed: 2},
+					Score:        &report.Score{Value: 16.67, Achieved: 0.5, Total: 3},
+				},
+				{
+					Time:         march,
+					StatusCounts: map[rule.Status]int{rule.Passed: 3, rule.Failed: 1, rule.Errored: 1},
+					Score:        &report.Score{Value: 33.33, Achieved: 1, Total: 3},
+				},
+			}))
+
+			Expect(trend.Rules).To(HaveLen(3))
+			Expect(trend.Rules[0].Statuses).To(Equal([]rule.Status{rule.Passed, rule.Failed, rule.Failed}))
+			Expect(trend.Rules[0].Changes).To(Equal([]report.StatusChange{
+				{Time: february, From: rule.Passed, To: rule.Failed},
+			}))
+			Expect(trend.Rules[1].Changes).To(Equal([]report.StatusChange{
+				{Time: march, From: rule.Failed, To: rule.Errored},
+			}))
+			Expect(trend.Rules[2].Changes).To(Equal([]report.StatusChange{
+				{Time: february, From: "", To: rule.Warning},
+				{Time: march, From: rule.Warning, To: rule.Passed},
+			}))
+
+			Expect(trend.PersistentFailures).To(Equal([]report.PersistentFailure{
+				{
+					ProviderID:     "provider-foo",
+					RulesetID:      "ruleset-foo",
+					RulesetVersion: "v1",
+					RuleID:         "2",
+					RuleName:       "2",
+					Status:         rule.Errored,
+					FailingSince:   january,
+					Reports:        3,
+				},
+				{
+					ProviderID:     "provider-foo",
+					RulesetID:      "ruleset-foo",
+					RulesetVersion: "v1",
+					RuleID:         "1",
+					RuleName:       "1",
+					Status:         rule.Failed,
+					FailingSince:   february,
+					Reports:        2,
+				},
+			}))
+		})
+
+		It("should allow ruleset versions to change", func() {
+			january, february := newReport(time.January, rule.Failed), newReport(time.February, rule.Passed)
+			february.Providers[0].Rulesets[0].Version = "v2"
+
+			trend, err := report.NewTrend([]*report.Report{january, february})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trend.Points).To(HaveLen(2))
+			Expect(trend.Rules).To(HaveLen(2))
+		})
+
+		DescribeTable("should return error when the reports do not describe the same clusters",
+			func(modify func(*report.Report), expectedErr string) {
+				january, february := newReport(time.January, rule.Passed), newReport(time.February, rule.Passed)
+				january.Providers[0].Metadata = map[string]string{"shootName": "foo"}
+				february.Providers[0].Metadata = map[string]string{"shootName": "foo"}
+				modify(february)
+
+				trend, err := report.NewTrend([]*report.Report{february, january})
+				Expect(trend).To(BeNil())
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("different provider",
+				func(r *report.Report) { r.Providers[0].ID = "provider-bar" },
+				"report from 2000-02-01T00:00:00Z contains providers [provider-bar] instead of [provider-foo]"),
+			Entry("additional provider",
+				func(r *report.Report) {
+					r.Providers = append(r.Providers, report.Provider{ID: "provider-bar"})
+				},
+				"report from 2000-02-01T00:00:00Z contains providers [provider-bar provider-foo] instead of [provider-foo]"),
+			Entry("different cluster identity",
+				func(r *report.Report) { r.Providers[0].Metadata["shootName"] = "bar" },
+				"provider provider-foo of report from 2000-02-01T00:00:00Z has metadata map[shootName:bar] instead of map[shootName:foo]"),
+			Entry("missing cluster identity",
+				func(r *report.Report) { r.Providers[0].Metadata = nil },
+				"provider provider-foo of report from 2000-02-01T00:00:00Z has metadata map[] instead of map[shootName:foo]"),
+			Entry("different ruleset",
+				func(r *report.Report) { r.Providers[0].Rulesets[0].ID = "ruleset-bar" },
+				"provider provider-foo of report from 2000-02-01T00:00:00Z contains rulesets [ruleset-bar] instead of [ruleset-foo]"),
+		)
+
+		It("should limit the number of persistent failures", func() {
+			reports := []*report.Report{
+				newReport(time.January, rule.Failed, rule.Failed),
+			}
+
+			trend, err := report.NewTrend(reports, report.MaxPersistentFailures(1))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(trend.PersistentFailures).To(HaveLen(1))
+			Expect(trend.PersistentFailures[0].RuleID).To(Equal("1"))
+		})
+	})
+})
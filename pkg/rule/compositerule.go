@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rule
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ Rule = &CompositeRule{}
+
+// CompositeRule is a Rule that reports the check results of other rules
+// under its own id and name. It is used by rulesets whose requirements
+// are checked by rules of other rulesets.
+type CompositeRule struct {
+	id    string
+	name  string
+	rules []Rule
+}
+
+// NewCompositeRule returns a new Rule which runs the given rules.
+func NewCompositeRule(id, name string, rules ...Rule) *CompositeRule {
+	return &CompositeRule{
+		id:    id,
+		name:  name,
+		rules: rules,
+	}
+}
+
+// ID returns the id of the Rule.
+func (c *CompositeRule) ID() string {
+	return c.id
+}
+
+// Name returns the name of the Rule.
+func (c *CompositeRule) Name() string {
+	return c.name
+}
+
+// Run runs all composed rules and returns their check results.
+func (c *CompositeRule) Run(ctx context.Context) (RuleResult, error) {
+	checkResults := []CheckResult{}
+	for _, r := range c.rules {
+		result, err := r.Run(ctx)
+		if err != nil {
+			return RuleResult{}, fmt.Errorf("failed to run rule %s: %w", r.ID(), err)
+		}
+		checkResults = append(checkResults, result.CheckResults...)
+	}
+
+	return RuleResult{
+		RuleID:       c.ID(),
+		RuleName:     c.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rule_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+var _ = Describe("CompositeRule", func() {
+	It("should report the check results of all rules with its own id and name", func() {
+		r := rule.NewCompositeRule("foo", "Foo",
+			rule.NewSkipRule("1", "one", "skipped", rule.Skipped),
+			rule.NewSkipRule("2", "two", "accepted", rule.Accepted),
+		)
+
+		Expect(r.ID()).To(Equal("foo"))
+		Expect(r.Name()).To(Equal("Foo"))
+		Expect(r.Run(context.TODO())).To(Equal(rule.RuleResult{
+			RuleID:   "foo",
+			RuleName: "Foo",
+			CheckResults: []rule.CheckResult{
+				{Status: rule.Skipped, Message: "skipped"},
+				{Status: rule.Accepted, Message: "accepted"},
+			},
+		}))
+	})
+})
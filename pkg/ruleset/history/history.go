@@ -0,0 +1,320 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/diki/pkg/config"
+)
+
+// Version declares the rules of a ruleset version. The first version of a ruleset
+// lists all of its rules. Every later version inherits the rules of its base
+// version and only declares the rules which were added, removed or changed.
+type Version struct {
+	// Version is the version of the ruleset.
+	Version string `json:"version"`
+	// Base is the version whose rules are inherited. It is empty for the first version.
+	Base string `json:"base,omitempty"`
+	// Rules are the ids of all rules of a version without a base.
+	Rules []string `json:"rules,omitempty"`
+	// Added are the ids of rules which are new in the version.
+	Added []string `json:"added,omitempty"`
+	// Removed are the ids of base rules which were retired in the version.
+	Removed []string `json:"removed,omitempty"`
+	// Changed are the ids of base rules whose requirements changed in the version.
+	Changed []string `json:"changed,omitempty"`
+}
+
+// History contains all versions of a ruleset.
+type History struct {
+	rulesetID string
+	versions  []Version
+	rules     map[string][]string
+}
+
+// New creates a History of the versions of a ruleset. Base versions have to be declared before the
+// versions which inherit from them. Added rules must not be part of the base version, removed and
+// changed rules must be part of the base version.
+func New(rulesetID string, versions ...Version) (*History, error) {
+	h := &History{
+		rulesetID: rulesetID,
+		rules:     make(map[string][]string, len(versions)),
+	}
+
+	for _, v := range versions {
+		if len(v.Version) == 0 {
+			return nil, errors.New("version must be set")
+		}
+		if _, ok := h.rules[v.Version]; ok {
+			return nil, fmt.Errorf("version %s is declared more than once", v.Version)
+		}
+
+		rules, err := h.resolve(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %s: %w", v.Version, err)
+		}
+		h.rules[v.Version] = rules
+		h.versions = append(h.versions, v)
+	}
+	return h, nil
+}
+
+// MustNew creates a History like [New] and panics if the versions are invalid.
+func MustNew(rulesetID string, versions ...Version) *History {
+	h, err := New(rulesetID, versions...)
+	if err != nil {
+		panic(fmt.Sprintf("invalid history of ruleset %s: %s", rulesetID, err))
+	}
+	return h
+}
+
+func (h *History) resolve(v Version) ([]string, error) {
+	if len(v.Base) == 0 {
+		if len(v.Added) > 0 || len(v.Removed) > 0 || len(v.Changed) > 0 {
+			return nil, errors.New("a version without base must only declare rules")
+		}
+		if err := checkDuplicates(v.Rules); err != nil {
+			return nil, err
+		}
+		return sorted(v.Rules), nil
+	}
+
+	if len(v.Rules) > 0 {
+		return nil, errors.New("a version with base must only declare added, removed and changed rules")
+	}
+	baseRules, ok := h.rules[v.Base]
+	if !ok {
+		return nil, fmt.Errorf("base version %s is not declared", v.Base)
+	}
+	if err := checkDuplicates(concat(v.Added, v.Removed, v.Changed)); err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for _, id := range v.Added {
+		if slices.Contains(baseRules, id) {
+			errs = errors.Join(errs, fmt.Errorf("added rule %s is already part of base version %s", id, v.Base))
+		}
+	}
+	for _, id := range concat(v.Removed, v.Changed) {
+		if !slices.Contains(baseRules, id) {
+			errs = errors.Join(errs, fmt.Errorf("rule %s is not part of base version %s", id, v.Base))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	rules := slices.DeleteFunc(slices.Clone(baseRules), func(id string) bool {
+		return slices.Contains(v.Removed, id)
+	})
+	return sorted(concat(rules, v.Added)), nil
+}
+
+// RulesetID returns the id of the ruleset.
+func (h *History) RulesetID() string {
+	return h.rulesetID
+}
+
+// Versions returns all versions in the order of their declaration.
+func (h *History) Versions() []Version {
+	return slices.Clone(h.versions)
+}
+
+// Version returns the declaration of a version.
+func (h *History) Version(version string) (Version, error) {
+	idx := slices.IndexFunc(h.versions, func(v Version) bool {
+		return v.Version == version
+	})
+	if idx < 0 {
+		return Version{}, fmt.Errorf("ruleset %s does not have version %s", h.rulesetID, version)
+	}
+	return h.versions[idx], nil
+}
+
+// RuleIDs returns the sorted ids of all rules of a version.
+func (h *History) RuleIDs(version string) ([]string, error) {
+	rules, ok := h.rules[version]
+	if !ok {
+		return nil, fmt.Errorf("ruleset %s does not have version %s", h.rulesetID, version)
+	}
+	return slices.Clone(rules), nil
+}
+
+// OtherVersionRuleIDs returns the sorted ids of rules of other versions of the ruleset which are not part
+// of the given version, e.g. the ids of rules retired by the version. Ids which are not part of any version are ignored.
+func (h *History) OtherVersionRuleIDs(version string, ids []string) ([]string, error) {
+	rules, ok := h.rules[version]
+	if !ok {
+		return nil, fmt.Errorf("ruleset %s does not have version %s", h.rulesetID, version)
+	}
+
+	otherVersionRules := []string{}
+	for _, id := range ids {
+		if slices.Contains(rules, id) || slices.Contains(otherVersionRules, id) {
+			continue
+		}
+		for _, versionRules := range h.rules {
+			if slices.Contains(versionRules, id) {
+				otherVersionRules = append(otherVersionRules, id)
+				break
+			}
+		}
+	}
+	return sorted(otherVersionRules), nil
+}
+
+// chain returns the versions from the first version to the given version.
+func (h *History) chain(version string) ([]Version, error) {
+	var chain []Version
+	for len(version) > 0 {
+		v, err := h.Version(version)
+		if err != nil {
+			return nil, err
+		}
+		chain = append([]Version{v}, chain...)
+		version = v.Base
+	}
+	return chain, nil
+}
+
+// Diff contains the rule level differences between two ruleset versions.
+type Diff struct {
+	// RulesetID is the id of the ruleset.
+	RulesetID string `json:"rulesetID"`
+	// From is the version which is compared.
+	From string `json:"from"`
+	// To is the version which From is compared to.
+	To string `json:"to"`
+	// Added are the rules which are only part of To.
+	Added []string `json:"added"`
+	// Removed are the rules which are only part of From.
+	Removed []string `json:"removed"`
+	// Changed are the rules of both versions whose requirements changed between the versions.
+	Changed []string `json:"changed"`
+}
+
+// Diff returns the rule level differences between two versions. Rules are changed when they are
+// declared as changed by a version between the common base of both versions and one of the versions.
+func (h *History) Diff(from, to string) (Diff, error) {
+	fromChain, err := h.chain(from)
+	if err != nil {
+		return Diff{}, err
+	}
+	toChain, err := h.chain(to)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	common := 0
+	for common < len(fromChain) && common < len(toChain) && fromChain[common].Version == toChain[common].Version {
+		common++
+	}
+
+	fromRules, toRules := h.rules[from], h.rules[to]
+	diff := Diff{
+		RulesetID: h.rulesetID,
+		From:      from,
+		To:        to,
+		Added:     []string{},
+		Removed:   []string{},
+		Changed:   []string{},
+	}
+
+	for _, id := range toRules {
+		if !slices.Contains(fromRules, id) {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+	for _, id := range fromRules {
+		if !slices.Contains(toRules, id) {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	for _, v := range append(slices.Clone(fromChain[common:]), toChain[common:]...) {
+		for _, id := range v.Changed {
+			if slices.Contains(fromRules, id) && slices.Contains(toRules, id) && !slices.Contains(diff.Changed, id) {
+				diff.Changed = append(diff.Changed, id)
+			}
+		}
+	}
+	slices.Sort(diff.Changed)
+	return diff, nil
+}
+
+// MigrateRuleOptions rewrites rule options of the From version to rule options of the To version.
+// Options of rules which are not part of the To version are returned as retired.
+func (d Diff) MigrateRuleOptions(ruleOptions []config.RuleOptionsConfig) (migrated, retired []config.RuleOptionsConfig) {
+	migrated = make([]config.RuleOptionsConfig, 0, len(ruleOptions))
+	for _, opt := range ruleOptions {
+		if slices.Contains(d.Removed, opt.RuleID) {
+			retired = append(retired, opt)
+			continue
+		}
+		migrated = append(migrated, opt)
+	}
+	return migrated, retired
+}
+
+// Changelog returns a markdown changelog with the added, removed and changed rules of all versions.
+func (h *History) Changelog() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "# Changelog of ruleset %s\n", h.rulesetID)
+	for i := len(h.versions) - 1; i >= 0; i-- {
+		v := h.versions[i]
+		fmt.Fprintf(sb, "\n## %s\n\n", v.Version)
+		if len(v.Base) == 0 {
+			fmt.Fprintf(sb, "Initial version with %d rules.\n", len(v.Rules))
+			continue
+		}
+
+		fmt.Fprintf(sb, "Based on %s, %d rules.\n", v.Base, len(h.rules[v.Version]))
+		for _, section := range []struct {
+			title string
+			rules []string
+		}{
+			{"Added", v.Added},
+			{"Removed", v.Removed},
+			{"Changed", v.Changed},
+		} {
+			if len(section.rules) > 0 {
+				fmt.Fprintf(sb, "\n### %s\n\n", section.title)
+				for _, id := range sorted(section.rules) {
+					fmt.Fprintf(sb, "- %s\n", id)
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+func checkDuplicates(ids []string) error {
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("rule %s is declared more than once", id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+func sorted(ids []string) []string {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return ids
+}
+
+func concat(lists ...[]string) []string {
+	var result []string
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
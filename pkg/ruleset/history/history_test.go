@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+var _ = Describe("history", func() {
+	var (
+		v1 = history.Version{Version: "v1", Rules: []string{"3", "1", "2"}}
+		v2 = history.Version{Version: "v2", Base: "v1", Added: []string{"4"}, Removed: []string{"1"}, Changed: []string{"2"}}
+		v3 = history.Version{Version: "v3", Base: "v2", Removed: []string{"4"}, Changed: []string{"3"}}
+		b2 = history.Version{Version: "b2", Base: "v1", Added: []string{"5"}}
+	)
+
+	Describe("#New", func() {
+		It("should resolve the rules of all versions", func() {
+			h, err := history.New("foo", v1, v2, v3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(h.RulesetID()).To(Equal("foo"))
+			Expect(h.Versions()).To(Equal([]history.Version{v1, v2, v3}))
+			Expect(h.RuleIDs("v1")).To(Equal([]string{"1", "2", "3"}))
+			Expect(h.RuleIDs("v2")).To(Equal([]string{"2", "3", "4"}))
+			Expect(h.RuleIDs("v3")).To(Equal([]string{"2", "3"}))
+
+			_, err = h.RuleIDs("v4")
+			Expect(err).To(MatchError("ruleset foo does not have version v4"))
+		})
+
+		DescribeTable("invalid versions",
+			func(versions []history.Version, expectedErr string) {
+				_, err := history.New("foo", versions...)
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("version without name",
+				[]history.Version{{Rules: []string{"1"}}},
+				"version must be set"),
+			Entry("duplicate version",
+				[]history.Version{v1, v1},
+				"version v1 is declared more than once"),
+			Entry("unknown base",
+				[]history.Version{v2},
+				"invalid version v2: base version v1 is not declared"),
+			Entry("version without base declaring changes",
+				[]history.Version{{Version: "v1", Added: []string{"1"}}},
+				"invalid version v1: a version without base must only declare rules"),
+			Entry("version with base declaring rules",
+				[]history.Version{v1, {Version: "v2", Base: "v1", Rules: []string{"1"}}},
+				"invalid version v2: a version with base must only declare added, removed and changed rules"),
+			Entry("duplicate rule",
+				[]history.Version{v1, {Version: "v2", Base: "v1", Removed: []string{"1"}, Changed: []string{"1"}}},
+				"invalid version v2: rule 1 is declared more than once"),
+			Entry("added rule of base",
+				[]history.Version{v1, {Version: "v2", Base: "v1", Added: []string{"1"}}},
+				"invalid version v2: added rule 1 is already part of base version v1"),
+			Entry("removed rule not in base",
+				[]history.Version{v1, {Version: "v2", Base: "v1", Removed: []string{"4"}}},
+				"invalid version v2: rule 4 is not part of base version v1"),
+		)
+
+		It("should panic for invalid versions", func() {
+			Expect(func() { history.MustNew("foo", v2) }).To(Panic())
+		})
+	})
+
+	Describe("#Diff", func() {
+		It("should return the differences to a later version", func() {
+			h := history.MustNew("foo", v1, v2, v3)
+
+			Expect(h.Diff("v1", "v3")).To(Equal(history.Diff{
+				RulesetID: "foo",
+				From:      "v1",
+				To:        "v3",
+				Added:     []string{},
+				Removed:   []string{"1"},
+				Changed:   []string{"2", "3"},
+			}))
+		})
+
+		It("should return the differences between versions of different branches", func() {
+			h := history.MustNew("foo", v1, v2, b2)
+
+			Expect(h.Diff("b2", "v2")).To(Equal(history.Diff{
+				RulesetID: "foo",
+				From:      "b2",
+				To:        "v2",
+				Added:     []string{"4"},
+				Removed:   []string{"1", "5"},
+				Changed:   []string{"2"},
+			}))
+		})
+
+		It("should return an error for unknown versions", func() {
+			h := history.MustNew("foo", v1)
+
+			_, err := h.Diff("v1", "v2")
+			Expect(err).To(MatchError("ruleset foo does not have version v2"))
+		})
+	})
+
+	Describe("#OtherVersionRuleIDs", func() {
+		It("should return the rules of other versions", func() {
+			h := history.MustNew("foo", v1, v2, v3)
+
+			Expect(h.OtherVersionRuleIDs("v2", []string{"2", "4", "bar"})).To(BeEmpty())
+			Expect(h.OtherVersionRuleIDs("v3", []string{"4", "2", "1", "4"})).To(Equal([]string{"1", "4"}))
+		})
+
+		It("should return an error for an unknown version", func() {
+			h := history.MustNew("foo", v1, v2, v3)
+
+			_, err := h.OtherVersionRuleIDs("v4", nil)
+			Expect(err).To(MatchError("ruleset foo does not have version v4"))
+		})
+	})
+
+	Describe("#MigrateRuleOptions", func() {
+		It("should keep the options of remaining rules and return the options of retired rules", func() {
+			diff := history.Diff{Removed: []string{"4"}}
+
+			migrated, retired := diff.MigrateRuleOptions([]config.RuleOptionsConfig{
+				{RuleID: "2", Args: map[string]any{"foo": "bar"}},
+				{RuleID: "3"},
+				{RuleID: "4", Skip: &config.RuleOptionSkipConfig{Enabled: true}},
+			})
+
+			Expect(migrated).To(Equal([]config.RuleOptionsConfig{
+				{RuleID: "2", Args: map[string]any{"foo": "bar"}},
+				{RuleID: "3"},
+			}))
+			Expect(retired).To(Equal([]config.RuleOptionsConfig{
+				{RuleID: "4", Skip: &config.RuleOptionSkipConfig{Enabled: true}},
+			}))
+		})
+	})
+
+	Describe("#Changelog", func() {
+		It("should list the changes of all versions", func() {
+			h := history.MustNew("foo", v1, v2, v3)
+
+			Expect(h.Changelog()).To(Equal(`# Changelog of ruleset foo
+
+## v3
+
+Based on v2, 2 rules.
+
+### Removed
+
+- 4
+
+### Changed
+
+- 3
+
+## v2
+
+Based on v1, 3 rules.
+
+### Added
+
+- 4
+
+### Removed
+
+- 1
+
+### Changed
+
+- 2
+
+## v1
+
+Initial version with 3 rules.
+`))
+		})
+	})
+
+})
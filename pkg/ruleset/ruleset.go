@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
 )
 
 // RulesetResult contains the results of Rule runs belonging to the same Ruleset.
@@ -16,6 +17,8 @@ type RulesetResult struct {
 	RulesetName    string
 	RulesetVersion string
 	RuleResults    []rule.RuleResult
+	// Tailoring is the tailoring profile applied to the Ruleset.
+	Tailoring *tailoring.Profile
 }
 
 // Ruleset is a set of Rules.
@@ -26,3 +29,10 @@ type Ruleset interface {
 	Run(ctx context.Context) (RulesetResult, error)
 	RunRule(ctx context.Context, id string) (rule.RuleResult, error)
 }
+
+// Tailored is implemented by Rulesets which can be tailored by a tailoring profile.
+type Tailored interface {
+	// Tailoring returns the tailoring profile applied to the Ruleset
+	// or nil when the Ruleset is not tailored.
+	Tailoring() *tailoring.Profile
+}
@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tailoring
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gardener/diki/pkg/config"
+)
+
+// Profile is an organisation specific tailoring of a ruleset. It is modelled
+// after XCCDF tailoring and sets the values of rule parameters which are
+// left to be defined by the organisation.
+type Profile struct {
+	// ID is the unique identifier of the profile.
+	ID string `json:"id" yaml:"id"`
+	// Version is the version of the profile.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// Title is the user friendly name of the profile.
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+	// Description describes the purpose of the profile.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Benchmark references the tailored ruleset.
+	Benchmark Benchmark `json:"benchmark" yaml:"benchmark"`
+	// SetValues are the rule parameter values set by the profile.
+	SetValues []SetValue `json:"setValues" yaml:"setValues"`
+}
+
+// Benchmark references a ruleset version.
+type Benchmark struct {
+	// ID is the id of the ruleset.
+	ID string `json:"id" yaml:"id"`
+	// Version is the version of the ruleset.
+	Version string `json:"version" yaml:"version"`
+}
+
+// SetValue sets the value of a single rule parameter.
+type SetValue struct {
+	// RuleID is the id of the rule.
+	RuleID string `json:"ruleID" yaml:"ruleID"`
+	// Parameter is the name of the rule parameter.
+	Parameter string `json:"parameter" yaml:"parameter"`
+	// Value is the value of the rule parameter.
+	Value any `json:"value" yaml:"value"`
+}
+
+// ParameterType is the type of a rule parameter.
+type ParameterType string
+
+const (
+	// ParameterTypeString is the type of string parameters.
+	ParameterTypeString ParameterType = "string"
+	// ParameterTypeInteger is the type of integer parameters.
+	ParameterTypeInteger ParameterType = "integer"
+	// ParameterTypeStringList is the type of string list parameters.
+	ParameterTypeStringList ParameterType = "stringList"
+)
+
+// Parameter describes a rule parameter that can be tailored.
+type Parameter struct {
+	// Name is the name of the parameter. It matches the name of the rule option.
+	Name string
+	// Type is the type of the parameter.
+	Type ParameterType
+	// Enum contains the allowed values of string and string list parameters.
+	// All values are allowed when empty.
+	Enum []string
+	// Minimum is the minimal allowed value of integer parameters.
+	Minimum *int64
+}
+
+// Schema maps rule ids to the parameters of the rules that can be tailored.
+type Schema map[string][]Parameter
+
+// Parse parses a tailoring profile in yaml format.
+func Parse(data []byte) (*Profile, error) {
+	profile := &Profile{}
+	if err := yaml.Unmarshal(data, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Load reads and parses a tailoring profile file.
+func Load(path string) (*Profile, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Validate validates that the profile tailors the given ruleset version
+// and that all set values comply with the parameter schema of the rules.
+func (p *Profile) Validate(rulesetID, rulesetVersion string, schema Schema) error {
+	var errs error
+	if len(p.ID) == 0 {
+		errs = errors.Join(errs, errors.New("profile id must be set"))
+	}
+	if p.Benchmark.ID != rulesetID || p.Benchmark.Version != rulesetVersion {
+		errs = errors.Join(errs, fmt.Errorf("profile tailors ruleset %s version %s instead of ruleset %s version %s", p.Benchmark.ID, p.Benchmark.Version, rulesetID, rulesetVersion))
+	}
+
+	type parameterKey struct {
+		ruleID, parameter string
+	}
+	setValues := map[parameterKey]struct{}{}
+	for _, setValue := range p.SetValues {
+		key := parameterKey{setValue.RuleID, setValue.Parameter}
+		if _, ok := setValues[key]; ok {
+			errs = errors.Join(errs, fmt.Errorf("parameter %s of rule %s is set more than once", setValue.Parameter, setValue.RuleID))
+			continue
+		}
+		setValues[key] = struct{}{}
+
+		parameters, ok := schema[setValue.RuleID]
+		if !ok {
+			errs = errors.Join(errs, fmt.Errorf("rule %s does not have parameters that can be tailored", setValue.RuleID))
+			continue
+		}
+		idx := slices.IndexFunc(parameters, func(parameter Parameter) bool {
+			return parameter.Name == setValue.Parameter
+		})
+		if idx < 0 {
+			errs = errors.Join(errs, fmt.Errorf("rule %s does not have parameter %s", setValue.RuleID, setValue.Parameter))
+			continue
+		}
+		if _, err := parameters[idx].value(setValue.Value); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid value for parameter %s of rule %s: %w", setValue.Parameter, setValue.RuleID, err))
+		}
+	}
+	return errs
+}
+
+// ApplyToRuleOptions sets the values of the profile as arguments of the rule options.
+// Values of the profile take precedence over already configured arguments.
+// The profile is expected to be validated against the schema.
+func (p *Profile) ApplyToRuleOptions(ruleOptions map[string]config.RuleOptionsConfig, schema Schema) error {
+	for _, setValue := range p.SetValues {
+		idx := slices.IndexFunc(schema[setValue.RuleID], func(parameter Parameter) bool {
+			return parameter.Name == setValue.Parameter
+		})
+		if idx < 0 {
+			return fmt.Errorf("rule %s does not have parameter %s", setValue.RuleID, setValue.Parameter)
+		}
+		value, err := schema[setValue.RuleID][idx].value(setValue.Value)
+		if err != nil {
+			return err
+		}
+
+		ruleOption := ruleOptions[setValue.RuleID]
+		ruleOption.RuleID = setValue.RuleID
+		args := map[string]any{}
+		switch ruleArgs := ruleOption.Args.(type) {
+		case nil:
+		case map[string]any:
+			args = maps.Clone(ruleArgs)
+		default:
+			return fmt.Errorf("arguments of rule %s cannot be tailored: expected a map, got %T", setValue.RuleID, ruleOption.Args)
+		}
+		args[setValue.Parameter] = value
+		ruleOption.Args = args
+		ruleOptions[setValue.RuleID] = ruleOption
+	}
+	return nil
+}
+
+// Apply loads the tailoring profile referenced by the ruleset config, validates it
+// against the schema and applies it to the rule options. It returns nil when the
+// ruleset config does not reference a tailoring profile.
+func Apply(rulesetConfig config.RulesetConfig, ruleOptions map[string]config.RuleOptionsConfig, schema Schema) (*Profile, error) {
+	if len(rulesetConfig.Tailoring) == 0 {
+		return nil, nil
+	}
+
+	profile, err := Load(rulesetConfig.Tailoring)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tailoring profile: %w", err)
+	}
+	if err := profile.Validate(rulesetConfig.ID, rulesetConfig.Version, schema); err != nil {
+		return nil, fmt.Errorf("invalid tailoring profile %s: %w", profile.ID, err)
+	}
+	if err := profile.ApplyToRuleOptions(ruleOptions, schema); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// value converts a raw value into the type of the parameter
+// and validates it against the constraints of the parameter.
+func (p Parameter) value(raw any) (any, error) {
+	switch p.Type {
+	case ParameterTypeString:
+		value, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", raw)
+		}
+		if len(p.Enum) > 0 && !slices.Contains(p.Enum, value) {
+			return nil, fmt.Errorf("value %s is not one of %v", value, p.Enum)
+		}
+		return value, nil
+	case ParameterTypeInteger:
+		value, ok := toInt64(raw)
+		if !ok {
+			return nil, fmt.Errorf("expected an integer, got %v", raw)
+		}
+		if p.Minimum != nil && value < *p.Minimum {
+			return nil, fmt.Errorf("value %d is less than the minimum %d", value, *p.Minimum)
+		}
+		return value, nil
+	case ParameterTypeStringList:
+		rawValues, ok := raw.([]any)
+		if !ok {
+			if values, ok := raw.([]string); ok {
+				rawValues = make([]any, 0, len(values))
+				for _, value := range values {
+					rawValues = append(rawValues, value)
+				}
+			} else {
+				return nil, fmt.Errorf("expected a list of strings, got %T", raw)
+			}
+		}
+		if len(rawValues) == 0 {
+			return nil, errors.New("expected at least one value")
+		}
+		values := make([]string, 0, len(rawValues))
+		for _, rawValue := range rawValues {
+			value, ok := rawValue.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got element of type %T", rawValue)
+			}
+			if len(p.Enum) > 0 && !slices.Contains(p.Enum, value) {
+				return nil, fmt.Errorf("value %s is not one of %v", value, p.Enum)
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unknown parameter type %s", p.Type)
+	}
+}
+
+func toInt64(raw any) (int64, bool) {
+	switch value := raw.(type) {
+	case int:
+		return int64(value), true
+	case int64:
+		return value, true
+	case uint64:
+		if value > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(value), true
+	case float64:
+		if value != math.Trunc(value) || value > math.MaxInt64 || value < math.MinInt64 {
+			return 0, false
+		}
+		return int64(value), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tailoring_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
+)
+
+var _ = Describe("tailoring", func() {
+	var (
+		minimum = int64(10)
+		schema  = tailoring.Schema{
+			"1": {
+				{Name: "level", Type: tailoring.ParameterTypeString, Enum: []string{"low", "high"}},
+				{Name: "size", Type: tailoring.ParameterTypeInteger, Minimum: &minimum},
+			},
+			"2": {
+				{Name: "ciphers", Type: tailoring.ParameterTypeStringList},
+			},
+		}
+		data = []byte(`id: acme
+version: "1.0"
+title: ACME
+benchmark:
+  id: foo
+  version: v1
+setValues:
+- ruleID: "1"
+  parameter: level
+  value: high
+- ruleID: "1"
+  parameter: size
+  value: 20
+- ruleID: "2"
+  parameter: ciphers
+  value:
+  - a
+  - b
+`)
+	)
+
+	Describe("#Parse", func() {
+		It("should parse a tailoring profile", func() {
+			profile, err := tailoring.Parse(data)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(profile).To(Equal(&tailoring.Profile{
+				ID:        "acme",
+				Version:   "1.0",
+				Title:     "ACME",
+				Benchmark: tailoring.Benchmark{ID: "foo", Version: "v1"},
+				SetValues: []tailoring.SetValue{
+					{RuleID: "1", Parameter: "level", Value: "high"},
+					{RuleID: "1", Parameter: "size", Value: 20},
+					{RuleID: "2", Parameter: "ciphers", Value: []any{"a", "b"}},
+				},
+			}))
+		})
+	})
+
+	Describe("#Validate", func() {
+		It("should accept a valid profile", func() {
+			profile, err := tailoring.Parse(data)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(profile.Validate("foo", "v1", schema)).To(Succeed())
+		})
+
+		It("should reject a profile for another ruleset version", func() {
+			profile, err := tailoring.Parse(data)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(profile.Validate("foo", "v2", schema)).To(MatchError("profile tailors ruleset foo version v1 instead of ruleset foo version v2"))
+		})
+
+		DescribeTable("invalid set values",
+			func(setValue tailoring.SetValue, expectedErr string) {
+				profile := &tailoring.Profile{
+					ID:        "acme",
+					Benchmark: tailoring.Benchmark{ID: "foo", Version: "v1"},
+					SetValues: []tailoring.SetValue{setValue},
+				}
+
+				Expect(profile.Validate("foo", "v1", schema)).To(MatchError(expectedErr))
+			},
+			Entry("unknown rule",
+				tailoring.SetValue{RuleID: "3", Parameter: "level", Value: "high"},
+				"rule 3 does not have parameters that can be tailored"),
+			Entry("unknown parameter",
+				tailoring.SetValue{RuleID: "1", Parameter: "foo", Value: "high"},
+				"rule 1 does not have parameter foo"),
+			Entry("string not in enum",
+				tailoring.SetValue{RuleID: "1", Parameter: "level", Value: "medium"},
+				"invalid value for parameter level of rule 1: value medium is not one of [low high]"),
+			Entry("integer of wrong type",
+				tailoring.SetValue{RuleID: "1", Parameter: "size", Value: "20"},
+				"invalid value for parameter size of rule 1: expected an integer, got 20"),
+			Entry("integer lower than the minimum",
+				tailoring.SetValue{RuleID: "1", Parameter: "size", Value: 5},
+				"invalid value for parameter size of rule 1: value 5 is less than the minimum 10"),
+			Entry("list with non string elements",
+				tailoring.SetValue{RuleID: "2", Parameter: "ciphers", Value: []any{"a", 1}},
+				"invalid value for parameter ciphers of rule 2: expected a list of strings, got element of type int"),
+			Entry("empty list",
+				tailoring.SetValue{RuleID: "2", Parameter: "ciphers", Value: []any{}},
+				"invalid value for parameter ciphers of rule 2: expected at least one value"),
+		)
+
+		It("should reject parameters which are set more than once", func() {
+			profile := &tailoring.Profile{
+				ID:        "acme",
+				Benchmark: tailoring.Benchmark{ID: "foo", Version: "v1"},
+				SetValues: []tailoring.SetValue{
+					{RuleID: "1", Parameter: "level", Value: "high"},
+					{RuleID: "1", Parameter: "level", Value: "low"},
+				},
+			}
+
+			Expect(profile.Validate("foo", "v1", schema)).To(MatchError("parameter level of rule 1 is set more than once"))
+		})
+	})
+
+	Describe("#ApplyToRuleOptions", func() {
+		It("should set the values as rule arguments", func() {
+			profile, err := tailoring.Parse(data)
+			Expect(err).ToNot(HaveOccurred())
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"1": {
+					RuleID: "1",
+					Skip:   &config.RuleOptionSkipConfig{Enabled: false},
+					Args:   map[string]any{"level": "low", "foo": "bar"},
+				},
+			}
+
+			Expect(profile.ApplyToRuleOptions(ruleOptions, schema)).To(Succeed())
+
+			Expect(ruleOptions).To(Equal(map[string]config.RuleOptionsConfig{
+				"1": {
+					RuleID: "1",
+					Skip:   &config.RuleOptionSkipConfig{Enabled: false},
+					Args:   map[string]any{"level": "high", "size": int64(20), "foo": "bar"},
+				},
+				"2": {
+					RuleID: "2",
+					Args:   map[string]any{"ciphers": []string{"a", "b"}},
+				},
+			}))
+		})
+
+		It("should return an error when the rule arguments are not a map", func() {
+			profile, err := tailoring.Parse(data)
+			Expect(err).ToNot(HaveOccurred())
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"2": {RuleID: "2", Args: []any{"foo"}},
+			}
+
+			Expect(profile.ApplyToRuleOptions(ruleOptions, schema)).To(MatchError("arguments of rule 2 cannot be tailored: expected a map, got []interface {}"))
+		})
+	})
+
+	Describe("#Apply", func() {
+		It("should return nil when the ruleset is not tailored", func() {
+			profile, err := tailoring.Apply(config.RulesetConfig{ID: "foo", Version: "v1"}, map[string]config.RuleOptionsConfig{}, schema)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile).To(BeNil())
+		})
+
+		It("should load, validate and apply the referenced profile", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "tailoring.yaml")
+			Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+			ruleOptions := map[string]config.RuleOptionsConfig{}
+
+			profile, err := tailoring.Apply(config.RulesetConfig{ID: "foo", Version: "v1", Tailoring: path}, ruleOptions, schema)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile.ID).To(Equal("acme"))
+			Expect(ruleOptions).To(HaveKey("1"))
+			Expect(ruleOptions).To(HaveKey("2"))
+		})
+
+		It("should return an error when the profile is invalid", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "tailoring.yaml")
+			Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+
+			_, err := tailoring.Apply(config.RulesetConfig{ID: "foo", Version: "v1", Tailoring: path}, map[string]config.RuleOptionsConfig{}, tailoring.Schema{})
+			Expect(err).To(MatchError(ContainSubstring("invalid tailoring profile acme: rule 1 does not have parameters that can be tailored")))
+		})
+	})
+})
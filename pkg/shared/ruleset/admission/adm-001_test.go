@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+)
+
+var _ = Describe("#adm-001", func() {
+	var (
+		ctx    = context.TODO()
+		ignore = admissionregistrationv1.Ignore
+		fail   = admissionregistrationv1.Fail
+
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		fakeClient = newClient(
+			mutatingWebhookConfiguration("policy",
+				admissionregistrationv1.MutatingWebhook{Name: "pods.policy.example.com", FailurePolicy: &ignore, Rules: rulesFor("", "pods")},
+				admissionregistrationv1.MutatingWebhook{Name: "configmaps.policy.example.com", FailurePolicy: &ignore, Rules: rulesFor("", "configmaps")},
+			),
+			validatingWebhookConfiguration("validator",
+				admissionregistrationv1.ValidatingWebhook{Name: "apps.validator.example.com", FailurePolicy: &fail, Rules: rulesFor("apps", "*")},
+				admissionregistrationv1.ValidatingWebhook{Name: "rbac.validator.example.com", Rules: rulesFor("rbac.authorization.k8s.io", "roles", "rolebindings")},
+			),
+		)
+	})
+
+	It("should pass when the cluster does not have webhooks", func() {
+		r := &admission.FailurePolicyRule{Client: newClient(), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("adm-001"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("The cluster does not have any webhooks.", clusterTarget),
+		}))
+	})
+
+	It("should fail when webhooks ignore failures for security-relevant resources", func() {
+		r := &admission.FailurePolicyRule{Client: fakeClient, Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Webhook ignores failures for security-relevant resources.", mutatingTarget("policy", "pods.policy.example.com").With("details", "resources: pods")),
+			rule.PassedCheckResult("Webhook does not intercept security-relevant resources.", mutatingTarget("policy", "configmaps.policy.example.com")),
+			rule.PassedCheckResult("Webhook has failure policy Fail.", validatingTarget("validator", "apps.validator.example.com")),
+			rule.PassedCheckResult("Webhook has failure policy Fail.", validatingTarget("validator", "rbac.validator.example.com")),
+		}))
+	})
+
+	It("should check the security-relevant resources of the rule options", func() {
+		r := &admission.FailurePolicyRule{Client: fakeClient, Target: clusterTarget, Options: &admission.FailurePolicyOptions{SecurityResources: []string{"configmaps"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Webhook does not intercept security-relevant resources.", mutatingTarget("policy", "pods.policy.example.com")),
+			rule.FailedCheckResult("Webhook ignores failures for security-relevant resources.", mutatingTarget("policy", "configmaps.policy.example.com").With("details", "resources: configmaps")),
+			rule.PassedCheckResult("Webhook does not intercept security-relevant resources.", validatingTarget("validator", "apps.validator.example.com")),
+			rule.PassedCheckResult("Webhook does not intercept security-relevant resources.", validatingTarget("validator", "rbac.validator.example.com")),
+		}))
+	})
+
+	It("should accept webhooks which are selected by the rule options", func() {
+		r := &admission.FailurePolicyRule{Client: fakeClient, Target: clusterTarget, Options: &admission.FailurePolicyOptions{
+			AcceptedWebhooks: []admission.AcceptedWebhook{{Name: "pol*", Justification: "The policy engine is not critical."}},
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.AcceptedCheckResult("The policy engine is not critical.", mutatingTarget("policy", "pods.policy.example.com").With("details", "resources: pods")),
+		))
+
+		r.Options.AcceptedWebhooks[0].Justification = ""
+		ruleResult, err = r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.AcceptedCheckResult("Webhook ignores failures for security-relevant resources and is accepted.", mutatingTarget("policy", "pods.policy.example.com").With("details", "resources: pods")),
+		))
+	})
+
+	It("should check validating admission policies", func() {
+		policyIgnore := admissionregistrationv1beta1.Ignore
+		r := &admission.FailurePolicyRule{Client: newClient(
+			&admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-privileged"},
+				Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+					FailurePolicy: &policyIgnore,
+					MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+						ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{{
+							RuleWithOperations: admissionregistrationv1beta1.RuleWithOperations{
+								Rule: admissionregistrationv1beta1.Rule{APIGroups: []string{"", "batch"}, Resources: []string{"pods", "jobs"}},
+							},
+						}},
+					},
+				},
+			},
+			&admissionregistrationv1beta1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "no-constraints"}},
+		), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Webhook ignores failures for security-relevant resources.", clusterTarget.With("kind", "validatingAdmissionPolicy", "name", "deny-privileged", "details", "resources: pods, jobs.batch")),
+			rule.PassedCheckResult("Webhook does not intercept security-relevant resources.", clusterTarget.With("kind", "validatingAdmissionPolicy", "name", "no-constraints")),
+		}))
+	})
+})
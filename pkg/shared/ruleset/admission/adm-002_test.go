@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+)
+
+var _ = Describe("#adm-002", func() {
+	var (
+		ctx = context.TODO()
+
+		fakeClient client.Client
+	)
+
+	excludeNamespaces := func(namespaces ...string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "kubernetes.io/metadata.name",
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   namespaces,
+		}}}
+	}
+
+	BeforeEach(func() {
+		fakeClient = newClient(
+			validatingWebhookConfiguration("validator",
+				admissionregistrationv1.ValidatingWebhook{Name: "all.validator.example.com"},
+				admissionregistrationv1.ValidatingWebhook{Name: "system.validator.example.com", NamespaceSelector: excludeNamespaces("kube-system")},
+				admissionregistrationv1.ValidatingWebhook{Name: "policy.validator.example.com", NamespaceSelector: excludeNamespaces("kube-system", "policy")},
+			),
+		)
+	})
+
+	It("should fail when webhooks exempt namespaces which are not allowed to be exempted", func() {
+		r := &admission.NamespaceSelectorRule{Client: fakeClient, Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("adm-002"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Webhook does not exempt namespaces which are not allowed to be exempted.", validatingTarget("validator", "all.validator.example.com")),
+			rule.PassedCheckResult("Webhook does not exempt namespaces which are not allowed to be exempted.", validatingTarget("validator", "system.validator.example.com")),
+			rule.FailedCheckResult("Webhook exempts namespaces which are not allowed to be exempted.", validatingTarget("validator", "policy.validator.example.com").With("details", "namespaces: policy")),
+		}))
+	})
+
+	It("should allow the exempt namespaces of the rule options", func() {
+		r := &admission.NamespaceSelectorRule{Client: fakeClient, Target: clusterTarget, Options: &admission.NamespaceSelectorOptions{ExemptNamespaces: []string{"policy"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Webhook does not exempt namespaces which are not allowed to be exempted.", validatingTarget("validator", "all.validator.example.com")),
+			rule.FailedCheckResult("Webhook exempts namespaces which are not allowed to be exempted.", validatingTarget("validator", "system.validator.example.com").With("details", "namespaces: kube-system")),
+			rule.FailedCheckResult("Webhook exempts namespaces which are not allowed to be exempted.", validatingTarget("validator", "policy.validator.example.com").With("details", "namespaces: kube-system")),
+		}))
+	})
+
+	It("should list at most 10 exempted namespaces", func() {
+		var objs []client.Object
+		for i := 0; i < 11; i++ {
+			objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("ns-%02d", i), Labels: map[string]string{"exempt": "true"}}})
+		}
+		objs = append(objs, validatingWebhookConfiguration("validator", admissionregistrationv1.ValidatingWebhook{
+			Name:              "validator.example.com",
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "exempt", Operator: metav1.LabelSelectorOpDoesNotExist}}},
+		}))
+		r := &admission.NamespaceSelectorRule{Client: newClient(objs...), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Webhook exempts namespaces which are not allowed to be exempted.",
+				validatingTarget("validator", "validator.example.com").With("details", "namespaces: ns-00, ns-01, ns-02, ns-03, ns-04, ns-05, ns-06, ns-07, ns-08, ns-09, and 1 more")),
+		}))
+	})
+})
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+)
+
+var _ = Describe("#adm-003", func() {
+	var (
+		ctx = context.TODO()
+
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		fakeClient = newClient(
+			mutatingWebhookConfiguration("defaulter",
+				admissionregistrationv1.MutatingWebhook{Name: "defaulter.example.com"},
+			),
+			validatingWebhookConfiguration("validator",
+				admissionregistrationv1.ValidatingWebhook{Name: "fast.validator.example.com", TimeoutSeconds: pointer.Int32(5)},
+				admissionregistrationv1.ValidatingWebhook{Name: "slow.validator.example.com", TimeoutSeconds: pointer.Int32(30)},
+			),
+			&admissionregistrationv1beta1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "deny-privileged"}},
+		)
+	})
+
+	It("should fail when webhooks do not set a short timeout", func() {
+		r := &admission.TimeoutRule{Client: fakeClient, Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("adm-003"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Webhook does not set a timeout.", mutatingTarget("defaulter", "defaulter.example.com")),
+			rule.PassedCheckResult("Webhook has a timeout of 5s.", validatingTarget("validator", "fast.validator.example.com")),
+			rule.FailedCheckResult("Webhook timeout of 30s exceeds 10s.", validatingTarget("validator", "slow.validator.example.com")),
+		}))
+	})
+
+	It("should respect the maximum timeout of the rule options", func() {
+		r := &admission.TimeoutRule{Client: fakeClient, Target: clusterTarget, Options: &admission.TimeoutOptions{MaxTimeoutSeconds: 30}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Webhook does not set a timeout.", mutatingTarget("defaulter", "defaulter.example.com")),
+			rule.PassedCheckResult("Webhook has a timeout of 5s.", validatingTarget("validator", "fast.validator.example.com")),
+			rule.PassedCheckResult("Webhook has a timeout of 30s.", validatingTarget("validator", "slow.validator.example.com")),
+		}))
+	})
+
+	It("should not check validating admission policies", func() {
+		r := &admission.TimeoutRule{Client: newClient(&admissionregistrationv1beta1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "deny-privileged"}}), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("The cluster does not have any webhooks.", clusterTarget),
+		}))
+	})
+
+	It("should error when the maximum timeout of the rule options is invalid", func() {
+		r := &admission.TimeoutRule{Client: fakeClient, Target: clusterTarget, Options: &admission.TimeoutOptions{MaxTimeoutSeconds: 60}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("maxTimeoutSeconds must be between 1 and 30", clusterTarget),
+		}))
+
+		_, err = admission.Rules(fakeClient, clusterTarget, map[string]config.RuleOptionsConfig{"adm-003": {Args: map[string]any{"maxTimeoutSeconds": 60}}})
+		Expect(err).To(MatchError("invalid options of rule adm-003: maxTimeoutSeconds must be between 1 and 30"))
+	})
+})
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+)
+
+var _ = Describe("#adm-004", func() {
+	var ctx = context.TODO()
+
+	It("should fail when webhooks intercept pods in the namespace of their service", func() {
+		r := &admission.OwnNamespaceRule{Client: newClient(
+			mutatingWebhookConfiguration("policy",
+				admissionregistrationv1.MutatingWebhook{Name: "pods.policy.example.com", Rules: rulesFor("", "pods"), ClientConfig: service("policy")},
+				admissionregistrationv1.MutatingWebhook{Name: "secrets.policy.example.com", Rules: rulesFor("", "secrets"), ClientConfig: service("policy")},
+				admissionregistrationv1.MutatingWebhook{Name: "external.policy.example.com", Rules: rulesFor("", "pods"), ClientConfig: admissionregistrationv1.WebhookClientConfig{URL: pointer.String("https://policy.example.com")}},
+			),
+			validatingWebhookConfiguration("validator",
+				admissionregistrationv1.ValidatingWebhook{
+					Name:         "validator.example.com",
+					Rules:        rulesFor("", "pods"),
+					ClientConfig: service("validator"),
+					NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "kubernetes.io/metadata.name",
+						Operator: metav1.LabelSelectorOpNotIn,
+						Values:   []string{"policy"},
+					}}},
+				},
+			),
+		), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("adm-004"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Webhook intercepts pods in its own namespace.", mutatingTarget("policy", "pods.policy.example.com").With("details", "namespace: policy")),
+			rule.PassedCheckResult("Webhook does not intercept pods in its own namespace.", mutatingTarget("policy", "secrets.policy.example.com")),
+			rule.PassedCheckResult("Webhook is not served by a service of the cluster.", mutatingTarget("policy", "external.policy.example.com")),
+			rule.PassedCheckResult("Webhook does not intercept pods in its own namespace.", validatingTarget("validator", "validator.example.com")),
+		}))
+	})
+
+	It("should accept webhooks which are selected by the rule options", func() {
+		r := &admission.OwnNamespaceRule{Client: newClient(
+			mutatingWebhookConfiguration("policy",
+				admissionregistrationv1.MutatingWebhook{Name: "policy.example.com", Rules: rulesFor("", "pods"), ClientConfig: service("policy")},
+			),
+		), Target: clusterTarget, Options: &admission.Options{AcceptedWebhooks: []admission.AcceptedWebhook{{Name: "policy"}}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("Webhook intercepts pods in its own namespace and is accepted.", mutatingTarget("policy", "policy.example.com").With("details", "namespace: policy")),
+		}))
+	})
+})
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/admission"
+)
+
+var _ = Describe("#adm-005", func() {
+	var ctx = context.TODO()
+
+	It("should fail when webhooks are called through a URL", func() {
+		r := &admission.EndpointRule{Client: newClient(
+			mutatingWebhookConfiguration("policy",
+				admissionregistrationv1.MutatingWebhook{Name: "policy.example.com", ClientConfig: service("policy")},
+			),
+			validatingWebhookConfiguration("validator",
+				admissionregistrationv1.ValidatingWebhook{Name: "validator.example.com", ClientConfig: admissionregistrationv1.WebhookClientConfig{URL: pointer.String("https://validator.example.com/validate")}},
+			),
+		), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("adm-005"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Webhook uses a service reference.", mutatingTarget("policy", "policy.example.com")),
+			rule.FailedCheckResult("Webhook uses a URL instead of a service reference.", validatingTarget("validator", "validator.example.com").With("details", "url: https://validator.example.com/validate")),
+		}))
+	})
+})
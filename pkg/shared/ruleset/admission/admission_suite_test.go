@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+func TestAdmission(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Admission Control Ruleset Test Suite")
+}
+
+var clusterTarget = rule.NewTarget("cluster", "shoot")
+
+// newClient creates a fake client with the kube-system, default and policy namespaces and the given objects.
+func newClient(objs ...client.Object) client.Client {
+	fakeClient := fakeclient.NewClientBuilder().Build()
+	for _, name := range []string{"kube-system", "default", "policy"} {
+		Expect(fakeClient.Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/metadata.name": name}}})).To(Succeed())
+	}
+	for _, obj := range objs {
+		Expect(fakeClient.Create(context.TODO(), obj)).To(Succeed())
+	}
+	return fakeClient
+}
+
+// rulesFor returns webhook rules which intercept the creation of the resources of the api group.
+func rulesFor(apiGroup string, resources ...string) []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{apiGroup},
+			APIVersions: []string{"*"},
+			Resources:   resources,
+		},
+	}}
+}
+
+// service returns a client configuration which calls the service with the name in the policy namespace.
+func service(name string) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{Service: &admissionregistrationv1.ServiceReference{Name: name, Namespace: "policy"}}
+}
+
+func validatingWebhookConfiguration(name string, webhooks ...admissionregistrationv1.ValidatingWebhook) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}, Webhooks: webhooks}
+}
+
+func mutatingWebhookConfiguration(name string, webhooks ...admissionregistrationv1.MutatingWebhook) *admissionregistrationv1.MutatingWebhookConfiguration {
+	return &admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}, Webhooks: webhooks}
+}
+
+func validatingTarget(configuration, webhook string) rule.Target {
+	return clusterTarget.With("kind", "validatingWebhookConfiguration", "name", configuration, "webhook", webhook)
+}
+
+func mutatingTarget(configuration, webhook string) rule.Target {
+	return clusterTarget.With("kind", "mutatingWebhookConfiguration", "name", configuration, "webhook", webhook)
+}
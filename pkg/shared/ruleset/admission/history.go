@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// History contains the rules of all Admission Control versions.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1",
+		Rules:   []string{"adm-001", "adm-002", "adm-003", "adm-004", "adm-005"},
+	},
+)
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// hook is a webhook of a webhook configuration or a validating admission policy.
+type hook struct {
+	kind string
+	// configuration is the name of the webhook configuration or of the validating admission policy.
+	configuration string
+	// name is the name of the webhook. It is empty for validating admission policies.
+	name              string
+	failurePolicy     admissionregistrationv1.FailurePolicyType
+	rules             []admissionregistrationv1.Rule
+	namespaceSelector *metav1.LabelSelector
+	timeoutSeconds    *int32
+	clientConfig      *admissionregistrationv1.WebhookClientConfig
+}
+
+// isWebhook returns whether the hook is a webhook and not a validating admission policy.
+func (h hook) isWebhook() bool {
+	return h.clientConfig != nil
+}
+
+// target returns the target of the hook.
+func (h hook) target(clusterTarget rule.Target) rule.Target {
+	target := clusterTarget.With("kind", h.kind, "name", h.configuration)
+	if h.name != "" {
+		target = target.With("webhook", h.name)
+	}
+	return target
+}
+
+// interceptedResources returns the resources of the list which are intercepted by the hook.
+func (h hook) interceptedResources(resources []string) []string {
+	var intercepted []string
+	for _, resource := range resources {
+		name, group, _ := strings.Cut(resource, ".")
+		if slices.ContainsFunc(h.rules, func(r admissionregistrationv1.Rule) bool {
+			return matchesAny(r.APIGroups, group) && (matchesAny(r.Resources, name) || slices.Contains(r.Resources, "*/*"))
+		}) {
+			intercepted = append(intercepted, resource)
+		}
+	}
+	return intercepted
+}
+
+func matchesAny(values []string, value string) bool {
+	return slices.Contains(values, "*") || slices.Contains(values, value)
+}
+
+// objects are the admission related objects of a cluster.
+type objects struct {
+	hooks      []hook
+	namespaces map[string]corev1.Namespace
+}
+
+// listObjects lists the admission related objects of the cluster. On error the
+// kind of the objects which could not be listed is returned as well.
+func listObjects(ctx context.Context, c client.Client) (objects, string, error) {
+	var (
+		result objects
+		err    error
+	)
+
+	if result.namespaces, err = kubeutils.GetNamespaces(ctx, c); err != nil {
+		return objects{}, "namespaceList", err
+	}
+
+	validatingWebhookConfigurations := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := c.List(ctx, validatingWebhookConfigurations); err != nil {
+		return objects{}, "validatingWebhookConfigurationList", err
+	}
+	for _, configuration := range validatingWebhookConfigurations.Items {
+		for _, webhook := range configuration.Webhooks {
+			result.hooks = append(result.hooks, newWebhook("validatingWebhookConfiguration", configuration.Name, webhook.Name, webhook.FailurePolicy,
+				webhook.Rules, webhook.NamespaceSelector, webhook.TimeoutSeconds, webhook.ClientConfig))
+		}
+	}
+
+	mutatingWebhookConfigurations := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := c.List(ctx, mutatingWebhookConfigurations); err != nil {
+		return objects{}, "mutatingWebhookConfigurationList", err
+	}
+	for _, configuration := range mutatingWebhookConfigurations.Items {
+		for _, webhook := range configuration.Webhooks {
+			result.hooks = append(result.hooks, newWebhook("mutatingWebhookConfiguration", configuration.Name, webhook.Name, webhook.FailurePolicy,
+				webhook.Rules, webhook.NamespaceSelector, webhook.TimeoutSeconds, webhook.ClientConfig))
+		}
+	}
+
+	// validating admission policies are not served by clusters which do not enable the feature
+	policies := &admissionregistrationv1beta1.ValidatingAdmissionPolicyList{}
+	if err := c.List(ctx, policies); err != nil && !meta.IsNoMatchError(err) {
+		return objects{}, "validatingAdmissionPolicyList", err
+	}
+	for _, policy := range policies.Items {
+		h := hook{
+			kind:          "validatingAdmissionPolicy",
+			configuration: policy.Name,
+			failurePolicy: admissionregistrationv1.Fail,
+		}
+		if policy.Spec.FailurePolicy != nil {
+			h.failurePolicy = admissionregistrationv1.FailurePolicyType(*policy.Spec.FailurePolicy)
+		}
+		if policy.Spec.MatchConstraints != nil {
+			h.namespaceSelector = policy.Spec.MatchConstraints.NamespaceSelector
+			for _, resourceRule := range policy.Spec.MatchConstraints.ResourceRules {
+				h.rules = append(h.rules, admissionregistrationv1.Rule{
+					APIGroups: resourceRule.APIGroups,
+					Resources: resourceRule.Resources,
+				})
+			}
+		}
+		result.hooks = append(result.hooks, h)
+	}
+
+	sort.SliceStable(result.hooks, func(i, j int) bool {
+		if result.hooks[i].kind != result.hooks[j].kind {
+			return result.hooks[i].kind < result.hooks[j].kind
+		}
+		return result.hooks[i].configuration < result.hooks[j].configuration
+	})
+	return result, "", nil
+}
+
+func newWebhook(
+	kind, configuration, name string,
+	failurePolicy *admissionregistrationv1.FailurePolicyType,
+	rules []admissionregistrationv1.RuleWithOperations,
+	namespaceSelector *metav1.LabelSelector,
+	timeoutSeconds *int32,
+	clientConfig admissionregistrationv1.WebhookClientConfig,
+) hook {
+	h := hook{
+		kind:              kind,
+		configuration:     configuration,
+		name:              name,
+		failurePolicy:     admissionregistrationv1.Fail,
+		namespaceSelector: namespaceSelector,
+		timeoutSeconds:    timeoutSeconds,
+		clientConfig:      &clientConfig,
+	}
+	if failurePolicy != nil {
+		h.failurePolicy = *failurePolicy
+	}
+	for _, r := range rules {
+		h.rules = append(h.rules, r.Rule)
+	}
+	return h
+}
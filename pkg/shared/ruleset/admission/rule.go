@@ -0,0 +1,431 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// defaultSecurityResources are the security-relevant resources in the format resource.group.
+var defaultSecurityResources = []string{
+	"pods",
+	"secrets",
+	"serviceaccounts",
+	"namespaces",
+	"roles.rbac.authorization.k8s.io",
+	"rolebindings.rbac.authorization.k8s.io",
+	"clusterroles.rbac.authorization.k8s.io",
+	"clusterrolebindings.rbac.authorization.k8s.io",
+	"deployments.apps",
+	"daemonsets.apps",
+	"statefulsets.apps",
+	"replicasets.apps",
+	"jobs.batch",
+	"cronjobs.batch",
+}
+
+// defaultExemptNamespaces are the namespaces which can be exempted from webhooks.
+var defaultExemptNamespaces = []string{"kube-system", "kube-public", "kube-node-lease"}
+
+// Options are the rule options of the webhook rules.
+type Options struct {
+	AcceptedWebhooks []AcceptedWebhook `json:"acceptedWebhooks" yaml:"acceptedWebhooks"`
+}
+
+// AcceptedWebhook selects webhooks which are accepted to violate a rule.
+type AcceptedWebhook struct {
+	// Name is a shell file name pattern matched against the name of the webhook
+	// configuration or of the validating admission policy.
+	Name          string `json:"name" yaml:"name"`
+	Justification string `json:"justification" yaml:"justification"`
+}
+
+func accepted(acceptedWebhooks []AcceptedWebhook, h hook) (bool, string) {
+	for _, acceptedWebhook := range acceptedWebhooks {
+		if ok, _ := path.Match(acceptedWebhook.Name, h.configuration); ok {
+			return true, acceptedWebhook.Justification
+		}
+	}
+	return false, ""
+}
+
+// FailurePolicyOptions are the rule options of the rule which checks the failure policy of webhooks.
+type FailurePolicyOptions struct {
+	// SecurityResources are the security-relevant resources in the format resource.group, e.g. pods or deployments.apps.
+	SecurityResources []string          `json:"securityResources" yaml:"securityResources"`
+	AcceptedWebhooks  []AcceptedWebhook `json:"acceptedWebhooks" yaml:"acceptedWebhooks"`
+}
+
+// NamespaceSelectorOptions are the rule options of the rule which checks the namespaces exempted from webhooks.
+type NamespaceSelectorOptions struct {
+	// ExemptNamespaces are the namespaces which can be exempted from webhooks. Defaults to kube-system, kube-public and kube-node-lease.
+	ExemptNamespaces []string          `json:"exemptNamespaces" yaml:"exemptNamespaces"`
+	AcceptedWebhooks []AcceptedWebhook `json:"acceptedWebhooks" yaml:"acceptedWebhooks"`
+}
+
+// TimeoutOptions are the rule options of the rule which checks the timeouts of webhooks.
+type TimeoutOptions struct {
+	// MaxTimeoutSeconds is the maximum timeout of webhooks. Defaults to 10.
+	MaxTimeoutSeconds int32             `json:"maxTimeoutSeconds" yaml:"maxTimeoutSeconds"`
+	AcceptedWebhooks  []AcceptedWebhook `json:"acceptedWebhooks" yaml:"acceptedWebhooks"`
+}
+
+func (o *FailurePolicyOptions) securityResources() []string {
+	if o == nil || len(o.SecurityResources) == 0 {
+		return defaultSecurityResources
+	}
+	return o.SecurityResources
+}
+
+func (o *FailurePolicyOptions) acceptedWebhooks() []AcceptedWebhook {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedWebhooks
+}
+
+func (o *NamespaceSelectorOptions) exemptNamespaces() []string {
+	if o == nil || len(o.ExemptNamespaces) == 0 {
+		return defaultExemptNamespaces
+	}
+	return o.ExemptNamespaces
+}
+
+func (o *NamespaceSelectorOptions) acceptedWebhooks() []AcceptedWebhook {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedWebhooks
+}
+
+// maxTimeoutSeconds returns the configured maximum timeout or 10.
+func (o *TimeoutOptions) maxTimeoutSeconds() (int32, error) {
+	switch {
+	case o == nil || o.MaxTimeoutSeconds == 0:
+		return 10, nil
+	case o.MaxTimeoutSeconds < 0 || o.MaxTimeoutSeconds > 30:
+		return 0, errors.New("maxTimeoutSeconds must be between 1 and 30")
+	default:
+		return o.MaxTimeoutSeconds, nil
+	}
+}
+
+func (o *TimeoutOptions) acceptedWebhooks() []AcceptedWebhook {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedWebhooks
+}
+
+func (o *Options) acceptedWebhooks() []AcceptedWebhook {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedWebhooks
+}
+
+// finding is the result of the check of a webhook.
+type finding struct {
+	status  rule.Status
+	message string
+	details string
+}
+
+// checkHooks checks the webhooks of all webhook configurations and the validating admission policies
+// of the cluster. check returns the finding of a webhook or nil if the rule does not apply to it.
+func checkHooks(ctx context.Context, r rule.Rule, c client.Client, clusterTarget rule.Target, check func(hook, objects) *finding, acceptedWebhooks []AcceptedWebhook) rule.RuleResult {
+	objs, errKind, err := listObjects(ctx, c)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), clusterTarget.With("kind", errKind)))
+	}
+
+	var checkResults []rule.CheckResult
+	for _, h := range objs.hooks {
+		f := check(h, objs)
+		if f == nil {
+			continue
+		}
+
+		target := h.target(clusterTarget)
+		if f.details != "" {
+			target = target.With("details", f.details)
+		}
+		if f.status == rule.Failed {
+			if ok, justification := accepted(acceptedWebhooks, h); ok {
+				if justification == "" {
+					justification = strings.TrimSuffix(f.message, ".") + " and is accepted."
+				}
+				checkResults = append(checkResults, rule.AcceptedCheckResult(justification, target))
+				continue
+			}
+		}
+		checkResults = append(checkResults, rule.CheckResult{Status: f.status, Message: f.message, Target: target})
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any webhooks.", clusterTarget))
+	}
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}
+}
+
+var _ rule.Rule = &FailurePolicyRule{}
+
+// FailurePolicyRule checks that webhooks and validating admission policies
+// which intercept security-relevant resources do not ignore failures.
+type FailurePolicyRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *FailurePolicyOptions
+}
+
+// ID returns the id of the rule.
+func (r *FailurePolicyRule) ID() string {
+	return "adm-001"
+}
+
+// Name returns the name of the rule.
+func (r *FailurePolicyRule) Name() string {
+	return "Webhooks and admission policies for security-relevant resources must not ignore failures (HIGH adm-001)"
+}
+
+// Run checks the failure policies of all webhooks of the cluster.
+func (r *FailurePolicyRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkHooks(ctx, r, r.Client, r.Target, failurePolicyCheck(r.Options.securityResources()), r.Options.acceptedWebhooks()), nil
+}
+
+var _ rule.Rule = &NamespaceSelectorRule{}
+
+// NamespaceSelectorRule checks that webhooks and validating admission policies
+// exempt only allowed namespaces by their namespace selectors.
+type NamespaceSelectorRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *NamespaceSelectorOptions
+}
+
+// ID returns the id of the rule.
+func (r *NamespaceSelectorRule) ID() string {
+	return "adm-002"
+}
+
+// Name returns the name of the rule.
+func (r *NamespaceSelectorRule) Name() string {
+	return "Webhooks and admission policies must not exempt namespaces by overly broad namespace selectors (MEDIUM adm-002)"
+}
+
+// Run checks the namespace selectors of all webhooks of the cluster.
+func (r *NamespaceSelectorRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkHooks(ctx, r, r.Client, r.Target, namespaceSelectorCheck(r.Options.exemptNamespaces()), r.Options.acceptedWebhooks()), nil
+}
+
+var _ rule.Rule = &TimeoutRule{}
+
+// TimeoutRule checks that webhooks set a short timeout.
+type TimeoutRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *TimeoutOptions
+}
+
+// ID returns the id of the rule.
+func (r *TimeoutRule) ID() string {
+	return "adm-003"
+}
+
+// Name returns the name of the rule.
+func (r *TimeoutRule) Name() string {
+	return "Webhooks must set a short timeout (MEDIUM adm-003)"
+}
+
+// Run checks the timeouts of all webhooks of the cluster.
+func (r *TimeoutRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	maxTimeoutSeconds, err := r.Options.maxTimeoutSeconds()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target)), nil
+	}
+	return checkHooks(ctx, r, r.Client, r.Target, timeoutCheck(maxTimeoutSeconds), r.Options.acceptedWebhooks()), nil
+}
+
+var _ rule.Rule = &OwnNamespaceRule{}
+
+// OwnNamespaceRule checks that webhooks do not intercept pods in the namespace of their service.
+type OwnNamespaceRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *Options
+}
+
+// ID returns the id of the rule.
+func (r *OwnNamespaceRule) ID() string {
+	return "adm-004"
+}
+
+// Name returns the name of the rule.
+func (r *OwnNamespaceRule) Name() string {
+	return "Webhooks must not intercept pods in their own namespace (MEDIUM adm-004)"
+}
+
+// Run checks the pods intercepted by all webhooks of the cluster.
+func (r *OwnNamespaceRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkHooks(ctx, r, r.Client, r.Target, ownNamespaceCheck, r.Options.acceptedWebhooks()), nil
+}
+
+var _ rule.Rule = &EndpointRule{}
+
+// EndpointRule checks that webhooks are called through service references.
+type EndpointRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *Options
+}
+
+// ID returns the id of the rule.
+func (r *EndpointRule) ID() string {
+	return "adm-005"
+}
+
+// Name returns the name of the rule.
+func (r *EndpointRule) Name() string {
+	return "Webhooks must be called through service references (MEDIUM adm-005)"
+}
+
+// Run checks the client configurations of all webhooks of the cluster.
+func (r *EndpointRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkHooks(ctx, r, r.Client, r.Target, endpointCheck, r.Options.acceptedWebhooks()), nil
+}
+
+func failurePolicyCheck(securityResources []string) func(hook, objects) *finding {
+	return func(h hook, _ objects) *finding {
+		intercepted := h.interceptedResources(securityResources)
+		switch {
+		case len(intercepted) == 0:
+			return &finding{status: rule.Passed, message: "Webhook does not intercept security-relevant resources."}
+		case h.failurePolicy == admissionregistrationv1.Ignore:
+			return &finding{status: rule.Failed, message: "Webhook ignores failures for security-relevant resources.", details: "resources: " + strings.Join(intercepted, ", ")}
+		default:
+			return &finding{status: rule.Passed, message: fmt.Sprintf("Webhook has failure policy %s.", h.failurePolicy)}
+		}
+	}
+}
+
+// unselectedNamespaces returns the namespaces which are not selected by the namespace selector.
+func unselectedNamespaces(selector *metav1.LabelSelector, namespaces map[string]corev1.Namespace) ([]string, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var unselected []string
+	for name, namespace := range namespaces {
+		if !s.Matches(labels.Set(namespace.Labels)) {
+			unselected = append(unselected, name)
+		}
+	}
+	slices.Sort(unselected)
+	return unselected, nil
+}
+
+func namespaceSelectorCheck(exemptNamespaces []string) func(hook, objects) *finding {
+	return func(h hook, objs objects) *finding {
+		unselected, err := unselectedNamespaces(h.namespaceSelector, objs.namespaces)
+		if err != nil {
+			return &finding{status: rule.Errored, message: err.Error()}
+		}
+
+		var exempted []string
+		for _, namespace := range unselected {
+			if !slices.Contains(exemptNamespaces, namespace) {
+				exempted = append(exempted, namespace)
+			}
+		}
+		if len(exempted) == 0 {
+			return &finding{status: rule.Passed, message: "Webhook does not exempt namespaces which are not allowed to be exempted."}
+		}
+
+		const maxListed = 10
+		details := strings.Join(exempted[:min(len(exempted), maxListed)], ", ")
+		if len(exempted) > maxListed {
+			details += fmt.Sprintf(", and %d more", len(exempted)-maxListed)
+		}
+		return &finding{status: rule.Failed, message: "Webhook exempts namespaces which are not allowed to be exempted.", details: "namespaces: " + details}
+	}
+}
+
+func timeoutCheck(maxTimeoutSeconds int32) func(hook, objects) *finding {
+	return func(h hook, _ objects) *finding {
+		switch {
+		case !h.isWebhook():
+			return nil
+		case h.timeoutSeconds == nil:
+			return &finding{status: rule.Failed, message: "Webhook does not set a timeout."}
+		case *h.timeoutSeconds > maxTimeoutSeconds:
+			return &finding{status: rule.Failed, message: fmt.Sprintf("Webhook timeout of %ds exceeds %ds.", *h.timeoutSeconds, maxTimeoutSeconds)}
+		default:
+			return &finding{status: rule.Passed, message: fmt.Sprintf("Webhook has a timeout of %ds.", *h.timeoutSeconds)}
+		}
+	}
+}
+
+func ownNamespaceCheck(h hook, objs objects) *finding {
+	if !h.isWebhook() {
+		return nil
+	}
+	if h.clientConfig.Service == nil {
+		return &finding{status: rule.Passed, message: "Webhook is not served by a service of the cluster."}
+	}
+
+	serviceNamespace := h.clientConfig.Service.Namespace
+	namespace, ok := objs.namespaces[serviceNamespace]
+	if !ok || len(h.interceptedResources([]string{"pods"})) == 0 {
+		return &finding{status: rule.Passed, message: "Webhook does not intercept pods in its own namespace."}
+	}
+
+	selector := labels.Everything()
+	if h.namespaceSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(h.namespaceSelector); err != nil {
+			return &finding{status: rule.Errored, message: err.Error()}
+		}
+	}
+	if !selector.Matches(labels.Set(namespace.Labels)) {
+		return &finding{status: rule.Passed, message: "Webhook does not intercept pods in its own namespace."}
+	}
+	return &finding{status: rule.Failed, message: "Webhook intercepts pods in its own namespace.", details: "namespace: " + serviceNamespace}
+}
+
+func endpointCheck(h hook, _ objects) *finding {
+	switch {
+	case !h.isWebhook():
+		return nil
+	case h.clientConfig.Service != nil:
+		return &finding{status: rule.Passed, message: "Webhook uses a service reference."}
+	default:
+		url := ""
+		if h.clientConfig.URL != nil {
+			url = *h.clientConfig.URL
+		}
+		return &finding{status: rule.Failed, message: "Webhook uses a URL instead of a service reference.", details: "url: " + url}
+	}
+}
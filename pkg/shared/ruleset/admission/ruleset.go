@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of an Admission Control Ruleset
+	RulesetID = "admission-control"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset checks the webhook configurations and the
+// validating admission policies of a cluster.
+type Ruleset struct {
+	version    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "Admission Control"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules check the
+// objects of the cluster of clusterConfig which is described by clusterTarget.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, clusterConfig *rest.Config, clusterTarget rule.Target) (*Ruleset, error) {
+	ruleset, err := New(WithVersion(rulesetConfig.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	c, err := client.New(clusterConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := Rules(c, clusterTarget, ruleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// Rules creates the rules of the ruleset which check the objects listed by the client.
+func Rules(c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	failurePolicyOptions, err := parseOptions[FailurePolicyOptions](ruleOptions["adm-001"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule adm-001: %w", err)
+	}
+
+	namespaceSelectorOptions, err := parseOptions[NamespaceSelectorOptions](ruleOptions["adm-002"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule adm-002: %w", err)
+	}
+
+	timeoutOptions, err := parseOptions[TimeoutOptions](ruleOptions["adm-003"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule adm-003: %w", err)
+	}
+	if _, err := timeoutOptions.maxTimeoutSeconds(); err != nil {
+		return nil, fmt.Errorf("invalid options of rule adm-003: %w", err)
+	}
+
+	ownNamespaceOptions, err := parseOptions[Options](ruleOptions["adm-004"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule adm-004: %w", err)
+	}
+
+	endpointOptions, err := parseOptions[Options](ruleOptions["adm-005"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule adm-005: %w", err)
+	}
+
+	return []rule.Rule{
+		&FailurePolicyRule{Client: c, Target: clusterTarget, Options: failurePolicyOptions},
+		&NamespaceSelectorRule{Client: c, Target: clusterTarget, Options: namespaceSelectorOptions},
+		&TimeoutRule{Client: c, Target: clusterTarget, Options: timeoutOptions},
+		&OwnNamespaceRule{Client: c, Target: clusterTarget, Options: ownNamespaceOptions},
+		&EndpointRule{Client: c, Target: clusterTarget, Options: endpointOptions},
+	}, nil
+}
+
+func parseOptions[O any](args any) (*O, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options O
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
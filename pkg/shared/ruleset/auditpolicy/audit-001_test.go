@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+)
+
+var _ = Describe("#audit-001", func() {
+	const policy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: None
+  resources:
+  - group: ""
+    resources:
+    - configmaps
+  namespaces:
+  - kube-system
+- level: Metadata
+  resources:
+  - group: ""
+    resources:
+    - secrets
+- level: Request
+  verbs:
+  - create
+  - update
+  resources:
+  - group: ""
+    resources:
+    - configmaps
+- level: Metadata
+`
+	var ctx = context.TODO()
+
+	It("should report the sensitive resources which are logged above level Metadata", func() {
+		r := &auditpolicy.SensitiveResourceRule{APIServer: newAPIServer(policy)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("audit-001"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Resource is not logged above level Metadata.", target.With("resource", "secrets", "details", "rule 2 (level Metadata; resources secrets) matches verbs get, list, watch, create, update, patch, delete, deletecollection")),
+			rule.FailedCheckResult("Resource is logged above level Metadata.", target.With("resource", "configmaps", "details", "rule 3 (level Request; verbs create, update; resources configmaps) matches verbs create, update")),
+		}))
+	})
+
+	It("should respect the resources and the maximum level of the rule options", func() {
+		r := &auditpolicy.SensitiveResourceRule{APIServer: newAPIServer(policy), Options: &auditpolicy.SensitiveResourceOptions{
+			Resources: []auditpolicy.Resource{{Resource: "configmaps"}},
+			MaxLevel:  "Request",
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Resource is not logged above level Request.", target.With("resource", "configmaps", "details", "rule 1 (level None; resources configmaps; namespaces kube-system) matches verbs get, list, watch, create, update, patch, delete, deletecollection; rule 4 (level Metadata) matches verbs get, list, watch, patch, delete, deletecollection; rule 3 (level Request; verbs create, update; resources configmaps) matches verbs create, update")),
+		}))
+	})
+
+	It("should error when the maximum level is unknown", func() {
+		r := &auditpolicy.SensitiveResourceRule{APIServer: newAPIServer(policy), Options: &auditpolicy.SensitiveResourceOptions{MaxLevel: "Foo"}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("unknown level Foo", target),
+		}))
+	})
+
+	It("should fail when the audit policy file is not set", func() {
+		r := &auditpolicy.SensitiveResourceRule{APIServer: newAPIServer("")}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Option audit-policy-file has not been set.", target),
+		}))
+	})
+
+	It("should error when the kube-apiserver is not found", func() {
+		apiServer := newAPIServer(policy)
+		apiServer.Client = fakeclient.NewClientBuilder().Build()
+		r := &auditpolicy.SensitiveResourceRule{APIServer: apiServer}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult(`deployments.apps "kube-apiserver" not found`, target),
+		}))
+	})
+
+	It("should return an error when the maximum level of the rule options is unknown", func() {
+		_, err := auditpolicy.Rules(newAPIServer(policy), map[string]config.RuleOptionsConfig{"audit-001": {Args: map[string]any{"maxLevel": "Foo"}}})
+		Expect(err).To(MatchError("invalid options of rule audit-001: unknown level Foo"))
+	})
+})
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+)
+
+var _ = Describe("#audit-002", func() {
+	const policy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: None
+  userGroups:
+  - system:masters
+  resources:
+  - group: rbac.authorization.k8s.io
+    resources:
+    - roles
+    - rolebindings
+- level: RequestResponse
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - deletecollection
+  resources:
+  - group: rbac.authorization.k8s.io
+- level: None
+`
+	var ctx = context.TODO()
+
+	It("should report the RBAC resources whose writes are not logged for all users", func() {
+		r := &auditpolicy.WriteRule{APIServer: newAPIServer(policy)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("audit-002"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Writes of resource are logged at least at level Metadata.", target.With("resource", "clusterroles.rbac.authorization.k8s.io", "details", "rule 2 (level RequestResponse; verbs create, update, patch, delete, deletecollection; resources *.rbac.authorization.k8s.io) matches verbs create, update, patch, delete, deletecollection")),
+			rule.PassedCheckResult("Writes of resource are logged at least at level Metadata.", target.With("resource", "clusterrolebindings.rbac.authorization.k8s.io", "details", "rule 2 (level RequestResponse; verbs create, update, patch, delete, deletecollection; resources *.rbac.authorization.k8s.io) matches verbs create, update, patch, delete, deletecollection")),
+			rule.FailedCheckResult("Writes of resource are not logged at least at level Metadata.", target.With("resource", "roles.rbac.authorization.k8s.io", "details", "rule 1 (level None; userGroups system:masters; resources roles.rbac.authorization.k8s.io, rolebindings.rbac.authorization.k8s.io) matches verbs create, update, patch, delete, deletecollection")),
+			rule.FailedCheckResult("Writes of resource are not logged at least at level Metadata.", target.With("resource", "rolebindings.rbac.authorization.k8s.io", "details", "rule 1 (level None; userGroups system:masters; resources roles.rbac.authorization.k8s.io, rolebindings.rbac.authorization.k8s.io) matches verbs create, update, patch, delete, deletecollection")),
+		}))
+	})
+
+	It("should respect the resources and the minimum level of the rule options", func() {
+		r := &auditpolicy.WriteRule{APIServer: newAPIServer(policy), Options: &auditpolicy.WriteOptions{
+			Resources: []auditpolicy.Resource{{Resource: "secrets"}, {Group: "rbac.authorization.k8s.io", Resource: "clusterroles"}},
+			MinLevel:  "RequestResponse",
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Writes of resource are not logged at least at level RequestResponse.", target.With("resource", "secrets", "details", "rule 3 (level None) matches verbs create, update, patch, delete, deletecollection")),
+			rule.PassedCheckResult("Writes of resource are logged at least at level RequestResponse.", target.With("resource", "clusterroles.rbac.authorization.k8s.io", "details", "rule 2 (level RequestResponse; verbs create, update, patch, delete, deletecollection; resources *.rbac.authorization.k8s.io) matches verbs create, update, patch, delete, deletecollection")),
+		}))
+	})
+
+	It("should fail when the audit policy file is not set", func() {
+		r := &auditpolicy.WriteRule{APIServer: newAPIServer("")}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Option audit-policy-file has not been set.", target),
+		}))
+	})
+
+	It("should return an error when the minimum level of the rule options is unknown", func() {
+		_, err := auditpolicy.Rules(newAPIServer(policy), map[string]config.RuleOptionsConfig{"audit-002": {Args: map[string]any{"minLevel": "Foo"}}})
+		Expect(err).To(MatchError("invalid options of rule audit-002: unknown level Foo"))
+	})
+})
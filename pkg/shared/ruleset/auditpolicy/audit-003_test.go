@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+)
+
+var _ = Describe("#audit-003", func() {
+	const policy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+- level: Metadata
+  omitStages:
+  - ResponseComplete
+  resources:
+  - group: ""
+    resources:
+    - secrets
+- level: Metadata
+`
+	var ctx = context.TODO()
+
+	It("should report the resources whose ResponseComplete stage is omitted by a policy rule", func() {
+		r := &auditpolicy.StageRule{APIServer: newAPIServer(policy)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("audit-003"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Stage ResponseComplete is omitted for resource.", target.With("resource", "secrets", "details", "rule 1 (level Metadata; resources secrets) matches verbs get, list, watch, create, update, patch, delete, deletecollection")),
+			rule.PassedCheckResult("Stage ResponseComplete is not omitted for resource.", target.With("resource", "configmaps")),
+			rule.PassedCheckResult("Stage ResponseComplete is not omitted for resource.", target.With("resource", "clusterroles.rbac.authorization.k8s.io")),
+			rule.PassedCheckResult("Stage ResponseComplete is not omitted for resource.", target.With("resource", "clusterrolebindings.rbac.authorization.k8s.io")),
+			rule.PassedCheckResult("Stage ResponseComplete is not omitted for resource.", target.With("resource", "roles.rbac.authorization.k8s.io")),
+			rule.PassedCheckResult("Stage ResponseComplete is not omitted for resource.", target.With("resource", "rolebindings.rbac.authorization.k8s.io")),
+		}))
+	})
+
+	It("should report the resources whose ResponseComplete stage is omitted by the policy", func() {
+		r := &auditpolicy.StageRule{
+			APIServer: newAPIServer("apiVersion: audit.k8s.io/v1\nkind: Policy\nomitStages:\n- ResponseComplete\nrules:\n- level: Metadata\n"),
+			Options:   &auditpolicy.StageOptions{Resources: []auditpolicy.Resource{{Resource: "configmaps"}}},
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Stage ResponseComplete is omitted for resource.", target.With("resource", "configmaps", "details", "rule 1 (level Metadata) matches verbs get, list, watch, create, update, patch, delete, deletecollection")),
+		}))
+	})
+
+	It("should fail when the audit policy file is not set", func() {
+		r := &auditpolicy.StageRule{APIServer: newAPIServer("")}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Option audit-policy-file has not been set.", target),
+		}))
+	})
+})
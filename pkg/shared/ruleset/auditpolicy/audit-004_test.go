@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+)
+
+var _ = Describe("#audit-004", func() {
+	var ctx = context.TODO()
+
+	It("should pass when the policy ends with a catch-all rule and no rule is shadowed", func() {
+		r := &auditpolicy.ShadowRule{APIServer: newAPIServer(`apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: None
+  nonResourceURLs:
+  - /healthz*
+- level: Metadata
+  resources:
+  - group: ""
+    resources:
+    - secrets
+- level: Request
+  verbs:
+  - get
+  resources:
+  - group: ""
+    resources:
+    - configmaps
+- level: Metadata
+`)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("audit-004"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Audit policy has a catch-all rule and no shadowed rules.", target),
+		}))
+	})
+
+	It("should report rules which are shadowed by a broader previous rule", func() {
+		r := &auditpolicy.ShadowRule{APIServer: newAPIServer(`apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: RequestResponse
+  resources:
+  - group: ""
+    resources:
+    - secrets
+- level: Metadata
+  verbs:
+  - get
+  resources:
+  - group: ""
+    resources:
+    - secrets
+  namespaces:
+  - kube-system
+- level: Metadata
+`)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Policy rule is never matched because a previous rule matches all of its requests.", target.With("details", "rule 2 (level Metadata; verbs get; resources secrets; namespaces kube-system) is shadowed by rule 1 (level RequestResponse; resources secrets)")),
+		}))
+	})
+
+	It("should report rules which follow the catch-all rule", func() {
+		r := &auditpolicy.ShadowRule{APIServer: newAPIServer(`apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: Metadata
+- level: RequestResponse
+  nonResourceURLs:
+  - /healthz*
+`)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Policy rule is never matched because a previous rule matches all of its requests.", target.With("details", "rule 2 (level RequestResponse; nonResourceURLs /healthz*) is shadowed by rule 1 (level Metadata)")),
+		}))
+	})
+
+	It("should fail when the policy does not have a catch-all rule", func() {
+		r := &auditpolicy.ShadowRule{APIServer: newAPIServer(`apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: Metadata
+  resources:
+  - group: ""
+    resources:
+    - secrets
+`)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Audit policy does not have a catch-all rule.", target.With("details", "Requests which do not match any rule are not logged.")),
+		}))
+	})
+
+	It("should fail when the audit policy file is not set", func() {
+		r := &auditpolicy.ShadowRule{APIServer: newAPIServer("")}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Option audit-policy-file has not been set.", target),
+		}))
+	})
+})
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/auditpolicy"
+)
+
+func TestAuditPolicy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Policy Validation Ruleset Test Suite")
+}
+
+const namespace = "shoot--foo--bar"
+
+// target is the target of the check results of the kube-apiserver created by newAPIServer.
+var target = rule.NewTarget("cluster", "seed", "kind", "deployment", "name", "kube-apiserver", "namespace", namespace)
+
+// newAPIServer creates a kube-apiserver deployment which reads the audit policy from a config map.
+// The deployment does not set the audit-policy-file flag when the policy is empty.
+func newAPIServer(policy string) auditpolicy.APIServer {
+	var (
+		ctx        = context.TODO()
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		container  = corev1.Container{Name: "kube-apiserver", Command: []string{"kube-apiserver"}}
+		volumes    []corev1.Volume
+	)
+	if policy != "" {
+		container.Command = append(container.Command, "--audit-policy-file=/etc/kubernetes/audit/audit-policy.yaml")
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "audit-policy-config", MountPath: "/etc/kubernetes/audit"}}
+		volumes = []corev1.Volume{{
+			Name: "audit-policy-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "audit-policy-config"}},
+			},
+		}}
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "audit-policy-config", Namespace: namespace},
+			Data:       map[string]string{"audit-policy.yaml": policy},
+		})).To(Succeed())
+	}
+	Expect(fakeClient.Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver", Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{container}, Volumes: volumes},
+			},
+		},
+	})).To(Succeed())
+
+	return auditpolicy.APIServer{
+		Client:         fakeClient,
+		Namespace:      namespace,
+		DeploymentName: "kube-apiserver",
+		ContainerName:  "kube-apiserver",
+		Target:         rule.NewTarget("cluster", "seed"),
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// History contains the rules of all Audit Policy Validation versions.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1",
+		Rules:   []string{"audit-001", "audit-002", "audit-003", "audit-004"},
+	},
+)
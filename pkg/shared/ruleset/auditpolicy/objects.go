@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// APIServer selects the deployment of a kube-apiserver. The audit policy is read from
+// the file referenced by the audit-policy-file flag of its container.
+type APIServer struct {
+	Client         client.Client
+	Namespace      string
+	DeploymentName string
+	ContainerName  string
+	// Target describes the cluster of the kube-apiserver, e.g. cluster=seed.
+	Target rule.Target
+}
+
+// target returns the target of the kube-apiserver deployment.
+func (a APIServer) target() rule.Target {
+	target := rule.NewTarget("kind", "deployment", "name", a.DeploymentName, "namespace", a.Namespace)
+	maps.Copy(target, a.Target)
+	return target
+}
+
+// readPolicy returns the audit policy of the kube-apiserver. The policy is nil
+// without an error when the audit-policy-file flag is not set.
+func readPolicy(ctx context.Context, apiServer APIServer) (*auditv1.Policy, error) {
+	deployment := &appsv1.Deployment{}
+	if err := apiServer.Client.Get(ctx, client.ObjectKey{Name: apiServer.DeploymentName, Namespace: apiServer.Namespace}, deployment); err != nil {
+		return nil, err
+	}
+
+	container, found := kubeutils.GetContainerFromDeployment(deployment, apiServer.ContainerName)
+	if !found {
+		return nil, fmt.Errorf("deployment: %s does not contain container: %s", apiServer.DeploymentName, apiServer.ContainerName)
+	}
+
+	values := kubeutils.FindFlagValueRaw(append(container.Command, container.Args...), "audit-policy-file")
+	switch {
+	case len(values) == 0 || values[0] == "":
+		return nil, nil
+	case len(values) > 1:
+		return nil, fmt.Errorf("option audit-policy-file has been set more than once in container command")
+	}
+
+	data, err := kubeutils.GetVolumeConfigByteSliceByMountPath(ctx, apiServer.Client, deployment, apiServer.ContainerName, values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &auditv1.Policy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to decode audit policy: %w", err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+// levels orders the audit levels by the amount of data they log.
+var levels = map[auditv1.Level]int{
+	auditv1.LevelNone:            0,
+	auditv1.LevelMetadata:        1,
+	auditv1.LevelRequest:         2,
+	auditv1.LevelRequestResponse: 3,
+}
+
+// allVerbs are the verbs of resource requests.
+var allVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}
+
+// writeVerbs are the verbs of resource requests which modify objects.
+var writeVerbs = []string{"create", "update", "patch", "delete", "deletecollection"}
+
+// Resource is a resource of an API group. The core API group is the empty group.
+type Resource struct {
+	Group    string `json:"group" yaml:"group"`
+	Resource string `json:"resource" yaml:"resource"`
+}
+
+// String returns the resource in the format resource.group.
+func (r Resource) String() string {
+	if r.Group == "" {
+		return r.Resource
+	}
+	return r.Resource + "." + r.Group
+}
+
+// request describes resource requests of any user in any namespace.
+type request struct {
+	resource Resource
+	verb     string
+}
+
+// match is a policy rule which matches a request.
+type match struct {
+	index int
+	rule  auditv1.PolicyRule
+}
+
+// matches returns the policy rules which can be the first matching rule of the request. Users, user
+// groups, namespaces and resource names are unknown and therefore rules which select them only match
+// some requests. The evaluation stops at the first rule which matches all requests. The second return
+// value is false when no rule matches all requests, i.e. some requests are not logged.
+func matches(policy *auditv1.Policy, req request) ([]match, bool) {
+	var result []match
+	for i, policyRule := range policy.Rules {
+		if !matchesResource(policyRule, req) {
+			continue
+		}
+
+		result = append(result, match{index: i, rule: policyRule})
+		if !isConditional(policyRule) {
+			return result, true
+		}
+	}
+	return result, false
+}
+
+func matchesResource(policyRule auditv1.PolicyRule, req request) bool {
+	if len(policyRule.Verbs) > 0 && !slices.Contains(policyRule.Verbs, req.verb) {
+		return false
+	}
+	if len(policyRule.Resources) == 0 {
+		// rules without resources match all resource requests unless they select non-resource urls
+		return len(policyRule.NonResourceURLs) == 0
+	}
+
+	for _, groupResources := range policyRule.Resources {
+		if groupResources.Group != req.resource.Group {
+			continue
+		}
+		if len(groupResources.Resources) == 0 || slices.Contains(groupResources.Resources, req.resource.Resource) || slices.Contains(groupResources.Resources, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// isConditional returns whether the policy rule only matches the requests of some users, namespaces or objects.
+func isConditional(policyRule auditv1.PolicyRule) bool {
+	if len(policyRule.Users) > 0 || len(policyRule.UserGroups) > 0 || len(policyRule.Namespaces) > 0 {
+		return true
+	}
+	for _, groupResources := range policyRule.Resources {
+		if len(groupResources.ResourceNames) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isCatchAll returns whether the policy rule matches all requests.
+func isCatchAll(policyRule auditv1.PolicyRule) bool {
+	return len(policyRule.Users) == 0 && len(policyRule.UserGroups) == 0 && len(policyRule.Verbs) == 0 &&
+		len(policyRule.Resources) == 0 && len(policyRule.Namespaces) == 0 && len(policyRule.NonResourceURLs) == 0
+}
+
+// omitsStage returns whether events of the stage are omitted for requests matching the policy rule.
+func omitsStage(policy *auditv1.Policy, policyRule auditv1.PolicyRule, stage auditv1.Stage) bool {
+	return slices.Contains(policy.OmitStages, stage) || slices.Contains(policyRule.OmitStages, stage)
+}
+
+// shadows returns whether the policy rule matches all requests which are matched by the other rule.
+func shadows(policyRule, other auditv1.PolicyRule) bool {
+	if !coversAll(policyRule.Users, other.Users) || !coversAll(policyRule.UserGroups, other.UserGroups) ||
+		!coversAll(policyRule.Verbs, other.Verbs) || !coversAll(policyRule.Namespaces, other.Namespaces) {
+		return false
+	}
+
+	switch {
+	case len(policyRule.Resources) == 0 && len(policyRule.NonResourceURLs) == 0:
+		return true
+	case len(policyRule.NonResourceURLs) > 0:
+		if len(other.NonResourceURLs) == 0 || len(other.Resources) > 0 {
+			return false
+		}
+		for _, url := range other.NonResourceURLs {
+			if !slices.ContainsFunc(policyRule.NonResourceURLs, func(pattern string) bool { return matchesURL(pattern, url) }) {
+				return false
+			}
+		}
+		return true
+	default:
+		if len(other.Resources) == 0 || len(other.NonResourceURLs) > 0 {
+			return false
+		}
+		for _, groupResources := range other.Resources {
+			if !slices.ContainsFunc(policyRule.Resources, func(gr auditv1.GroupResources) bool { return coversGroupResources(gr, groupResources) }) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// coversAll returns whether a selector matches all values of the other selector. Empty selectors match all values.
+func coversAll(selector, other []string) bool {
+	if len(selector) == 0 {
+		return true
+	}
+	if len(other) == 0 {
+		return false
+	}
+	for _, value := range other {
+		if !slices.Contains(selector, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func coversGroupResources(groupResources, other auditv1.GroupResources) bool {
+	if groupResources.Group != other.Group || !coversAll(groupResources.ResourceNames, other.ResourceNames) {
+		return false
+	}
+	if slices.Contains(groupResources.Resources, "*") {
+		return true
+	}
+	return coversAll(groupResources.Resources, other.Resources)
+}
+
+// matchesURL returns whether the non-resource url pattern of a policy rule, e.g. /healthz*, matches the url.
+func matchesURL(pattern, url string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(url, prefix)
+	}
+	return pattern == url
+}
+
+// describe returns a description of the policy rule which explains the requests it matches.
+func describe(index int, policyRule auditv1.PolicyRule) string {
+	selectors := []string{fmt.Sprintf("level %s", policyRule.Level)}
+	add := func(name string, values []string) {
+		if len(values) > 0 {
+			selectors = append(selectors, fmt.Sprintf("%s %s", name, strings.Join(values, ", ")))
+		}
+	}
+
+	var resources []string
+	for _, groupResources := range policyRule.Resources {
+		names := groupResources.Resources
+		if len(names) == 0 {
+			names = []string{"*"}
+		}
+		for _, name := range names {
+			resources = append(resources, Resource{Group: groupResources.Group, Resource: name}.String())
+		}
+	}
+
+	add("users", policyRule.Users)
+	add("userGroups", policyRule.UserGroups)
+	add("verbs", policyRule.Verbs)
+	add("resources", resources)
+	add("namespaces", policyRule.Namespaces)
+	add("nonResourceURLs", policyRule.NonResourceURLs)
+	// rules are numbered starting with 1 like in the policy file
+	return fmt.Sprintf("rule %d (%s)", index+1, strings.Join(selectors, "; "))
+}
@@ -0,0 +1,357 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditpolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// SensitiveResourceOptions are the rule options of the rule which checks the level of sensitive resources.
+type SensitiveResourceOptions struct {
+	// Resources are the sensitive resources. Defaults to secrets and configmaps.
+	Resources []Resource `json:"resources" yaml:"resources"`
+	// MaxLevel is the highest level at which the resources can be logged. Defaults to Metadata.
+	MaxLevel auditv1.Level `json:"maxLevel" yaml:"maxLevel"`
+}
+
+// WriteOptions are the rule options of the rule which checks the level of writes to RBAC resources.
+type WriteOptions struct {
+	// Resources are the resources whose writes are checked. Defaults to the resources of the rbac.authorization.k8s.io group.
+	Resources []Resource `json:"resources" yaml:"resources"`
+	// MinLevel is the lowest level at which writes must be logged. Defaults to Metadata.
+	MinLevel auditv1.Level `json:"minLevel" yaml:"minLevel"`
+}
+
+// StageOptions are the rule options of the rule which checks the omitted stages of sensitive resources.
+type StageOptions struct {
+	// Resources are the resources whose ResponseComplete stage must be logged.
+	// Defaults to secrets, configmaps and the resources of the rbac.authorization.k8s.io group.
+	Resources []Resource `json:"resources" yaml:"resources"`
+}
+
+var (
+	defaultSensitiveResources = []Resource{
+		{Resource: "secrets"},
+		{Resource: "configmaps"},
+	}
+	defaultRBACResources = []Resource{
+		{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+		{Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+		{Group: "rbac.authorization.k8s.io", Resource: "roles"},
+		{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+	}
+)
+
+func (o *SensitiveResourceOptions) resources() []Resource {
+	if o == nil || len(o.Resources) == 0 {
+		return defaultSensitiveResources
+	}
+	return o.Resources
+}
+
+// maxLevel returns the configured maximum level or Metadata.
+func (o *SensitiveResourceOptions) maxLevel() (auditv1.Level, error) {
+	if o == nil || o.MaxLevel == "" {
+		return auditv1.LevelMetadata, nil
+	}
+	if _, ok := levels[o.MaxLevel]; !ok {
+		return "", fmt.Errorf("unknown level %s", o.MaxLevel)
+	}
+	return o.MaxLevel, nil
+}
+
+func (o *WriteOptions) resources() []Resource {
+	if o == nil || len(o.Resources) == 0 {
+		return defaultRBACResources
+	}
+	return o.Resources
+}
+
+// minLevel returns the configured minimum level or Metadata.
+func (o *WriteOptions) minLevel() (auditv1.Level, error) {
+	if o == nil || o.MinLevel == "" {
+		return auditv1.LevelMetadata, nil
+	}
+	if _, ok := levels[o.MinLevel]; !ok {
+		return "", fmt.Errorf("unknown level %s", o.MinLevel)
+	}
+	return o.MinLevel, nil
+}
+
+func (o *StageOptions) resources() []Resource {
+	if o == nil || len(o.Resources) == 0 {
+		return append(slices.Clone(defaultSensitiveResources), defaultRBACResources...)
+	}
+	return o.Resources
+}
+
+// checkPolicy checks the audit policy of the kube-apiserver. check returns the check results of the
+// audit policy, their targets extend the target of the kube-apiserver.
+func checkPolicy(ctx context.Context, r rule.Rule, apiServer APIServer, check func(*auditv1.Policy, rule.Target) []rule.CheckResult) rule.RuleResult {
+	target := apiServer.target()
+	policy, err := readPolicy(ctx, apiServer)
+	switch {
+	case err != nil:
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target))
+	case policy == nil:
+		return rule.SingleCheckResult(r, rule.FailedCheckResult("Option audit-policy-file has not been set.", target))
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: check(policy, target),
+	}
+}
+
+var _ rule.Rule = &SensitiveResourceRule{}
+
+// SensitiveResourceRule checks that the audit policy does not log the payloads of sensitive resources.
+type SensitiveResourceRule struct {
+	APIServer APIServer
+	Options   *SensitiveResourceOptions
+}
+
+// ID returns the id of the rule.
+func (r *SensitiveResourceRule) ID() string {
+	return "audit-001"
+}
+
+// Name returns the name of the rule.
+func (r *SensitiveResourceRule) Name() string {
+	return "Audit policies must not log the payloads of sensitive resources (HIGH audit-001)"
+}
+
+// Run checks the levels of the sensitive resources in the audit policy of the kube-apiserver.
+func (r *SensitiveResourceRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	maxLevel, err := r.Options.maxLevel()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.APIServer.target())), nil
+	}
+	return checkPolicy(ctx, r, r.APIServer, sensitiveResourceCheck(r.Options.resources(), maxLevel)), nil
+}
+
+var _ rule.Rule = &WriteRule{}
+
+// WriteRule checks that the audit policy logs writes to RBAC resources.
+type WriteRule struct {
+	APIServer APIServer
+	Options   *WriteOptions
+}
+
+// ID returns the id of the rule.
+func (r *WriteRule) ID() string {
+	return "audit-002"
+}
+
+// Name returns the name of the rule.
+func (r *WriteRule) Name() string {
+	return "Audit policies must log writes to RBAC resources (MEDIUM audit-002)"
+}
+
+// Run checks the levels of writes to the resources in the audit policy of the kube-apiserver.
+func (r *WriteRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	minLevel, err := r.Options.minLevel()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.APIServer.target())), nil
+	}
+	return checkPolicy(ctx, r, r.APIServer, writeCheck(r.Options.resources(), minLevel)), nil
+}
+
+var _ rule.Rule = &StageRule{}
+
+// StageRule checks that the audit policy does not omit the ResponseComplete stage for sensitive resources.
+type StageRule struct {
+	APIServer APIServer
+	Options   *StageOptions
+}
+
+// ID returns the id of the rule.
+func (r *StageRule) ID() string {
+	return "audit-003"
+}
+
+// Name returns the name of the rule.
+func (r *StageRule) Name() string {
+	return "Audit policies must not omit the ResponseComplete stage for sensitive resources (MEDIUM audit-003)"
+}
+
+// Run checks the omitted stages of the resources in the audit policy of the kube-apiserver.
+func (r *StageRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkPolicy(ctx, r, r.APIServer, stageCheck(r.Options.resources())), nil
+}
+
+var _ rule.Rule = &ShadowRule{}
+
+// ShadowRule checks that the audit policy has a catch-all rule and does not contain shadowed rules.
+type ShadowRule struct {
+	APIServer APIServer
+}
+
+// ID returns the id of the rule.
+func (r *ShadowRule) ID() string {
+	return "audit-004"
+}
+
+// Name returns the name of the rule.
+func (r *ShadowRule) Name() string {
+	return "Audit policies must have a catch-all rule and must not contain shadowed rules (MEDIUM audit-004)"
+}
+
+// Run checks the order of the rules in the audit policy of the kube-apiserver.
+func (r *ShadowRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkPolicy(ctx, r, r.APIServer, shadowCheck), nil
+}
+
+// explanation lists the policy rules which match requests together with the verbs of the requests.
+type explanation struct {
+	rules []string
+	verbs map[string][]string
+}
+
+func (e *explanation) add(description, verb string) {
+	if e.verbs == nil {
+		e.verbs = map[string][]string{}
+	}
+	if _, ok := e.verbs[description]; !ok {
+		e.rules = append(e.rules, description)
+	}
+	e.verbs[description] = append(e.verbs[description], verb)
+}
+
+func (e *explanation) empty() bool {
+	return len(e.rules) == 0
+}
+
+func (e *explanation) String() string {
+	parts := make([]string, 0, len(e.rules))
+	for _, description := range e.rules {
+		parts = append(parts, fmt.Sprintf("%s matches verbs %s", description, strings.Join(e.verbs[description], ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+const notMatched = "no rule"
+
+func sensitiveResourceCheck(resources []Resource, maxLevel auditv1.Level) func(*auditv1.Policy, rule.Target) []rule.CheckResult {
+	return func(policy *auditv1.Policy, target rule.Target) []rule.CheckResult {
+		checkResults := make([]rule.CheckResult, 0, len(resources))
+		for _, resource := range resources {
+			var matched, violations explanation
+			for _, verb := range allVerbs {
+				ms, _ := matches(policy, request{resource: resource, verb: verb})
+				for _, m := range ms {
+					description := describe(m.index, m.rule)
+					matched.add(description, verb)
+					if levels[m.rule.Level] > levels[maxLevel] {
+						violations.add(description, verb)
+					}
+				}
+			}
+
+			resourceTarget := target.With("resource", resource.String())
+			if !violations.empty() {
+				msg := fmt.Sprintf("Resource is logged above level %s.", maxLevel)
+				checkResults = append(checkResults, rule.FailedCheckResult(msg, resourceTarget.With("details", violations.String())))
+				continue
+			}
+			msg := fmt.Sprintf("Resource is not logged above level %s.", maxLevel)
+			if matched.empty() {
+				checkResults = append(checkResults, rule.PassedCheckResult(msg, resourceTarget.With("details", "Resource is not matched by any rule.")))
+				continue
+			}
+			checkResults = append(checkResults, rule.PassedCheckResult(msg, resourceTarget.With("details", matched.String())))
+		}
+		return checkResults
+	}
+}
+
+func writeCheck(resources []Resource, minLevel auditv1.Level) func(*auditv1.Policy, rule.Target) []rule.CheckResult {
+	return func(policy *auditv1.Policy, target rule.Target) []rule.CheckResult {
+		checkResults := make([]rule.CheckResult, 0, len(resources))
+		for _, resource := range resources {
+			var matched, violations explanation
+			for _, verb := range writeVerbs {
+				ms, complete := matches(policy, request{resource: resource, verb: verb})
+				for _, m := range ms {
+					description := describe(m.index, m.rule)
+					matched.add(description, verb)
+					if levels[m.rule.Level] < levels[minLevel] {
+						violations.add(description, verb)
+					}
+				}
+				if !complete {
+					violations.add(notMatched, verb)
+				}
+			}
+
+			resourceTarget := target.With("resource", resource.String())
+			if !violations.empty() {
+				msg := fmt.Sprintf("Writes of resource are not logged at least at level %s.", minLevel)
+				checkResults = append(checkResults, rule.FailedCheckResult(msg, resourceTarget.With("details", violations.String())))
+				continue
+			}
+			msg := fmt.Sprintf("Writes of resource are logged at least at level %s.", minLevel)
+			checkResults = append(checkResults, rule.PassedCheckResult(msg, resourceTarget.With("details", matched.String())))
+		}
+		return checkResults
+	}
+}
+
+func stageCheck(resources []Resource) func(*auditv1.Policy, rule.Target) []rule.CheckResult {
+	return func(policy *auditv1.Policy, target rule.Target) []rule.CheckResult {
+		checkResults := make([]rule.CheckResult, 0, len(resources))
+		for _, resource := range resources {
+			var violations explanation
+			for _, verb := range allVerbs {
+				ms, _ := matches(policy, request{resource: resource, verb: verb})
+				for _, m := range ms {
+					if m.rule.Level != auditv1.LevelNone && omitsStage(policy, m.rule, auditv1.StageResponseComplete) {
+						violations.add(describe(m.index, m.rule), verb)
+					}
+				}
+			}
+
+			resourceTarget := target.With("resource", resource.String())
+			if !violations.empty() {
+				checkResults = append(checkResults, rule.FailedCheckResult("Stage ResponseComplete is omitted for resource.", resourceTarget.With("details", violations.String())))
+				continue
+			}
+			checkResults = append(checkResults, rule.PassedCheckResult("Stage ResponseComplete is not omitted for resource.", resourceTarget))
+		}
+		return checkResults
+	}
+}
+
+func shadowCheck(policy *auditv1.Policy, target rule.Target) []rule.CheckResult {
+	var checkResults []rule.CheckResult
+	hasCatchAll := false
+	for i, policyRule := range policy.Rules {
+		hasCatchAll = hasCatchAll || isCatchAll(policyRule)
+		for j := 0; j < i; j++ {
+			if shadows(policy.Rules[j], policyRule) {
+				details := fmt.Sprintf("%s is shadowed by %s", describe(i, policyRule), describe(j, policy.Rules[j]))
+				checkResults = append(checkResults, rule.FailedCheckResult("Policy rule is never matched because a previous rule matches all of its requests.", target.With("details", details)))
+				break
+			}
+		}
+	}
+
+	if !hasCatchAll {
+		checkResults = append(checkResults, rule.FailedCheckResult("Audit policy does not have a catch-all rule.", target.With("details", "Requests which do not match any rule are not logged.")))
+	}
+
+	if len(checkResults) == 0 {
+		return []rule.CheckResult{rule.PassedCheckResult("Audit policy has a catch-all rule and no shadowed rules.", target)}
+	}
+	return checkResults
+}
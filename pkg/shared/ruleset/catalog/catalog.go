@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+// Requirement is a requirement of a catalog which is checked by a rule, e.g. a control of a benchmark.
+type Requirement struct {
+	// ID is the id of the requirement which is the id of its rule.
+	ID string
+	// Name is the name of the rule of the requirement.
+	Name string
+	// DISARuleIDs are the ids of the DISA Kubernetes STIG rules which check the same requirements.
+	DISARuleIDs []string
+	// Justification explains why a requirement without implementation and DISA Kubernetes STIG rules is not checked.
+	Justification string
+	// Deviation describes how the DISA Kubernetes STIG rules check less than the requirement, e.g. a wider file mode.
+	// Passed check results of such rules are reported as Warning with the deviation.
+	Deviation string
+	// Component is the name of the component whose files are checked by the requirement, e.g. kube-scheduler.
+	// DISA Kubernetes STIG rules which also check the files of other components for other requirements
+	// report only the check results which do not belong to these other components.
+	Component string
+}
+
+// Catalog contains the requirements of a catalog, e.g. the controls of a benchmark. Requirements which
+// overlap with rules of the DISA Kubernetes STIG are checked by these rules.
+type Catalog struct {
+	// Kind is the kind of the requirements which is used in errors, e.g. control.
+	Kind string
+	// Document is the kind of the catalog which is used in errors, e.g. benchmark.
+	Document string
+	// Requirements are the requirements of the catalog.
+	Requirements []Requirement
+}
+
+// NewRule creates a rule which checks a requirement with the given rules
+// of other rulesets which check the same requirements.
+func NewRule(requirement Requirement, rules ...rule.Rule) *rule.CompositeRule {
+	if len(requirement.Deviation) > 0 {
+		deviationRules := make([]rule.Rule, 0, len(rules))
+		for _, r := range rules {
+			deviationRules = append(deviationRules, &deviationRule{Rule: r, deviation: requirement.Deviation})
+		}
+		rules = deviationRules
+	}
+	return rule.NewCompositeRule(requirement.ID, requirement.Name, rules...)
+}
+
+// deviationRule reports the passed check results of a rule which checks less
+// than a requirement as Warning, since they do not prove that the requirement is met.
+type deviationRule struct {
+	rule.Rule
+	deviation string
+}
+
+// Run runs the rule and replaces its passed check results with warnings.
+func (r *deviationRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	result, err := r.Rule.Run(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	checkResults := make([]rule.CheckResult, 0, len(result.CheckResults))
+	for _, checkResult := range result.CheckResults {
+		if checkResult.Status == rule.Passed {
+			checkResult = rule.WarningCheckResult(fmt.Sprintf("%s (%s)", checkResult.Message, r.deviation), checkResult.Target)
+		}
+		checkResults = append(checkResults, checkResult)
+	}
+	result.CheckResults = checkResults
+	return result, nil
+}
+
+// Rules returns the rules for the requirements. Requirements with an implementation in rules
+// are checked by it, requirements overlapping with DISA Kubernetes STIG rules are checked by the
+// rules of disaRules and all other requirements are not implemented with their justification.
+func (c Catalog) Rules(disaRules sharedruleset.RuleGetter, rules ...rule.Rule) ([]rule.Rule, error) {
+	var (
+		result           []rule.Rule
+		errs             []error
+		implemented      = make(map[string]rule.Rule, len(rules))
+		requirementIDs   = make(map[string]struct{}, len(c.Requirements))
+		disaRequirements []Requirement
+	)
+	for _, requirement := range c.Requirements {
+		requirementIDs[requirement.ID] = struct{}{}
+	}
+	for _, r := range rules {
+		if _, ok := requirementIDs[r.ID()]; !ok {
+			errs = append(errs, fmt.Errorf("rule %s is not a %s of the %s", r.ID(), c.Kind, c.Document))
+		}
+		implemented[r.ID()] = r
+	}
+	for _, requirement := range c.Requirements {
+		if _, ok := implemented[requirement.ID]; !ok && len(requirement.DISARuleIDs) > 0 {
+			disaRequirements = append(disaRequirements, requirement)
+		}
+	}
+
+	requirementRules, err := c.disaRules(disaRequirements, disaRules)
+	if err != nil {
+		return nil, errors.Join(append(errs, err)...)
+	}
+
+	for _, requirement := range c.Requirements {
+		r, ok := implemented[requirement.ID]
+		switch {
+		case ok:
+			result = append(result, r)
+		case len(requirement.DISARuleIDs) > 0:
+			result = append(result, NewRule(requirement, requirementRules[requirement.ID]...))
+		default:
+			result = append(result, rule.NewSkipRule(requirement.ID, requirement.Name, requirement.Justification, rule.NotImplemented))
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// disaRules returns the DISA Kubernetes STIG rules of the requirements by requirement id. Rules which check
+// several requirements run only once and report to every requirement only the check results which do not
+// belong to the components of the other requirements.
+func (c Catalog) disaRules(requirements []Requirement, disaRules sharedruleset.RuleGetter) (map[string][]rule.Rule, error) {
+	var (
+		errs             []error
+		requirementRules = make(map[string][]rule.Rule, len(requirements))
+		sharedBy         = map[string][]Requirement{}
+	)
+	for _, requirement := range requirements {
+		for _, id := range requirement.DISARuleIDs {
+			r, ok := disaRules.Rule(id)
+			if !ok {
+				errs = append(errs, fmt.Errorf("rule %s of %s %s is not registered in the DISA Kubernetes STIG ruleset", id, c.Kind, requirement.ID))
+				continue
+			}
+			// a rule can implement several DISA rules of a requirement
+			if slices.ContainsFunc(requirementRules[requirement.ID], func(other rule.Rule) bool { return other.ID() == r.ID() }) {
+				continue
+			}
+			requirementRules[requirement.ID] = append(requirementRules[requirement.ID], r)
+			sharedBy[r.ID()] = append(sharedBy[r.ID()], requirement)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	onceRules := map[string]*onceRule{}
+	for _, requirement := range requirements {
+		for i, r := range requirementRules[requirement.ID] {
+			if len(sharedBy[r.ID()]) < 2 {
+				continue
+			}
+			if _, ok := onceRules[r.ID()]; !ok {
+				onceRules[r.ID()] = &onceRule{Rule: r}
+			}
+
+			var otherComponents []string
+			for _, other := range sharedBy[r.ID()] {
+				if len(other.Component) > 0 && other.Component != requirement.Component && !slices.Contains(otherComponents, other.Component) {
+					otherComponents = append(otherComponents, other.Component)
+				}
+			}
+			if len(requirement.Component) == 0 || len(otherComponents) == 0 {
+				requirementRules[requirement.ID][i] = onceRules[r.ID()]
+				continue
+			}
+			requirementRules[requirement.ID][i] = &componentRule{Rule: onceRules[r.ID()], component: requirement.Component, otherComponents: otherComponents}
+		}
+	}
+	return requirementRules, nil
+}
+
+// onceRule runs a rule which checks several requirements only once and returns its result to all requirements.
+type onceRule struct {
+	rule.Rule
+	once   sync.Once
+	result rule.RuleResult
+	err    error
+}
+
+// Run runs the rule on the first call and returns the same result on all calls.
+func (r *onceRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	r.once.Do(func() {
+		r.result, r.err = r.Rule.Run(ctx)
+	})
+	return r.result, r.err
+}
+
+// componentRule reports only the check results of a rule which do not belong to other components
+// than the component of a requirement, e.g. the results of the kube-scheduler pods are not reported
+// for a requirement of the kube-apiserver.
+type componentRule struct {
+	rule.Rule
+	component       string
+	otherComponents []string
+}
+
+// Run runs the rule and drops the check results of the other components.
+func (r *componentRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	result, err := r.Rule.Run(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	checkResults := make([]rule.CheckResult, 0, len(result.CheckResults))
+	for _, checkResult := range result.CheckResults {
+		belongsToOther := slices.ContainsFunc(r.otherComponents, func(component string) bool {
+			return belongsTo(checkResult.Target, component)
+		})
+		if belongsToOther && !belongsTo(checkResult.Target, r.component) {
+			continue
+		}
+		checkResults = append(checkResults, checkResult)
+	}
+	result.CheckResults = checkResults
+	return result, nil
+}
+
+// belongsTo returns whether the target names an object of the component, e.g. a pod of its deployment.
+func belongsTo(target rule.Target, component string) bool {
+	return strings.Contains(target["name"], component)
+}
+
+// DISARuleOptions translates the rule options of requirements to the rule options of the
+// DISA Kubernetes STIG rules which check the requirements. Only the rule args are translated,
+// skipped requirements are handled by the ruleset of the catalog.
+func (c Catalog) DISARuleOptions(ruleOptions []config.RuleOptionsConfig) ([]config.RuleOptionsConfig, error) {
+	requirementsByID := make(map[string]Requirement, len(c.Requirements))
+	for _, requirement := range c.Requirements {
+		requirementsByID[requirement.ID] = requirement
+	}
+
+	var (
+		disaRuleOptions []config.RuleOptionsConfig
+		configuredBy    = map[string]string{}
+	)
+	for _, opt := range ruleOptions {
+		if opt.Args == nil {
+			continue
+		}
+
+		requirement, ok := requirementsByID[opt.RuleID]
+		if !ok {
+			continue
+		}
+
+		for _, id := range requirement.DISARuleIDs {
+			if other, ok := configuredBy[id]; ok {
+				return nil, fmt.Errorf("rule options of %ss %s and %s both configure args of rule %s", c.Kind, other, requirement.ID, id)
+			}
+			configuredBy[id] = requirement.ID
+			disaRuleOptions = append(disaRuleOptions, config.RuleOptionsConfig{RuleID: id, Args: opt.Args})
+		}
+	}
+	return disaRuleOptions, nil
+}
+
+// SkipRules replaces rules which cannot be checked by the provider
+// with rules that are skipped with the given justification.
+func SkipRules(rules []rule.Rule, justification string) []rule.Rule {
+	skipRules := make([]rule.Rule, 0, len(rules))
+	for _, r := range rules {
+		skipRules = append(skipRules, rule.NewSkipRule(r.ID(), r.Name(), justification, rule.Skipped))
+	}
+	return skipRules
+}
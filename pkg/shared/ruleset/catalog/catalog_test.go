@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+)
+
+type fakeRule struct {
+	id           string
+	checkResults []rule.CheckResult
+}
+
+func (r *fakeRule) ID() string   { return r.id }
+func (r *fakeRule) Name() string { return "fake " + r.id }
+func (r *fakeRule) Run(context.Context) (rule.RuleResult, error) {
+	return rule.RuleResult{RuleID: r.id, RuleName: r.Name(), CheckResults: r.checkResults}, nil
+}
+
+// countingRule counts how often it is run.
+type countingRule struct {
+	fakeRule
+	runs int
+}
+
+func (r *countingRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	r.runs++
+	return r.fakeRule.Run(ctx)
+}
+
+type fakeRuleGetter map[string]rule.Rule
+
+func (g fakeRuleGetter) Rule(id string) (rule.Rule, bool) {
+	r, ok := g[id]
+	return r, ok
+}
+
+var _ = Describe("catalog", func() {
+	var (
+		ctx       = context.TODO()
+		benchmark = catalog.Catalog{
+			Kind:     "control",
+			Document: "benchmark",
+			Requirements: []catalog.Requirement{
+				{ID: "1.1", Name: "Foo", DISARuleIDs: []string{"1", "2"}},
+				{ID: "1.2", Name: "Bar", Justification: "bar"},
+				{ID: "1.3", Name: "Baz"},
+			},
+		}
+		disaRules = fakeRuleGetter{
+			"1": &fakeRule{id: "1", checkResults: []rule.CheckResult{rule.PassedCheckResult("one", rule.NewTarget())}},
+			"2": &fakeRule{id: "2", checkResults: []rule.CheckResult{rule.FailedCheckResult("two", rule.NewTarget())}},
+		}
+	)
+
+	Describe("#NewRule", func() {
+		It("should return the check results of all rules of the requirement", func() {
+			r := catalog.NewRule(benchmark.Requirements[0], disaRules["1"], disaRules["2"])
+
+			Expect(r.ID()).To(Equal("1.1"))
+			Expect(r.Name()).To(Equal("Foo"))
+			Expect(r.Run(ctx)).To(Equal(rule.RuleResult{
+				RuleID:   "1.1",
+				RuleName: "Foo",
+				CheckResults: []rule.CheckResult{
+					rule.PassedCheckResult("one", rule.NewTarget()),
+					rule.FailedCheckResult("two", rule.NewTarget()),
+				},
+			}))
+		})
+
+		It("should report the passed check results of rules with a deviation as warnings", func() {
+			requirement := catalog.Requirement{ID: "1.4", Name: "Qux", DISARuleIDs: []string{"1", "2"}, Deviation: "checked against 644 instead of 600"}
+			r := catalog.NewRule(requirement, disaRules["1"], disaRules["2"])
+
+			Expect(r.Run(ctx)).To(Equal(rule.RuleResult{
+				RuleID:   "1.4",
+				RuleName: "Qux",
+				CheckResults: []rule.CheckResult{
+					rule.WarningCheckResult("one (checked against 644 instead of 600)", rule.NewTarget()),
+					rule.FailedCheckResult("two", rule.NewTarget()),
+				},
+			}))
+		})
+	})
+
+	Describe("#Rules", func() {
+		It("should return the rules of all requirements", func() {
+			implemented := &fakeRule{id: "1.3"}
+			rules, err := benchmark.Rules(disaRules, implemented)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rules).To(Equal([]rule.Rule{
+				catalog.NewRule(benchmark.Requirements[0], disaRules["1"], disaRules["2"]),
+				rule.NewSkipRule("1.2", "Bar", "bar", rule.NotImplemented),
+				implemented,
+			}))
+		})
+
+		It("should run DISA rules of several requirements only once", func() {
+			shared := &countingRule{fakeRule: fakeRule{id: "3", checkResults: []rule.CheckResult{rule.PassedCheckResult("three", rule.NewTarget())}}}
+			benchmark := catalog.Catalog{
+				Requirements: []catalog.Requirement{
+					{ID: "1.1", Name: "Foo", DISARuleIDs: []string{"3"}},
+					{ID: "1.2", Name: "Bar", DISARuleIDs: []string{"3", "4"}},
+				},
+			}
+			rules, err := benchmark.Rules(fakeRuleGetter{"3": shared, "4": shared})
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, r := range rules {
+				result, err := r.Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.CheckResults).To(Equal([]rule.CheckResult{rule.PassedCheckResult("three", rule.NewTarget())}))
+			}
+			Expect(shared.runs).To(Equal(1))
+		})
+
+		It("should report only the check results of the component of a requirement", func() {
+			var (
+				apiserverTarget = rule.NewTarget("name", "kube-apiserver-abc", "kind", "pod")
+				schedulerTarget = rule.NewTarget("name", "kube-scheduler-abc", "kind", "pod")
+				execPodTarget   = rule.NewTarget("name", "diki-3-abc", "kind", "pod")
+				shared          = &fakeRule{id: "3", checkResults: []rule.CheckResult{
+					rule.PassedCheckResult("apiserver", apiserverTarget),
+					rule.FailedCheckResult("scheduler", schedulerTarget),
+					rule.ErroredCheckResult("exec pod", execPodTarget),
+				}}
+				benchmark = catalog.Catalog{
+					Requirements: []catalog.Requirement{
+						{ID: "1.1", Name: "Foo", DISARuleIDs: []string{"3"}, Component: "kube-apiserver"},
+						{ID: "1.2", Name: "Bar", DISARuleIDs: []string{"3"}, Component: "kube-scheduler"},
+						{ID: "1.3", Name: "Baz", DISARuleIDs: []string{"3"}},
+					},
+				}
+			)
+			rules, err := benchmark.Rules(fakeRuleGetter{"3": shared})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rules).To(HaveLen(3))
+
+			Expect(rules[0].Run(ctx)).To(HaveField("CheckResults", Equal([]rule.CheckResult{
+				rule.PassedCheckResult("apiserver", apiserverTarget),
+				rule.ErroredCheckResult("exec pod", execPodTarget),
+			})))
+			Expect(rules[1].Run(ctx)).To(HaveField("CheckResults", Equal([]rule.CheckResult{
+				rule.FailedCheckResult("scheduler", schedulerTarget),
+				rule.ErroredCheckResult("exec pod", execPodTarget),
+			})))
+			Expect(rules[2].Run(ctx)).To(HaveField("CheckResults", Equal(shared.checkResults)))
+		})
+
+		It("should return an error for missing DISA rules and unknown requirements", func() {
+			_, err := benchmark.Rules(fakeRuleGetter{}, &fakeRule{id: "2.1"})
+			Expect(err).To(MatchError(And(
+				ContainSubstring("rule 2.1 is not a control of the benchmark"),
+				ContainSubstring("rule 1 of control 1.1 is not registered in the DISA Kubernetes STIG ruleset"),
+			)))
+		})
+	})
+
+	Describe("#DISARuleOptions", func() {
+		It("should translate the args of requirements to their DISA rules", func() {
+			args := map[string]any{"foo": "bar"}
+			disaRuleOptions, err := benchmark.DISARuleOptions([]config.RuleOptionsConfig{
+				{RuleID: "1.1", Args: args},
+				{RuleID: "1.2", Args: args},
+				{RuleID: "1.3", Skip: &config.RuleOptionSkipConfig{Enabled: true}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(disaRuleOptions).To(Equal([]config.RuleOptionsConfig{
+				{RuleID: "1", Args: args},
+				{RuleID: "2", Args: args},
+			}))
+		})
+
+		It("should return an error when multiple requirements configure the same DISA rule", func() {
+			benchmark := catalog.Catalog{
+				Kind:     "control",
+				Document: "benchmark",
+				Requirements: []catalog.Requirement{
+					{ID: "1.1", DISARuleIDs: []string{"1"}},
+					{ID: "1.4", DISARuleIDs: []string{"1"}},
+				},
+			}
+			_, err := benchmark.DISARuleOptions([]config.RuleOptionsConfig{
+				{RuleID: "1.1", Args: map[string]any{}},
+				{RuleID: "1.4", Args: map[string]any{}},
+			})
+			Expect(err).To(MatchError("rule options of controls 1.1 and 1.4 both configure args of rule 1"))
+		})
+	})
+
+	Describe("#SkipRules", func() {
+		It("should skip the rules with the justification", func() {
+			Expect(catalog.SkipRules([]rule.Rule{&fakeRule{id: "1.3"}}, "foo")).To(Equal([]rule.Rule{
+				rule.NewSkipRule("1.3", "fake 1.3", "foo", rule.Skipped),
+			}))
+		})
+	})
+})
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/certificates"
+)
+
+var _ = Describe("#cert-001", func() {
+	var (
+		ctx        = context.TODO()
+		fakeClient client.Client
+		sources    certificates.Sources
+
+		ca, valid, expiring, expired, future certificate
+	)
+
+	BeforeEach(func() {
+		now := time.Now()
+		ca = newCA("ca")
+		valid = newServer("valid", ca, "valid.default.svc")
+		expiring = newCertificate(&x509.Certificate{
+			Subject:  pkix.Name{CommonName: "expiring"},
+			NotAfter: now.Add(10 * 24 * time.Hour),
+		}, newECDSAKey(elliptic.P256()), &ca)
+		expired = newCertificate(&x509.Certificate{
+			Subject:   pkix.Name{CommonName: "expired"},
+			NotBefore: now.Add(-48 * time.Hour),
+			NotAfter:  now.Add(-24 * time.Hour),
+		}, newECDSAKey(elliptic.P256()), &ca)
+		future = newCertificate(&x509.Certificate{
+			Subject:   pkix.Name{CommonName: "future"},
+			NotBefore: now.Add(24 * time.Hour),
+		}, newECDSAKey(elliptic.P256()), &ca)
+
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		for _, secret := range []*corev1.Secret{
+			tlsSecret("valid", valid, nil),
+			tlsSecret("expiring", expiring, nil),
+			tlsSecret("expired", expired, nil),
+			tlsSecret("future", future, nil),
+		} {
+			Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		}
+		sources = certificates.Sources{
+			Secrets: []certificates.SecretSource{{Client: fakeClient, Namespace: "default", Target: rule.NewTarget("cluster", "shoot")}},
+		}
+	})
+
+	It("should pass when no certificates are found", func() {
+		sources.Secrets[0].Client = fakeclient.NewClientBuilder().Build()
+		r := &certificates.ExpiryRule{Sources: sources}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("No certificates found.", rule.NewTarget()),
+		}))
+	})
+
+	It("should report expired, expiring and not yet valid certificates", func() {
+		r := &certificates.ExpiryRule{Sources: sources}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("cert-001"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Certificate expired at "+expired.cert.NotAfter.UTC().Format(time.RFC3339)+".", secretTarget("expired", "expired")),
+			rule.FailedCheckResult("Certificate expires at "+expiring.cert.NotAfter.UTC().Format(time.RFC3339)+" which is within 720h0m0s.", secretTarget("expiring", "expiring")),
+			rule.WarningCheckResult("Certificate is not valid before "+future.cert.NotBefore.UTC().Format(time.RFC3339)+".", secretTarget("future", "future")),
+			rule.PassedCheckResult("Certificate is valid until "+valid.cert.NotAfter.UTC().Format(time.RFC3339)+".", secretTarget("valid", "valid")),
+		}))
+	})
+
+	It("should respect the expiry window", func() {
+		r := &certificates.ExpiryRule{Sources: sources, Options: &certificates.ExpiryOptions{ExpiryWindow: "24h"}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.PassedCheckResult("Certificate is valid until "+expiring.cert.NotAfter.UTC().Format(time.RFC3339)+".", secretTarget("expiring", "expiring")),
+		))
+	})
+
+	It("should accept the certificates selected by the rule options", func() {
+		r := &certificates.ExpiryRule{Sources: sources, Options: &certificates.ExpiryOptions{
+			AcceptedCertificates: []certificates.AcceptedCertificate{
+				{Name: "exp*", Namespace: "default"},
+				{Name: "future", Namespace: "other"},
+			},
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("Certificate expired at "+expired.cert.NotAfter.UTC().Format(time.RFC3339)+" and is accepted.", secretTarget("expired", "expired")),
+			rule.AcceptedCheckResult("Certificate expires at "+expiring.cert.NotAfter.UTC().Format(time.RFC3339)+" which is within 720h0m0s and is accepted.", secretTarget("expiring", "expiring")),
+			rule.WarningCheckResult("Certificate is not valid before "+future.cert.NotBefore.UTC().Format(time.RFC3339)+".", secretTarget("future", "future")),
+			rule.PassedCheckResult("Certificate is valid until "+valid.cert.NotAfter.UTC().Format(time.RFC3339)+".", secretTarget("valid", "valid")),
+		}))
+	})
+
+	It("should report secrets which do not contain certificates", func() {
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": []byte("foo")},
+		})).To(Succeed())
+		r := &certificates.ExpiryRule{Sources: sources}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.ErroredCheckResult("data does not contain PEM encoded certificates", rule.NewTarget("cluster", "shoot", "kind", "secret", "name", "broken", "namespace", "default", "key", "tls.crt")),
+		))
+	})
+
+	It("should error when the expiry window is invalid", func() {
+		r := &certificates.ExpiryRule{Sources: sources, Options: &certificates.ExpiryOptions{ExpiryWindow: "30d"}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult(`invalid expiry window: time: unknown unit "d" in duration "30d"`, rule.NewTarget()),
+		}))
+	})
+
+	It("should check the certificates referenced by the flags of components", func() {
+		seedTarget := rule.NewTarget("cluster", "seed")
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver-tls", Namespace: "default"},
+			Data:       map[string][]byte{"server.crt": valid.pem, "ca.crt": ca.pem},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:         "kube-apiserver",
+							Command:      []string{"kube-apiserver", "--tls-cert-file=/srv/tls/server.crt", "--client-ca-file=/srv/tls/ca.crt", "--etcd-certfile=/srv/etcd/tls.crt"},
+							VolumeMounts: []corev1.VolumeMount{{Name: "tls", MountPath: "/srv/tls"}},
+						}},
+						Volumes: []corev1.Volume{{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "kube-apiserver-tls"}}}},
+					},
+				},
+			},
+		})).To(Succeed())
+		r := &certificates.ExpiryRule{Sources: certificates.Sources{
+			Components: []certificates.ComponentSource{
+				{Client: fakeClient, Namespace: "default", DeploymentName: "kube-apiserver", ContainerName: "kube-apiserver", Target: seedTarget},
+				{Client: fakeClient, Namespace: "default", DeploymentName: "kube-apiserver", ContainerName: "foo", Target: seedTarget},
+			},
+		}}
+		componentTarget := seedTarget.With("kind", "deployment", "name", "kube-apiserver", "namespace", "default")
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Certificate is valid until "+valid.cert.NotAfter.UTC().Format(time.RFC3339)+".", componentTarget.With("container", "kube-apiserver", "flag", "tls-cert-file", "certificate", "valid")),
+			rule.PassedCheckResult("Certificate is valid until "+ca.cert.NotAfter.UTC().Format(time.RFC3339)+".", componentTarget.With("container", "kube-apiserver", "flag", "client-ca-file", "certificate", "ca")),
+			rule.ErroredCheckResult("cannot find volume with path /srv/etcd/tls.crt", componentTarget.With("container", "kube-apiserver", "flag", "etcd-certfile")),
+			rule.ErroredCheckResult("deployment: kube-apiserver does not contain container: foo", componentTarget),
+		}))
+	})
+
+	It("should return an error when the expiry window of the rule options is invalid", func() {
+		_, err := certificates.Rules(sources, map[string]config.RuleOptionsConfig{"cert-001": {Args: map[string]any{"expiryWindow": "30d"}}})
+		Expect(err).To(MatchError(`invalid options of rule cert-001: time: unknown unit "d" in duration "30d"`))
+	})
+})
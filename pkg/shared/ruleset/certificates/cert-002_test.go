@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/certificates"
+)
+
+var _ = Describe("#cert-002", func() {
+	var (
+		ctx     = context.TODO()
+		sources certificates.Sources
+	)
+
+	BeforeEach(func() {
+		ca := newCA("ca")
+		fakeClient := fakeclient.NewClientBuilder().Build()
+		for _, secret := range []*corev1.Secret{
+			tlsSecret("ecdsa-256", newServer("ecdsa-256", ca, "foo"), nil),
+			tlsSecret("ecdsa-224", newCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "ecdsa-224"}}, newECDSAKey(elliptic.P224()), &ca), nil),
+			tlsSecret("rsa-1024", newCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "rsa-1024"}}, newRSAKey(1024), &ca), nil),
+			tlsSecret("rsa-2048", newCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "rsa-2048"}}, newRSAKey(2048), &ca), nil),
+		} {
+			Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		}
+		sources = certificates.Sources{
+			Secrets: []certificates.SecretSource{{Client: fakeClient, Namespace: "default", Target: rule.NewTarget("cluster", "shoot")}},
+		}
+	})
+
+	It("should report certificates with small RSA and ECDSA keys", func() {
+		r := &certificates.KeyRule{Sources: sources}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("cert-002"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Certificate uses a 224 bit ECDSA key which is smaller than 256 bits.", secretTarget("ecdsa-224", "ecdsa-224")),
+			rule.PassedCheckResult("Certificate uses a strong key and signature algorithm.", secretTarget("ecdsa-256", "ecdsa-256")),
+			rule.FailedCheckResult("Certificate uses a 1024 bit RSA key which is smaller than 2048 bits.", secretTarget("rsa-1024", "rsa-1024")),
+			rule.PassedCheckResult("Certificate uses a strong key and signature algorithm.", secretTarget("rsa-2048", "rsa-2048")),
+		}))
+	})
+
+	It("should respect the minimum key sizes of the rule options", func() {
+		r := &certificates.KeyRule{Sources: sources, Options: &certificates.KeyOptions{MinRSAKeySize: 1024, MinECDSAKeySize: 384}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Certificate uses a 224 bit ECDSA key which is smaller than 384 bits.", secretTarget("ecdsa-224", "ecdsa-224")),
+			rule.FailedCheckResult("Certificate uses a 256 bit ECDSA key which is smaller than 384 bits.", secretTarget("ecdsa-256", "ecdsa-256")),
+			rule.PassedCheckResult("Certificate uses a strong key and signature algorithm.", secretTarget("rsa-1024", "rsa-1024")),
+			rule.PassedCheckResult("Certificate uses a strong key and signature algorithm.", secretTarget("rsa-2048", "rsa-2048")),
+		}))
+	})
+
+	It("should accept the certificates selected by the rule options", func() {
+		r := &certificates.KeyRule{Sources: sources, Options: &certificates.KeyOptions{
+			AcceptedCertificates: []certificates.AcceptedCertificate{{Name: "rsa-*", Justification: "legacy client"}},
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElements(
+			rule.FailedCheckResult("Certificate uses a 224 bit ECDSA key which is smaller than 256 bits.", secretTarget("ecdsa-224", "ecdsa-224")),
+			rule.AcceptedCheckResult("legacy client", secretTarget("rsa-1024", "rsa-1024")),
+		))
+	})
+})
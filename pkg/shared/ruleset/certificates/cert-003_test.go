@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/certificates"
+)
+
+var _ = Describe("#cert-003", func() {
+	var (
+		ctx     = context.TODO()
+		sources certificates.Sources
+	)
+
+	BeforeEach(func() {
+		ca := newCA("ca")
+		fakeClient := fakeclient.NewClientBuilder().Build()
+		for _, secret := range []*corev1.Secret{
+			tlsSecret("client", newCertificate(&x509.Certificate{
+				Subject:     pkix.Name{CommonName: "client"},
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			}, newECDSAKey(elliptic.P256()), &ca), nil),
+			tlsSecret("dns", newServer("dns", ca, "dns.default.svc"), nil),
+			tlsSecret("ip", newCertificate(&x509.Certificate{
+				Subject:     pkix.Name{CommonName: "ip"},
+				IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			}, newECDSAKey(elliptic.P256()), &ca), nil),
+			tlsSecret("no-san", newServer("no-san", ca), nil),
+		} {
+			Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		}
+		sources = certificates.Sources{
+			Secrets: []certificates.SecretSource{{Client: fakeClient, Namespace: "default", Target: rule.NewTarget("cluster", "shoot")}},
+		}
+	})
+
+	It("should report server certificates without subject alternative names", func() {
+		r := &certificates.SubjectAlternativeNameRule{Sources: sources}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("cert-003"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Certificate is not a server certificate.", secretTarget("client", "client")),
+			rule.PassedCheckResult("Server certificate has subject alternative names.", secretTarget("dns", "dns")),
+			rule.PassedCheckResult("Server certificate has subject alternative names.", secretTarget("ip", "ip")),
+			rule.FailedCheckResult("Server certificate does not have subject alternative names.", secretTarget("no-san", "no-san")),
+		}))
+	})
+
+	It("should accept the certificates selected by the rule options", func() {
+		r := &certificates.SubjectAlternativeNameRule{Sources: sources, Options: &certificates.Options{
+			AcceptedCertificates: []certificates.AcceptedCertificate{{Name: "no-san", Justification: "internal only"}},
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.AcceptedCheckResult("internal only", secretTarget("no-san", "no-san")),
+		))
+	})
+})
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/certificates"
+)
+
+var _ = Describe("#cert-004", func() {
+	var (
+		ctx     = context.TODO()
+		sources certificates.Sources
+	)
+
+	BeforeEach(func() {
+		var (
+			ca           = newCA("ca")
+			intermediate = newCertificate(&x509.Certificate{
+				Subject:               pkix.Name{CommonName: "intermediate"},
+				IsCA:                  true,
+				BasicConstraintsValid: true,
+				KeyUsage:              x509.KeyUsageCertSign,
+			}, newECDSAKey(elliptic.P256()), &ca)
+			server  = newServer("server", ca, "server.default.svc")
+			leaf    = newServer("leaf", intermediate, "leaf.default.svc")
+			foreign = newServer("foreign", newCA("other-ca"), "foreign.default.svc")
+			caData  = map[string][]byte{"ca.crt": ca.pem}
+		)
+
+		fakeClient := fakeclient.NewClientBuilder().Build()
+		for _, secret := range []*corev1.Secret{
+			tlsSecret("server", server, caData),
+			tlsSecret("foreign", foreign, caData),
+			tlsSecret("chain", certificate{pem: append(append([]byte{}, leaf.pem...), intermediate.pem...)}, caData),
+			tlsSecret("incomplete-chain", leaf, caData),
+			tlsSecret("without-ca", certificate{pem: append(append([]byte{}, server.pem...), ca.pem...)}, nil),
+			tlsSecret("unordered", certificate{pem: append(append([]byte{}, leaf.pem...), ca.pem...)}, nil),
+			tlsSecret("single", server, nil),
+		} {
+			Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		}
+		sources = certificates.Sources{
+			Secrets: []certificates.SecretSource{{Client: fakeClient, Namespace: "default", Target: rule.NewTarget("cluster", "shoot")}},
+		}
+	})
+
+	It("should verify the chains with the CA bundle or their order without it", func() {
+		r := &certificates.ChainRule{Sources: sources}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("cert-004"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Certificate chain is verified by the CA bundle.", secretTarget("chain", "leaf")),
+			rule.FailedCheckResult("Certificate chain cannot be verified: x509: certificate signed by unknown authority.", secretTarget("foreign", "foreign")),
+			rule.FailedCheckResult("Certificate chain cannot be verified: x509: certificate signed by unknown authority.", secretTarget("incomplete-chain", "leaf")),
+			rule.PassedCheckResult("Certificate chain is verified by the CA bundle.", secretTarget("server", "server")),
+			rule.WarningCheckResult("Certificate chain cannot be verified without a CA bundle.", secretTarget("single", "server")),
+			rule.FailedCheckResult("Certificate leaf is not signed by the next certificate ca of the chain.", secretTarget("unordered", "leaf")),
+			rule.WarningCheckResult("Certificate chain is ordered but cannot be verified without a CA bundle.", secretTarget("without-ca", "server")),
+		}))
+	})
+
+	It("should accept the certificates selected by the rule options", func() {
+		r := &certificates.ChainRule{Sources: sources, Options: &certificates.Options{
+			AcceptedCertificates: []certificates.AcceptedCertificate{{Name: "foreign", Namespace: "default", Justification: "signed by an external CA"}},
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.AcceptedCheckResult("signed by an external CA", secretTarget("foreign", "foreign")),
+		))
+	})
+})
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+func TestCertificates(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Certificate Inventory and Expiry Ruleset Test Suite")
+}
+
+type certificate struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+	pem  []byte
+}
+
+func newECDSAKey(curve elliptic.Curve) crypto.Signer {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	return key
+}
+
+func newRSAKey(bits int) crypto.Signer {
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	Expect(err).ToNot(HaveOccurred())
+	return key
+}
+
+// newCertificate creates a certificate with the key which is signed by the parent or is self-signed if the parent is nil.
+// The certificate is valid for a year unless the template sets its validity period.
+func newCertificate(template *x509.Certificate, key crypto.Signer, parent *certificate) certificate {
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+	}
+	if template.NotAfter.IsZero() {
+		template.NotAfter = time.Now().Add(365 * 24 * time.Hour)
+	}
+
+	signerCert, signerKey := template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, key.Public(), signerKey)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+	return certificate{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func newCA(name string) certificate {
+	return newCertificate(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, newECDSAKey(elliptic.P256()), nil)
+}
+
+func newServer(name string, ca certificate, dnsNames ...string) certificate {
+	return newCertificate(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		DNSNames:    dnsNames,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, newECDSAKey(elliptic.P256()), &ca)
+}
+
+// tlsSecret creates a secret of type tls in the default namespace with the data and the certificate as tls.crt.
+func tlsSecret(name string, cert certificate, data map[string][]byte) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": cert.pem},
+	}
+	for key, value := range data {
+		secret.Data[key] = value
+	}
+	return secret
+}
+
+// secretTarget returns the target of a certificate of the tls.crt key of a secret in the default namespace.
+func secretTarget(name, cert string) rule.Target {
+	return rule.NewTarget("cluster", "shoot", "kind", "secret", "name", name, "namespace", "default", "key", "tls.crt", "certificate", cert)
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// History contains the rules of all Certificate Inventory and Expiry versions.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1",
+		Rules:   []string{"cert-001", "cert-002", "cert-003", "cert-004"},
+	},
+)
@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// certificateFlag is a flag of a component which references a certificate file.
+type certificateFlag struct {
+	flag string
+	// caFlag is the flag which references the CA bundle used to verify the certificate.
+	caFlag string
+}
+
+// certificateFlags are the flags of the Kubernetes control plane components which reference certificate files.
+var certificateFlags = []certificateFlag{
+	{flag: "tls-cert-file"},
+	{flag: "client-ca-file"},
+	{flag: "etcd-certfile", caFlag: "etcd-cafile"},
+	{flag: "etcd-cafile"},
+	{flag: "kubelet-client-certificate"},
+	{flag: "kubelet-certificate-authority"},
+	{flag: "proxy-client-cert-file", caFlag: "requestheader-client-ca-file"},
+	{flag: "requestheader-client-ca-file"},
+	{flag: "root-ca-file"},
+	{flag: "cluster-signing-cert-file"},
+}
+
+// SecretSource selects the secrets of a cluster which contain certificates. Secrets of type
+// kubernetes.io/tls and all other secrets with a tls.crt key are checked. All namespaces are
+// selected when Namespace is empty.
+type SecretSource struct {
+	Client    client.Client
+	Namespace string
+	// Target describes the cluster of the secrets, e.g. cluster=shoot.
+	Target rule.Target
+}
+
+// ComponentSource selects a deployment of a control plane component. The certificate
+// files referenced by the flags of its container are read from the mounted volumes.
+type ComponentSource struct {
+	Client         client.Client
+	Namespace      string
+	DeploymentName string
+	ContainerName  string
+	// Target describes the cluster of the component, e.g. cluster=seed.
+	Target rule.Target
+}
+
+// entry is a certificate file or a certificate key of a secret together with the CA
+// certificates which are used to verify it.
+type entry struct {
+	name         string
+	namespace    string
+	target       rule.Target
+	certificates []*x509.Certificate
+	roots        []*x509.Certificate
+	err          error
+}
+
+// leaf returns the first certificate of the entry when it is not a CA certificate.
+func (e entry) leaf() (*x509.Certificate, bool) {
+	if len(e.certificates) == 0 || e.certificates[0].IsCA {
+		return nil, false
+	}
+	return e.certificates[0], true
+}
+
+// certificateTarget returns the target of a certificate of the entry.
+func (e entry) certificateTarget(cert *x509.Certificate) rule.Target {
+	return e.target.With("certificate", certificateName(cert))
+}
+
+// certificateName returns the common name of the certificate or its serial number if the common name is empty.
+func certificateName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return "serial " + hex.EncodeToString(cert.SerialNumber.Bytes())
+}
+
+// parseCertificates parses all PEM encoded certificates of the data.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, cert)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("data does not contain PEM encoded certificates")
+	}
+	return certificates, nil
+}
+
+// loadEntries returns the certificate entries of all sources. Sources which cannot
+// be read are returned as entries with an error.
+func loadEntries(ctx context.Context, sources Sources) []entry {
+	var entries []entry
+	for _, source := range sources.Secrets {
+		entries = append(entries, secretEntries(ctx, source)...)
+	}
+	for _, source := range sources.Components {
+		entries = append(entries, componentEntries(ctx, source)...)
+	}
+	return entries
+}
+
+func secretEntries(ctx context.Context, source SecretSource) []entry {
+	secrets, err := kubeutils.GetSecrets(ctx, source.Client, source.Namespace, labels.NewSelector(), 300)
+	if err != nil {
+		target := rule.NewTarget("kind", "secretList")
+		maps.Copy(target, source.Target)
+		if source.Namespace != "" {
+			target = target.With("namespace", source.Namespace)
+		}
+		return []entry{{namespace: source.Namespace, target: target, err: err}}
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Namespace != secrets[j].Namespace {
+			return secrets[i].Namespace < secrets[j].Namespace
+		}
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	var entries []entry
+	for _, secret := range secrets {
+		data, ok := secret.Data[corev1.TLSCertKey]
+		if !ok && secret.Type != corev1.SecretTypeTLS {
+			continue
+		}
+
+		target := rule.NewTarget("kind", "secret", "name", secret.Name, "namespace", secret.Namespace, "key", corev1.TLSCertKey)
+		maps.Copy(target, source.Target)
+		e := entry{name: secret.Name, namespace: secret.Namespace, target: target}
+		if e.certificates, e.err = parseCertificates(data); e.err == nil {
+			if caData, ok := secret.Data["ca.crt"]; ok && len(caData) > 0 {
+				e.roots, e.err = parseCertificates(caData)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func componentEntries(ctx context.Context, source ComponentSource) []entry {
+	target := rule.NewTarget("kind", "deployment", "name", source.DeploymentName, "namespace", source.Namespace)
+	maps.Copy(target, source.Target)
+
+	deployment := &appsv1.Deployment{}
+	if err := source.Client.Get(ctx, client.ObjectKey{Name: source.DeploymentName, Namespace: source.Namespace}, deployment); err != nil {
+		return []entry{{name: source.DeploymentName, namespace: source.Namespace, target: target, err: err}}
+	}
+
+	container, found := kubeutils.GetContainerFromDeployment(deployment, source.ContainerName)
+	if !found {
+		err := fmt.Errorf("deployment: %s does not contain container: %s", source.DeploymentName, source.ContainerName)
+		return []entry{{name: source.DeploymentName, namespace: source.Namespace, target: target, err: err}}
+	}
+	command := append(container.Command, container.Args...)
+
+	readFile := func(flag string) ([]*x509.Certificate, bool, error) {
+		values := kubeutils.FindFlagValueRaw(command, flag)
+		if len(values) == 0 || values[0] == "" {
+			return nil, false, nil
+		}
+		data, err := kubeutils.GetVolumeConfigByteSliceByMountPath(ctx, source.Client, deployment, source.ContainerName, values[0])
+		if err != nil {
+			return nil, true, err
+		}
+		certificates, err := parseCertificates(data)
+		return certificates, true, err
+	}
+
+	var entries []entry
+	for _, f := range certificateFlags {
+		certificates, set, err := readFile(f.flag)
+		if !set {
+			continue
+		}
+
+		e := entry{
+			name:         source.DeploymentName,
+			namespace:    source.Namespace,
+			target:       target.With("container", source.ContainerName, "flag", f.flag),
+			certificates: certificates,
+			err:          err,
+		}
+		if e.err == nil && f.caFlag != "" {
+			e.roots, _, e.err = readFile(f.caFlag)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
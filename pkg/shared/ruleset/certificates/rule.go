@@ -0,0 +1,388 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"context"
+	"crypto/dsa" //nolint:staticcheck // DSA keys are only detected to be reported
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// Options are the rule options of the certificate rules.
+type Options struct {
+	AcceptedCertificates []AcceptedCertificate `json:"acceptedCertificates" yaml:"acceptedCertificates"`
+}
+
+// AcceptedCertificate selects the secrets or components whose certificates are accepted to violate a rule.
+type AcceptedCertificate struct {
+	// Name is a shell file name pattern matched against the name of the secret or deployment.
+	Name string `json:"name" yaml:"name"`
+	// Namespace is a shell file name pattern matched against the namespace. All namespaces are matched when it is empty.
+	Namespace     string `json:"namespace" yaml:"namespace"`
+	Justification string `json:"justification" yaml:"justification"`
+}
+
+func (o *Options) acceptedCertificates() []AcceptedCertificate {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedCertificates
+}
+
+func accepted(acceptedCertificates []AcceptedCertificate, e entry) (bool, string) {
+	for _, acceptedCertificate := range acceptedCertificates {
+		if ok, _ := path.Match(acceptedCertificate.Name, e.name); !ok {
+			continue
+		}
+		if ok, _ := path.Match(acceptedCertificate.Namespace, e.namespace); ok || acceptedCertificate.Namespace == "" {
+			return true, acceptedCertificate.Justification
+		}
+	}
+	return false, ""
+}
+
+// ExpiryOptions are the rule options of the rule which checks the validity period of certificates.
+type ExpiryOptions struct {
+	// ExpiryWindow is the duration before the expiry of a certificate in which it is reported, e.g. 720h.
+	ExpiryWindow         string                `json:"expiryWindow" yaml:"expiryWindow"`
+	AcceptedCertificates []AcceptedCertificate `json:"acceptedCertificates" yaml:"acceptedCertificates"`
+}
+
+// defaultExpiryWindow is the expiry window of certificates when it is not configured.
+const defaultExpiryWindow = 30 * 24 * time.Hour
+
+// expiryWindow returns the configured expiry window or the default expiry window.
+func (o *ExpiryOptions) expiryWindow() (time.Duration, error) {
+	if o == nil || o.ExpiryWindow == "" {
+		return defaultExpiryWindow, nil
+	}
+	return time.ParseDuration(o.ExpiryWindow)
+}
+
+func (o *ExpiryOptions) acceptedCertificates() []AcceptedCertificate {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedCertificates
+}
+
+// KeyOptions are the rule options of the rule which checks the keys and signature algorithms of certificates.
+type KeyOptions struct {
+	// MinRSAKeySize is the minimum size of RSA keys in bits. Defaults to 2048.
+	MinRSAKeySize int `json:"minRSAKeySize" yaml:"minRSAKeySize"`
+	// MinECDSAKeySize is the minimum size of ECDSA keys in bits. Defaults to 256.
+	MinECDSAKeySize      int                   `json:"minECDSAKeySize" yaml:"minECDSAKeySize"`
+	AcceptedCertificates []AcceptedCertificate `json:"acceptedCertificates" yaml:"acceptedCertificates"`
+}
+
+func (o *KeyOptions) minRSAKeySize() int {
+	if o == nil || o.MinRSAKeySize == 0 {
+		return 2048
+	}
+	return o.MinRSAKeySize
+}
+
+func (o *KeyOptions) minECDSAKeySize() int {
+	if o == nil || o.MinECDSAKeySize == 0 {
+		return 256
+	}
+	return o.MinECDSAKeySize
+}
+
+func (o *KeyOptions) acceptedCertificates() []AcceptedCertificate {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedCertificates
+}
+
+// finding is the result of a check of a certificate entry. The certificate
+// is nil when the finding concerns the entry as a whole, e.g. its chain.
+type finding struct {
+	certificate *x509.Certificate
+	status      rule.Status
+	message     string
+}
+
+// checkCertificates checks every certificate of the secrets and components of the sources. The check results
+// are reported per certificate or, for chain checks, per secret key or component flag. check returns the findings
+// of a certificate entry.
+func checkCertificates(ctx context.Context, r rule.Rule, sources Sources, check func(entry) []finding, acceptedCertificates []AcceptedCertificate) rule.RuleResult {
+	entries := loadEntries(ctx, sources)
+	if len(entries) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("No certificates found.", rule.NewTarget()))
+	}
+
+	var checkResults []rule.CheckResult
+	for _, e := range entries {
+		if e.err != nil {
+			checkResults = append(checkResults, rule.ErroredCheckResult(e.err.Error(), e.target))
+			continue
+		}
+
+		for _, f := range check(e) {
+			target := e.target
+			if f.certificate != nil {
+				target = e.certificateTarget(f.certificate)
+			}
+
+			if f.status == rule.Failed {
+				if ok, justification := accepted(acceptedCertificates, e); ok {
+					if justification == "" {
+						justification = strings.TrimSuffix(f.message, ".") + " and is accepted."
+					}
+					checkResults = append(checkResults, rule.AcceptedCheckResult(justification, target))
+					continue
+				}
+			}
+			checkResults = append(checkResults, rule.CheckResult{Status: f.status, Message: f.message, Target: target})
+		}
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}
+}
+
+var _ rule.Rule = &ExpiryRule{}
+
+// ExpiryRule checks that certificates are not expired and do not expire within the expiry window.
+type ExpiryRule struct {
+	Sources Sources
+	Options *ExpiryOptions
+}
+
+// ID returns the id of the rule.
+func (r *ExpiryRule) ID() string {
+	return "cert-001"
+}
+
+// Name returns the name of the rule.
+func (r *ExpiryRule) Name() string {
+	return "Certificates must not be expired or expire soon (HIGH cert-001)"
+}
+
+// Run checks the validity period of all certificates.
+func (r *ExpiryRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	window, err := r.Options.expiryWindow()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(fmt.Sprintf("invalid expiry window: %s", err), rule.NewTarget())), nil
+	}
+	return checkCertificates(ctx, r, r.Sources, expiryCheck(time.Now, window), r.Options.acceptedCertificates()), nil
+}
+
+var _ rule.Rule = &KeyRule{}
+
+// KeyRule checks that certificates use strong keys and signature algorithms.
+type KeyRule struct {
+	Sources Sources
+	Options *KeyOptions
+}
+
+// ID returns the id of the rule.
+func (r *KeyRule) ID() string {
+	return "cert-002"
+}
+
+// Name returns the name of the rule.
+func (r *KeyRule) Name() string {
+	return "Certificates must use strong keys and signature algorithms (HIGH cert-002)"
+}
+
+// Run checks the keys and signature algorithms of all certificates.
+func (r *KeyRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkCertificates(ctx, r, r.Sources, keyCheck(r.Options), r.Options.acceptedCertificates()), nil
+}
+
+var _ rule.Rule = &SubjectAlternativeNameRule{}
+
+// SubjectAlternativeNameRule checks that server certificates have subject alternative names.
+type SubjectAlternativeNameRule struct {
+	Sources Sources
+	Options *Options
+}
+
+// ID returns the id of the rule.
+func (r *SubjectAlternativeNameRule) ID() string {
+	return "cert-003"
+}
+
+// Name returns the name of the rule.
+func (r *SubjectAlternativeNameRule) Name() string {
+	return "Server certificates must have subject alternative names (MEDIUM cert-003)"
+}
+
+// Run checks the subject alternative names of the leaf certificates.
+func (r *SubjectAlternativeNameRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkCertificates(ctx, r, r.Sources, subjectAlternativeNameCheck, r.Options.acceptedCertificates()), nil
+}
+
+var _ rule.Rule = &ChainRule{}
+
+// ChainRule checks that certificate chains are complete and can be verified.
+type ChainRule struct {
+	Sources Sources
+	Options *Options
+}
+
+// ID returns the id of the rule.
+func (r *ChainRule) ID() string {
+	return "cert-004"
+}
+
+// Name returns the name of the rule.
+func (r *ChainRule) Name() string {
+	return "Certificate chains must be complete and verifiable (MEDIUM cert-004)"
+}
+
+// Run checks the chains of the leaf certificates.
+func (r *ChainRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkCertificates(ctx, r, r.Sources, chainCheck(time.Now), r.Options.acceptedCertificates()), nil
+}
+
+func expiryCheck(now func() time.Time, window time.Duration) func(entry) []finding {
+	return func(e entry) []finding {
+		findings := make([]finding, 0, len(e.certificates))
+		for _, cert := range e.certificates {
+			t := now()
+			switch {
+			case t.After(cert.NotAfter):
+				msg := fmt.Sprintf("Certificate expired at %s.", cert.NotAfter.UTC().Format(time.RFC3339))
+				findings = append(findings, finding{certificate: cert, status: rule.Failed, message: msg})
+			case t.Before(cert.NotBefore):
+				msg := fmt.Sprintf("Certificate is not valid before %s.", cert.NotBefore.UTC().Format(time.RFC3339))
+				findings = append(findings, finding{certificate: cert, status: rule.Warning, message: msg})
+			case t.Add(window).After(cert.NotAfter):
+				msg := fmt.Sprintf("Certificate expires at %s which is within %s.", cert.NotAfter.UTC().Format(time.RFC3339), window)
+				findings = append(findings, finding{certificate: cert, status: rule.Failed, message: msg})
+			default:
+				msg := fmt.Sprintf("Certificate is valid until %s.", cert.NotAfter.UTC().Format(time.RFC3339))
+				findings = append(findings, finding{certificate: cert, status: rule.Passed, message: msg})
+			}
+		}
+		return findings
+	}
+}
+
+// weakSignatureAlgorithms are the signature algorithms which use MD5 or SHA-1 hashes.
+var weakSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.MD2WithRSA:    true,
+	x509.MD5WithRSA:    true,
+	x509.SHA1WithRSA:   true,
+	x509.DSAWithSHA1:   true,
+	x509.ECDSAWithSHA1: true,
+}
+
+func keyCheck(options *KeyOptions) func(entry) []finding {
+	minRSAKeySize, minECDSAKeySize := options.minRSAKeySize(), options.minECDSAKeySize()
+	return func(e entry) []finding {
+		findings := make([]finding, 0, len(e.certificates))
+		for _, cert := range e.certificates {
+			var violations []string
+			switch key := cert.PublicKey.(type) {
+			case *rsa.PublicKey:
+				if size := key.N.BitLen(); size < minRSAKeySize {
+					violations = append(violations, fmt.Sprintf("uses a %d bit RSA key which is smaller than %d bits", size, minRSAKeySize))
+				}
+			case *ecdsa.PublicKey:
+				if size := key.Curve.Params().BitSize; size < minECDSAKeySize {
+					violations = append(violations, fmt.Sprintf("uses a %d bit ECDSA key which is smaller than %d bits", size, minECDSAKeySize))
+				}
+			case *dsa.PublicKey:
+				violations = append(violations, "uses a DSA key")
+			}
+
+			// the signatures of self-signed roots are not verified by clients
+			selfSigned := cert.IsCA && cert.CheckSignatureFrom(cert) == nil
+			if weakSignatureAlgorithms[cert.SignatureAlgorithm] && !selfSigned {
+				violations = append(violations, fmt.Sprintf("is signed with weak algorithm %s", cert.SignatureAlgorithm))
+			}
+
+			if len(violations) == 0 {
+				findings = append(findings, finding{certificate: cert, status: rule.Passed, message: "Certificate uses a strong key and signature algorithm."})
+				continue
+			}
+			msg := fmt.Sprintf("Certificate %s.", strings.Join(violations, " and "))
+			findings = append(findings, finding{certificate: cert, status: rule.Failed, message: msg})
+		}
+		return findings
+	}
+}
+
+func subjectAlternativeNameCheck(e entry) []finding {
+	cert, ok := e.leaf()
+	if !ok {
+		return nil
+	}
+
+	isServer := len(cert.ExtKeyUsage) == 0
+	for _, usage := range cert.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageServerAuth || usage == x509.ExtKeyUsageAny {
+			isServer = true
+		}
+	}
+	if !isServer {
+		return []finding{{certificate: cert, status: rule.Passed, message: "Certificate is not a server certificate."}}
+	}
+
+	if len(cert.DNSNames) == 0 && len(cert.IPAddresses) == 0 && len(cert.URIs) == 0 && len(cert.EmailAddresses) == 0 {
+		return []finding{{certificate: cert, status: rule.Failed, message: "Server certificate does not have subject alternative names."}}
+	}
+	return []finding{{certificate: cert, status: rule.Passed, message: "Server certificate has subject alternative names."}}
+}
+
+func chainCheck(now func() time.Time) func(entry) []finding {
+	return func(e entry) []finding {
+		cert, ok := e.leaf()
+		if !ok {
+			return nil
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, c := range e.certificates[1:] {
+			intermediates.AddCert(c)
+		}
+
+		if len(e.roots) > 0 {
+			roots := x509.NewCertPool()
+			for _, c := range e.roots {
+				roots.AddCert(c)
+			}
+
+			_, err := cert.Verify(x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: intermediates,
+				CurrentTime:   now(),
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			})
+			if err != nil {
+				return []finding{{certificate: cert, status: rule.Failed, message: fmt.Sprintf("Certificate chain cannot be verified: %s.", err)}}
+			}
+			return []finding{{certificate: cert, status: rule.Passed, message: "Certificate chain is verified by the CA bundle."}}
+		}
+
+		if len(e.certificates) == 1 {
+			return []finding{{certificate: cert, status: rule.Warning, message: "Certificate chain cannot be verified without a CA bundle."}}
+		}
+
+		for i := 1; i < len(e.certificates); i++ {
+			if err := e.certificates[i-1].CheckSignatureFrom(e.certificates[i]); err != nil {
+				msg := fmt.Sprintf("Certificate %s is not signed by the next certificate %s of the chain.", certificateName(e.certificates[i-1]), certificateName(e.certificates[i]))
+				return []finding{{certificate: cert, status: rule.Failed, message: msg}}
+			}
+		}
+		return []finding{{certificate: cert, status: rule.Warning, message: "Certificate chain is ordered but cannot be verified without a CA bundle."}}
+	}
+}
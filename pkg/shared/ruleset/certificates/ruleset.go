@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of a Certificate Inventory and Expiry Ruleset
+	RulesetID = "certificate-inventory"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset checks the certificates of the TLS secrets and control plane components of a cluster.
+type Ruleset struct {
+	version    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// Sources are the secrets and components whose certificates are checked.
+type Sources struct {
+	Secrets    []SecretSource
+	Components []ComponentSource
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "Certificate Inventory and Expiry"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules check the certificates of the sources.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, sources Sources) (*Ruleset, error) {
+	ruleset, err := New(WithVersion(rulesetConfig.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	rules, err := Rules(sources, ruleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// Rules creates the rules of the ruleset which check the certificates of the sources.
+func Rules(sources Sources, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	expiryOptions, err := parseOptions[ExpiryOptions](ruleOptions["cert-001"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule cert-001: %w", err)
+	}
+	if _, err := expiryOptions.expiryWindow(); err != nil {
+		return nil, fmt.Errorf("invalid options of rule cert-001: %w", err)
+	}
+
+	keyOptions, err := parseOptions[KeyOptions](ruleOptions["cert-002"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule cert-002: %w", err)
+	}
+
+	options := map[string]*Options{}
+	for _, id := range []string{"cert-003", "cert-004"} {
+		opts, err := parseOptions[Options](ruleOptions[id].Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse options of rule %s: %w", id, err)
+		}
+		options[id] = opts
+	}
+
+	return []rule.Rule{
+		&ExpiryRule{Sources: sources, Options: expiryOptions},
+		&KeyRule{Sources: sources, Options: keyOptions},
+		&SubjectAlternativeNameRule{Sources: sources, Options: options["cert-003"]},
+		&ChainRule{Sources: sources, Options: options["cert-004"]},
+	}, nil
+}
+
+func parseOptions[O any](args any) (*O, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options O
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// ProfileLevel1 is the profile which contains the Level 1 controls.
+	ProfileLevel1 = "level-1"
+	// ProfileLevel2 is the profile which contains the Level 1 and Level 2 controls.
+	ProfileLevel2 = "level-2"
+)
+
+// Args are the arguments of a CIS Kubernetes Benchmark [Ruleset].
+type Args struct {
+	// Profile is the profile whose controls are checked.
+	// Defaults to [ProfileLevel1].
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+}
+
+// ParseArgs parses the generic ruleset arguments into [Args].
+func ParseArgs(args any) (*Args, error) {
+	parsedArgs := Args{}
+	if args != nil {
+		argsByte, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(argsByte, &parsedArgs); err != nil {
+			return nil, fmt.Errorf("failed to parse ruleset args: %w", err)
+		}
+	}
+
+	switch parsedArgs.Profile {
+	case "":
+		parsedArgs.Profile = ProfileLevel1
+	case ProfileLevel1, ProfileLevel2:
+	default:
+		return nil, fmt.Errorf("unknown profile %s, supported profiles are %s and %s", parsedArgs.Profile, ProfileLevel1, ProfileLevel2)
+	}
+	return &parsedArgs, nil
+}
+
+// Level returns the highest control level of the profile.
+func (a *Args) Level() Level {
+	if a.Profile == ProfileLevel2 {
+		return Level2
+	}
+	return Level1
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+)
+
+var _ = Describe("args", func() {
+	Describe("#ParseArgs", func() {
+		It("should default to the level-1 profile", func() {
+			args, err := cisk8s.ParseArgs(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args.Profile).To(Equal(cisk8s.ProfileLevel1))
+			Expect(args.Level()).To(Equal(cisk8s.Level1))
+		})
+
+		It("should parse the profile", func() {
+			args, err := cisk8s.ParseArgs(map[string]any{"profile": "level-2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(args.Profile).To(Equal(cisk8s.ProfileLevel2))
+			Expect(args.Level()).To(Equal(cisk8s.Level2))
+		})
+
+		It("should return an error for unknown profiles", func() {
+			_, err := cisk8s.ParseArgs(map[string]any{"profile": "level-3"})
+			Expect(err).To(MatchError("unknown profile level-3, supported profiles are level-1 and level-2"))
+		})
+	})
+})
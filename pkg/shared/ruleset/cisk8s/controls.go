@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"fmt"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+)
+
+// Level is the profile level of a CIS control.
+type Level int
+
+const (
+	// Level1 controls are practical and prudent and do not inhibit the utility of the technology.
+	Level1 Level = 1
+	// Level2 controls are intended for environments where security is paramount.
+	Level2 Level = 2
+)
+
+// Control is a recommendation of the CIS Kubernetes Benchmark.
+type Control struct {
+	// ID is the section number of the control, e.g. 1.2.1.
+	ID string
+	// Title is the title of the control.
+	Title string
+	// Level is the lowest profile level which contains the control.
+	Level Level
+	// DISARuleIDs are the ids of the DISA Kubernetes STIG rules which check the same requirements.
+	DISARuleIDs []string
+	// Justification explains why a control without implementation and DISA Kubernetes STIG rules is not checked.
+	Justification string
+	// Deviation describes how the DISA Kubernetes STIG rules check less than the control.
+	// Passed check results of the DISA rules are reported as Warning with the deviation.
+	Deviation string
+	// Component is the name of the component whose files are checked by the control, e.g. kube-scheduler.
+	// DISA Kubernetes STIG rules which check the files of several components report for the control
+	// only the check results which do not belong to the components of other controls.
+	Component string
+}
+
+// Name returns the rule name of the control.
+func (c Control) Name() string {
+	return fmt.Sprintf("%s (L%d %s)", c.Title, c.Level, c.ID)
+}
+
+func (c Control) requirement() catalog.Requirement {
+	return catalog.Requirement{ID: c.ID, Name: c.Name(), DISARuleIDs: c.DISARuleIDs, Justification: c.Justification, Deviation: c.Deviation, Component: c.Component}
+}
+
+// DISAVersion is the version of the DISA Kubernetes STIG whose rules are reused by the CIS controls.
+const DISAVersion = "v1r11"
+
+// cniJustification is the justification of the controls of the Container Network Interface files.
+const cniJustification = "The Container Network Interface files are installed by the network plugin of the cluster and are not checked by a DISA Kubernetes STIG rule."
+
+// mode644Deviation is the deviation of the controls which require file permissions of 600
+// and are checked by DISA Kubernetes STIG rules which allow file permissions up to 644.
+const mode644Deviation = "checked against 644 instead of 600"
+
+// mode640Deviation is the deviation of the controls which require file permissions of 600
+// and are checked by DISA Kubernetes STIG rules which allow file permissions up to 640.
+const mode640Deviation = "checked against 640 instead of 600"
+
+// mode700Deviation is the deviation of the controls which require directory permissions of 700
+// and are checked by DISA Kubernetes STIG rules which allow file permissions up to 644.
+const mode700Deviation = "checked against 644 instead of 700"
+
+// The components whose files are checked by the controls of section 1.1. The admin.conf
+// of the cluster administrator is checked in the files of the kube-apiserver.
+const (
+	componentAPIServer         = "kube-apiserver"
+	componentControllerManager = "kube-controller-manager"
+	componentScheduler         = "kube-scheduler"
+	componentEtcd              = "etcd"
+)
+
+// V18Controls are the controls of the CIS Kubernetes Benchmark v1.8.
+var V18Controls = []Control{
+	{ID: "1.1.1", Title: "Ensure that the API server pod specification file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242408"}, Deviation: mode644Deviation, Component: componentAPIServer},
+	{ID: "1.1.2", Title: "Ensure that the API server pod specification file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242405"}, Component: componentAPIServer},
+	{ID: "1.1.3", Title: "Ensure that the controller manager pod specification file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242408"}, Deviation: mode644Deviation, Component: componentControllerManager},
+	{ID: "1.1.4", Title: "Ensure that the controller manager pod specification file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242405"}, Component: componentControllerManager},
+	{ID: "1.1.5", Title: "Ensure that the scheduler pod specification file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242408"}, Deviation: mode644Deviation, Component: componentScheduler},
+	{ID: "1.1.6", Title: "Ensure that the scheduler pod specification file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242405"}, Component: componentScheduler},
+	{ID: "1.1.7", Title: "Ensure that the etcd pod specification file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242408"}, Deviation: mode644Deviation, Component: componentEtcd},
+	{ID: "1.1.8", Title: "Ensure that the etcd pod specification file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242405"}, Component: componentEtcd},
+	{ID: "1.1.9", Title: "Ensure that the Container Network Interface file permissions are set to 600 or more restrictive", Level: Level1, Justification: cniJustification},
+	{ID: "1.1.10", Title: "Ensure that the Container Network Interface file ownership is set to root:root", Level: Level1, Justification: cniJustification},
+	{ID: "1.1.11", Title: "Ensure that the etcd data directory permissions are set to 700 or more restrictive", Level: Level1, DISARuleIDs: []string{"242459"}, Deviation: mode700Deviation},
+	{ID: "1.1.12", Title: "Ensure that the etcd data directory ownership is set to etcd:etcd", Level: Level1, DISARuleIDs: []string{"242445"}},
+	{ID: "1.1.13", Title: "Ensure that the admin.conf file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242460"}, Deviation: mode644Deviation, Component: componentAPIServer},
+	{ID: "1.1.14", Title: "Ensure that the admin.conf file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242446"}, Component: componentAPIServer},
+	{ID: "1.1.15", Title: "Ensure that the scheduler.conf file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242460"}, Deviation: mode644Deviation, Component: componentScheduler},
+	{ID: "1.1.16", Title: "Ensure that the scheduler.conf file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242446"}, Component: componentScheduler},
+	{ID: "1.1.17", Title: "Ensure that the controller-manager.conf file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242460"}, Deviation: mode644Deviation, Component: componentControllerManager},
+	{ID: "1.1.18", Title: "Ensure that the controller-manager.conf file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242446"}, Component: componentControllerManager},
+	{ID: "1.1.19", Title: "Ensure that the Kubernetes PKI directory and file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242451"}},
+	{ID: "1.1.20", Title: "Ensure that the Kubernetes PKI certificate file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242466"}, Deviation: mode644Deviation},
+	{ID: "1.1.21", Title: "Ensure that the Kubernetes PKI key file permissions are set to 600", Level: Level1, DISARuleIDs: []string{"242467"}, Deviation: mode640Deviation},
+	{ID: "1.2.1", Title: "Ensure that the --anonymous-auth argument is set to false", Level: Level1, DISARuleIDs: []string{"242390"}},
+	{ID: "1.2.2", Title: "Ensure that the --token-auth-file parameter is not set", Level: Level1, DISARuleIDs: []string{"245543"}},
+	{ID: "1.2.3", Title: "Ensure that the --DenyServiceExternalIPs is set", Level: Level1},
+	{ID: "1.2.4", Title: "Ensure that the --kubelet-client-certificate and --kubelet-client-key arguments are set as appropriate", Level: Level1},
+	{ID: "1.2.5", Title: "Ensure that the --kubelet-certificate-authority argument is set as appropriate", Level: Level1},
+	{ID: "1.2.6", Title: "Ensure that the --authorization-mode argument is not set to AlwaysAllow", Level: Level1, DISARuleIDs: []string{"242382"}},
+	{ID: "1.2.7", Title: "Ensure that the --authorization-mode argument includes Node", Level: Level1, DISARuleIDs: []string{"242382"}},
+	{ID: "1.2.8", Title: "Ensure that the --authorization-mode argument includes RBAC", Level: Level1, DISARuleIDs: []string{"242382"}},
+	{ID: "1.2.9", Title: "Ensure that the admission control plugin EventRateLimit is set", Level: Level1},
+	{ID: "1.2.10", Title: "Ensure that the admission control plugin AlwaysAdmit is not set", Level: Level1},
+	{ID: "1.2.11", Title: "Ensure that the admission control plugin AlwaysPullImages is set", Level: Level1},
+	{ID: "1.2.12", Title: "Ensure that the admission control plugin SecurityContextDeny is set if PodSecurityPolicy is not used", Level: Level1},
+	{ID: "1.2.13", Title: "Ensure that the admission control plugin ServiceAccount is set", Level: Level2},
+	{ID: "1.2.14", Title: "Ensure that the admission control plugin NamespaceLifecycle is set", Level: Level2},
+	{ID: "1.2.15", Title: "Ensure that the admission control plugin NodeRestriction is set", Level: Level2},
+	{ID: "1.2.16", Title: "Ensure that the --profiling argument is set to false", Level: Level1},
+	{ID: "1.2.17", Title: "Ensure that the --audit-log-path argument is set", Level: Level1, DISARuleIDs: []string{"242402"}},
+	{ID: "1.2.18", Title: "Ensure that the --audit-log-maxage argument is set to 30 or as appropriate", Level: Level1, DISARuleIDs: []string{"242464"}},
+	{ID: "1.2.19", Title: "Ensure that the --audit-log-maxbackup argument is set to 10 or as appropriate", Level: Level1, DISARuleIDs: []string{"242463"}},
+	{ID: "1.2.20", Title: "Ensure that the --audit-log-maxsize argument is set to 100 or as appropriate", Level: Level1, DISARuleIDs: []string{"242462"}},
+	{ID: "1.2.21", Title: "Ensure that the --request-timeout argument is set as appropriate", Level: Level1, DISARuleIDs: []string{"242438"}},
+	{ID: "1.2.22", Title: "Ensure that the --service-account-lookup argument is set to true", Level: Level1},
+	{ID: "1.2.23", Title: "Ensure that the --service-account-key-file argument is set as appropriate", Level: Level1},
+	{ID: "1.2.24", Title: "Ensure that the --etcd-certfile and --etcd-keyfile arguments are set as appropriate", Level: Level1, DISARuleIDs: []string{"242430", "242431"}},
+	{ID: "1.2.25", Title: "Ensure that the --tls-cert-file and --tls-private-key-file arguments are set as appropriate", Level: Level1, DISARuleIDs: []string{"242422"}},
+	{ID: "1.2.26", Title: "Ensure that the --client-ca-file argument is set as appropriate", Level: Level1, DISARuleIDs: []string{"242419"}},
+	{ID: "1.2.27", Title: "Ensure that the --etcd-cafile argument is set as appropriate", Level: Level1, DISARuleIDs: []string{"242429"}},
+	{ID: "1.2.28", Title: "Ensure that the --encryption-provider-config argument is set as appropriate", Level: Level1},
+	{ID: "1.2.29", Title: "Ensure that encryption providers are appropriately configured", Level: Level1},
+	{ID: "1.2.30", Title: "Ensure that the API Server only makes use of Strong Cryptographic Ciphers", Level: Level1, DISARuleIDs: []string{"242418"}},
+	{ID: "1.3.1", Title: "Ensure that the --terminated-pod-gc-threshold argument is set as appropriate", Level: Level1},
+	{ID: "1.3.2", Title: "Ensure that the --profiling argument is set to false", Level: Level1, DISARuleIDs: []string{"242409"}},
+	{ID: "1.3.3", Title: "Ensure that the --use-service-account-credentials argument is set to true", Level: Level1, DISARuleIDs: []string{"242381"}},
+	{ID: "1.3.4", Title: "Ensure that the --service-account-private-key-file argument is set as appropriate", Level: Level1},
+	{ID: "1.3.5", Title: "Ensure that the --root-ca-file argument is set as appropriate", Level: Level1, DISARuleIDs: []string{"242421"}},
+	{ID: "1.3.6", Title: "Ensure that the RotateKubeletServerCertificate argument is set to true", Level: Level2},
+	{ID: "1.3.7", Title: "Ensure that the --bind-address argument is set to 127.0.0.1", Level: Level1, DISARuleIDs: []string{"242385"}},
+	{ID: "1.4.1", Title: "Ensure that the --profiling argument is set to false", Level: Level1},
+	{ID: "1.4.2", Title: "Ensure that the --bind-address argument is set to 127.0.0.1", Level: Level1, DISARuleIDs: []string{"242384"}},
+	{ID: "2.1", Title: "Ensure that the --cert-file and --key-file arguments are set as appropriate", Level: Level1, DISARuleIDs: []string{"242428", "242427"}},
+	{ID: "2.2", Title: "Ensure that the --client-cert-auth argument is set to true", Level: Level1, DISARuleIDs: []string{"242423"}},
+	{ID: "2.3", Title: "Ensure that the --auto-tls argument is not set to true", Level: Level1, DISARuleIDs: []string{"242379"}},
+	{ID: "2.4", Title: "Ensure that the --peer-cert-file and --peer-key-file arguments are set as appropriate", Level: Level1, DISARuleIDs: []string{"242432", "242433"}},
+	{ID: "2.5", Title: "Ensure that the --peer-client-cert-auth argument is set to true", Level: Level1, DISARuleIDs: []string{"242426"}},
+	{ID: "2.6", Title: "Ensure that the --peer-auto-tls argument is not set to true", Level: Level1, DISARuleIDs: []string{"242380"}},
+	{ID: "2.7", Title: "Ensure that a unique Certificate Authority is used for etcd", Level: Level2},
+	{ID: "3.1.1", Title: "Client certificate authentication should not be used for users", Level: Level2},
+	{ID: "3.1.2", Title: "Service account token authentication should not be used for users", Level: Level2},
+	{ID: "3.1.3", Title: "Bootstrap token authentication should not be used for users", Level: Level2},
+	{ID: "3.2.1", Title: "Ensure that a minimal audit policy is created", Level: Level1, DISARuleIDs: []string{"242403"}},
+	{ID: "3.2.2", Title: "Ensure that the audit policy covers key security concerns", Level: Level2},
+	{ID: "4.1.1", Title: "Ensure that the kubelet service file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242407"}, Deviation: mode644Deviation},
+	{ID: "4.1.2", Title: "Ensure that the kubelet service file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242406"}},
+	{ID: "4.1.3", Title: "If proxy kubeconfig file exists ensure permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242447"}, Deviation: mode644Deviation},
+	{ID: "4.1.4", Title: "If proxy kubeconfig file exists ensure ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242448"}},
+	{ID: "4.1.5", Title: "Ensure that the --kubeconfig kubelet.conf file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242452"}, Deviation: mode644Deviation},
+	{ID: "4.1.6", Title: "Ensure that the --kubeconfig kubelet.conf file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242453"}},
+	{ID: "4.1.7", Title: "Ensure that the certificate authorities file permissions are set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242449"}, Deviation: mode644Deviation},
+	{ID: "4.1.8", Title: "Ensure that the client certificate authorities file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242450"}},
+	{ID: "4.1.9", Title: "If the kubelet config.yaml configuration file is being used validate permissions set to 600 or more restrictive", Level: Level1, DISARuleIDs: []string{"242407"}, Deviation: mode644Deviation},
+	{ID: "4.1.10", Title: "If the kubelet config.yaml configuration file is being used validate file ownership is set to root:root", Level: Level1, DISARuleIDs: []string{"242406"}},
+	{ID: "4.2.1", Title: "Ensure that the --anonymous-auth argument is set to false", Level: Level1, DISARuleIDs: []string{"242391"}},
+	{ID: "4.2.2", Title: "Ensure that the --authorization-mode argument is not set to AlwaysAllow", Level: Level1, DISARuleIDs: []string{"242392"}},
+	{ID: "4.2.3", Title: "Ensure that the --client-ca-file argument is set as appropriate", Level: Level1, DISARuleIDs: []string{"242420"}},
+	{ID: "4.2.4", Title: "Verify that the --read-only-port argument is set to 0", Level: Level1, DISARuleIDs: []string{"242387"}},
+	{ID: "4.2.5", Title: "Ensure that the --streaming-connection-idle-timeout argument is not set to 0", Level: Level1, DISARuleIDs: []string{"245541"}},
+	{ID: "4.2.6", Title: "Ensure that the --make-iptables-util-chains argument is set to true", Level: Level1},
+	{ID: "4.2.7", Title: "Ensure that the --hostname-override argument is not set", Level: Level1, DISARuleIDs: []string{"242404"}},
+	{ID: "4.2.8", Title: "Ensure that the eventRecordQPS argument is set to a level which ensures appropriate event capture", Level: Level2},
+	{ID: "4.2.9", Title: "Ensure that the --tls-cert-file and --tls-private-key-file arguments are set as appropriate", Level: Level1, DISARuleIDs: []string{"242425", "242424"}},
+	{ID: "4.2.10", Title: "Ensure that the --rotate-certificates argument is not set to false", Level: Level1},
+	{ID: "4.2.11", Title: "Verify that the RotateKubeletServerCertificate argument is set to true", Level: Level1},
+	{ID: "4.2.12", Title: "Ensure that the Kubelet only makes use of Strong Cryptographic Ciphers", Level: Level1},
+	{ID: "4.2.13", Title: "Ensure that a limit is set on pod PIDs", Level: Level1},
+	{ID: "5.1.1", Title: "Ensure that the cluster-admin role is only used where required", Level: Level1},
+	{ID: "5.1.2", Title: "Minimize access to secrets", Level: Level1},
+	{ID: "5.1.3", Title: "Minimize wildcard use in Roles and ClusterRoles", Level: Level1},
+	{ID: "5.1.4", Title: "Minimize access to create pods", Level: Level1},
+	{ID: "5.1.5", Title: "Ensure that default service accounts are not actively used", Level: Level1},
+	{ID: "5.1.6", Title: "Ensure that Service Account Tokens are only mounted where necessary", Level: Level1},
+	{ID: "5.1.7", Title: "Avoid use of system:masters group", Level: Level1},
+	{ID: "5.1.8", Title: "Limit use of the Bind, Impersonate and Escalate permissions in the Kubernetes cluster", Level: Level1},
+	{ID: "5.2.1", Title: "Ensure that the cluster has at least one active policy control mechanism in place", Level: Level1, DISARuleIDs: []string{"254800"}},
+	{ID: "5.2.2", Title: "Minimize the admission of privileged containers", Level: Level1},
+	{ID: "5.2.3", Title: "Minimize the admission of containers wishing to share the host process ID namespace", Level: Level1},
+	{ID: "5.2.4", Title: "Minimize the admission of containers wishing to share the host IPC namespace", Level: Level1},
+	{ID: "5.2.5", Title: "Minimize the admission of containers wishing to share the host network namespace", Level: Level1},
+	{ID: "5.2.6", Title: "Minimize the admission of containers with allowPrivilegeEscalation", Level: Level1},
+	{ID: "5.2.7", Title: "Minimize the admission of root containers", Level: Level2},
+	{ID: "5.2.8", Title: "Minimize the admission of containers with the NET_RAW capability", Level: Level1},
+	{ID: "5.2.9", Title: "Minimize the admission of containers with added capabilities", Level: Level1},
+	{ID: "5.2.10", Title: "Minimize the admission of containers with capabilities assigned", Level: Level2},
+	{ID: "5.2.11", Title: "Minimize the admission of Windows HostProcess containers", Level: Level1},
+	{ID: "5.2.12", Title: "Minimize the admission of HostPath volumes", Level: Level1},
+	{ID: "5.2.13", Title: "Minimize the admission of containers which use HostPorts", Level: Level1},
+	{ID: "5.3.1", Title: "Ensure that the CNI in use supports NetworkPolicies", Level: Level1},
+	{ID: "5.3.2", Title: "Ensure that all Namespaces have NetworkPolicies defined", Level: Level2},
+	{ID: "5.4.1", Title: "Prefer using Secrets as files over Secrets as environment variables", Level: Level2, DISARuleIDs: []string{"242415"}},
+	{ID: "5.4.2", Title: "Consider external secret storage", Level: Level2},
+	{ID: "5.5.1", Title: "Configure Image Provenance using ImagePolicyWebhook admission controller", Level: Level2},
+	{ID: "5.7.1", Title: "Create administrative boundaries between resources using namespaces", Level: Level1},
+	{ID: "5.7.2", Title: "Ensure that the seccomp profile is set to docker/default in your Pod definitions", Level: Level2},
+	{ID: "5.7.3", Title: "Apply SecurityContext to your Pods and Containers", Level: Level2},
+	{ID: "5.7.4", Title: "The default namespace should not be used", Level: Level2, DISARuleIDs: []string{"242383"}},
+}
+
+// Controls returns the controls of a benchmark version.
+func Controls(version string) ([]Control, error) {
+	switch version {
+	case "v1.8":
+		return V18Controls, nil
+	default:
+		return nil, fmt.Errorf("unknown ruleset %s version: %s", RulesetID, version)
+	}
+}
+
+func controlByID(controls []Control, id string) (Control, bool) {
+	for _, control := range controls {
+		if control.ID == id {
+			return control, true
+		}
+	}
+	return Control{}, false
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// History contains the controls of all CIS Kubernetes Benchmark versions.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1.8",
+		Rules:   controlIDs(V18Controls),
+	},
+)
+
+func controlIDs(controls []Control) []string {
+	ids := make([]string, 0, len(controls))
+	for _, control := range controls {
+		ids = append(ids, control.ID)
+	}
+	return ids
+}
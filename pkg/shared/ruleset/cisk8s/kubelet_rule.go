@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/kubernetes/config"
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+var _ rule.Rule = &KubeletConfigRule{}
+
+// KubeletConfigRule checks a control which requires an option
+// of the runtime kubelet config of all nodes to be set to an allowed value.
+type KubeletConfigRule struct {
+	Control          Control
+	Client           client.Client
+	CoreV1RESTClient rest.Interface
+	// Target is the target of the cluster whose nodes are checked.
+	Target rule.Target
+	// Option is the name of the kubelet config option.
+	Option string
+	// Check returns whether the option is set in the kubelet config and whether its value is allowed.
+	Check func(kubeletConfig *config.KubeletConfig) (set, allowed bool)
+	// DefaultAllowed indicates that the default value of the option is allowed.
+	DefaultAllowed bool
+}
+
+// ID returns the id of the checked control.
+func (r *KubeletConfigRule) ID() string {
+	return r.Control.ID
+}
+
+// Name returns the name of the checked control.
+func (r *KubeletConfigRule) Name() string {
+	return r.Control.Name()
+}
+
+// Run checks the option in the kubelet config of all ready nodes.
+func (r *KubeletConfigRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	nodes, err := kubeutils.GetNodes(ctx, r.Client, 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "nodeList"))), nil
+	}
+
+	checkResults := []rule.CheckResult{}
+	for _, node := range nodes {
+		target := r.Target.With("kind", "node", "name", node.Name)
+		if !kubeutils.NodeReadyStatus(node) {
+			checkResults = append(checkResults, rule.WarningCheckResult("Node is not in Ready state.", target))
+			continue
+		}
+
+		kubeletConfig, err := kubeutils.GetNodeConfigz(ctx, r.CoreV1RESTClient, node.Name)
+		if err != nil {
+			checkResults = append(checkResults, rule.ErroredCheckResult(err.Error(), target))
+			continue
+		}
+
+		set, allowed := r.Check(kubeletConfig)
+		switch {
+		case !set && r.DefaultAllowed:
+			checkResults = append(checkResults, rule.PassedCheckResult(fmt.Sprintf("Option %s not set and defaults to allowed value.", r.Option), target))
+		case !set:
+			checkResults = append(checkResults, rule.FailedCheckResult(fmt.Sprintf("Option %s not set.", r.Option), target))
+		case allowed:
+			checkResults = append(checkResults, rule.PassedCheckResult(fmt.Sprintf("Option %s set to allowed value.", r.Option), target))
+		default:
+			checkResults = append(checkResults, rule.FailedCheckResult(fmt.Sprintf("Option %s set to not allowed value.", r.Option), target))
+		}
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any nodes.", r.Target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+// KubeletConfigRules returns the rules of the controls which check
+// options of the kubelet config and are not covered by DISA rules.
+func KubeletConfigRules(controls []Control, c client.Client, coreV1RESTClient rest.Interface, target rule.Target) []rule.Rule {
+	// defaults ref https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/
+	kubeletRules := []KubeletConfigRule{
+		{
+			Control: Control{ID: "4.2.6"},
+			Option:  "makeIPTablesUtilChains",
+			Check: func(kubeletConfig *config.KubeletConfig) (bool, bool) {
+				return kubeletConfig.MakeIPTablesUtilChains != nil, kubeletConfig.MakeIPTablesUtilChains != nil && *kubeletConfig.MakeIPTablesUtilChains
+			},
+			DefaultAllowed: true,
+		},
+		{
+			Control: Control{ID: "4.2.10"},
+			Option:  "rotateCertificates",
+			Check: func(kubeletConfig *config.KubeletConfig) (bool, bool) {
+				return kubeletConfig.RotateCertificates != nil, kubeletConfig.RotateCertificates != nil && *kubeletConfig.RotateCertificates
+			},
+		},
+		{
+			Control: Control{ID: "4.2.13"},
+			Option:  "podPidsLimit",
+			Check: func(kubeletConfig *config.KubeletConfig) (bool, bool) {
+				// podPidsLimit -1 and 0 do not limit the number of pod PIDs.
+				return kubeletConfig.PodPidsLimit != nil, kubeletConfig.PodPidsLimit != nil && *kubeletConfig.PodPidsLimit > 0
+			},
+		},
+	}
+
+	var rules []rule.Rule
+	for _, r := range kubeletRules {
+		r := r
+		control, ok := controlByID(controls, r.Control.ID)
+		if !ok {
+			continue
+		}
+
+		r.Control = control
+		r.Client = c
+		r.CoreV1RESTClient = coreV1RESTClient
+		r.Target = target
+		rules = append(rules, &r)
+	}
+	return rules
+}
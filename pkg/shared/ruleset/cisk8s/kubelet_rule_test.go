@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	manualfake "k8s.io/client-go/rest/fake"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+)
+
+var _ = Describe("KubeletConfigRule", func() {
+	const (
+		allowedNodeConfig    = `{"kubeletconfig":{"makeIPTablesUtilChains":true,"rotateCertificates":true,"podPidsLimit":4096}}`
+		notAllowedNodeConfig = `{"kubeletconfig":{"makeIPTablesUtilChains":false,"rotateCertificates":false,"podPidsLimit":-1}}`
+		notSetNodeConfig     = `{"kubeletconfig":{"authentication":{"webhook":{"enabled":true}}}}`
+	)
+
+	var (
+		ctx    = context.TODO()
+		target = rule.NewTarget("cluster", "shoot", "kind", "node")
+	)
+
+	It("should check the kubelet config of all ready nodes", func() {
+		fakeClient := fakeclient.NewClientBuilder().Build()
+		for name, ready := range map[string]corev1.ConditionStatus{"node1": corev1.ConditionTrue, "node2": corev1.ConditionTrue, "node3": corev1.ConditionTrue, "node4": corev1.ConditionFalse} {
+			Expect(fakeClient.Create(ctx, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+				},
+			})).To(Succeed())
+		}
+
+		fakeRESTClient := &manualfake.RESTClient{
+			GroupVersion:         schema.GroupVersion{Group: "", Version: "v1"},
+			NegotiatedSerializer: scheme.Codecs,
+			Client: manualfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				switch req.URL.String() {
+				case "https://localhost/nodes/node1/proxy/configz":
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(allowedNodeConfig)))}, nil
+				case "https://localhost/nodes/node2/proxy/configz":
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(notAllowedNodeConfig)))}, nil
+				case "https://localhost/nodes/node3/proxy/configz":
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(notSetNodeConfig)))}, nil
+				default:
+					return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(&bytes.Buffer{})}, nil
+				}
+			}),
+		}
+
+		rules := cisk8s.KubeletConfigRules(cisk8s.V18Controls, fakeClient, fakeRESTClient, rule.NewTarget("cluster", "shoot"))
+		Expect(rules).To(HaveLen(3))
+
+		results := map[string][]rule.CheckResult{}
+		for _, r := range rules {
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			results[ruleResult.RuleID] = ruleResult.CheckResults
+		}
+
+		notReady := rule.WarningCheckResult("Node is not in Ready state.", target.With("name", "node4"))
+		Expect(results).To(Equal(map[string][]rule.CheckResult{
+			"4.2.6": {
+				rule.PassedCheckResult("Option makeIPTablesUtilChains set to allowed value.", target.With("name", "node1")),
+				rule.FailedCheckResult("Option makeIPTablesUtilChains set to not allowed value.", target.With("name", "node2")),
+				rule.PassedCheckResult("Option makeIPTablesUtilChains not set and defaults to allowed value.", target.With("name", "node3")),
+				notReady,
+			},
+			"4.2.10": {
+				rule.PassedCheckResult("Option rotateCertificates set to allowed value.", target.With("name", "node1")),
+				rule.FailedCheckResult("Option rotateCertificates set to not allowed value.", target.With("name", "node2")),
+				rule.FailedCheckResult("Option rotateCertificates not set.", target.With("name", "node3")),
+				notReady,
+			},
+			"4.2.13": {
+				rule.PassedCheckResult("Option podPidsLimit set to allowed value.", target.With("name", "node1")),
+				rule.FailedCheckResult("Option podPidsLimit set to not allowed value.", target.With("name", "node2")),
+				rule.FailedCheckResult("Option podPidsLimit not set.", target.With("name", "node3")),
+				notReady,
+			},
+		}))
+	})
+})
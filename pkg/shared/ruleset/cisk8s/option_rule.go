@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+var _ rule.Rule = &OptionRule{}
+
+// OptionRule checks a control which requires a command line
+// option of a deployment container to be set to a single value.
+type OptionRule struct {
+	Control        Control
+	Client         client.Client
+	Namespace      string
+	DeploymentName string
+	ContainerName  string
+	// Option is the name of the command line option without leading dashes.
+	Option string
+	// AllowedValue is the only allowed value of the option.
+	AllowedValue string
+	// DefaultAllowed indicates that the default value of the option is the allowed value.
+	DefaultAllowed bool
+}
+
+// ID returns the id of the checked control.
+func (r *OptionRule) ID() string {
+	return r.Control.ID
+}
+
+// Name returns the name of the checked control.
+func (r *OptionRule) Name() string {
+	return r.Control.Name()
+}
+
+// Run checks the option of the deployment container.
+func (r *OptionRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	target := rule.NewTarget("name", r.DeploymentName, "namespace", r.Namespace, "kind", "deployment")
+
+	optSlice, err := kubeutils.GetCommandOptionFromDeployment(ctx, r.Client, r.DeploymentName, r.ContainerName, r.Namespace, r.Option)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
+	}
+
+	switch {
+	case len(optSlice) == 0 && r.DefaultAllowed:
+		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s has not been set and defaults to allowed value.", r.Option), target)), nil
+	case len(optSlice) == 0:
+		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s has not been set.", r.Option), target)), nil
+	case len(optSlice) > 1:
+		return rule.SingleCheckResult(r, rule.WarningCheckResult(fmt.Sprintf("Option %s has been set more than once in container command.", r.Option), target)), nil
+	case optSlice[0] == r.AllowedValue:
+		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s set to allowed value.", r.Option), target)), nil
+	default:
+		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s set to not allowed value.", r.Option), target)), nil
+	}
+}
+
+// APIServerOptionRules returns the rules of the controls which check
+// options of a kube-apiserver deployment and are not covered by DISA rules.
+func APIServerOptionRules(controls []Control, c client.Client, namespace, deploymentName, containerName string) []rule.Rule {
+	return optionRules(controls, c, namespace, deploymentName, containerName, []OptionRule{
+		{Control: Control{ID: "1.2.16"}, Option: "profiling", AllowedValue: "false"},
+		{Control: Control{ID: "1.2.22"}, Option: "service-account-lookup", AllowedValue: "true", DefaultAllowed: true},
+	})
+}
+
+// SchedulerOptionRules returns the rules of the controls which check
+// options of a kube-scheduler deployment and are not covered by DISA rules.
+func SchedulerOptionRules(controls []Control, c client.Client, namespace, deploymentName, containerName string) []rule.Rule {
+	return optionRules(controls, c, namespace, deploymentName, containerName, []OptionRule{
+		{Control: Control{ID: "1.4.1"}, Option: "profiling", AllowedValue: "false"},
+	})
+}
+
+func optionRules(controls []Control, c client.Client, namespace, deploymentName, containerName string, optionRules []OptionRule) []rule.Rule {
+	var rules []rule.Rule
+	for _, r := range optionRules {
+		r := r
+		control, ok := controlByID(controls, r.Control.ID)
+		if !ok {
+			continue
+		}
+
+		r.Control = control
+		r.Client = c
+		r.Namespace = namespace
+		r.DeploymentName = deploymentName
+		r.ContainerName = containerName
+		rules = append(rules, &r)
+	}
+	return rules
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+)
+
+var _ = Describe("OptionRule", func() {
+	var (
+		fakeClient client.Client
+		ctx        = context.TODO()
+		namespace  = "foo"
+		target     = rule.NewTarget("name", "kube-apiserver", "namespace", namespace, "kind", "deployment")
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+	})
+
+	DescribeTable("Run cases",
+		func(command []string, expectedCheckResults []rule.CheckResult) {
+			Expect(fakeClient.Create(ctx, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kube-apiserver",
+					Namespace: namespace,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:    "kube-apiserver",
+									Command: command,
+								},
+							},
+						},
+					},
+				},
+			})).To(Succeed())
+
+			rules := cisk8s.APIServerOptionRules(cisk8s.V18Controls, fakeClient, namespace, "kube-apiserver", "kube-apiserver")
+			Expect(rules).To(HaveLen(2))
+
+			var checkResults []rule.CheckResult
+			for _, r := range rules {
+				ruleResult, err := r.Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ruleResult.RuleID).To(Equal(r.ID()))
+				checkResults = append(checkResults, ruleResult.CheckResults...)
+			}
+			Expect(checkResults).To(Equal(expectedCheckResults))
+		},
+		Entry("should pass when options are set to allowed values",
+			[]string{"--profiling=false", "--service-account-lookup=true"},
+			[]rule.CheckResult{
+				rule.PassedCheckResult("Option profiling set to allowed value.", target),
+				rule.PassedCheckResult("Option service-account-lookup set to allowed value.", target),
+			}),
+		Entry("should only pass for options with allowed defaults when options are not set",
+			[]string{"--foo=bar"},
+			[]rule.CheckResult{
+				rule.FailedCheckResult("Option profiling has not been set.", target),
+				rule.PassedCheckResult("Option service-account-lookup has not been set and defaults to allowed value.", target),
+			}),
+		Entry("should fail when options are set to not allowed values",
+			[]string{"--profiling=true", "--service-account-lookup=false"},
+			[]rule.CheckResult{
+				rule.FailedCheckResult("Option profiling set to not allowed value.", target),
+				rule.FailedCheckResult("Option service-account-lookup set to not allowed value.", target),
+			}),
+		Entry("should warn when options are set more than once",
+			[]string{"--profiling=false", "--profiling=false", "--service-account-lookup=true"},
+			[]rule.CheckResult{
+				rule.WarningCheckResult("Option profiling has been set more than once in container command.", target),
+				rule.PassedCheckResult("Option service-account-lookup set to allowed value.", target),
+			}),
+	)
+
+	It("should not return rules for controls which are not part of the benchmark version", func() {
+		Expect(cisk8s.SchedulerOptionRules([]cisk8s.Control{{ID: "1.2.16"}}, fakeClient, namespace, "kube-scheduler", "kube-scheduler")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+)
+
+// NewRule creates a rule which checks a control with the given rules
+// of other rulesets which check the same requirements.
+func NewRule(control Control, rules ...rule.Rule) *rule.CompositeRule {
+	return catalog.NewRule(control.requirement(), rules...)
+}
+
+// Rules returns the rules for the controls up to the given level. Controls with an implementation
+// in rules are checked by it, controls overlapping with DISA Kubernetes STIG rules are checked
+// by the rules of disaRules and all other controls are not implemented with the justification of the control.
+func Rules(controls []Control, level Level, disaRules sharedruleset.RuleGetter, rules ...rule.Rule) ([]rule.Rule, error) {
+	var (
+		levelControls    []Control
+		levelRules       []rule.Rule
+		excludedControls = map[string]struct{}{}
+	)
+	for _, control := range controls {
+		if control.Level > level {
+			excludedControls[control.ID] = struct{}{}
+			continue
+		}
+		levelControls = append(levelControls, control)
+	}
+	for _, r := range rules {
+		if _, ok := excludedControls[r.ID()]; !ok {
+			levelRules = append(levelRules, r)
+		}
+	}
+
+	return benchmark(levelControls).Rules(disaRules, levelRules...)
+}
+
+// DISARuleOptions translates the rule options of controls to the rule options of the
+// DISA Kubernetes STIG rules which check the controls. Only the rule args are translated,
+// skipped controls are handled by the CIS ruleset.
+func DISARuleOptions(controls []Control, ruleOptions []config.RuleOptionsConfig) ([]config.RuleOptionsConfig, error) {
+	return benchmark(controls).DISARuleOptions(ruleOptions)
+}
+
+// benchmark returns the catalog of the controls.
+func benchmark(controls []Control) catalog.Catalog {
+	requirements := make([]catalog.Requirement, 0, len(controls))
+	for _, control := range controls {
+		requirements = append(requirements, control.requirement())
+	}
+	return catalog.Catalog{Kind: "control", Document: "benchmark", Requirements: requirements}
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/cisk8s"
+)
+
+type fakeRule struct {
+	id           string
+	checkResults []rule.CheckResult
+}
+
+func (r *fakeRule) ID() string   { return r.id }
+func (r *fakeRule) Name() string { return "fake " + r.id }
+func (r *fakeRule) Run(context.Context) (rule.RuleResult, error) {
+	return rule.RuleResult{RuleID: r.id, RuleName: r.Name(), CheckResults: r.checkResults}, nil
+}
+
+type fakeRuleGetter map[string]rule.Rule
+
+func (g fakeRuleGetter) Rule(id string) (rule.Rule, bool) {
+	r, ok := g[id]
+	return r, ok
+}
+
+var _ = Describe("rule", func() {
+	var (
+		controls = []cisk8s.Control{
+			{ID: "1.1", Title: "Foo", Level: cisk8s.Level1, DISARuleIDs: []string{"1", "2"}},
+			{ID: "1.2", Title: "Bar", Level: cisk8s.Level1, Justification: "foo"},
+			{ID: "1.3", Title: "Baz", Level: cisk8s.Level2},
+			{ID: "1.4", Title: "Qux", Level: cisk8s.Level1},
+		}
+		disaRules = fakeRuleGetter{
+			"1": &fakeRule{id: "1", checkResults: []rule.CheckResult{rule.PassedCheckResult("one", rule.NewTarget())}},
+			"2": &fakeRule{id: "2", checkResults: []rule.CheckResult{rule.FailedCheckResult("two", rule.NewTarget())}},
+		}
+	)
+
+	Describe("#Rules", func() {
+		It("should return the rules of the controls of the level", func() {
+			implemented := &fakeRule{id: "1.4"}
+			rules, err := cisk8s.Rules(controls, cisk8s.Level1, disaRules, implemented, &fakeRule{id: "1.3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rules).To(Equal([]rule.Rule{
+				cisk8s.NewRule(controls[0], disaRules["1"], disaRules["2"]),
+				rule.NewSkipRule("1.2", "Bar (L1 1.2)", "foo", rule.NotImplemented),
+				implemented,
+			}))
+
+			rules, err = cisk8s.Rules(controls, cisk8s.Level2, disaRules)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rules).To(HaveLen(4))
+		})
+
+		It("should return an error for missing DISA rules and unknown controls", func() {
+			_, err := cisk8s.Rules(controls, cisk8s.Level1, fakeRuleGetter{}, &fakeRule{id: "2.1"})
+			Expect(err).To(MatchError(And(
+				ContainSubstring("rule 2.1 is not a control of the benchmark"),
+				ContainSubstring("rule 1 of control 1.1 is not registered in the DISA Kubernetes STIG ruleset"),
+			)))
+		})
+	})
+
+	Describe("#V18Controls", func() {
+		It("should have unique ids", func() {
+			ids := map[string]struct{}{}
+			for _, control := range cisk8s.V18Controls {
+				Expect(ids).ToNot(HaveKey(control.ID))
+				ids[control.ID] = struct{}{}
+			}
+			Expect(cisk8s.History.RuleIDs("v1.8")).To(HaveLen(len(cisk8s.V18Controls)))
+		})
+
+		It("should check the file controls with DISA rules or justify them", func() {
+			for _, control := range cisk8s.V18Controls {
+				if !strings.HasPrefix(control.ID, "1.1.") && !strings.HasPrefix(control.ID, "4.1.") {
+					continue
+				}
+				Expect(len(control.DISARuleIDs) > 0 || len(control.Justification) > 0).To(BeTrue(), control.ID)
+			}
+		})
+
+		It("should declare a deviation for the 600 and 700 permission controls checked by DISA rules", func() {
+			for _, control := range cisk8s.V18Controls {
+				if !strings.Contains(control.Title, "set to 600") && !strings.Contains(control.Title, "set to 700") || len(control.DISARuleIDs) == 0 {
+					continue
+				}
+				Expect(control.Deviation).ToNot(BeEmpty(), control.ID)
+			}
+		})
+
+		It("should declare the component of the controls which share DISA rules of several components", func() {
+			for _, id := range []string{"242405", "242408", "242446", "242460"} {
+				for _, control := range cisk8s.V18Controls {
+					if slices.Contains(control.DISARuleIDs, id) {
+						Expect(control.Component).ToNot(BeEmpty(), control.ID)
+					}
+				}
+			}
+		})
+	})
+})
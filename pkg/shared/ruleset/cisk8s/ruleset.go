@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cisk8s
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of a CIS Kubernetes Benchmark Ruleset
+	RulesetID = "cis-kubernetes-benchmark"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset implements the CIS Kubernetes Benchmark.
+type Ruleset struct {
+	version    string
+	profile    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		profile:    ProfileLevel1,
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "CIS Kubernetes Benchmark"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// Profile returns the profile of the Ruleset.
+func (r *Ruleset) Profile() string {
+	return r.profile
+}
+
+// DISARulesetFunc creates the DISA Kubernetes STIG ruleset of a provider with the given rule options.
+type DISARulesetFunc func(disaRuleOptions []config.RuleOptionsConfig) (sharedruleset.RuleGetter, error)
+
+// RulesFunc creates the provider specific rules for the given controls.
+type RulesFunc func(controls []Control) ([]rule.Rule, error)
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. Controls which overlap with DISA Kubernetes STIG
+// rules are checked by the rules of the ruleset created by disaRuleset, the rules created by rules check
+// the remaining implemented controls of the provider.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, disaRuleset DISARulesetFunc, rules RulesFunc) (*Ruleset, error) {
+	args, err := ParseArgs(rulesetConfig.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset, err := New(
+		WithVersion(rulesetConfig.Version),
+		WithProfile(args.Profile),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	controls, err := Controls(rulesetConfig.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	disaRuleOptions, err := DISARuleOptions(controls, rulesetConfig.RuleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	disaRules, err := disaRuleset(disaRuleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	providerRules, err := rules(controls)
+	if err != nil {
+		return nil, err
+	}
+
+	controlRules, err := Rules(controls, args.Level(), disaRules, providerRules...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(controlRules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
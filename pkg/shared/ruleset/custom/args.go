@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package custom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Args are the arguments of a custom [Ruleset].
+type Args struct {
+	// Rules are the rules of the ruleset.
+	Rules []RuleConfig `json:"rules" yaml:"rules"`
+}
+
+// RuleConfig declares a rule which evaluates CEL expressions for Kubernetes objects.
+type RuleConfig struct {
+	// ID is the unique identifier of the rule.
+	ID string `json:"id" yaml:"id"`
+	// Name is the user friendly name of the rule.
+	Name string `json:"name" yaml:"name"`
+	// Cluster is the name of the cluster whose objects are evaluated.
+	// Defaults to the default cluster of the provider.
+	Cluster string `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+	// Resource describes the objects which are evaluated by the rule.
+	// The evaluated object is available as `object` in all expressions.
+	Resource ResourceConfig `json:"resource" yaml:"resource"`
+	// References describe additional objects which are available as
+	// lists with the name of the reference in all expressions.
+	References []ReferenceConfig `json:"references,omitempty" yaml:"references,omitempty"`
+	// Match is an optional expression which selects the objects to evaluate.
+	Match string `json:"match,omitempty" yaml:"match,omitempty"`
+	// Validation is the expression which has to evaluate to true for compliant objects.
+	Validation string `json:"validation" yaml:"validation"`
+	// PassMessage is the message of checks for compliant objects.
+	PassMessage string `json:"passMessage" yaml:"passMessage"`
+	// FailMessage is the message of checks for not compliant objects.
+	FailMessage string `json:"failMessage" yaml:"failMessage"`
+	// TargetFields maps additional target keys to expressions which return the target values.
+	TargetFields map[string]string `json:"targetFields,omitempty" yaml:"targetFields,omitempty"`
+}
+
+// ResourceConfig describes Kubernetes objects to fetch.
+type ResourceConfig struct {
+	// APIVersion is the group version of the objects.
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+	// Kind is the kind of the objects.
+	Kind string `json:"kind" yaml:"kind"`
+	// Namespace restricts the objects to a single namespace.
+	// Objects from all namespaces are fetched when empty.
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// LabelSelector restricts the objects to the ones matching the label selector.
+	LabelSelector string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
+}
+
+// ReferenceConfig describes Kubernetes objects which are referenced by a rule.
+type ReferenceConfig struct {
+	ResourceConfig `json:",inline" yaml:",inline"`
+	// Name is the name of the variable which contains the referenced objects.
+	Name string `json:"name" yaml:"name"`
+}
+
+// ParseArgs parses the generic ruleset arguments into [Args].
+func ParseArgs(args any) (*Args, error) {
+	if args == nil {
+		return nil, errors.New("ruleset args with rules are required")
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedArgs Args
+	if err := json.Unmarshal(argsByte, &parsedArgs); err != nil {
+		return nil, fmt.Errorf("failed to parse ruleset args: %w", err)
+	}
+
+	if len(parsedArgs.Rules) == 0 {
+		return nil, errors.New("ruleset args do not contain any rules")
+	}
+	return &parsedArgs, nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package custom_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/custom"
+)
+
+var _ = Describe("args", func() {
+	Describe("#ParseArgs", func() {
+		It("should parse the rules of generic args", func() {
+			args, err := custom.ParseArgs(map[string]any{
+				"rules": []any{
+					map[string]any{
+						"id":   "ns-001",
+						"name": "foo",
+						"resource": map[string]any{
+							"apiVersion": "v1",
+							"kind":       "Namespace",
+						},
+						"references": []any{
+							map[string]any{
+								"name":       "pods",
+								"apiVersion": "v1",
+								"kind":       "Pod",
+								"namespace":  "foo",
+							},
+						},
+						"validation":  "true",
+						"passMessage": "pass",
+						"failMessage": "fail",
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(args).To(Equal(&custom.Args{
+				Rules: []custom.RuleConfig{
+					{
+						ID:       "ns-001",
+						Name:     "foo",
+						Resource: custom.ResourceConfig{APIVersion: "v1", Kind: "Namespace"},
+						References: []custom.ReferenceConfig{
+							{Name: "pods", ResourceConfig: custom.ResourceConfig{APIVersion: "v1", Kind: "Pod", Namespace: "foo"}},
+						},
+						Validation:  "true",
+						PassMessage: "pass",
+						FailMessage: "fail",
+					},
+				},
+			}))
+		})
+
+		It("should return an error when there are no rules", func() {
+			_, err := custom.ParseArgs(nil)
+			Expect(err).To(MatchError("ruleset args with rules are required"))
+
+			_, err = custom.ParseArgs(map[string]any{"rules": []any{}})
+			Expect(err).To(MatchError("ruleset args do not contain any rules"))
+		})
+	})
+})
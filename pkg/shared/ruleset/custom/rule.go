@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package custom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+const objectVariable = "object"
+
+var (
+	_ rule.Rule = &Rule{}
+
+	variableNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Rule evaluates CEL expressions for Kubernetes objects.
+type Rule struct {
+	config       RuleConfig
+	client       client.Client
+	resource     resource
+	references   []resource
+	match        cel.Program
+	validation   cel.Program
+	targetFields map[string]cel.Program
+}
+
+type resource struct {
+	name          string
+	gvk           schema.GroupVersionKind
+	namespace     string
+	labelSelector labels.Selector
+}
+
+// NewRule creates a Rule from a [RuleConfig]. The objects are fetched with the given client.
+func NewRule(ruleConfig RuleConfig, c client.Client) (*Rule, error) {
+	if len(ruleConfig.ID) == 0 {
+		return nil, errors.New("rule id must be set")
+	}
+	if len(ruleConfig.Name) == 0 {
+		return nil, fmt.Errorf("rule %s: name must be set", ruleConfig.ID)
+	}
+	if len(ruleConfig.Validation) == 0 {
+		return nil, fmt.Errorf("rule %s: validation must be set", ruleConfig.ID)
+	}
+	if len(ruleConfig.PassMessage) == 0 || len(ruleConfig.FailMessage) == 0 {
+		return nil, fmt.Errorf("rule %s: passMessage and failMessage must be set", ruleConfig.ID)
+	}
+
+	r := &Rule{
+		config:       ruleConfig,
+		client:       c,
+		targetFields: make(map[string]cel.Program, len(ruleConfig.TargetFields)),
+	}
+
+	var err error
+	if r.resource, err = newResource(objectVariable, ruleConfig.Resource); err != nil {
+		return nil, fmt.Errorf("rule %s: %w", ruleConfig.ID, err)
+	}
+
+	envOptions := []cel.EnvOption{
+		ext.Strings(),
+		cel.Variable(objectVariable, cel.DynType),
+	}
+	for _, referenceConfig := range ruleConfig.References {
+		if !variableNameRegex.MatchString(referenceConfig.Name) || referenceConfig.Name == objectVariable {
+			return nil, fmt.Errorf("rule %s: invalid reference name %q", ruleConfig.ID, referenceConfig.Name)
+		}
+		if slices.ContainsFunc(r.references, func(reference resource) bool {
+			return reference.name == referenceConfig.Name
+		}) {
+			return nil, fmt.Errorf("rule %s: reference %s is declared more than once", ruleConfig.ID, referenceConfig.Name)
+		}
+
+		reference, err := newResource(referenceConfig.Name, referenceConfig.ResourceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", ruleConfig.ID, err)
+		}
+		r.references = append(r.references, reference)
+		envOptions = append(envOptions, cel.Variable(referenceConfig.Name, cel.ListType(cel.DynType)))
+	}
+
+	env, err := cel.NewEnv(envOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", ruleConfig.ID, err)
+	}
+
+	if len(ruleConfig.Match) > 0 {
+		if r.match, err = compile(env, ruleConfig.Match, cel.BoolType); err != nil {
+			return nil, fmt.Errorf("rule %s: invalid match expression: %w", ruleConfig.ID, err)
+		}
+	}
+	if r.validation, err = compile(env, ruleConfig.Validation, cel.BoolType); err != nil {
+		return nil, fmt.Errorf("rule %s: invalid validation expression: %w", ruleConfig.ID, err)
+	}
+	for key, expression := range ruleConfig.TargetFields {
+		if r.targetFields[key], err = compile(env, expression, cel.DynType); err != nil {
+			return nil, fmt.Errorf("rule %s: invalid target field %s expression: %w", ruleConfig.ID, key, err)
+		}
+	}
+
+	return r, nil
+}
+
+func newResource(name string, resourceConfig ResourceConfig) (resource, error) {
+	gv, err := schema.ParseGroupVersion(resourceConfig.APIVersion)
+	if err != nil {
+		return resource{}, fmt.Errorf("invalid apiVersion of %s: %w", name, err)
+	}
+	if len(gv.Version) == 0 || len(resourceConfig.Kind) == 0 {
+		return resource{}, fmt.Errorf("apiVersion and kind of %s must be set", name)
+	}
+
+	selector := labels.Everything()
+	if len(resourceConfig.LabelSelector) > 0 {
+		if selector, err = labels.Parse(resourceConfig.LabelSelector); err != nil {
+			return resource{}, fmt.Errorf("invalid labelSelector of %s: %w", name, err)
+		}
+	}
+
+	return resource{
+		name:          name,
+		gvk:           gv.WithKind(resourceConfig.Kind),
+		namespace:     resourceConfig.Namespace,
+		labelSelector: selector,
+	}, nil
+}
+
+// compile compiles an expression which has to return the expected type.
+// Expressions returning dynamic values are checked during evaluation.
+func compile(env *cel.Env, expression string, expectedType *cel.Type) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); outputType != cel.DynType && !expectedType.IsAssignableType(outputType) {
+		return nil, fmt.Errorf("expression must return %s, got %s", expectedType, outputType)
+	}
+	return env.Program(ast)
+}
+
+// ID returns the id of the Rule.
+func (r *Rule) ID() string {
+	return r.config.ID
+}
+
+// Name returns the name of the Rule.
+func (r *Rule) Name() string {
+	return r.config.Name
+}
+
+// Run evaluates the expressions of the Rule for all matching objects.
+func (r *Rule) Run(ctx context.Context) (rule.RuleResult, error) {
+	objects, err := r.list(ctx, r.resource)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), rule.NewTarget("kind", r.resource.gvk.Kind))), nil
+	}
+
+	activation := map[string]any{}
+	for _, reference := range r.references {
+		referencedObjects, err := r.list(ctx, reference)
+		if err != nil {
+			return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), rule.NewTarget("kind", reference.gvk.Kind))), nil
+		}
+		activation[reference.name] = referencedObjects
+	}
+
+	checkResults := []rule.CheckResult{}
+	for _, object := range objects {
+		activation[objectVariable] = object
+		target := r.objectTarget(object)
+
+		if r.match != nil {
+			matches, err := evalBool(r.match, activation)
+			if err != nil {
+				checkResults = append(checkResults, rule.ErroredCheckResult(fmt.Sprintf("failed to evaluate match expression: %s", err), target))
+				continue
+			}
+			if !matches {
+				continue
+			}
+		}
+
+		target, err := r.withTargetFields(target, activation)
+		if err != nil {
+			checkResults = append(checkResults, rule.ErroredCheckResult(err.Error(), target))
+			continue
+		}
+
+		valid, err := evalBool(r.validation, activation)
+		switch {
+		case err != nil:
+			checkResults = append(checkResults, rule.ErroredCheckResult(fmt.Sprintf("failed to evaluate validation expression: %s", err), target))
+		case valid:
+			checkResults = append(checkResults, rule.PassedCheckResult(r.config.PassMessage, target))
+		default:
+			checkResults = append(checkResults, rule.FailedCheckResult(r.config.FailMessage, target))
+		}
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("There are no objects to evaluate.", rule.NewTarget("kind", r.resource.gvk.Kind))), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+// objectTarget returns the target of an object. It contains the kind, name and namespace of the object.
+func (r *Rule) objectTarget(object map[string]any) rule.Target {
+	obj := unstructured.Unstructured{Object: object}
+	target := rule.NewTarget("kind", r.resource.gvk.Kind, "name", obj.GetName())
+	if len(obj.GetNamespace()) > 0 {
+		target = target.With("namespace", obj.GetNamespace())
+	}
+	return target
+}
+
+// withTargetFields adds the configured target fields to the target of an object.
+// The target is returned unchanged if a target field cannot be evaluated.
+func (r *Rule) withTargetFields(target rule.Target, activation map[string]any) (rule.Target, error) {
+	keys := make([]string, 0, len(r.targetFields))
+	for key := range r.targetFields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	fields := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		value, _, err := r.targetFields[key].Eval(activation)
+		if err != nil {
+			return target, fmt.Errorf("failed to evaluate target field %s expression: %w", key, err)
+		}
+		fields = append(fields, key, fmt.Sprint(value.Value()))
+	}
+	return target.With(fields...), nil
+}
+
+func evalBool(program cel.Program, activation map[string]any) (bool, error) {
+	value, _, err := program.Eval(activation)
+	if err != nil {
+		return false, err
+	}
+	result, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %v instead of a bool", value.Value())
+	}
+	return result, nil
+}
+
+// list returns the contents of all objects of a resource.
+func (r *Rule) list(ctx context.Context, res resource) ([]map[string]any, error) {
+	objects := []map[string]any{}
+	listOptions := &client.ListOptions{
+		Namespace:     res.namespace,
+		LabelSelector: res.labelSelector,
+		Limit:         500,
+	}
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(res.gvk.GroupVersion().WithKind(res.gvk.Kind + "List"))
+		if err := r.client.List(ctx, list, listOptions); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			objects = append(objects, item.Object)
+		}
+
+		listOptions.Continue = list.GetContinue()
+		if len(listOptions.Continue) == 0 {
+			return objects, nil
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package custom_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/custom"
+)
+
+var _ = Describe("rule", func() {
+	var (
+		fakeClient client.Client
+		ctx        = context.TODO()
+		ruleConfig custom.RuleConfig
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		ruleConfig = custom.RuleConfig{
+			ID:   "ns-001",
+			Name: "Namespaces must have an owner label.",
+			Resource: custom.ResourceConfig{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			},
+			Match:       `!object.metadata.name.startsWith("kube-")`,
+			Validation:  `has(object.metadata.labels) && "owner" in object.metadata.labels`,
+			PassMessage: "Namespace has an owner.",
+			FailMessage: "Namespace does not have an owner.",
+		}
+	})
+
+	Describe("#NewRule", func() {
+		DescribeTable("invalid rule configs",
+			func(mutate func(*custom.RuleConfig), expectedErr string) {
+				mutate(&ruleConfig)
+				_, err := custom.NewRule(ruleConfig, fakeClient)
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			},
+			Entry("missing id",
+				func(c *custom.RuleConfig) { c.ID = "" },
+				"rule id must be set"),
+			Entry("missing messages",
+				func(c *custom.RuleConfig) { c.FailMessage = "" },
+				"rule ns-001: passMessage and failMessage must be set"),
+			Entry("missing kind",
+				func(c *custom.RuleConfig) { c.Resource.Kind = "" },
+				"rule ns-001: apiVersion and kind of object must be set"),
+			Entry("invalid label selector",
+				func(c *custom.RuleConfig) { c.Resource.LabelSelector = "foo in" },
+				"rule ns-001: invalid labelSelector of object"),
+			Entry("invalid validation expression",
+				func(c *custom.RuleConfig) { c.Validation = "object.metadata.name ==" },
+				"rule ns-001: invalid validation expression"),
+			Entry("validation expression which does not return a bool",
+				func(c *custom.RuleConfig) { c.Validation = `"foo"` },
+				"rule ns-001: invalid validation expression: expression must return bool, got string"),
+			Entry("invalid reference name",
+				func(c *custom.RuleConfig) {
+					c.References = []custom.ReferenceConfig{{Name: "object", ResourceConfig: custom.ResourceConfig{APIVersion: "v1", Kind: "Pod"}}}
+				},
+				`rule ns-001: invalid reference name "object"`),
+			Entry("duplicate reference name",
+				func(c *custom.RuleConfig) {
+					c.References = []custom.ReferenceConfig{
+						{Name: "pods", ResourceConfig: custom.ResourceConfig{APIVersion: "v1", Kind: "Pod"}},
+						{Name: "pods", ResourceConfig: custom.ResourceConfig{APIVersion: "v1", Kind: "Pod"}},
+					}
+				},
+				"rule ns-001: reference pods is declared more than once"),
+		)
+	})
+
+	Describe("#Run", func() {
+		It("should evaluate the matching objects", func() {
+			for _, namespace := range []*corev1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: map[string]string{"owner": "team-foo"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			} {
+				Expect(fakeClient.Create(ctx, namespace)).To(Succeed())
+			}
+			ruleConfig.TargetFields = map[string]string{"owner": `has(object.metadata.labels) && "owner" in object.metadata.labels ? object.metadata.labels.owner : "none"`}
+
+			r, err := custom.NewRule(ruleConfig, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.RuleID).To(Equal("ns-001"))
+			Expect(ruleResult.RuleName).To(Equal("Namespaces must have an owner label."))
+			Expect(ruleResult.CheckResults).To(ConsistOf(
+				rule.FailedCheckResult("Namespace does not have an owner.", rule.NewTarget("kind", "Namespace", "name", "bar", "owner", "none")),
+				rule.PassedCheckResult("Namespace has an owner.", rule.NewTarget("kind", "Namespace", "name", "foo", "owner", "team-foo")),
+			))
+		})
+
+		It("should evaluate objects against referenced objects", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bar"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "foo"}})).To(Succeed())
+
+			ruleConfig.Match = ""
+			ruleConfig.References = []custom.ReferenceConfig{
+				{
+					Name:           "networkPolicies",
+					ResourceConfig: custom.ResourceConfig{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+				},
+			}
+			ruleConfig.Validation = `networkPolicies.exists(np, np.metadata.namespace == object.metadata.name)`
+			ruleConfig.PassMessage = "Namespace has a NetworkPolicy."
+			ruleConfig.FailMessage = "Namespace does not have a NetworkPolicy."
+
+			r, err := custom.NewRule(ruleConfig, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.CheckResults).To(ConsistOf(
+				rule.FailedCheckResult("Namespace does not have a NetworkPolicy.", rule.NewTarget("kind", "Namespace", "name", "bar")),
+				rule.PassedCheckResult("Namespace has a NetworkPolicy.", rule.NewTarget("kind", "Namespace", "name", "foo")),
+			))
+		})
+
+		It("should restrict the objects to a namespace and label selector", func() {
+			for _, service := range []*corev1.Service{
+				{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "foo", Labels: map[string]string{"app": "foo"}}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "foo"}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar", Labels: map[string]string{"app": "foo"}}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}},
+			} {
+				Expect(fakeClient.Create(ctx, service)).To(Succeed())
+			}
+
+			r, err := custom.NewRule(custom.RuleConfig{
+				ID:   "svc-001",
+				Name: "Services must not be exposed.",
+				Resource: custom.ResourceConfig{
+					APIVersion:    "v1",
+					Kind:          "Service",
+					Namespace:     "foo",
+					LabelSelector: "app=foo",
+				},
+				Validation:  `object.spec.type != "LoadBalancer"`,
+				PassMessage: "Service is not exposed.",
+				FailMessage: "Service is exposed.",
+			}, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Service is exposed.", rule.NewTarget("kind", "Service", "name", "foo", "namespace", "foo")),
+			}))
+		})
+
+		It("should pass when there are no matching objects", func() {
+			r, err := custom.NewRule(ruleConfig, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.PassedCheckResult("There are no objects to evaluate.", rule.NewTarget("kind", "Namespace")),
+			}))
+		})
+
+		It("should error when an expression cannot be evaluated", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}})).To(Succeed())
+			ruleConfig.Validation = `object.metadata.labels.owner == "foo"`
+
+			r, err := custom.NewRule(ruleConfig, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.CheckResults).To(HaveLen(1))
+			Expect(ruleResult.CheckResults[0].Status).To(Equal(rule.Errored))
+			Expect(ruleResult.CheckResults[0].Message).To(HavePrefix("failed to evaluate validation expression: "))
+		})
+
+		It("should error when a target field cannot be evaluated", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}})).To(Succeed())
+			ruleConfig.TargetFields = map[string]string{"owner": `object.metadata.labels.owner`}
+
+			r, err := custom.NewRule(ruleConfig, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.ErroredCheckResult("failed to evaluate target field owner expression: no such key: labels", rule.NewTarget("kind", "Namespace", "name", "foo")),
+			}))
+		})
+
+		It("should not evaluate the target fields of objects which do not match", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})).To(Succeed())
+			ruleConfig.TargetFields = map[string]string{"owner": `object.metadata.labels.owner`}
+
+			r, err := custom.NewRule(ruleConfig, fakeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.PassedCheckResult("There are no objects to evaluate.", rule.NewTarget("kind", "Namespace")),
+			}))
+		})
+	})
+})
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package custom
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of a Custom Ruleset
+	RulesetID = "custom"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset implements a ruleset whose rules are declared in the
+// configuration and evaluate CEL expressions for Kubernetes objects.
+type Ruleset struct {
+	version    string
+	name       string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	if len(r.name) == 0 {
+		return "Custom Ruleset"
+	}
+	return r.name
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules are declared in the
+// ruleset args and evaluate the objects of the cluster they reference by name in clusterConfigs.
+// Rules which do not reference a cluster evaluate the objects of defaultCluster.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, clusterConfigs map[string]*rest.Config, defaultCluster string) (*Ruleset, error) {
+	ruleset, err := New(
+		WithVersion(rulesetConfig.Version),
+		WithName(rulesetConfig.Name),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := ParseArgs(rulesetConfig.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	clients := map[string]client.Client{}
+	rules := make([]rule.Rule, 0, len(args.Rules))
+	for _, ruleConfig := range args.Rules {
+		cluster := ruleConfig.Cluster
+		if len(cluster) == 0 {
+			cluster = defaultCluster
+		}
+
+		c, ok := clients[cluster]
+		if !ok {
+			clusterConfig, ok := clusterConfigs[cluster]
+			if !ok {
+				return nil, fmt.Errorf("rule %s references unknown cluster %s", ruleConfig.ID, cluster)
+			}
+			if c, err = client.New(clusterConfig, client.Options{}); err != nil {
+				return nil, err
+			}
+			clients[cluster] = c
+		}
+
+		r, err := NewRule(ruleConfig, c)
+		if err != nil {
+			return nil, err
+		}
+
+		if opt, ok := ruleOptions[r.ID()]; ok && opt.Skip != nil && opt.Skip.Enabled {
+			rules = append(rules, rule.NewSkipRule(r.ID(), r.Name(), opt.Skip.Justification, rule.Accepted))
+			continue
+		}
+		rules = append(rules, r)
+	}
+
+	if err := ruleset.AddRules(rules...); err != nil {
+		return nil, err
+	}
+
+	return ruleset, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
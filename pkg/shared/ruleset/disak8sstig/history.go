@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package disak8sstig
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/ruleset/history"
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
+	sharedv1r11 "github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
+)
+
+const (
+	// RulesetID is a constant containing the id of a DISA Kubernetes STIG Ruleset
+	RulesetID = "disa-kubernetes-stig"
+)
+
+// History contains the rules of all DISA Kubernetes STIG versions.
+// A new STIG release is declared with the rules which were added, removed or changed by the release,
+// as listed in the revision history of the release.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1r10",
+		Rules: []string{
+			"242376", "242377", "242378", "242379", "242380", "242381", "242382", "242383", "242384", "242385",
+			"242386", "242387", "242388", "242389", "242390", "242391", "242392", "242393", "242394", "242395",
+			"242396", "242397", "242398", "242399", "242400", "242401", "242402", "242403", "242404", "242405",
+			"242406", "242407", "242408", "242409", "242410", "242411", "242412", "242413", "242414", "242415",
+			"242417", "242418", "242419", "242420", "242421", "242422", "242423", "242424", "242425", "242426",
+			"242427", "242428", "242429", "242430", "242431", "242432", "242433", "242434", "242435", "242436",
+			"242437", "242438", "242442", "242443", "242444", "242445", "242446", "242447", "242448", "242449",
+			"242450", "242451", "242452", "242453", "242454", "242455", "242456", "242457", "242459", "242460",
+			"242461", "242462", "242463", "242464", "242465", "242466", "242467", "245541", "245542", "245543",
+			"245544", "254800", "254801",
+		},
+	},
+	// Kubernetes STIG Version 1, Release 11: removes the checks of the deprecated
+	// kube-proxy and kubelet flags (V-242401, V-242435).
+	history.Version{
+		Version: "v1r11",
+		Base:    "v1r10",
+		Removed: []string{"242401", "242435"},
+	},
+)
+
+// ApplyTailoring ignores rule options of rules which are not part of the configured version, e.g. of rules
+// retired by a newer version, and applies the tailoring profile of the ruleset config to the rule options.
+// Only the parameters of the given rules which are part of the configured version can be tailored.
+func ApplyTailoring(logger *slog.Logger, rulesetConfig config.RulesetConfig, ruleOptions map[string]config.RuleOptionsConfig, tailorableRuleIDs ...string) (*tailoring.Profile, error) {
+	versionRuleIDs, err := History.RuleIDs(rulesetConfig.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	optionRuleIDs := make([]string, 0, len(ruleOptions))
+	for id := range ruleOptions {
+		optionRuleIDs = append(optionRuleIDs, id)
+	}
+	otherVersionRuleIDs, err := History.OtherVersionRuleIDs(rulesetConfig.Version, optionRuleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range otherVersionRuleIDs {
+		logger.Warn("ignoring rule options of rule which is not part of the ruleset version, the configuration can be migrated with the ruleset migrate command", "rule", id)
+		delete(ruleOptions, id)
+	}
+
+	var ids []string
+	for _, id := range tailorableRuleIDs {
+		if slices.Contains(versionRuleIDs, id) {
+			ids = append(ids, id)
+		}
+	}
+	return tailoring.Apply(rulesetConfig, ruleOptions, sharedv1r11.TailoringSchema(ids...))
+}
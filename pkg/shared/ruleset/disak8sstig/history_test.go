@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package disak8sstig_test
+
+import (
+	"bytes"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/shared/ruleset/disak8sstig"
+)
+
+var _ = Describe("History", func() {
+	It("should contain all supported versions", func() {
+		var versions []string
+		for _, v := range disak8sstig.History.Versions() {
+			versions = append(versions, v.Version)
+		}
+		Expect(versions).To(Equal([]string{"v1r10", "v1r11"}))
+	})
+
+	It("should not contain retired rules in newer versions", func() {
+		v1r11, err := disak8sstig.History.RuleIDs("v1r11")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(v1r11).To(HaveLen(91))
+		Expect(v1r11).ToNot(ContainElements("242401", "242435"))
+	})
+
+	It("should report the delta between v1r10 and v1r11", func() {
+		diff, err := disak8sstig.History.Diff("v1r10", "v1r11")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(diff.Added).To(BeEmpty())
+		Expect(diff.Removed).To(Equal([]string{"242401", "242435"}))
+		Expect(diff.Changed).To(BeEmpty())
+	})
+
+	Describe("#ApplyTailoring", func() {
+		var (
+			buf    *bytes.Buffer
+			logger *slog.Logger
+		)
+
+		BeforeEach(func() {
+			buf = &bytes.Buffer{}
+			logger = slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+		})
+
+		It("should ignore rule options of retired rules with a warning", func() {
+			rulesetConfig := config.RulesetConfig{ID: disak8sstig.RulesetID, Version: "v1r11"}
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"242401": {RuleID: "242401"},
+				"242376": {RuleID: "242376"},
+				"foo":    {RuleID: "foo"},
+			}
+
+			profile, err := disak8sstig.ApplyTailoring(logger, rulesetConfig, ruleOptions, "242376")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile).To(BeNil())
+			Expect(ruleOptions).To(Equal(map[string]config.RuleOptionsConfig{
+				"242376": {RuleID: "242376"},
+				"foo":    {RuleID: "foo"},
+			}))
+			Expect(buf.String()).To(Equal("level=WARN msg=\"ignoring rule options of rule which is not part of the ruleset version, the configuration can be migrated with the ruleset migrate command\" rule=242401\n"))
+		})
+
+		It("should return error for an unknown version", func() {
+			rulesetConfig := config.RulesetConfig{ID: disak8sstig.RulesetID, Version: "v0r1"}
+
+			_, err := disak8sstig.ApplyTailoring(logger, rulesetConfig, nil)
+
+			Expect(err).To(MatchError("ruleset disa-kubernetes-stig does not have version v0r1"))
+		})
+
+		It("should not return a profile without tailoring", func() {
+			rulesetConfig := config.RulesetConfig{ID: disak8sstig.RulesetID, Version: "v1r11"}
+			ruleOptions := map[string]config.RuleOptionsConfig{"242376": {RuleID: "242376"}}
+
+			profile, err := disak8sstig.ApplyTailoring(logger, rulesetConfig, ruleOptions, "242376")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile).To(BeNil())
+		})
+	})
+})
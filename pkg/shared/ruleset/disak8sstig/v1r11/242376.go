@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -20,10 +21,20 @@ var _ rule.Rule = &Rule242376{}
 type Rule242376 struct {
 	Client         client.Client
 	Namespace      string
+	Options        *Options242376
 	DeploymentName string
 	ContainerName  string
 }
 
+type Options242376 struct {
+	MinTLSVersion string `json:"minTLSVersion" yaml:"minTLSVersion"`
+}
+
+// Validate validates the options of the rule.
+func (o *Options242376) Validate() error {
+	return validateMinTLSVersion(o.MinTLSVersion)
+}
+
 func (r *Rule242376) ID() string {
 	return ID242376
 }
@@ -52,17 +63,41 @@ func (r *Rule242376) Run(ctx context.Context) (rule.RuleResult, error) {
 		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
 	}
 
-	// empty options are allowed because min version defaults to TLS 1.2
+	minTLSVersion := defaultMinTLSVersion
+	if r.Options != nil && r.Options.MinTLSVersion != "" {
+		minTLSVersion = r.Options.MinTLSVersion
+	}
+
 	switch {
+	// empty options are allowed when the default min version TLS 1.2 is allowed
+	case len(optSlice) == 0 && tlsVersionLess(defaultMinTLSVersion, minTLSVersion):
+		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s has not been set.", option), target)), nil
 	case len(optSlice) == 0:
 		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s has not been set.", option), target)), nil
 	case len(optSlice) > 1:
 		return rule.SingleCheckResult(r, rule.WarningCheckResult(fmt.Sprintf("Option %s has been set more than once in container command.", option), target)), nil
-	case slices.Contains([]string{"VersionTLS10", "VersionTLS11"}, optSlice[0]):
+	case !slices.Contains(tlsVersions, optSlice[0]):
+		return rule.SingleCheckResult(r, rule.WarningCheckResult(fmt.Sprintf("Option %s has been set to unknown value.", option), target)), nil
+	case tlsVersionLess(optSlice[0], minTLSVersion):
 		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s set to not allowed value.", option), target)), nil
-	case slices.Contains([]string{"VersionTLS12", "VersionTLS13"}, optSlice[0]):
-		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s set to allowed value.", option), target)), nil
 	default:
-		return rule.SingleCheckResult(r, rule.WarningCheckResult(fmt.Sprintf("Option %s has been set to unknown value.", option), target)), nil
+		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s set to allowed value.", option), target)), nil
+	}
+}
+
+const defaultMinTLSVersion = "VersionTLS12"
+
+// tlsVersions contains the known tls versions in ascending order.
+var tlsVersions = []string{"VersionTLS10", "VersionTLS11", "VersionTLS12", "VersionTLS13"}
+
+// validateMinTLSVersion returns an error when the min tls version is set to an unknown tls version.
+func validateMinTLSVersion(minTLSVersion string) error {
+	if minTLSVersion == "" || slices.Contains(tlsVersions, minTLSVersion) {
+		return nil
 	}
+	return fmt.Errorf("invalid minTLSVersion %s: must be one of %s", minTLSVersion, strings.Join(tlsVersions, ", "))
+}
+
+func tlsVersionLess(a, b string) bool {
+	return slices.Index(tlsVersions, a) < slices.Index(tlsVersions, b)
 }
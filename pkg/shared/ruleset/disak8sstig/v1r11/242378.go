@@ -20,10 +20,20 @@ var _ rule.Rule = &Rule242378{}
 type Rule242378 struct {
 	Client         client.Client
 	Namespace      string
+	Options        *Options242378
 	DeploymentName string
 	ContainerName  string
 }
 
+type Options242378 struct {
+	MinTLSVersion string `json:"minTLSVersion" yaml:"minTLSVersion"`
+}
+
+// Validate validates the options of the rule.
+func (o *Options242378) Validate() error {
+	return validateMinTLSVersion(o.MinTLSVersion)
+}
+
 func (r *Rule242378) ID() string {
 	return ID242378
 }
@@ -52,17 +62,24 @@ func (r *Rule242378) Run(ctx context.Context) (rule.RuleResult, error) {
 		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
 	}
 
-	// empty options are allowed because min version defaults to TLS 1.2
+	minTLSVersion := defaultMinTLSVersion
+	if r.Options != nil && r.Options.MinTLSVersion != "" {
+		minTLSVersion = r.Options.MinTLSVersion
+	}
+
 	switch {
+	// empty options are allowed when the default min version TLS 1.2 is allowed
+	case len(optSlice) == 0 && tlsVersionLess(defaultMinTLSVersion, minTLSVersion):
+		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s has not been set.", option), target)), nil
 	case len(optSlice) == 0:
 		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s has not been set.", option), target)), nil
 	case len(optSlice) > 1:
 		return rule.SingleCheckResult(r, rule.WarningCheckResult(fmt.Sprintf("Option %s has been set more than once in container command.", option), target)), nil
-	case slices.Contains([]string{"VersionTLS10", "VersionTLS11"}, optSlice[0]):
+	case !slices.Contains(tlsVersions, optSlice[0]):
+		return rule.SingleCheckResult(r, rule.WarningCheckResult(fmt.Sprintf("Option %s has been set to unknown value.", option), target)), nil
+	case tlsVersionLess(optSlice[0], minTLSVersion):
 		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s set to not allowed value.", option), target)), nil
-	case slices.Contains([]string{"VersionTLS12", "VersionTLS13"}, optSlice[0]):
-		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s set to allowed value.", option), target)), nil
 	default:
-		return rule.SingleCheckResult(r, rule.WarningCheckResult(fmt.Sprintf("Option %s has been set to unknown value.", option), target)), nil
+		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s set to allowed value.", option), target)), nil
 	}
 }
@@ -127,4 +127,40 @@ var _ = Describe("#242378", func() {
 			[]rule.CheckResult{{Status: rule.Errored, Message: "deployment: kube-apiserver does not contain container: kube-apiserver", Target: target}},
 			BeNil()),
 	)
+
+	DescribeTable("Run cases with min tls version option",
+		func(container corev1.Container, minTLSVersion string, expectedCheckResults []rule.CheckResult) {
+			deployment.Spec.Template.Spec.Containers = []corev1.Container{container}
+			Expect(fakeClient.Create(ctx, deployment)).To(Succeed())
+
+			r := &v1r11.Rule242378{Client: fakeClient, Namespace: namespace, Options: &v1r11.Options242378{MinTLSVersion: minTLSVersion}}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal(expectedCheckResults))
+		},
+
+		Entry("should fail when tls-min-version is not set and the default is lower than the min version",
+			corev1.Container{Name: "kube-apiserver", Command: []string{"--flag1=value1"}}, "VersionTLS13",
+			[]rule.CheckResult{{Status: rule.Failed, Message: "Option tls-min-version has not been set.", Target: target}}),
+		Entry("should fail when tls-min-version is set lower than the min version",
+			corev1.Container{Name: "kube-apiserver", Command: []string{"--tls-min-version=VersionTLS12"}}, "VersionTLS13",
+			[]rule.CheckResult{{Status: rule.Failed, Message: "Option tls-min-version set to not allowed value.", Target: target}}),
+		Entry("should pass when tls-min-version is set to the min version",
+			corev1.Container{Name: "kube-apiserver", Command: []string{"--tls-min-version=VersionTLS13"}}, "VersionTLS13",
+			[]rule.CheckResult{{Status: rule.Passed, Message: "Option tls-min-version set to allowed value.", Target: target}}),
+		Entry("should pass when tls-min-version is set higher than a lower min version",
+			corev1.Container{Name: "kube-apiserver", Command: []string{"--tls-min-version=VersionTLS11"}}, "VersionTLS11",
+			[]rule.CheckResult{{Status: rule.Passed, Message: "Option tls-min-version set to allowed value.", Target: target}}),
+	)
+	Describe("#Validate", func() {
+		It("should accept known tls versions", func() {
+			Expect((&v1r11.Options242378{MinTLSVersion: "VersionTLS13"}).Validate()).To(Succeed())
+			Expect((&v1r11.Options242378{}).Validate()).To(Succeed())
+		})
+
+		It("should return an error for unknown tls versions", func() {
+			Expect((&v1r11.Options242378{MinTLSVersion: "TLS13"}).Validate()).To(MatchError("invalid minTLSVersion TLS13: must be one of VersionTLS10, VersionTLS11, VersionTLS12, VersionTLS13"))
+		})
+	})
 })
@@ -21,10 +21,15 @@ var _ rule.Rule = &Rule242418{}
 type Rule242418 struct {
 	Client         client.Client
 	Namespace      string
+	Options        *Options242418
 	DeploymentName string
 	ContainerName  string
 }
 
+type Options242418 struct {
+	RequiredCipherSuites []string `json:"requiredCipherSuites" yaml:"requiredCipherSuites"`
+}
+
 func (r *Rule242418) ID() string {
 	return ID242418
 }
@@ -67,6 +72,9 @@ func (r *Rule242418) Run(ctx context.Context) (rule.RuleResult, error) {
 		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
 		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
 	}
+	if r.Options != nil && len(r.Options.RequiredCipherSuites) > 0 {
+		requiredCiphers = r.Options.RequiredCipherSuites
+	}
 
 	if utils.Subset(requiredCiphers, ciphers) {
 		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("Option %s set to allowed values.", option), target)), nil
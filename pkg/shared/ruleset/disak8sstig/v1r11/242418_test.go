@@ -101,4 +101,15 @@ var _ = Describe("#242418", func() {
 			[]rule.CheckResult{{Status: rule.Errored, Message: "deployment: kube-apiserver does not contain container: kube-apiserver", Target: target}},
 			BeNil()),
 	)
+
+	It("should use the required cipher suites from the options", func() {
+		kcmDeployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "kube-apiserver", Command: []string{"--tls-cipher-suites=TLS_AES_128_GCM_SHA256,TLS_AES_256_GCM_SHA384"}}}
+		Expect(fakeClient.Create(ctx, kcmDeployment)).To(Succeed())
+
+		r := &v1r11.Rule242418{Client: fakeClient, Namespace: namespace, Options: &v1r11.Options242418{RequiredCipherSuites: []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384"}}}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{{Status: rule.Passed, Message: "Option tls-cipher-suites set to allowed values.", Target: target}}))
+	})
 })
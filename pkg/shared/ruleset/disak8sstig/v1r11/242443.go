@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1r11
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/vulnerability"
+)
+
+var _ rule.Rule = &Rule242443{}
+
+type Rule242443 struct {
+	// PodSources are the pods whose running images are checked.
+	PodSources []PodSource
+	Options    *Options242443
+}
+
+// PodSource selects the pods of a cluster. All namespaces are selected when
+// Namespace is empty and all pods are selected when Selector is nil.
+type PodSource struct {
+	Client    client.Client
+	Namespace string
+	Selector  labels.Selector
+	// Target describes the pods in check results, e.g. cluster=seed.
+	Target rule.Target
+}
+
+// Options242443 configures the offline vulnerability database.
+type Options242443 struct {
+	// Advisories is the path of an OSV advisory file or of a directory with OSV advisory files.
+	Advisories string `json:"advisories" yaml:"advisories"`
+	// SBOMs is the path of a directory with a CycloneDX or SPDX JSON file per image digest.
+	// The file of digest sha256:abc is named sha256-abc.json.
+	SBOMs string `json:"sboms" yaml:"sboms"`
+	// MinSeverity is the lowest severity of reported vulnerabilities. Defaults to CRITICAL.
+	MinSeverity string `json:"minSeverity" yaml:"minSeverity"`
+}
+
+// Validate validates the options of the rule.
+func (o *Options242443) Validate() error {
+	if o.MinSeverity == "" {
+		return nil
+	}
+	if _, err := vulnerability.ParseSeverity(o.MinSeverity); err != nil {
+		return fmt.Errorf("invalid minSeverity: %w", err)
+	}
+	return nil
+}
+
+// runningImage is an image which is run by a container.
+type runningImage struct {
+	// name is the repository of the image, or the image of the container
+	// if the image id of the container does not contain a repository.
+	name   string
+	digest string
+}
+
+// imageFromStatus returns the running image of a container status. Container runtimes prefix
+// image ids with their scheme, e.g. docker-pullable:// or containerd://, and the image ids
+// of images which were not pulled from a registry only consist of the digest, e.g. sha256:abc.
+func imageFromStatus(status corev1.ContainerStatus) (runningImage, bool) {
+	imageID := status.ImageID
+	if _, withoutScheme, ok := strings.Cut(imageID, "://"); ok {
+		imageID = withoutScheme
+	}
+
+	if name, digest, ok := strings.Cut(imageID, "@"); ok && strings.Contains(digest, ":") {
+		return runningImage{name: name, digest: digest}, true
+	}
+	if algorithm, hex, ok := strings.Cut(imageID, ":"); ok && len(algorithm) > 0 && len(hex) > 0 && !strings.ContainsAny(imageID, "/@") {
+		return runningImage{name: status.Image, digest: imageID}, true
+	}
+	return runningImage{}, false
+}
+
+func (r *Rule242443) ID() string {
+	return ID242443
+}
+
+func (r *Rule242443) Name() string {
+	return "Kubernetes must contain the latest updates as authorized by IAVMs, CTOs, DTMs, and STIGs (MEDIUM 242443)"
+}
+
+func (r *Rule242443) Run(ctx context.Context) (rule.RuleResult, error) {
+	if r.Options == nil || r.Options.Advisories == "" {
+		return rule.SingleCheckResult(r, rule.SkippedCheckResult("Scanning/patching security vulnerabilities should be enforced organizationally. Security vulnerability scanning should be automated and maintainers should be informed automatically.", rule.NewTarget())), nil
+	}
+
+	minSeverity := vulnerability.SeverityCritical
+	if r.Options.MinSeverity != "" {
+		var err error
+		if minSeverity, err = vulnerability.ParseSeverity(r.Options.MinSeverity); err != nil {
+			return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), rule.NewTarget())), nil
+		}
+	}
+
+	advisories, err := vulnerability.ReadAdvisories(r.Options.Advisories)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), rule.NewTarget())), nil
+	}
+	database := vulnerability.NewDatabase(advisories)
+
+	var (
+		images []runningImage
+		// unknownDigests are the images of the containers whose image ids do not contain a digest
+		unknownDigests []string
+	)
+	for _, source := range r.PodSources {
+		selector := source.Selector
+		if selector == nil {
+			selector = labels.NewSelector()
+		}
+
+		pods, err := kubeutils.GetPods(ctx, source.Client, source.Namespace, selector, 300)
+		if err != nil {
+			target := rule.NewTarget("kind", "podList")
+			maps.Copy(target, source.Target)
+			if source.Namespace != "" {
+				target = target.With("namespace", source.Namespace)
+			}
+			return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
+		}
+
+		for _, pod := range pods {
+			for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+				for _, status := range statuses {
+					image, ok := imageFromStatus(status)
+					switch {
+					case !ok && !slices.Contains(unknownDigests, status.Image):
+						unknownDigests = append(unknownDigests, status.Image)
+					case ok && !slices.Contains(images, image):
+						images = append(images, image)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].name != images[j].name {
+			return images[i].name < images[j].name
+		}
+		return images[i].digest < images[j].digest
+	})
+	sort.Strings(unknownDigests)
+
+	checkResults := []rule.CheckResult{}
+	for _, image := range images {
+		target := rule.NewTarget("image", image.name, "digest", image.digest)
+		components, err := vulnerability.ReadSBOM(filepath.Join(r.Options.SBOMs, strings.Replace(image.digest, ":", "-", 1)+".json"))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			checkResults = append(checkResults, rule.WarningCheckResult("No SBOM found for image digest.", target))
+			continue
+		case err != nil:
+			checkResults = append(checkResults, rule.ErroredCheckResult(err.Error(), target))
+			continue
+		}
+
+		var findings []string
+		for _, finding := range database.Match(components) {
+			if finding.Severity >= minSeverity {
+				findings = append(findings, fmt.Sprintf("%s (%s) in %s", finding.Advisory.ID, finding.Severity, finding.Component))
+			}
+		}
+		if len(findings) > 0 {
+			msg := fmt.Sprintf("Image has known vulnerabilities with severity %s or higher.", minSeverity)
+			checkResults = append(checkResults, rule.FailedCheckResult(msg, target.With("details", strings.Join(findings, "; "))))
+			continue
+		}
+		checkResults = append(checkResults, rule.PassedCheckResult(fmt.Sprintf("Image has no known vulnerabilities with severity %s or higher.", minSeverity), target))
+	}
+	for _, image := range unknownDigests {
+		checkResults = append(checkResults, rule.WarningCheckResult("Image digest could not be determined.", rule.NewTarget("image", image)))
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("There are no running containers.", rule.NewTarget())), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1r11_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/disak8sstig/v1r11"
+)
+
+var _ = Describe("#242443", func() {
+	const (
+		vulnerableDigest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		patchedDigest    = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+		unknownDigest    = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+		advisories       = `[
+  {"id": "CVE-2024-0001", "database_specific": {"severity": "CRITICAL"}, "affected": [{"package": {"ecosystem": "Go", "name": "golang.org/x/net"}, "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "0.23.0"}]}]}]},
+  {"id": "CVE-2024-0002", "database_specific": {"severity": "HIGH"}, "affected": [{"package": {"ecosystem": "Go", "name": "golang.org/x/net"}, "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "0.24.0"}]}]}]}
+]`
+	)
+
+	var (
+		fakeClient client.Client
+		ctx        = context.TODO()
+		dir        string
+		options    *v1r11.Options242443
+		sbom       = func(version string) []byte {
+			return []byte(`{"components": [{"purl": "pkg:golang/golang.org/x/net@v` + version + `"}]}`)
+		}
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "advisories.json"), []byte(advisories), 0600)).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(dir, "sboms"), 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "sboms", "sha256-1111111111111111111111111111111111111111111111111111111111111111.json"), sbom("0.22.0"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "sboms", "sha256-2222222222222222222222222222222222222222222222222222222222222222.json"), sbom("0.23.0"), 0600)).To(Succeed())
+		options = &v1r11.Options242443{Advisories: filepath.Join(dir, "advisories.json"), SBOMs: filepath.Join(dir, "sboms")}
+
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "foo"},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", Image: "eu.gcr.io/foo:v1", ImageID: "eu.gcr.io/foo@" + vulnerableDigest}},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "patched", Image: "eu.gcr.io/bar:v1", ImageID: "docker-pullable://eu.gcr.io/bar@" + patchedDigest},
+					{Name: "unknown", Image: "eu.gcr.io/baz:v1", ImageID: "eu.gcr.io/baz@" + unknownDigest},
+					{Name: "local", Image: "local:v1", ImageID: "docker://sha256:4444"},
+					{Name: "pending", Image: "eu.gcr.io/pending:v1"},
+				},
+			},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "bar"},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "foo", Image: "eu.gcr.io/foo:v1", ImageID: "eu.gcr.io/foo@" + vulnerableDigest}},
+			},
+		})).To(Succeed())
+	})
+
+	It("should skip the rule when no advisories are configured", func() {
+		r := &v1r11.Rule242443{PodSources: []v1r11.PodSource{{Client: fakeClient}}}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.SkippedCheckResult("Scanning/patching security vulnerabilities should be enforced organizationally. Security vulnerability scanning should be automated and maintainers should be informed automatically.", rule.NewTarget()),
+		}))
+	})
+
+	It("should report images with critical vulnerabilities", func() {
+		r := &v1r11.Rule242443{PodSources: []v1r11.PodSource{{Client: fakeClient}}, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Image has no known vulnerabilities with severity CRITICAL or higher.", rule.NewTarget("image", "eu.gcr.io/bar", "digest", patchedDigest)),
+			rule.WarningCheckResult("No SBOM found for image digest.", rule.NewTarget("image", "eu.gcr.io/baz", "digest", unknownDigest)),
+			rule.FailedCheckResult("Image has known vulnerabilities with severity CRITICAL or higher.", rule.NewTarget("image", "eu.gcr.io/foo", "digest", vulnerableDigest, "details", "CVE-2024-0001 (CRITICAL) in golang.org/x/net@v0.22.0")),
+			rule.WarningCheckResult("No SBOM found for image digest.", rule.NewTarget("image", "local:v1", "digest", "sha256:4444")),
+			rule.WarningCheckResult("Image digest could not be determined.", rule.NewTarget("image", "eu.gcr.io/pending:v1")),
+		}))
+	})
+
+	It("should strip the prefixes of all container runtimes from the image ids", func() {
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "baz"},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "containerd", Image: "eu.gcr.io/foo:v1", ImageID: "containerd://eu.gcr.io/foo@" + vulnerableDigest},
+					{Name: "cri-o", Image: "eu.gcr.io/bar:v1", ImageID: "cri-o://eu.gcr.io/bar@" + patchedDigest},
+				},
+			},
+		})).To(Succeed())
+
+		r := &v1r11.Rule242443{PodSources: []v1r11.PodSource{{Client: fakeClient, Namespace: "baz"}}, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Image has no known vulnerabilities with severity CRITICAL or higher.", rule.NewTarget("image", "eu.gcr.io/bar", "digest", patchedDigest)),
+			rule.FailedCheckResult("Image has known vulnerabilities with severity CRITICAL or higher.", rule.NewTarget("image", "eu.gcr.io/foo", "digest", vulnerableDigest, "details", "CVE-2024-0001 (CRITICAL) in golang.org/x/net@v0.22.0")),
+		}))
+	})
+
+	It("should report vulnerabilities above the configured severity of the selected pods", func() {
+		options.MinSeverity = "high"
+		r := &v1r11.Rule242443{PodSources: []v1r11.PodSource{{Client: fakeClient, Namespace: "bar"}}, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Image has known vulnerabilities with severity HIGH or higher.", rule.NewTarget("image", "eu.gcr.io/foo", "digest", vulnerableDigest, "details", "CVE-2024-0001 (CRITICAL) in golang.org/x/net@v0.22.0; CVE-2024-0002 (HIGH) in golang.org/x/net@v0.22.0")),
+		}))
+	})
+
+	It("should pass images without known vulnerabilities", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "sboms", "sha256-1111111111111111111111111111111111111111111111111111111111111111.json"), sbom("0.24.0"), 0600)).To(Succeed())
+		r := &v1r11.Rule242443{PodSources: []v1r11.PodSource{{Client: fakeClient, Namespace: "bar"}}, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Image has no known vulnerabilities with severity CRITICAL or higher.", rule.NewTarget("image", "eu.gcr.io/foo", "digest", vulnerableDigest)),
+		}))
+	})
+
+	It("should pass when there are no running containers", func() {
+		r := &v1r11.Rule242443{PodSources: []v1r11.PodSource{{Client: fakeClient, Namespace: "empty"}}, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("There are no running containers.", rule.NewTarget()),
+		}))
+	})
+
+	It("should error when the min severity is unknown", func() {
+		options.MinSeverity = "foo"
+		r := &v1r11.Rule242443{PodSources: []v1r11.PodSource{{Client: fakeClient}}, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("unknown severity foo", rule.NewTarget()),
+		}))
+	})
+
+	Describe("#Validate", func() {
+		It("should accept known severities", func() {
+			Expect((&v1r11.Options242443{MinSeverity: "moderate"}).Validate()).To(Succeed())
+			Expect((&v1r11.Options242443{}).Validate()).To(Succeed())
+		})
+
+		It("should return an error for unknown severities", func() {
+			Expect((&v1r11.Options242443{MinSeverity: "foo"}).Validate()).To(MatchError("invalid minSeverity: unknown severity foo"))
+		})
+	})
+})
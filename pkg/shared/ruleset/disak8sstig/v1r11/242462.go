@@ -20,10 +20,15 @@ var _ rule.Rule = &Rule242462{}
 type Rule242462 struct {
 	Client         client.Client
 	Namespace      string
+	Options        *Options242462
 	DeploymentName string
 	ContainerName  string
 }
 
+type Options242462 struct {
+	MinAuditLogMaxSize int64 `json:"minAuditLogMaxSize" yaml:"minAuditLogMaxSize"`
+}
+
 func (r *Rule242462) ID() string {
 	return ID242462
 }
@@ -64,7 +69,12 @@ func (r *Rule242462) Run(ctx context.Context) (rule.RuleResult, error) {
 		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
 	}
 
-	if auditLogMaxSize < 100 {
+	minAuditLogMaxSize := int64(100)
+	if r.Options != nil && r.Options.MinAuditLogMaxSize > 0 {
+		minAuditLogMaxSize = r.Options.MinAuditLogMaxSize
+	}
+
+	if auditLogMaxSize < minAuditLogMaxSize {
 		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s set to not allowed value.", option), target)), nil
 	}
 
@@ -20,10 +20,15 @@ var _ rule.Rule = &Rule242463{}
 type Rule242463 struct {
 	Client         client.Client
 	Namespace      string
+	Options        *Options242463
 	DeploymentName string
 	ContainerName  string
 }
 
+type Options242463 struct {
+	MinAuditLogMaxBackup int64 `json:"minAuditLogMaxBackup" yaml:"minAuditLogMaxBackup"`
+}
+
 func (r *Rule242463) ID() string {
 	return ID242463
 }
@@ -63,7 +68,12 @@ func (r *Rule242463) Run(ctx context.Context) (rule.RuleResult, error) {
 		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
 	}
 
-	if auditLogMaxBackup < 10 {
+	minAuditLogMaxBackup := int64(10)
+	if r.Options != nil && r.Options.MinAuditLogMaxBackup > 0 {
+		minAuditLogMaxBackup = r.Options.MinAuditLogMaxBackup
+	}
+
+	if auditLogMaxBackup < minAuditLogMaxBackup {
 		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s set to not allowed value.", option), target)), nil
 	}
 
@@ -20,10 +20,15 @@ var _ rule.Rule = &Rule242464{}
 type Rule242464 struct {
 	Client         client.Client
 	Namespace      string
+	Options        *Options242464
 	DeploymentName string
 	ContainerName  string
 }
 
+type Options242464 struct {
+	MinAuditLogMaxAge int64 `json:"minAuditLogMaxAge" yaml:"minAuditLogMaxAge"`
+}
+
 func (r *Rule242464) ID() string {
 	return ID242464
 }
@@ -64,7 +69,12 @@ func (r *Rule242464) Run(ctx context.Context) (rule.RuleResult, error) {
 		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
 	}
 
-	if auditLogMaxAge < 30 {
+	minAuditLogMaxAge := int64(30)
+	if r.Options != nil && r.Options.MinAuditLogMaxAge > 0 {
+		minAuditLogMaxAge = r.Options.MinAuditLogMaxAge
+	}
+
+	if auditLogMaxAge < minAuditLogMaxAge {
 		return rule.SingleCheckResult(r, rule.FailedCheckResult(fmt.Sprintf("Option %s set to not allowed value.", option), target)), nil
 	}
 
@@ -101,4 +101,15 @@ var _ = Describe("#242464", func() {
 			[]rule.CheckResult{{Status: rule.Errored, Message: "deployment: kube-apiserver does not contain container: kube-apiserver", Target: target}},
 			BeNil()),
 	)
+
+	It("should use the minimum value from the options", func() {
+		kcmDeployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "kube-apiserver", Command: []string{"--audit-log-maxage=60"}}}
+		Expect(fakeClient.Create(ctx, kcmDeployment)).To(Succeed())
+
+		r := &v1r11.Rule242464{Client: fakeClient, Namespace: namespace, Options: &v1r11.Options242464{MinAuditLogMaxAge: 90}}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{{Status: rule.Failed, Message: "Option audit-log-maxage set to not allowed value.", Target: target}}))
+	})
 })
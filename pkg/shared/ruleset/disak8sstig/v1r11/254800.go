@@ -33,7 +33,7 @@ type Rule254800 struct {
 }
 
 type Options254800 struct {
-	MinPodSecurityLevel string
+	MinPodSecurityLevel string `json:"minPodSecurityLevel" yaml:"minPodSecurityLevel"`
 }
 
 func (r *Rule254800) ID() string {
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1r11
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/tailoring"
+)
+
+var (
+	minimumOne = int64(1)
+
+	tailoringSchema = tailoring.Schema{
+		ID242376: {
+			{Name: "minTLSVersion", Type: tailoring.ParameterTypeString, Enum: tlsVersions},
+		},
+		ID242378: {
+			{Name: "minTLSVersion", Type: tailoring.ParameterTypeString, Enum: tlsVersions},
+		},
+		ID242418: {
+			{Name: "requiredCipherSuites", Type: tailoring.ParameterTypeStringList},
+		},
+		ID242462: {
+			{Name: "minAuditLogMaxSize", Type: tailoring.ParameterTypeInteger, Minimum: &minimumOne},
+		},
+		ID242463: {
+			{Name: "minAuditLogMaxBackup", Type: tailoring.ParameterTypeInteger, Minimum: &minimumOne},
+		},
+		ID242464: {
+			{Name: "minAuditLogMaxAge", Type: tailoring.ParameterTypeInteger, Minimum: &minimumOne},
+		},
+		ID254800: {
+			{Name: "minPodSecurityLevel", Type: tailoring.ParameterTypeString, Enum: []string{"privileged", "baseline", "restricted"}},
+		},
+	}
+)
+
+// TailoringSchema returns the parameters of the rules with the given ids
+// which can be set by a tailoring profile. Rules without such parameters are omitted.
+func TailoringSchema(ids ...string) tailoring.Schema {
+	schema := tailoring.Schema{}
+	for _, id := range ids {
+		if parameters, ok := tailoringSchema[id]; ok {
+			schema[id] = parameters
+		}
+	}
+	return schema
+}
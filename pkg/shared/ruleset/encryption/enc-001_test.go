@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+var _ = Describe("#enc-001", func() {
+	const encryptionConfig = `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - configmaps
+  providers:
+  - identity: {}
+  - aescbc:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+- resources:
+  - '*.apps'
+  providers:
+  - kms:
+      apiVersion: v2
+      name: kms
+      endpoint: unix:///kms.sock
+- resources:
+  - secrets
+  providers:
+  - aesgcm:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+`
+	var ctx = context.TODO()
+
+	It("should pass when secrets are encrypted", func() {
+		r := &encryption.ResourceRule{APIServer: newAPIServer(encryptionConfig)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("enc-001"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Resource is encrypted with provider aesgcm.", target.With("resource", "secrets")),
+		}))
+	})
+
+	It("should check the resources of the rule options in addition to secrets", func() {
+		r := &encryption.ResourceRule{APIServer: newAPIServer(encryptionConfig), Options: &encryption.ResourceOptions{
+			Resources: []string{"secrets", "configmaps", "deployments.apps", "leases.coordination.k8s.io"},
+		}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Resource is encrypted with provider aesgcm.", target.With("resource", "secrets")),
+			rule.FailedCheckResult("Resource is not encrypted because the identity provider is the first provider.", target.With("resource", "configmaps")),
+			rule.PassedCheckResult("Resource is encrypted with provider kms-v2.", target.With("resource", "deployments.apps")),
+			rule.FailedCheckResult("Resource is not encrypted.", target.With("resource", "leases.coordination.k8s.io")),
+		}))
+	})
+
+	It("should match all resources with the wildcard", func() {
+		r := &encryption.ResourceRule{APIServer: newAPIServer(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - '*.*'
+  providers:
+  - secretbox:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+`), Options: &encryption.ResourceOptions{Resources: []string{"leases.coordination.k8s.io"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Resource is encrypted with provider secretbox.", target.With("resource", "secrets")),
+			rule.PassedCheckResult("Resource is encrypted with provider secretbox.", target.With("resource", "leases.coordination.k8s.io")),
+		}))
+	})
+
+	It("should fail when the encryption provider config is not set", func() {
+		r := &encryption.ResourceRule{APIServer: newAPIServer("")}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Option encryption-provider-config has not been set.", target),
+		}))
+	})
+
+	It("should error when the kube-apiserver is not found", func() {
+		apiServer := newAPIServer(encryptionConfig)
+		apiServer.Client = fakeclient.NewClientBuilder().Build()
+		r := &encryption.ResourceRule{APIServer: apiServer}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult(`deployments.apps "kube-apiserver" not found`, target),
+		}))
+	})
+})
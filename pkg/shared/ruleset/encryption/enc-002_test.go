@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+var _ = Describe("#enc-002", func() {
+	var ctx = context.TODO()
+
+	It("should report the resources whose identity provider is placed before an encryption provider", func() {
+		r := &encryption.IdentityRule{APIServer: newAPIServer(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - aesgcm:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+  - identity: {}
+- resources:
+  - configmaps
+  providers:
+  - identity: {}
+  - aescbc:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+- resources:
+  - events
+  providers:
+  - identity: {}
+`)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("enc-002"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Identity provider is not placed before encryption providers.", target.With("resources", "secrets")),
+			rule.FailedCheckResult("Identity provider is placed before encryption provider aescbc.", target.With("resources", "configmaps")),
+			rule.PassedCheckResult("Identity provider is not placed before encryption providers.", target.With("resources", "events")),
+		}))
+	})
+
+	It("should fail when the encryption provider config is not set", func() {
+		r := &encryption.IdentityRule{APIServer: newAPIServer("")}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Option encryption-provider-config has not been set.", target),
+		}))
+	})
+})
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+var _ = Describe("#enc-003", func() {
+	const encryptionConfig = `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - kms:
+      apiVersion: v2
+      name: kms
+      endpoint: unix:///kms.sock
+- resources:
+  - configmaps
+  providers:
+  - aescbc:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+- resources:
+  - '*.apps'
+  providers:
+  - kms:
+      name: kms
+      endpoint: unix:///kms.sock
+- resources:
+  - events
+  providers:
+  - identity: {}
+`
+	var ctx = context.TODO()
+
+	It("should report the resources which are encrypted with providers other than aesgcm and kms-v2", func() {
+		r := &encryption.ProviderRule{APIServer: newAPIServer(encryptionConfig)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("enc-003"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Resources are encrypted with allowed provider kms-v2.", target.With("resources", "secrets")),
+			rule.FailedCheckResult("Resources are encrypted with provider aescbc which is not allowed.", target.With("resources", "configmaps", "details", "allowed providers: aesgcm, kms-v2")),
+			rule.FailedCheckResult("Resources are encrypted with provider kms-v1 which is not allowed.", target.With("resources", "*.apps", "details", "allowed providers: aesgcm, kms-v2")),
+			rule.FailedCheckResult("Resources are not encrypted by any provider.", target.With("resources", "events")),
+		}))
+	})
+
+	It("should respect the allowed providers of the rule options", func() {
+		r := &encryption.ProviderRule{APIServer: newAPIServer(encryptionConfig), Options: &encryption.ProviderOptions{AllowedProviders: []string{"aescbc", "kms-v1"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Resources are encrypted with provider kms-v2 which is not allowed.", target.With("resources", "secrets", "details", "allowed providers: aescbc, kms-v1")),
+			rule.PassedCheckResult("Resources are encrypted with allowed provider aescbc.", target.With("resources", "configmaps")),
+			rule.PassedCheckResult("Resources are encrypted with allowed provider kms-v1.", target.With("resources", "*.apps")),
+			rule.FailedCheckResult("Resources are not encrypted by any provider.", target.With("resources", "events")),
+		}))
+	})
+
+	It("should error when a provider of the rule options is unknown", func() {
+		r := &encryption.ProviderRule{APIServer: newAPIServer(encryptionConfig), Options: &encryption.ProviderOptions{AllowedProviders: []string{"foo"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("unknown provider foo", target),
+		}))
+	})
+
+	It("should return an error when a provider of the rule options is unknown", func() {
+		apiServer := newAPIServer(encryptionConfig)
+		_, err := encryption.Rules(&apiServer, map[string]config.RuleOptionsConfig{"enc-003": {Args: map[string]any{"allowedProviders": []string{"foo"}}}})
+		Expect(err).To(MatchError("invalid options of rule enc-003: unknown provider foo"))
+	})
+})
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+var _ = Describe("#enc-004", func() {
+	var (
+		ctx = context.TODO()
+
+		recentKeyCreated = time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+		recentKey        = fmt.Sprintf("key%d", recentKeyCreated.Unix())
+		oldKey           = "key1600000000"
+		encryptionConfig = `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - aesgcm:
+      keys:
+      - name: ` + recentKey + `
+        secret: c2VjcmV0
+      - name: ` + oldKey + `
+        secret: c2VjcmV0
+- resources:
+  - configmaps
+  providers:
+  - secretbox:
+      keys:
+      - name: foo
+        secret: c2VjcmV0
+- resources:
+  - '*.apps'
+  providers:
+  - kms:
+      apiVersion: v2
+      name: kms
+      endpoint: unix:///kms.sock
+`
+	)
+
+	It("should report the keys which have not been rotated within 90 days", func() {
+		r := &encryption.RotationRule{APIServer: newAPIServer(encryptionConfig)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("enc-004"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Key was created at "+recentKeyCreated.Format(time.RFC3339)+".", target.With("resources", "secrets", "provider", "aesgcm", "key", recentKey)),
+			rule.WarningCheckResult("Creation time of key cannot be determined.", target.With("resources", "configmaps", "provider", "secretbox", "key", "foo")),
+			rule.PassedCheckResult("Keys of provider kms-v2 are managed by the KMS plugin.", target.With("resources", "*.apps")),
+		}))
+	})
+
+	It("should respect the maximum key age of the rule options", func() {
+		r := &encryption.RotationRule{APIServer: newAPIServer(encryptionConfig), Options: &encryption.RotationOptions{MaxKeyAge: "1h"}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.FailedCheckResult("Key was created at "+recentKeyCreated.Format(time.RFC3339)+" and has not been rotated within 1h0m0s.", target.With("resources", "secrets", "provider", "aesgcm", "key", recentKey)),
+		))
+	})
+})
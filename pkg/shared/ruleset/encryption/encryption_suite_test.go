@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+func TestEncryption(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Encryption at Rest Ruleset Test Suite")
+}
+
+const namespace = "shoot--foo--bar"
+
+// target is the target of the check results of the kube-apiserver created by newAPIServer.
+var target = rule.NewTarget("cluster", "seed", "kind", "deployment", "name", "kube-apiserver", "namespace", namespace)
+
+// newAPIServer creates a kube-apiserver deployment which reads the encryption configuration from a secret.
+// The deployment does not set the encryption-provider-config flag when the configuration is empty.
+func newAPIServer(encryptionConfig string) encryption.APIServer {
+	var (
+		ctx        = context.TODO()
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		container  = corev1.Container{Name: "kube-apiserver", Command: []string{"kube-apiserver"}}
+		volumes    []corev1.Volume
+	)
+	if encryptionConfig != "" {
+		container.Command = append(container.Command, "--encryption-provider-config=/etc/kubernetes/etcd-encryption-secret/encryption-configuration.yaml")
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "etcd-encryption-secret", MountPath: "/etc/kubernetes/etcd-encryption-secret"}}
+		volumes = []corev1.Volume{{
+			Name:         "etcd-encryption-secret",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "kube-apiserver-etcd-encryption-configuration"}},
+		}}
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver-etcd-encryption-configuration", Namespace: namespace},
+			Data:       map[string][]byte{"encryption-configuration.yaml": []byte(encryptionConfig)},
+		})).To(Succeed())
+	}
+	Expect(fakeClient.Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver", Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{container}, Volumes: volumes},
+			},
+		},
+	})).To(Succeed())
+
+	return encryption.APIServer{
+		Client:         fakeClient,
+		Namespace:      namespace,
+		DeploymentName: "kube-apiserver",
+		ContainerName:  "kube-apiserver",
+		Target:         rule.NewTarget("cluster", "seed"),
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// History contains the rules of all Encryption at Rest versions.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1",
+		Rules:   []string{"enc-001", "enc-002", "enc-003", "enc-004"},
+	},
+)
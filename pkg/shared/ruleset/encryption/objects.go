@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// APIServer selects the deployment of a kube-apiserver. The encryption configuration is read from
+// the file referenced by the encryption-provider-config flag of its container.
+type APIServer struct {
+	Client         client.Client
+	Namespace      string
+	DeploymentName string
+	ContainerName  string
+	// Target describes the cluster of the kube-apiserver, e.g. cluster=seed.
+	Target rule.Target
+}
+
+// target returns the target of the kube-apiserver deployment.
+func (a APIServer) target() rule.Target {
+	target := rule.NewTarget("kind", "deployment", "name", a.DeploymentName, "namespace", a.Namespace)
+	maps.Copy(target, a.Target)
+	return target
+}
+
+// readConfig returns the encryption configuration of the kube-apiserver. The configuration is nil
+// without an error when the encryption-provider-config flag is not set.
+func readConfig(ctx context.Context, apiServer APIServer) (*apiserverconfigv1.EncryptionConfiguration, error) {
+	deployment := &appsv1.Deployment{}
+	if err := apiServer.Client.Get(ctx, client.ObjectKey{Name: apiServer.DeploymentName, Namespace: apiServer.Namespace}, deployment); err != nil {
+		return nil, err
+	}
+
+	container, found := kubeutils.GetContainerFromDeployment(deployment, apiServer.ContainerName)
+	if !found {
+		return nil, fmt.Errorf("deployment: %s does not contain container: %s", apiServer.DeploymentName, apiServer.ContainerName)
+	}
+
+	values := kubeutils.FindFlagValueRaw(append(container.Command, container.Args...), "encryption-provider-config")
+	switch {
+	case len(values) == 0 || values[0] == "":
+		return nil, nil
+	case len(values) > 1:
+		return nil, fmt.Errorf("option encryption-provider-config has been set more than once in container command")
+	}
+
+	data, err := kubeutils.GetVolumeConfigByteSliceByMountPath(ctx, apiServer.Client, deployment, apiServer.ContainerName, values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	config := &apiserverconfigv1.EncryptionConfiguration{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to decode encryption configuration: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,388 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+const (
+	providerIdentity  = "identity"
+	providerAESCBC    = "aescbc"
+	providerAESGCM    = "aesgcm"
+	providerSecretbox = "secretbox"
+	providerKMSv1     = "kms-v1"
+	providerKMSv2     = "kms-v2"
+)
+
+// keyTimestamp matches the unix timestamp at the end of key names, e.g. key1700000000.
+var keyTimestamp = regexp.MustCompile(`(\d{10})$`)
+
+// ResourceOptions are the rule options of the rule which checks that resources are encrypted.
+type ResourceOptions struct {
+	// Resources are the resources which must be encrypted in addition to secrets,
+	// in the format of the encryption configuration, e.g. configmaps or deployments.apps.
+	Resources []string `json:"resources" yaml:"resources"`
+}
+
+// ProviderOptions are the rule options of the rule which checks the providers used to encrypt resources.
+type ProviderOptions struct {
+	// AllowedProviders are the providers which can be used to encrypt resources. Defaults to aesgcm and kms-v2.
+	AllowedProviders []string `json:"allowedProviders" yaml:"allowedProviders"`
+}
+
+// RotationOptions are the rule options of the rule which checks the rotation of encryption keys.
+type RotationOptions struct {
+	// MaxKeyAge is the maximum age of the keys used to encrypt resources, e.g. 2160h.
+	MaxKeyAge string `json:"maxKeyAge" yaml:"maxKeyAge"`
+}
+
+// resources returns secrets and the configured resources.
+func (o *ResourceOptions) resources() []string {
+	resources := []string{"secrets"}
+	if o == nil {
+		return resources
+	}
+	for _, resource := range o.Resources {
+		if !slices.Contains(resources, resource) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// allowedProviders returns the configured allowed providers or aesgcm and kms-v2.
+func (o *ProviderOptions) allowedProviders() ([]string, error) {
+	if o == nil || len(o.AllowedProviders) == 0 {
+		return []string{providerAESGCM, providerKMSv2}, nil
+	}
+	for _, provider := range o.AllowedProviders {
+		if !slices.Contains([]string{providerAESCBC, providerAESGCM, providerSecretbox, providerKMSv1, providerKMSv2}, provider) {
+			return nil, fmt.Errorf("unknown provider %s", provider)
+		}
+	}
+	return o.AllowedProviders, nil
+}
+
+// maxKeyAge returns the configured maximum key age or 90 days.
+func (o *RotationOptions) maxKeyAge() (time.Duration, error) {
+	if o == nil || o.MaxKeyAge == "" {
+		return 90 * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(o.MaxKeyAge)
+}
+
+// providerName returns the name of the provider.
+func providerName(provider apiserverconfigv1.ProviderConfiguration) string {
+	switch {
+	case provider.AESGCM != nil:
+		return providerAESGCM
+	case provider.AESCBC != nil:
+		return providerAESCBC
+	case provider.Secretbox != nil:
+		return providerSecretbox
+	case provider.KMS != nil && provider.KMS.APIVersion == "v2":
+		return providerKMSv2
+	case provider.KMS != nil:
+		return providerKMSv1
+	default:
+		return providerIdentity
+	}
+}
+
+// keys returns the keys of the provider. KMS providers do not have keys in the encryption configuration.
+func keys(provider apiserverconfigv1.ProviderConfiguration) []apiserverconfigv1.Key {
+	switch {
+	case provider.AESGCM != nil:
+		return provider.AESGCM.Keys
+	case provider.AESCBC != nil:
+		return provider.AESCBC.Keys
+	case provider.Secretbox != nil:
+		return provider.Secretbox.Keys
+	default:
+		return nil
+	}
+}
+
+// firstEncryptionProvider returns the first provider of the resource configuration which is not the identity provider.
+func firstEncryptionProvider(resourceConfig apiserverconfigv1.ResourceConfiguration) (apiserverconfigv1.ProviderConfiguration, bool) {
+	for _, provider := range resourceConfig.Providers {
+		if providerName(provider) != providerIdentity {
+			return provider, true
+		}
+	}
+	return apiserverconfigv1.ProviderConfiguration{}, false
+}
+
+// matchesResource returns whether a resource of the encryption configuration, e.g. *.apps, matches the resource,
+// e.g. deployments.apps. Resources of the core group do not have a group suffix and are matched by *. and *.*.
+func matchesResource(pattern, resource string) bool {
+	if pattern == "*.*" || pattern == resource {
+		return true
+	}
+	_, group, _ := strings.Cut(resource, ".")
+	return pattern == "*."+group
+}
+
+// resourceConfiguration returns the first resource configuration which matches the resource, like the kube-apiserver does.
+func resourceConfiguration(config *apiserverconfigv1.EncryptionConfiguration, resource string) (apiserverconfigv1.ResourceConfiguration, bool) {
+	for _, resourceConfig := range config.Resources {
+		if slices.ContainsFunc(resourceConfig.Resources, func(pattern string) bool { return matchesResource(pattern, resource) }) {
+			return resourceConfig, true
+		}
+	}
+	return apiserverconfigv1.ResourceConfiguration{}, false
+}
+
+// checkConfig checks the encryption configuration of the kube-apiserver. check returns the check results of
+// the encryption configuration, their targets extend the target of the kube-apiserver.
+func checkConfig(ctx context.Context, r rule.Rule, apiServer APIServer, check func(*apiserverconfigv1.EncryptionConfiguration, rule.Target) []rule.CheckResult) rule.RuleResult {
+	target := apiServer.target()
+	config, err := readConfig(ctx, apiServer)
+	switch {
+	case err != nil:
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target))
+	case config == nil:
+		return rule.SingleCheckResult(r, rule.FailedCheckResult("Option encryption-provider-config has not been set.", target))
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: check(config, target),
+	}
+}
+
+var _ rule.Rule = &ResourceRule{}
+
+// ResourceRule checks that secrets and the configured resources are encrypted at rest.
+type ResourceRule struct {
+	APIServer APIServer
+	Options   *ResourceOptions
+}
+
+// ID returns the id of the rule.
+func (r *ResourceRule) ID() string {
+	return "enc-001"
+}
+
+// Name returns the name of the rule.
+func (r *ResourceRule) Name() string {
+	return "Secrets and configured resources must be encrypted at rest (HIGH enc-001)"
+}
+
+// Run checks the providers of the resources in the encryption configuration of the kube-apiserver.
+func (r *ResourceRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkConfig(ctx, r, r.APIServer, resourceCheck(r.Options.resources())), nil
+}
+
+var _ rule.Rule = &IdentityRule{}
+
+// IdentityRule checks that the identity provider is not placed before encryption providers.
+type IdentityRule struct {
+	APIServer APIServer
+}
+
+// ID returns the id of the rule.
+func (r *IdentityRule) ID() string {
+	return "enc-002"
+}
+
+// Name returns the name of the rule.
+func (r *IdentityRule) Name() string {
+	return "The identity provider must not be placed before encryption providers (HIGH enc-002)"
+}
+
+// Run checks the order of the providers in the encryption configuration of the kube-apiserver.
+func (r *IdentityRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkConfig(ctx, r, r.APIServer, identityCheck), nil
+}
+
+var _ rule.Rule = &ProviderRule{}
+
+// ProviderRule checks that resources are encrypted with the allowed providers.
+type ProviderRule struct {
+	APIServer APIServer
+	Options   *ProviderOptions
+}
+
+// ID returns the id of the rule.
+func (r *ProviderRule) ID() string {
+	return "enc-003"
+}
+
+// Name returns the name of the rule.
+func (r *ProviderRule) Name() string {
+	return "Resources must be encrypted with allowed providers (MEDIUM enc-003)"
+}
+
+// Run checks the encryption providers in the encryption configuration of the kube-apiserver.
+func (r *ProviderRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	allowedProviders, err := r.Options.allowedProviders()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.APIServer.target())), nil
+	}
+	return checkConfig(ctx, r, r.APIServer, providerCheck(allowedProviders)), nil
+}
+
+var _ rule.Rule = &RotationRule{}
+
+// RotationRule checks that the keys of the encryption providers are rotated.
+type RotationRule struct {
+	APIServer APIServer
+	Options   *RotationOptions
+}
+
+// ID returns the id of the rule.
+func (r *RotationRule) ID() string {
+	return "enc-004"
+}
+
+// Name returns the name of the rule.
+func (r *RotationRule) Name() string {
+	return "Encryption keys must be rotated (MEDIUM enc-004)"
+}
+
+// Run checks the age of the keys in the encryption configuration of the kube-apiserver.
+func (r *RotationRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	maxKeyAge, err := r.Options.maxKeyAge()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(fmt.Sprintf("invalid maximum key age: %s", err), r.APIServer.target())), nil
+	}
+	return checkConfig(ctx, r, r.APIServer, rotationCheck(time.Now, maxKeyAge)), nil
+}
+
+func resourceCheck(resources []string) func(*apiserverconfigv1.EncryptionConfiguration, rule.Target) []rule.CheckResult {
+	return func(config *apiserverconfigv1.EncryptionConfiguration, target rule.Target) []rule.CheckResult {
+		checkResults := make([]rule.CheckResult, 0, len(resources))
+		for _, resource := range resources {
+			resourceTarget := target.With("resource", resource)
+			resourceConfig, ok := resourceConfiguration(config, resource)
+			if !ok || len(resourceConfig.Providers) == 0 {
+				checkResults = append(checkResults, rule.FailedCheckResult("Resource is not encrypted.", resourceTarget))
+				continue
+			}
+
+			name := providerName(resourceConfig.Providers[0])
+			if name == providerIdentity {
+				checkResults = append(checkResults, rule.FailedCheckResult("Resource is not encrypted because the identity provider is the first provider.", resourceTarget))
+				continue
+			}
+			checkResults = append(checkResults, rule.PassedCheckResult(fmt.Sprintf("Resource is encrypted with provider %s.", name), resourceTarget))
+		}
+		return checkResults
+	}
+}
+
+func identityCheck(config *apiserverconfigv1.EncryptionConfiguration, target rule.Target) []rule.CheckResult {
+	checkResults := make([]rule.CheckResult, 0, len(config.Resources))
+	for _, resourceConfig := range config.Resources {
+		resourceTarget := target.With("resources", strings.Join(resourceConfig.Resources, ", "))
+
+		var (
+			identityFound bool
+			shadowed      string
+		)
+		for _, provider := range resourceConfig.Providers {
+			name := providerName(provider)
+			if name == providerIdentity {
+				identityFound = true
+				continue
+			}
+			if identityFound {
+				shadowed = name
+				break
+			}
+		}
+
+		if shadowed != "" {
+			msg := fmt.Sprintf("Identity provider is placed before encryption provider %s.", shadowed)
+			checkResults = append(checkResults, rule.FailedCheckResult(msg, resourceTarget))
+			continue
+		}
+		checkResults = append(checkResults, rule.PassedCheckResult("Identity provider is not placed before encryption providers.", resourceTarget))
+	}
+	return checkResults
+}
+
+func providerCheck(allowedProviders []string) func(*apiserverconfigv1.EncryptionConfiguration, rule.Target) []rule.CheckResult {
+	return func(config *apiserverconfigv1.EncryptionConfiguration, target rule.Target) []rule.CheckResult {
+		checkResults := make([]rule.CheckResult, 0, len(config.Resources))
+		for _, resourceConfig := range config.Resources {
+			resourceTarget := target.With("resources", strings.Join(resourceConfig.Resources, ", "))
+			provider, ok := firstEncryptionProvider(resourceConfig)
+			if !ok {
+				checkResults = append(checkResults, rule.FailedCheckResult("Resources are not encrypted by any provider.", resourceTarget))
+				continue
+			}
+
+			name := providerName(provider)
+			if !slices.Contains(allowedProviders, name) {
+				msg := fmt.Sprintf("Resources are encrypted with provider %s which is not allowed.", name)
+				checkResults = append(checkResults, rule.FailedCheckResult(msg, resourceTarget.With("details", "allowed providers: "+strings.Join(allowedProviders, ", "))))
+				continue
+			}
+			checkResults = append(checkResults, rule.PassedCheckResult(fmt.Sprintf("Resources are encrypted with allowed provider %s.", name), resourceTarget))
+		}
+		return checkResults
+	}
+}
+
+func rotationCheck(now func() time.Time, maxKeyAge time.Duration) func(*apiserverconfigv1.EncryptionConfiguration, rule.Target) []rule.CheckResult {
+	return func(config *apiserverconfigv1.EncryptionConfiguration, target rule.Target) []rule.CheckResult {
+		checkResults := make([]rule.CheckResult, 0, len(config.Resources))
+		for _, resourceConfig := range config.Resources {
+			resourceTarget := target.With("resources", strings.Join(resourceConfig.Resources, ", "))
+			provider, ok := firstEncryptionProvider(resourceConfig)
+			if !ok {
+				continue
+			}
+
+			name := providerName(provider)
+			providerKeys := keys(provider)
+			if len(providerKeys) == 0 {
+				checkResults = append(checkResults, rule.PassedCheckResult(fmt.Sprintf("Keys of provider %s are managed by the KMS plugin.", name), resourceTarget))
+				continue
+			}
+
+			// the first key of a provider is used for encryption
+			key := providerKeys[0]
+			keyTarget := resourceTarget.With("provider", name, "key", key.Name)
+			match := keyTimestamp.FindStringSubmatch(key.Name)
+			if match == nil {
+				checkResults = append(checkResults, rule.WarningCheckResult("Creation time of key cannot be determined.", keyTarget))
+				continue
+			}
+
+			seconds, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil {
+				checkResults = append(checkResults, rule.ErroredCheckResult(err.Error(), keyTarget))
+				continue
+			}
+			created := time.Unix(seconds, 0).UTC()
+			if now().Sub(created) > maxKeyAge {
+				msg := fmt.Sprintf("Key was created at %s and has not been rotated within %s.", created.Format(time.RFC3339), maxKeyAge)
+				checkResults = append(checkResults, rule.FailedCheckResult(msg, keyTarget))
+				continue
+			}
+			checkResults = append(checkResults, rule.PassedCheckResult(fmt.Sprintf("Key was created at %s.", created.Format(time.RFC3339)), keyTarget))
+		}
+
+		if len(checkResults) == 0 {
+			return []rule.CheckResult{rule.FailedCheckResult("Encryption configuration does not contain encryption providers.", target)}
+		}
+		return checkResults
+	}
+}
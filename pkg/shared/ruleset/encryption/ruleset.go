@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of an Encryption at Rest Ruleset
+	RulesetID = "encryption-at-rest"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset checks the encryption configuration of a kube-apiserver.
+type Ruleset struct {
+	version    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "Encryption at Rest"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules check the encryption configuration of the
+// kube-apiserver. The rules report a warning when apiServer is nil, i.e. the kube-apiserver is not accessible.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, apiServer *APIServer) (*Ruleset, error) {
+	ruleset, err := New(WithVersion(rulesetConfig.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	rules, err := Rules(apiServer, ruleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// RuleIDs are the ids of the rules of the ruleset.
+var RuleIDs = []string{"enc-001", "enc-002", "enc-003", "enc-004"}
+
+// Rules creates the rules of the ruleset which check the encryption configuration of the kube-apiserver.
+// When apiServer is nil the rules report a warning since the encryption configuration cannot be checked.
+func Rules(apiServer *APIServer, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	resourceOptions, err := parseOptions[ResourceOptions](ruleOptions["enc-001"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule enc-001: %w", err)
+	}
+
+	providerOptions, err := parseOptions[ProviderOptions](ruleOptions["enc-003"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule enc-003: %w", err)
+	}
+	if _, err := providerOptions.allowedProviders(); err != nil {
+		return nil, fmt.Errorf("invalid options of rule enc-003: %w", err)
+	}
+
+	rotationOptions, err := parseOptions[RotationOptions](ruleOptions["enc-004"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule enc-004: %w", err)
+	}
+	if _, err := rotationOptions.maxKeyAge(); err != nil {
+		return nil, fmt.Errorf("invalid options of rule enc-004: %w", err)
+	}
+
+	var server APIServer
+	if apiServer != nil {
+		server = *apiServer
+	}
+	rules := []rule.Rule{
+		&ResourceRule{APIServer: server, Options: resourceOptions},
+		&IdentityRule{APIServer: server},
+		&ProviderRule{APIServer: server, Options: providerOptions},
+		&RotationRule{APIServer: server, Options: rotationOptions},
+	}
+
+	if apiServer == nil {
+		skipRules := make([]rule.Rule, 0, len(rules))
+		for _, r := range rules {
+			skipRules = append(skipRules, rule.NewSkipRule(r.ID(), r.Name(), "The encryption configuration of the kube-apiserver is not visible for this cluster.", rule.Warning))
+		}
+		return skipRules, nil
+	}
+	return rules, nil
+}
+
+func parseOptions[O any](args any) (*O, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options O
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
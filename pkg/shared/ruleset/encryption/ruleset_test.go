@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+var _ = Describe("#Rules", func() {
+	It("should warn when the kube-apiserver is not accessible", func() {
+		rules, err := encryption.Rules(nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		justification := "The encryption configuration of the kube-apiserver is not visible for this cluster."
+		Expect(rules).To(Equal([]rule.Rule{
+			rule.NewSkipRule("enc-001", "Secrets and configured resources must be encrypted at rest (HIGH enc-001)", justification, rule.Warning),
+			rule.NewSkipRule("enc-002", "The identity provider must not be placed before encryption providers (HIGH enc-002)", justification, rule.Warning),
+			rule.NewSkipRule("enc-003", "Resources must be encrypted with allowed providers (MEDIUM enc-003)", justification, rule.Warning),
+			rule.NewSkipRule("enc-004", "Encryption keys must be rotated (MEDIUM enc-004)", justification, rule.Warning),
+		}))
+	})
+})
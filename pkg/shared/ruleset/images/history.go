@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// History contains the rules of all Image Hygiene versions.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1",
+		Rules:   []string{"img-001", "img-002", "img-003", "img-004", "img-005"},
+	},
+)
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+)
+
+// maxListedContainers is the max number of containers which are listed in the check result of an image.
+const maxListedContainers = 10
+
+// image is a container image used by the containers of a cluster.
+type image struct {
+	name      string
+	reference Reference
+	// statusDigests are the digests of the image which the containers are running.
+	statusDigests []string
+	// containers are the containers using the image in the format namespace/pod/container.
+	containers []string
+}
+
+// digests returns the digests of the image. The digest of the reference is
+// used for pinned images, otherwise the digests of the container statuses.
+func (i image) digests() []string {
+	if i.reference.Digest != "" {
+		return []string{i.reference.Digest}
+	}
+	return i.statusDigests
+}
+
+// listedContainers returns a comma separated list of the containers using the image.
+func (i image) listedContainers() string {
+	if len(i.containers) <= maxListedContainers {
+		return strings.Join(i.containers, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(i.containers[:maxListedContainers], ", "), len(i.containers)-maxListedContainers)
+}
+
+// imagesFromPods returns the images of all containers, init containers and
+// ephemeral containers of the pods sorted by their name.
+func imagesFromPods(pods []corev1.Pod) []image {
+	imagesByName := map[string]*image{}
+	add := func(pod corev1.Pod, containerName, imageName string, statuses []corev1.ContainerStatus) {
+		i, ok := imagesByName[imageName]
+		if !ok {
+			i = &image{name: imageName, reference: ParseReference(imageName)}
+			imagesByName[imageName] = i
+		}
+		i.containers = append(i.containers, fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, containerName))
+
+		statusIdx := slices.IndexFunc(statuses, func(status corev1.ContainerStatus) bool {
+			return status.Name == containerName
+		})
+		if statusIdx < 0 {
+			return
+		}
+		if digest := digestFromImageID(statuses[statusIdx].ImageID); digest != "" && !slices.Contains(i.statusDigests, digest) {
+			i.statusDigests = append(i.statusDigests, digest)
+		}
+	}
+
+	for _, pod := range pods {
+		for _, container := range pod.Spec.InitContainers {
+			add(pod, container.Name, container.Image, pod.Status.InitContainerStatuses)
+		}
+		for _, container := range pod.Spec.Containers {
+			add(pod, container.Name, container.Image, pod.Status.ContainerStatuses)
+		}
+		for _, container := range pod.Spec.EphemeralContainers {
+			add(pod, container.Name, container.Image, pod.Status.EphemeralContainerStatuses)
+		}
+	}
+
+	images := make([]image, 0, len(imagesByName))
+	for _, i := range imagesByName {
+		sort.Strings(i.containers)
+		sort.Strings(i.statusDigests)
+		images = append(images, *i)
+	}
+	sort.Slice(images, func(a, b int) bool {
+		return images[a].name < images[b].name
+	})
+	return images
+}
+
+// listImages lists the pods of the cluster and returns the images used by their containers.
+func listImages(ctx context.Context, c client.Client) ([]image, error) {
+	pods, err := kubeutils.GetPods(ctx, c, "", labels.NewSelector(), 300)
+	if err != nil {
+		return nil, err
+	}
+	return imagesFromPods(pods), nil
+}
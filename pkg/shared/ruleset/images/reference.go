@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"strings"
+)
+
+const (
+	// defaultRegistry is the registry of images which do not specify a registry.
+	defaultRegistry = "docker.io"
+	// officialRepositoryPrefix is the prefix of repositories of the default registry which consist of a single path component.
+	officialRepositoryPrefix = "library/"
+)
+
+// Reference is a parsed container image reference.
+type Reference struct {
+	// Registry is the host of the registry, e.g. registry.k8s.io.
+	Registry string
+	// Repository is the path of the repository in the registry, e.g. kube-apiserver.
+	Repository string
+	// Tag is the tag of the image. It is empty when no tag is specified.
+	Tag string
+	// Digest is the digest of the image, e.g. sha256:abc... It is empty when the image is not pinned by digest.
+	Digest string
+}
+
+// ParseReference parses an image reference like the container runtimes do. Images
+// without a registry are pulled from docker.io and single component repositories
+// of docker.io are official images in the library repository.
+func ParseReference(image string) Reference {
+	var ref Reference
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, ref.Digest = image[:i], image[i+1:]
+	}
+
+	// a colon after the last slash separates the tag, a colon before it belongs to the registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image, ref.Tag = image[:i], image[i+1:]
+	}
+
+	ref.Registry, ref.Repository = defaultRegistry, image
+	if i := strings.Index(image, "/"); i >= 0 {
+		if host := image[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry, ref.Repository = host, image[i+1:]
+		}
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = officialRepositoryPrefix + ref.Repository
+	}
+	return ref
+}
+
+// Name returns the fully qualified name of the repository of the image.
+func (r Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// Latest returns whether the image uses the latest tag, either explicitly
+// or implicitly because it specifies neither a tag nor a digest.
+func (r Reference) Latest() bool {
+	return r.Tag == "latest" || (r.Tag == "" && r.Digest == "")
+}
+
+// digestFromImageID returns the digest of an image id of a container status, e.g.
+// docker-pullable://registry.k8s.io/pause@sha256:abc... Image ids which do not contain
+// a repository digest identify the image only locally and no digest is returned.
+func digestFromImageID(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/images"
+)
+
+var _ = Describe("#ParseReference", func() {
+	DescribeTable("should parse image references",
+		func(image string, expected images.Reference) {
+			Expect(images.ParseReference(image)).To(Equal(expected))
+		},
+		Entry("official image", "nginx",
+			images.Reference{Registry: "docker.io", Repository: "library/nginx"}),
+		Entry("docker.io image with tag", "bitnami/redis:7.2.4",
+			images.Reference{Registry: "docker.io", Repository: "bitnami/redis", Tag: "7.2.4"}),
+		Entry("image with registry, tag and digest", "registry.k8s.io/pause:3.9@sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097",
+			images.Reference{Registry: "registry.k8s.io", Repository: "pause", Tag: "3.9", Digest: "sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097"}),
+		Entry("image with registry port", "localhost:5000/foo/bar",
+			images.Reference{Registry: "localhost:5000", Repository: "foo/bar"}),
+		Entry("image with localhost registry", "localhost/foo:v1",
+			images.Reference{Registry: "localhost", Repository: "foo", Tag: "v1"}),
+	)
+
+	It("should determine whether the latest tag is used", func() {
+		Expect(images.ParseReference("nginx").Latest()).To(BeTrue())
+		Expect(images.ParseReference("nginx:latest").Latest()).To(BeTrue())
+		Expect(images.ParseReference("nginx@sha256:abc").Latest()).To(BeFalse())
+		Expect(images.ParseReference("nginx:1.25.4").Latest()).To(BeFalse())
+	})
+})
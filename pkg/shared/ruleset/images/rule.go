@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// versionTag matches tags of released versions, e.g. v1.29.3 or 3.19.1-alpine,
+// which are not expected to be moved to another image.
+var versionTag = regexp.MustCompile(`^v?\d+\.\d+\.\d+([-+_.][0-9A-Za-z][0-9A-Za-z._+-]*)?$`)
+
+// Options are the rule options of the image rules.
+type Options struct {
+	AcceptedImages []AcceptedImage `json:"acceptedImages" yaml:"acceptedImages"`
+}
+
+// AcceptedImage selects images which are accepted to violate a rule.
+type AcceptedImage struct {
+	// Name is a shell file name pattern matched against the fully qualified
+	// repository name of the image, e.g. registry.k8s.io/*.
+	Name          string `json:"name" yaml:"name"`
+	Justification string `json:"justification" yaml:"justification"`
+}
+
+func (o *Options) acceptedImages() []AcceptedImage {
+	if o == nil {
+		return nil
+	}
+	return o.AcceptedImages
+}
+
+func accepted(acceptedImages []AcceptedImage, ref Reference) (bool, string) {
+	for _, acceptedImage := range acceptedImages {
+		if ok, _ := path.Match(acceptedImage.Name, ref.Name()); ok {
+			return true, acceptedImage.Justification
+		}
+	}
+	return false, ""
+}
+
+// RegistryOptions are the rule options of the rule which checks the registries of images.
+type RegistryOptions struct {
+	// AllowedRegistries are the registries, optionally followed by a repository path
+	// prefix, e.g. europe-docker.pkg.dev/gardener-project, from which images can be pulled.
+	AllowedRegistries []string        `json:"allowedRegistries" yaml:"allowedRegistries"`
+	AcceptedImages    []AcceptedImage `json:"acceptedImages" yaml:"acceptedImages"`
+}
+
+// allowed returns whether the image is pulled from one of the allowed registries.
+func (o *RegistryOptions) allowed(ref Reference) bool {
+	if o == nil {
+		return false
+	}
+	for _, allowedRegistry := range o.AllowedRegistries {
+		allowedRegistry = strings.TrimSuffix(allowedRegistry, "/")
+		if ref.Name() == allowedRegistry || strings.HasPrefix(ref.Name(), allowedRegistry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkImages checks all images used by the containers of the cluster. The check results are grouped per image.
+// violation returns the reason why an image violates the rule or an empty string.
+func checkImages(ctx context.Context, r rule.Rule, c client.Client, target rule.Target, passedMessage string, violation func(Reference) string, acceptedImages []AcceptedImage) rule.RuleResult {
+	images, err := listImages(ctx, c)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target.With("kind", "podList")))
+	}
+
+	if len(images) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any containers.", target))
+	}
+
+	checkResults := make([]rule.CheckResult, 0, len(images))
+	for _, i := range images {
+		imageTarget := imageTarget(target, i)
+		reason := violation(i.reference)
+		if reason == "" {
+			checkResults = append(checkResults, rule.PassedCheckResult(passedMessage, imageTarget))
+			continue
+		}
+
+		if ok, justification := accepted(acceptedImages, i.reference); ok {
+			if justification == "" {
+				justification = strings.TrimSuffix(reason, ".") + " and is accepted."
+			}
+			checkResults = append(checkResults, rule.AcceptedCheckResult(justification, imageTarget))
+			continue
+		}
+		checkResults = append(checkResults, rule.FailedCheckResult(reason, imageTarget))
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}
+}
+
+func imageTarget(target rule.Target, i image) rule.Target {
+	return target.With("kind", "image", "name", i.name, "containers", i.listedContainers())
+}
+
+var _ rule.Rule = &LatestTagRule{}
+
+// LatestTagRule checks that images do not use the latest tag.
+type LatestTagRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *Options
+}
+
+// ID returns the id of the rule.
+func (r *LatestTagRule) ID() string {
+	return "img-001"
+}
+
+// Name returns the name of the rule.
+func (r *LatestTagRule) Name() string {
+	return "Images must not use the latest tag (MEDIUM img-001)"
+}
+
+// Run checks the tags of all images used by the containers of the cluster.
+func (r *LatestTagRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkImages(ctx, r, r.Client, r.Target, "Image does not use the latest tag.", latestTagViolation, r.Options.acceptedImages()), nil
+}
+
+var _ rule.Rule = &MutableTagRule{}
+
+// MutableTagRule checks that images which are not pinned by digest use a version tag.
+type MutableTagRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *Options
+}
+
+// ID returns the id of the rule.
+func (r *MutableTagRule) ID() string {
+	return "img-002"
+}
+
+// Name returns the name of the rule.
+func (r *MutableTagRule) Name() string {
+	return "Images must not use mutable tags (LOW img-002)"
+}
+
+// Run checks the tags of all images used by the containers of the cluster.
+func (r *MutableTagRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkImages(ctx, r, r.Client, r.Target, "Image uses a version tag or is pinned by digest.", mutableTagViolation, r.Options.acceptedImages()), nil
+}
+
+var _ rule.Rule = &RegistryRule{}
+
+// RegistryRule checks that images are pulled from the registries which are allowed by the rule options.
+type RegistryRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *RegistryOptions
+}
+
+// ID returns the id of the rule.
+func (r *RegistryRule) ID() string {
+	return "img-003"
+}
+
+// Name returns the name of the rule.
+func (r *RegistryRule) Name() string {
+	return "Images must be pulled from allowed registries (HIGH img-003)"
+}
+
+// Run checks the registries of all images used by the containers of the cluster.
+func (r *RegistryRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	var acceptedImages []AcceptedImage
+	if r.Options != nil {
+		acceptedImages = r.Options.AcceptedImages
+	}
+	return checkImages(ctx, r, r.Client, r.Target, "Image is pulled from an allowed registry.", registryViolation(r.Options), acceptedImages), nil
+}
+
+var _ rule.Rule = &DigestRule{}
+
+// DigestRule checks that images are pinned by digest.
+type DigestRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *Options
+}
+
+// ID returns the id of the rule.
+func (r *DigestRule) ID() string {
+	return "img-004"
+}
+
+// Name returns the name of the rule.
+func (r *DigestRule) Name() string {
+	return "Images must be pinned by digest (MEDIUM img-004)"
+}
+
+// Run checks the digests of all images used by the containers of the cluster.
+func (r *DigestRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkImages(ctx, r, r.Client, r.Target, "Image is pinned by digest.", digestViolation, r.Options.acceptedImages()), nil
+}
+
+func latestTagViolation(ref Reference) string {
+	switch {
+	case ref.Tag == "latest":
+		return "Image uses the latest tag."
+	case ref.Latest():
+		return "Image does not specify a tag or a digest and defaults to the latest tag."
+	default:
+		return ""
+	}
+}
+
+func mutableTagViolation(ref Reference) string {
+	if ref.Digest != "" || versionTag.MatchString(ref.Tag) {
+		return ""
+	}
+	if ref.Tag == "" {
+		return "Image does not specify a tag or a digest."
+	}
+	return fmt.Sprintf("Image uses mutable tag %s.", ref.Tag)
+}
+
+func digestViolation(ref Reference) string {
+	if ref.Digest == "" {
+		return "Image is not pinned by digest."
+	}
+	return ""
+}
+
+func registryViolation(options *RegistryOptions) func(Reference) string {
+	return func(ref Reference) string {
+		if options.allowed(ref) {
+			return ""
+		}
+		return fmt.Sprintf("Image is pulled from registry %s which is not allowed.", ref.Registry)
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/images"
+)
+
+var _ = Describe("image rules", func() {
+	const (
+		digest       = "sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097"
+		pinnedImage  = "registry.k8s.io/pause:3.9@" + digest
+		versionImage = "registry.k8s.io/kube-proxy:v1.29.3"
+		latestImage  = "nginx"
+		mutableImage = "quay.io/foo/bar:stable"
+	)
+
+	var (
+		fakeClient    client.Client
+		ctx           = context.TODO()
+		clusterTarget = rule.NewTarget("cluster", "shoot")
+		target        = func(image string, containers ...string) rule.Target {
+			return clusterTarget.With("kind", "image", "name", image, "containers", strings.Join(containers, ", "))
+		}
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Image: pinnedImage}},
+				Containers:     []corev1.Container{{Name: "app", Image: mutableImage}, {Name: "proxy", Image: versionImage}},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: latestImage}},
+				},
+			},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "kube-system"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "proxy", Image: versionImage}},
+			},
+		})).To(Succeed())
+	})
+
+	Describe("#LatestTagRule", func() {
+		It("should pass when the cluster does not have any containers", func() {
+			r := &images.LatestTagRule{Client: fakeclient.NewClientBuilder().Build(), Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.PassedCheckResult("The cluster does not have any containers.", clusterTarget),
+			}))
+		})
+
+		It("should check the latest tag and group the results per image", func() {
+			r := &images.LatestTagRule{Client: fakeClient, Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Image does not specify a tag or a digest and defaults to the latest tag.", target(latestImage, "default/foo/debug")),
+				rule.PassedCheckResult("Image does not use the latest tag.", target(mutableImage, "default/foo/app")),
+				rule.PassedCheckResult("Image does not use the latest tag.", target(versionImage, "default/foo/proxy", "kube-system/bar/proxy")),
+				rule.PassedCheckResult("Image does not use the latest tag.", target(pinnedImage, "default/foo/init")),
+			}))
+		})
+	})
+
+	Describe("#MutableTagRule", func() {
+		It("should check mutable tags", func() {
+			r := &images.MutableTagRule{Client: fakeClient, Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Image does not specify a tag or a digest.", target(latestImage, "default/foo/debug")),
+				rule.FailedCheckResult("Image uses mutable tag stable.", target(mutableImage, "default/foo/app")),
+				rule.PassedCheckResult("Image uses a version tag or is pinned by digest.", target(versionImage, "default/foo/proxy", "kube-system/bar/proxy")),
+				rule.PassedCheckResult("Image uses a version tag or is pinned by digest.", target(pinnedImage, "default/foo/init")),
+			}))
+		})
+
+		It("should accept images selected by the rule options", func() {
+			options := &images.Options{
+				AcceptedImages: []images.AcceptedImage{{Name: "docker.io/library/*", Justification: "debug image"}, {Name: "quay.io/foo/*"}},
+			}
+			r := &images.MutableTagRule{Client: fakeClient, Target: clusterTarget, Options: options}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.AcceptedCheckResult("debug image", target(latestImage, "default/foo/debug")),
+				rule.AcceptedCheckResult("Image uses mutable tag stable and is accepted.", target(mutableImage, "default/foo/app")),
+				rule.PassedCheckResult("Image uses a version tag or is pinned by digest.", target(versionImage, "default/foo/proxy", "kube-system/bar/proxy")),
+				rule.PassedCheckResult("Image uses a version tag or is pinned by digest.", target(pinnedImage, "default/foo/init")),
+			}))
+		})
+	})
+
+	Describe("#RegistryRule", func() {
+		It("should check that images are pulled from allowed registries", func() {
+			options := &images.RegistryOptions{AllowedRegistries: []string{"registry.k8s.io", "quay.io/foo/"}}
+			r := &images.RegistryRule{Client: fakeClient, Target: clusterTarget, Options: options}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Image is pulled from registry docker.io which is not allowed.", target(latestImage, "default/foo/debug")),
+				rule.PassedCheckResult("Image is pulled from an allowed registry.", target(mutableImage, "default/foo/app")),
+				rule.PassedCheckResult("Image is pulled from an allowed registry.", target(versionImage, "default/foo/proxy", "kube-system/bar/proxy")),
+				rule.PassedCheckResult("Image is pulled from an allowed registry.", target(pinnedImage, "default/foo/init")),
+			}))
+		})
+
+		It("should not allow repositories which only share the prefix of an allowed repository", func() {
+			options := &images.RegistryOptions{AllowedRegistries: []string{"quay.io/fo"}}
+			r := &images.RegistryRule{Client: fakeClient, Target: clusterTarget, Options: options}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(ContainElement(
+				rule.FailedCheckResult("Image is pulled from registry quay.io which is not allowed.", target(mutableImage, "default/foo/app")),
+			))
+		})
+	})
+
+	Describe("#DigestRule", func() {
+		It("should check that images are pinned by digest", func() {
+			r := &images.DigestRule{Client: fakeClient, Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Image is not pinned by digest.", target(latestImage, "default/foo/debug")),
+				rule.FailedCheckResult("Image is not pinned by digest.", target(mutableImage, "default/foo/app")),
+				rule.FailedCheckResult("Image is not pinned by digest.", target(versionImage, "default/foo/proxy", "kube-system/bar/proxy")),
+				rule.PassedCheckResult("Image is pinned by digest.", target(pinnedImage, "default/foo/init")),
+			}))
+		})
+	})
+
+	Describe("#Rules", func() {
+		It("should skip the registry rule when no registries are allowed", func() {
+			rules, err := images.Rules(fakeClient, clusterTarget, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rules).To(HaveLen(5))
+
+			ruleResult, err := rules[2].Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.RuleID).To(Equal("img-003"))
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				{Status: rule.Skipped, Message: "No allowed registries are configured."},
+			}))
+		})
+	})
+})
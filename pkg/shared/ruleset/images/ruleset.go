@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of an Image Hygiene Ruleset
+	RulesetID = "image-hygiene"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset checks the images used by the containers of a cluster.
+type Ruleset struct {
+	version    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "Image Hygiene"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules check the
+// objects of the cluster of clusterConfig which is described by clusterTarget.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, clusterConfig *rest.Config, clusterTarget rule.Target) (*Ruleset, error) {
+	ruleset, err := New(WithVersion(rulesetConfig.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	c, err := client.New(clusterConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := Rules(c, clusterTarget, ruleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// Rules creates the rules of the ruleset which check the images of the pods listed by the client.
+// The rule which verifies image signatures is skipped when no public keys are configured
+// and the rule which checks registries is skipped when no registries are allowed.
+func Rules(c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	options := map[string]*Options{}
+	for _, id := range []string{"img-001", "img-002", "img-004"} {
+		opts, err := parseOptions[Options](ruleOptions[id].Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse options of rule %s: %w", id, err)
+		}
+		if opts == nil {
+			opts = &Options{}
+		}
+		options[id] = opts
+	}
+
+	registryOptions, err := parseOptions[RegistryOptions](ruleOptions["img-003"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule img-003: %w", err)
+	}
+
+	signatureOptions, err := parseOptions[SignatureOptions](ruleOptions["img-005"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule img-005: %w", err)
+	}
+
+	rules := []rule.Rule{
+		&LatestTagRule{Client: c, Target: clusterTarget, Options: options["img-001"]},
+		&MutableTagRule{Client: c, Target: clusterTarget, Options: options["img-002"]},
+	}
+
+	registryRule := &RegistryRule{Client: c, Target: clusterTarget, Options: registryOptions}
+	if registryOptions == nil || len(registryOptions.AllowedRegistries) == 0 {
+		rules = append(rules, rule.NewSkipRule(registryRule.ID(), registryRule.Name(), "No allowed registries are configured.", rule.Skipped))
+	} else {
+		rules = append(rules, registryRule)
+	}
+
+	rules = append(rules, &DigestRule{Client: c, Target: clusterTarget, Options: options["img-004"]})
+
+	signatureRule := &SignatureRule{Client: c, Target: clusterTarget, Options: signatureOptions}
+	if signatureOptions == nil || len(signatureOptions.PublicKeys) == 0 {
+		return append(rules, rule.NewSkipRule(signatureRule.ID(), signatureRule.Name(), "No public keys are configured.", rule.Skipped)), nil
+	}
+	if len(signatureOptions.Signatures) == 0 {
+		return nil, errors.New("invalid options of rule img-005: signatures must be set when public keys are configured")
+	}
+	if _, err := readPublicKeys(signatureOptions.PublicKeys); err != nil {
+		return nil, fmt.Errorf("invalid options of rule img-005: %w", err)
+	}
+	return append(rules, signatureRule), nil
+}
+
+func parseOptions[O any](args any) (*O, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options O
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// SignatureOptions are the rule options of the rule which verifies the signatures of images.
+type SignatureOptions struct {
+	// PublicKeys are the paths of PEM encoded public keys which are trusted to sign images.
+	PublicKeys []string `json:"publicKeys" yaml:"publicKeys"`
+	// Signatures is the path of a directory with signature files. Each file contains JSON objects with
+	// a base64Signature and a base64 encoded simple signing payload field, e.g. the output of
+	// cosign download signature.
+	Signatures     string          `json:"signatures" yaml:"signatures"`
+	AcceptedImages []AcceptedImage `json:"acceptedImages" yaml:"acceptedImages"`
+}
+
+// signature is a signature of a simple signing payload.
+type signature struct {
+	Base64Signature string `json:"base64Signature"`
+	Payload         []byte `json:"payload"`
+}
+
+// simpleSigningPayload is the payload signed by container image signatures.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// readPublicKeys reads the PEM encoded public keys of the files.
+func readPublicKeys(files []string) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("file %s does not contain a PEM encoded public key", file)
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key of file %s: %w", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// readSignatures reads the signature files of the directory and returns the signatures by the signed digest.
+func readSignatures(dir string) (map[string][]signature, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := map[string][]signature{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for {
+			var s signature
+			if err := decoder.Decode(&s); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to decode signatures of file %s: %w", file, err)
+			}
+
+			var payload simpleSigningPayload
+			if err := json.Unmarshal(s.Payload, &payload); err != nil {
+				return nil, fmt.Errorf("failed to decode signature payload of file %s: %w", file, err)
+			}
+			digest := payload.Critical.Image.DockerManifestDigest
+			signatures[digest] = append(signatures[digest], s)
+		}
+	}
+	return signatures, nil
+}
+
+// verify returns whether the signature of the payload can be verified with one of the keys.
+func (s signature) verify(keys []crypto.PublicKey) bool {
+	sig, err := base64.StdEncoding.DecodeString(s.Base64Signature)
+	if err != nil {
+		return false
+	}
+
+	hash := sha256.Sum256(s.Payload)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hash[:], sig) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig) == nil || rsa.VerifyPSS(k, crypto.SHA256, hash[:], sig, nil) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, s.Payload, sig) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var _ rule.Rule = &SignatureRule{}
+
+// SignatureRule checks that the digests of all images used by the containers
+// of the cluster are signed by one of the trusted public keys.
+type SignatureRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *SignatureOptions
+}
+
+// ID returns the id of the rule.
+func (r *SignatureRule) ID() string {
+	return "img-005"
+}
+
+// Name returns the name of the rule.
+func (r *SignatureRule) Name() string {
+	return "Image signatures must be verified with trusted public keys (MEDIUM img-005)"
+}
+
+// Run verifies the signatures of all images used by the containers of the cluster.
+func (r *SignatureRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	if r.Options == nil || len(r.Options.PublicKeys) == 0 {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult("no public keys are configured", r.Target)), nil
+	}
+
+	images, err := listImages(ctx, r.Client)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "podList"))), nil
+	}
+
+	keys, err := readPublicKeys(r.Options.PublicKeys)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "publicKeys"))), nil
+	}
+
+	signatures, err := readSignatures(r.Options.Signatures)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "signatures", "name", r.Options.Signatures))), nil
+	}
+
+	if len(images) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any containers.", r.Target)), nil
+	}
+
+	checkResults := make([]rule.CheckResult, 0, len(images))
+	for _, i := range images {
+		target := imageTarget(r.Target, i)
+		digests := i.digests()
+		if len(digests) == 0 {
+			checkResults = append(checkResults, rule.WarningCheckResult("Image digest could not be determined.", target))
+			continue
+		}
+
+		var unverified []string
+		for _, digest := range digests {
+			if !slices.ContainsFunc(signatures[digest], func(s signature) bool { return s.verify(keys) }) {
+				unverified = append(unverified, digest)
+			}
+		}
+
+		if len(unverified) == 0 {
+			checkResults = append(checkResults, rule.PassedCheckResult("Image signature is verified.", target))
+			continue
+		}
+
+		if ok, justification := accepted(r.Options.AcceptedImages, i.reference); ok {
+			if justification == "" {
+				justification = "Image is not signed by a trusted key and is accepted."
+			}
+			checkResults = append(checkResults, rule.AcceptedCheckResult(justification, target))
+			continue
+		}
+		checkResults = append(checkResults, rule.FailedCheckResult("Image is not signed by a trusted key.", target.With("details", "digests: "+strings.Join(unverified, ", "))))
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
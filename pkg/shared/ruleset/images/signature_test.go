@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package images_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/images"
+)
+
+var _ = Describe("#SignatureRule", func() {
+	const (
+		signedDigest   = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		unsignedDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	)
+
+	var (
+		fakeClient    client.Client
+		ctx           = context.TODO()
+		clusterTarget = rule.NewTarget()
+		dir           string
+		options       *images.SignatureOptions
+		key           *ecdsa.PrivateKey
+	)
+
+	sign := func(digest string) map[string]string {
+		payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"foo"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+		hash := sha256.Sum256(payload)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		Expect(err).ToNot(HaveOccurred())
+		return map[string]string{
+			"base64Signature": base64.StdEncoding.EncodeToString(sig),
+			"payload":         base64.StdEncoding.EncodeToString(payload),
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir = GinkgoT().TempDir()
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+		keyFile := filepath.Join(dir, "cosign.pub")
+		Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600)).To(Succeed())
+
+		signaturesDir := filepath.Join(dir, "signatures")
+		Expect(os.Mkdir(signaturesDir, 0700)).To(Succeed())
+		data, err := json.Marshal(sign(signedDigest))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(signaturesDir, "foo.json"), data, 0600)).To(Succeed())
+
+		options = &images.SignatureOptions{
+			PublicKeys:     []string{keyFile},
+			Signatures:     signaturesDir,
+			AcceptedImages: []images.AcceptedImage{{Name: "docker.io/library/*", Justification: "debug image"}},
+		}
+
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "signed", Image: "registry.k8s.io/foo:v1.0.0"},
+					{Name: "unsigned", Image: "registry.k8s.io/bar@" + unsignedDigest},
+					{Name: "pending", Image: "registry.k8s.io/baz:v1.0.0"},
+					{Name: "debug", Image: "busybox:1.36.1"},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "signed", ImageID: "registry.k8s.io/foo@" + signedDigest},
+					{Name: "unsigned", ImageID: "registry.k8s.io/bar@" + unsignedDigest},
+					{Name: "debug", ImageID: "docker.io/library/busybox@" + unsignedDigest},
+				},
+			},
+		})).To(Succeed())
+	})
+
+	It("should verify the signatures of the image digests", func() {
+		target := func(image, container string) rule.Target {
+			return clusterTarget.With("kind", "image", "name", image, "containers", "default/foo/"+container)
+		}
+		r := &images.SignatureRule{Client: fakeClient, Target: clusterTarget, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("debug image", target("busybox:1.36.1", "debug")),
+			rule.FailedCheckResult("Image is not signed by a trusted key.", target("registry.k8s.io/bar@"+unsignedDigest, "unsigned").With("details", "digests: "+unsignedDigest)),
+			rule.WarningCheckResult("Image digest could not be determined.", target("registry.k8s.io/baz:v1.0.0", "pending")),
+			rule.PassedCheckResult("Image signature is verified.", target("registry.k8s.io/foo:v1.0.0", "signed")),
+		}))
+	})
+
+	It("should not verify signatures of untrusted keys", func() {
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := json.Marshal(sign(unsignedDigest))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(dir, "signatures", "bar.json"), data, 0600)).To(Succeed())
+
+		r := &images.SignatureRule{Client: fakeClient, Target: clusterTarget, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ruleResult.CheckResults).To(ContainElement(rule.FailedCheckResult("Image is not signed by a trusted key.",
+			clusterTarget.With("kind", "image", "name", "registry.k8s.io/bar@"+unsignedDigest, "containers", "default/foo/unsigned", "details", "digests: "+unsignedDigest))))
+	})
+
+	It("should error when the signatures cannot be read", func() {
+		options.Signatures = filepath.Join(dir, "missing")
+		r := &images.SignatureRule{Client: fakeClient, Target: clusterTarget, Options: options}
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult(fmt.Sprintf("open %s: no such file or directory", options.Signatures), clusterTarget.With("kind", "signatures", "name", options.Signatures)),
+		}))
+	})
+
+	It("should skip the rule when no public keys are configured", func() {
+		rules, err := images.Rules(fakeClient, clusterTarget, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(5))
+
+		ruleResult, err := rules[4].Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("img-005"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			{Status: rule.Skipped, Message: "No public keys are configured."},
+		}))
+	})
+
+	It("should return an error when the public keys are invalid", func() {
+		invalidKey := filepath.Join(dir, "invalid.pub")
+		Expect(os.WriteFile(invalidKey, []byte("foo"), 0600)).To(Succeed())
+
+		_, err := images.Rules(fakeClient, clusterTarget, map[string]config.RuleOptionsConfig{
+			"img-005": {Args: map[string]any{"publicKeys": []string{invalidKey}, "signatures": dir}},
+		})
+		Expect(err).To(MatchError(fmt.Sprintf("invalid options of rule img-005: file %s does not contain a PEM encoded public key", invalidKey)))
+	})
+})
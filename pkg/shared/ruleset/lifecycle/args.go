@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Args are the arguments of a Kubernetes Version Lifecycle [Ruleset].
+type Args struct {
+	// ReleaseData is the path to a release data file which replaces the release data bundled with diki.
+	ReleaseData string `json:"releaseData,omitempty" yaml:"releaseData,omitempty"`
+}
+
+// ParseArgs parses the generic ruleset arguments into [Args].
+func ParseArgs(args any) (*Args, error) {
+	parsedArgs := Args{}
+	if args != nil {
+		argsByte, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(argsByte, &parsedArgs); err != nil {
+			return nil, fmt.Errorf("failed to parse ruleset args: %w", err)
+		}
+	}
+	return &parsedArgs, nil
+}
+
+// LoadReleaseData returns the release data of the file set in the arguments
+// or the release data bundled with diki if no file is set.
+func (a *Args) LoadReleaseData() (*ReleaseData, error) {
+	if len(a.ReleaseData) == 0 {
+		return DefaultReleaseData(), nil
+	}
+	return LoadReleaseData(a.ReleaseData)
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclient "k8s.io/client-go/testing"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+)
+
+var _ = Describe("#lc-001", func() {
+	var (
+		ctx  = context.TODO()
+		year = 365 * 24 * time.Hour
+		day  = 24 * time.Hour
+
+		releaseData = &lifecycle.ReleaseData{Releases: []lifecycle.Release{
+			{Version: "1.27", EndOfLife: date(-year), LatestPatch: "1.27.16"},
+			{Version: "1.28", EndOfLife: date(-day), LatestPatch: "1.28.15"},
+			{Version: "1.29", EndOfLife: date(10 * day), LatestPatch: "1.29.10"},
+			{Version: "1.31", EndOfLife: date(year), LatestPatch: "1.31.2"},
+		}}
+	)
+
+	It("should report minor versions which are end of life or reach their end of life soon", func() {
+		r := &lifecycle.EndOfLifeRule{
+			Client: newClient(map[string]string{
+				"node-a": "v1.31.0",
+				"node-b": "v1.29.3",
+				"node-c": "v1.28.1",
+				"node-d": "v1.26.5",
+				"node-e": "v1.30.4",
+			}),
+			Discovery:   newDiscovery("v1.31.1"),
+			Target:      clusterTarget,
+			ReleaseData: releaseData,
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("lc-001"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Minor version 1.31 is supported until "+date(year)+".", controlPlaneTarget("v1.31.1")),
+			rule.PassedCheckResult("Minor version 1.31 is supported until "+date(year)+".", nodeTarget("node-a", "v1.31.0")),
+			rule.WarningCheckResult("Minor version 1.29 reaches its end of life on "+date(10*day)+".", nodeTarget("node-b", "v1.29.3")),
+			rule.FailedCheckResult("Minor version 1.28 reached its end of life on "+date(-day)+".", nodeTarget("node-c", "v1.28.1")),
+			rule.FailedCheckResult("Minor version 1.26 is older than all releases of the release data and is end of life.", nodeTarget("node-d", "v1.26.5")),
+			rule.WarningCheckResult("Release data does not contain minor version 1.30.", nodeTarget("node-e", "v1.30.4")),
+		}))
+	})
+
+	It("should respect the warning period of the rule options", func() {
+		r := &lifecycle.EndOfLifeRule{
+			Client:      newClient(map[string]string{"node-a": "v1.29.3"}),
+			Discovery:   newDiscovery("v1.29.3"),
+			Target:      clusterTarget,
+			ReleaseData: releaseData,
+			Options:     &lifecycle.EndOfLifeOptions{WarningPeriod: "24h"},
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Minor version 1.29 is supported until "+date(10*day)+".", controlPlaneTarget("v1.29.3")),
+			rule.PassedCheckResult("Minor version 1.29 is supported until "+date(10*day)+".", nodeTarget("node-a", "v1.29.3")),
+		}))
+	})
+
+	It("should error when the warning period of the rule options is invalid", func() {
+		r := &lifecycle.EndOfLifeRule{
+			Client:      newClient(nil),
+			Discovery:   newDiscovery("v1.31.1"),
+			Target:      clusterTarget,
+			ReleaseData: releaseData,
+			Options:     &lifecycle.EndOfLifeOptions{WarningPeriod: "foo"},
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult(`invalid warning period: time: invalid duration "foo"`, clusterTarget),
+		}))
+
+		_, err = lifecycle.Rules(r.Client, r.Discovery, clusterTarget, releaseData, map[string]config.RuleOptionsConfig{"lc-001": {Args: map[string]any{"warningPeriod": "foo"}}})
+		Expect(err).To(MatchError(`invalid options of rule lc-001: time: invalid duration "foo"`))
+	})
+
+	It("should error when the control plane version cannot be read", func() {
+		discovery := newDiscovery("v1.31.1")
+		discovery.AddReactor("get", "version", func(testingclient.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("foo")
+		})
+		r := &lifecycle.EndOfLifeRule{Client: newClient(nil), Discovery: discovery, Target: clusterTarget, ReleaseData: releaseData}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("foo", clusterTarget),
+		}))
+	})
+})
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+)
+
+var _ = Describe("#lc-002", func() {
+	var (
+		ctx = context.TODO()
+
+		// the end of life does not matter for the patch versions
+		releaseData = &lifecycle.ReleaseData{Releases: []lifecycle.Release{
+			{Version: "1.29", EndOfLife: "2025-02-28", LatestPatch: "1.29.15"},
+			{Version: "1.30", EndOfLife: "2025-06-28", LatestPatch: "1.30.5"},
+		}}
+	)
+
+	It("should report versions which lag behind the latest patch release", func() {
+		r := &lifecycle.PatchRule{
+			Client: newClient(map[string]string{
+				"node-a": "v1.30.5",
+				"node-b": "v1.29.3",
+				"node-c": "foo",
+			}),
+			Discovery:   newDiscovery("v1.30.2"),
+			Target:      clusterTarget,
+			ReleaseData: releaseData,
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("lc-002"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Version lags 3 patch releases behind the latest patch release 1.30.5.", controlPlaneTarget("v1.30.2")),
+			rule.PassedCheckResult("Version lags 0 patch releases behind the latest patch release 1.30.5.", nodeTarget("node-a", "v1.30.5")),
+			rule.FailedCheckResult("Version lags 12 patch releases behind the latest patch release 1.29.15.", nodeTarget("node-b", "v1.29.3").With("details", "maximum patch lag: 3")),
+			rule.ErroredCheckResult("Version cannot be parsed: Invalid Semantic Version.", nodeTarget("node-c", "foo")),
+		}))
+	})
+
+	It("should respect the maximum patch lag of the rule options", func() {
+		r := &lifecycle.PatchRule{
+			Client:      newClient(map[string]string{"node-a": "v1.30.5"}),
+			Discovery:   newDiscovery("v1.30.2"),
+			Target:      clusterTarget,
+			ReleaseData: releaseData,
+			Options:     &lifecycle.PatchOptions{MaxPatchLag: pointer.Uint64(0)},
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Version lags 3 patch releases behind the latest patch release 1.30.5.", controlPlaneTarget("v1.30.2").With("details", "maximum patch lag: 0")),
+			rule.PassedCheckResult("Version lags 0 patch releases behind the latest patch release 1.30.5.", nodeTarget("node-a", "v1.30.5")),
+		}))
+	})
+
+	It("should error when the release data is invalid", func() {
+		r := &lifecycle.PatchRule{
+			Client:    newClient(nil),
+			Discovery: newDiscovery("v1.30.2"),
+			Target:    clusterTarget,
+			ReleaseData: &lifecycle.ReleaseData{Releases: []lifecycle.Release{
+				{Version: "1.30", EndOfLife: "2025-06-28", LatestPatch: "1.29.15"},
+			}},
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("invalid release data: invalid latest patch 1.29.15 of release 1.30", clusterTarget),
+		}))
+
+		_, err = lifecycle.Rules(r.Client, r.Discovery, clusterTarget, r.ReleaseData, nil)
+		Expect(err).To(MatchError("invalid release data: invalid latest patch 1.29.15 of release 1.30"))
+	})
+})
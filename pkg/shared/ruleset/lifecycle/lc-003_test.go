@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+)
+
+var _ = Describe("#lc-003", func() {
+	var ctx = context.TODO()
+
+	It("should pass when the cluster does not have nodes", func() {
+		r := &lifecycle.SkewRule{Client: newClient(nil), Discovery: newDiscovery("v1.30.2"), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("lc-003"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Cluster does not have nodes.", controlPlaneTarget("v1.30.2")),
+		}))
+	})
+
+	It("should report kubelets which violate the version skew policy", func() {
+		r := &lifecycle.SkewRule{
+			Client: newClient(map[string]string{
+				"node-a": "v1.30.1",
+				"node-b": "v1.27.4",
+				"node-c": "v1.26.0",
+				"node-d": "v1.31.0",
+				"node-e": "v2.30.0",
+			}),
+			Discovery: newDiscovery("v1.30.2"),
+			Target:    clusterTarget,
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Kubelet is 0 minor versions older than control plane version 1.30.2.", nodeTarget("node-a", "v1.30.1")),
+			rule.PassedCheckResult("Kubelet is 3 minor versions older than control plane version 1.30.2.", nodeTarget("node-b", "v1.27.4")),
+			rule.FailedCheckResult("Kubelet is 4 minor versions older than control plane version 1.30.2.", nodeTarget("node-c", "v1.26.0").With("details", "maximum minor version skew: 3")),
+			rule.FailedCheckResult("Kubelet is newer than control plane version 1.30.2.", nodeTarget("node-d", "v1.31.0")),
+			rule.FailedCheckResult("Kubelet major version differs from control plane version 1.30.2.", nodeTarget("node-e", "v2.30.0")),
+		}))
+	})
+
+	It("should respect the maximum minor version skew of the rule options", func() {
+		r := &lifecycle.SkewRule{
+			Client:    newClient(map[string]string{"node-a": "v1.30.1", "node-b": "v1.28.4"}),
+			Discovery: newDiscovery("v1.30.2"),
+			Target:    clusterTarget,
+			Options:   &lifecycle.SkewOptions{MaxMinorSkew: pointer.Uint64(1)},
+		}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Kubelet is 0 minor versions older than control plane version 1.30.2.", nodeTarget("node-a", "v1.30.1")),
+			rule.FailedCheckResult("Kubelet is 2 minor versions older than control plane version 1.30.2.", nodeTarget("node-b", "v1.28.4").With("details", "maximum minor version skew: 1")),
+		}))
+	})
+
+	It("should use the version skew policy of control planes older than 1.28", func() {
+		r := &lifecycle.SkewRule{Client: newClient(map[string]string{"node-a": "v1.24.0"}), Discovery: newDiscovery("v1.27.4"), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Kubelet is 3 minor versions older than control plane version 1.27.4.", nodeTarget("node-a", "v1.24.0").With("details", "maximum minor version skew: 2")),
+		}))
+	})
+
+	It("should error when the control plane version cannot be parsed", func() {
+		r := &lifecycle.SkewRule{Client: newClient(map[string]string{"node-a": "v1.30.1"}), Discovery: newDiscovery("foo"), Target: clusterTarget}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("Version cannot be parsed: Invalid Semantic Version.", controlPlaneTarget("foo")),
+		}))
+	})
+})
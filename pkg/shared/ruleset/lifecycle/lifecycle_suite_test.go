@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	testingclient "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+func TestLifecycle(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kubernetes Version Lifecycle Ruleset Test Suite")
+}
+
+var clusterTarget = rule.NewTarget("cluster", "shoot")
+
+// newDiscovery creates a fake discovery client which reports the control plane version.
+func newDiscovery(controlPlaneVersion string) *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{Fake: &testingclient.Fake{}, FakedServerVersion: &version.Info{GitVersion: controlPlaneVersion}}
+}
+
+// newClient creates a fake client with nodes whose kubelets have the versions mapped to the node names.
+func newClient(kubeletVersions map[string]string) client.Client {
+	fakeClient := fakeclient.NewClientBuilder().Build()
+	for name, kubeletVersion := range kubeletVersions {
+		Expect(fakeClient.Create(context.TODO(), &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{KubeletVersion: kubeletVersion}},
+		})).To(Succeed())
+	}
+	return fakeClient
+}
+
+func controlPlaneTarget(version string) rule.Target {
+	return clusterTarget.With("kind", "controlPlane", "version", version)
+}
+
+func nodeTarget(name, version string) rule.Target {
+	return clusterTarget.With("kind", "node", "name", name, "version", version)
+}
+
+// date returns the date which is d away from now in the format of the release data.
+func date(d time.Duration) string {
+	return time.Now().Add(d).Format(time.DateOnly)
+}
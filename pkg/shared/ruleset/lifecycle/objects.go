@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// component is the control plane or a node of a cluster.
+type component struct {
+	target  rule.Target
+	version *semver.Version
+	// err is set if the version of the component cannot be parsed.
+	err error
+}
+
+// versions are the versions of the control plane and the kubelets of a cluster.
+type versions struct {
+	controlPlane component
+	nodes        []component
+}
+
+// listVersions reads the version of the control plane from the discovery client
+// and the versions of the kubelets of the nodes listed by the client.
+func listVersions(ctx context.Context, c client.Client, d discovery.ServerVersionInterface, clusterTarget rule.Target) (versions, error) {
+	serverVersion, err := d.ServerVersion()
+	if err != nil {
+		return versions{}, err
+	}
+
+	result := versions{controlPlane: newComponent(clusterTarget.With("kind", "controlPlane"), serverVersion.GitVersion)}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return versions{}, err
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool {
+		return nodes.Items[i].Name < nodes.Items[j].Name
+	})
+	for _, node := range nodes.Items {
+		result.nodes = append(result.nodes, newComponent(clusterTarget.With("kind", "node", "name", node.Name), node.Status.NodeInfo.KubeletVersion))
+	}
+	return result, nil
+}
+
+func newComponent(target rule.Target, version string) component {
+	v, err := semver.NewVersion(version)
+	return component{
+		target:  target.With("version", version),
+		version: v,
+		err:     err,
+	}
+}
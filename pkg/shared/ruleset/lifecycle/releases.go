@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed releases.yaml
+var defaultReleaseData []byte
+
+// ReleaseData is the lifecycle data of the Kubernetes minor releases.
+type ReleaseData struct {
+	Releases []Release `json:"releases" yaml:"releases"`
+}
+
+// Release is the lifecycle data of a Kubernetes minor release.
+type Release struct {
+	// Version is the minor version, e.g. 1.30.
+	Version string `json:"version" yaml:"version"`
+	// EndOfLife is the date in the format 2006-01-02 on which the minor version reaches its end of life.
+	EndOfLife string `json:"endOfLife" yaml:"endOfLife"`
+	// LatestPatch is the latest patch release of the minor version, e.g. 1.30.14.
+	LatestPatch string `json:"latestPatch" yaml:"latestPatch"`
+}
+
+// release is a parsed [Release].
+type release struct {
+	endOfLife   time.Time
+	latestPatch *semver.Version
+}
+
+// releases maps minor versions in the format major.minor to their lifecycle data.
+type releases map[string]release
+
+// minorVersion returns the minor version of v in the format major.minor.
+func minorVersion(v *semver.Version) string {
+	return fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+}
+
+// get returns the lifecycle data of the minor version of v.
+func (r releases) get(v *semver.Version) (release, bool) {
+	rel, ok := r[minorVersion(v)]
+	return rel, ok
+}
+
+// before returns whether the minor version of v is older than all minor versions of the release data.
+func (r releases) before(v *semver.Version) bool {
+	for _, rel := range r {
+		if rel.latestPatch.Major() < v.Major() || (rel.latestPatch.Major() == v.Major() && rel.latestPatch.Minor() <= v.Minor()) {
+			return false
+		}
+	}
+	return len(r) > 0
+}
+
+// ParseReleaseData parses and validates release data in yaml format.
+func ParseReleaseData(data []byte) (*ReleaseData, error) {
+	releaseData := &ReleaseData{}
+	if err := yaml.Unmarshal(data, releaseData); err != nil {
+		return nil, err
+	}
+	if _, err := releaseData.parse(); err != nil {
+		return nil, err
+	}
+	return releaseData, nil
+}
+
+// LoadReleaseData reads and parses a release data file.
+func LoadReleaseData(path string) (*ReleaseData, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseData(data)
+}
+
+// DefaultReleaseData returns the release data bundled with diki.
+func DefaultReleaseData() *ReleaseData {
+	releaseData, err := ParseReleaseData(defaultReleaseData)
+	if err != nil {
+		panic(err)
+	}
+	return releaseData
+}
+
+// parse parses and validates the release data. It parses the release data bundled with diki if d is nil.
+func (d *ReleaseData) parse() (releases, error) {
+	if d == nil {
+		d = DefaultReleaseData()
+	}
+
+	var (
+		parsed = releases{}
+		errs   error
+	)
+	for _, rel := range d.Releases {
+		minor, err := semver.StrictNewVersion(rel.Version + ".0")
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid version %s of release", rel.Version))
+			continue
+		}
+		if _, ok := parsed[rel.Version]; ok {
+			errs = errors.Join(errs, fmt.Errorf("release %s is defined more than once", rel.Version))
+			continue
+		}
+
+		endOfLife, err := time.Parse(time.DateOnly, rel.EndOfLife)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid end of life %s of release %s", rel.EndOfLife, rel.Version))
+			continue
+		}
+
+		latestPatch, err := semver.NewVersion(rel.LatestPatch)
+		if err != nil || latestPatch.Major() != minor.Major() || latestPatch.Minor() != minor.Minor() {
+			errs = errors.Join(errs, fmt.Errorf("invalid latest patch %s of release %s", rel.LatestPatch, rel.Version))
+			continue
+		}
+		parsed[rel.Version] = release{endOfLife: endOfLife, latestPatch: latestPatch}
+	}
+	return parsed, errs
+}
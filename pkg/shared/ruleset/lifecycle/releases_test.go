@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/lifecycle"
+)
+
+var _ = Describe("releases", func() {
+	It("should parse the bundled release data", func() {
+		releaseData := lifecycle.DefaultReleaseData()
+		Expect(releaseData.Releases).ToNot(BeEmpty())
+		Expect(releaseData.Releases[0]).To(Equal(lifecycle.Release{Version: "1.24", EndOfLife: "2023-07-28", LatestPatch: "1.24.17"}))
+	})
+
+	It("should load the release data of the file set in the args", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "releases.yaml")
+		Expect(os.WriteFile(file, []byte("releases:\n- version: \"1.40\"\n  endOfLife: \"2030-01-01\"\n  latestPatch: 1.40.3\n"), 0600)).To(Succeed())
+
+		args, err := lifecycle.ParseArgs(map[string]any{"releaseData": file})
+		Expect(err).ToNot(HaveOccurred())
+		releaseData, err := args.LoadReleaseData()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(releaseData.Releases).To(Equal([]lifecycle.Release{{Version: "1.40", EndOfLife: "2030-01-01", LatestPatch: "1.40.3"}}))
+	})
+
+	It("should return an error when the release data is invalid", func() {
+		_, err := lifecycle.ParseReleaseData([]byte(`releases:
+- version: "1.30"
+  endOfLife: "2025-06-28"
+  latestPatch: 1.31.0
+- version: "1.31"
+  endOfLife: "28.10.2025"
+  latestPatch: 1.31.0
+- version: "1.31"
+  endOfLife: "2025-10-28"
+  latestPatch: 1.31.0
+`))
+		Expect(err).To(MatchError("invalid latest patch 1.31.0 of release 1.30\ninvalid end of life 28.10.2025 of release 1.31"))
+	})
+})
@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// EndOfLifeOptions are the rule options of the rule which checks the end of life of minor versions.
+type EndOfLifeOptions struct {
+	// WarningPeriod is the period before the end of life of a minor version in which it is reported with Warning, e.g. 720h.
+	WarningPeriod string `json:"warningPeriod" yaml:"warningPeriod"`
+}
+
+// PatchOptions are the rule options of the rule which checks the patch versions.
+type PatchOptions struct {
+	// MaxPatchLag is the maximum number of patch releases a version can lag behind the latest patch release. Defaults to 3.
+	MaxPatchLag *uint64 `json:"maxPatchLag" yaml:"maxPatchLag"`
+}
+
+// SkewOptions are the rule options of the rule which checks the version skew between control plane and kubelets.
+type SkewOptions struct {
+	// MaxMinorSkew is the maximum number of minor versions a kubelet can be older than the control plane.
+	// Defaults to the version skew policy of Kubernetes, 3 since Kubernetes 1.28 and 2 before.
+	MaxMinorSkew *uint64 `json:"maxMinorSkew" yaml:"maxMinorSkew"`
+}
+
+// warningPeriod returns the configured warning period or 30 days.
+func (o *EndOfLifeOptions) warningPeriod() (time.Duration, error) {
+	if o == nil || o.WarningPeriod == "" {
+		return 30 * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(o.WarningPeriod)
+}
+
+// maxPatchLag returns the configured maximum patch lag or 3.
+func (o *PatchOptions) maxPatchLag() uint64 {
+	if o == nil || o.MaxPatchLag == nil {
+		return 3
+	}
+	return *o.MaxPatchLag
+}
+
+// maxMinorSkew returns the configured maximum minor version skew or the
+// one of the version skew policy of the control plane version.
+func (o *SkewOptions) maxMinorSkew(controlPlane *semver.Version) uint64 {
+	if o == nil || o.MaxMinorSkew == nil {
+		return defaultMaxMinorSkew(controlPlane)
+	}
+	return *o.MaxMinorSkew
+}
+
+// checkVersions checks the versions of the control plane and the kubelets of the cluster.
+// check returns the check results of the versions, their targets extend clusterTarget.
+func checkVersions(
+	ctx context.Context,
+	r rule.Rule,
+	c client.Client,
+	d discovery.ServerVersionInterface,
+	clusterTarget rule.Target,
+	check func(versions) []rule.CheckResult,
+) rule.RuleResult {
+	v, err := listVersions(ctx, c, d, clusterTarget)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), clusterTarget))
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: check(v),
+	}
+}
+
+var _ rule.Rule = &EndOfLifeRule{}
+
+// EndOfLifeRule checks that the minor versions of the control plane and the kubelets are not end of life.
+type EndOfLifeRule struct {
+	Client    client.Client
+	Discovery discovery.ServerVersionInterface
+	Target    rule.Target
+	// ReleaseData is the lifecycle data of the Kubernetes minor releases. Defaults to the release data bundled with diki.
+	ReleaseData *ReleaseData
+	Options     *EndOfLifeOptions
+}
+
+// ID returns the id of the rule.
+func (r *EndOfLifeRule) ID() string {
+	return "lc-001"
+}
+
+// Name returns the name of the rule.
+func (r *EndOfLifeRule) Name() string {
+	return "Kubernetes minor versions must not be end of life (HIGH lc-001)"
+}
+
+// Run checks the end of life of the minor versions of the cluster.
+func (r *EndOfLifeRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	rels, err := r.ReleaseData.parse()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(fmt.Sprintf("invalid release data: %s", err), r.Target)), nil
+	}
+	warningPeriod, err := r.Options.warningPeriod()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(fmt.Sprintf("invalid warning period: %s", err), r.Target)), nil
+	}
+	return checkVersions(ctx, r, r.Client, r.Discovery, r.Target, endOfLifeCheck(time.Now, warningPeriod, rels)), nil
+}
+
+var _ rule.Rule = &PatchRule{}
+
+// PatchRule checks that the versions of the control plane and the kubelets do not lag behind the latest patch release.
+type PatchRule struct {
+	Client    client.Client
+	Discovery discovery.ServerVersionInterface
+	Target    rule.Target
+	// ReleaseData is the lifecycle data of the Kubernetes minor releases. Defaults to the release data bundled with diki.
+	ReleaseData *ReleaseData
+	Options     *PatchOptions
+}
+
+// ID returns the id of the rule.
+func (r *PatchRule) ID() string {
+	return "lc-002"
+}
+
+// Name returns the name of the rule.
+func (r *PatchRule) Name() string {
+	return "Kubernetes versions must not lag behind the latest patch release (MEDIUM lc-002)"
+}
+
+// Run checks the patch versions of the cluster.
+func (r *PatchRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	rels, err := r.ReleaseData.parse()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(fmt.Sprintf("invalid release data: %s", err), r.Target)), nil
+	}
+	return checkVersions(ctx, r, r.Client, r.Discovery, r.Target, patchCheck(r.Options.maxPatchLag(), rels)), nil
+}
+
+var _ rule.Rule = &SkewRule{}
+
+// SkewRule checks that the versions of the kubelets comply with the version skew policy.
+type SkewRule struct {
+	Client    client.Client
+	Discovery discovery.ServerVersionInterface
+	Target    rule.Target
+	Options   *SkewOptions
+}
+
+// ID returns the id of the rule.
+func (r *SkewRule) ID() string {
+	return "lc-003"
+}
+
+// Name returns the name of the rule.
+func (r *SkewRule) Name() string {
+	return "Kubelet versions must comply with the version skew policy (MEDIUM lc-003)"
+}
+
+// Run checks the version skew between the control plane and the kubelets of the cluster.
+func (r *SkewRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	return checkVersions(ctx, r, r.Client, r.Discovery, r.Target, skewCheck(r.Options)), nil
+}
+
+// checkComponents checks the control plane and all nodes with check. Components
+// whose version cannot be parsed or is not contained in the release data are not passed to check.
+func checkComponents(v versions, rels releases, check func(component, release) rule.CheckResult) []rule.CheckResult {
+	checkResults := make([]rule.CheckResult, 0, len(v.nodes)+1)
+	for _, c := range append([]component{v.controlPlane}, v.nodes...) {
+		if c.err != nil {
+			checkResults = append(checkResults, rule.ErroredCheckResult(fmt.Sprintf("Version cannot be parsed: %s.", c.err), c.target))
+			continue
+		}
+
+		rel, ok := rels.get(c.version)
+		switch {
+		case !ok && rels.before(c.version):
+			checkResults = append(checkResults, rule.FailedCheckResult(fmt.Sprintf("Minor version %s is older than all releases of the release data and is end of life.", minorVersion(c.version)), c.target))
+		case !ok:
+			checkResults = append(checkResults, rule.WarningCheckResult(fmt.Sprintf("Release data does not contain minor version %s.", minorVersion(c.version)), c.target))
+		default:
+			checkResults = append(checkResults, check(c, rel))
+		}
+	}
+	return checkResults
+}
+
+func endOfLifeCheck(now func() time.Time, warningPeriod time.Duration, rels releases) func(versions) []rule.CheckResult {
+	return func(v versions) []rule.CheckResult {
+		return checkComponents(v, rels, func(c component, rel release) rule.CheckResult {
+			minor, endOfLife := minorVersion(c.version), rel.endOfLife.Format(time.DateOnly)
+			switch {
+			case !now().Before(rel.endOfLife):
+				return rule.FailedCheckResult(fmt.Sprintf("Minor version %s reached its end of life on %s.", minor, endOfLife), c.target)
+			case !now().Add(warningPeriod).Before(rel.endOfLife):
+				return rule.WarningCheckResult(fmt.Sprintf("Minor version %s reaches its end of life on %s.", minor, endOfLife), c.target)
+			default:
+				return rule.PassedCheckResult(fmt.Sprintf("Minor version %s is supported until %s.", minor, endOfLife), c.target)
+			}
+		})
+	}
+}
+
+func patchCheck(maxPatchLag uint64, rels releases) func(versions) []rule.CheckResult {
+	return func(v versions) []rule.CheckResult {
+		return checkComponents(v, rels, func(c component, rel release) rule.CheckResult {
+			var lag uint64
+			if rel.latestPatch.Patch() > c.version.Patch() {
+				lag = rel.latestPatch.Patch() - c.version.Patch()
+			}
+			if lag > maxPatchLag {
+				msg := fmt.Sprintf("Version lags %d patch releases behind the latest patch release %s.", lag, rel.latestPatch)
+				return rule.FailedCheckResult(msg, c.target.With("details", fmt.Sprintf("maximum patch lag: %d", maxPatchLag)))
+			}
+			return rule.PassedCheckResult(fmt.Sprintf("Version lags %d patch releases behind the latest patch release %s.", lag, rel.latestPatch), c.target)
+		})
+	}
+}
+
+// defaultMaxMinorSkew returns the maximum number of minor versions a kubelet can be
+// older than the control plane according to the version skew policy of Kubernetes.
+func defaultMaxMinorSkew(controlPlane *semver.Version) uint64 {
+	if controlPlane.Major() == 1 && controlPlane.Minor() < 28 {
+		return 2
+	}
+	return 3
+}
+
+func skewCheck(options *SkewOptions) func(versions) []rule.CheckResult {
+	return func(v versions) []rule.CheckResult {
+		if v.controlPlane.err != nil {
+			return []rule.CheckResult{rule.ErroredCheckResult(fmt.Sprintf("Version cannot be parsed: %s.", v.controlPlane.err), v.controlPlane.target)}
+		}
+		if len(v.nodes) == 0 {
+			return []rule.CheckResult{rule.PassedCheckResult("Cluster does not have nodes.", v.controlPlane.target)}
+		}
+
+		controlPlane := v.controlPlane.version
+		maxSkew := options.maxMinorSkew(controlPlane)
+
+		checkResults := make([]rule.CheckResult, 0, len(v.nodes))
+		for _, node := range v.nodes {
+			switch {
+			case node.err != nil:
+				checkResults = append(checkResults, rule.ErroredCheckResult(fmt.Sprintf("Version cannot be parsed: %s.", node.err), node.target))
+			case node.version.Major() != controlPlane.Major():
+				checkResults = append(checkResults, rule.FailedCheckResult(fmt.Sprintf("Kubelet major version differs from control plane version %s.", controlPlane), node.target))
+			case node.version.Minor() > controlPlane.Minor():
+				checkResults = append(checkResults, rule.FailedCheckResult(fmt.Sprintf("Kubelet is newer than control plane version %s.", controlPlane), node.target))
+			case controlPlane.Minor()-node.version.Minor() > maxSkew:
+				msg := fmt.Sprintf("Kubelet is %d minor versions older than control plane version %s.", controlPlane.Minor()-node.version.Minor(), controlPlane)
+				checkResults = append(checkResults, rule.FailedCheckResult(msg, node.target.With("details", fmt.Sprintf("maximum minor version skew: %d", maxSkew))))
+			default:
+				msg := fmt.Sprintf("Kubelet is %d minor versions older than control plane version %s.", controlPlane.Minor()-node.version.Minor(), controlPlane)
+				checkResults = append(checkResults, rule.PassedCheckResult(msg, node.target))
+			}
+		}
+		return checkResults
+	}
+}
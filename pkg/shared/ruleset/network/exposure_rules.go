@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/internal/utils"
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+const (
+	// loadBalancerSourceRangesAnnotation is the annotation which restricts the source ranges of a LoadBalancer
+	// service when spec.loadBalancerSourceRanges is not set.
+	loadBalancerSourceRangesAnnotation = "service.beta.kubernetes.io/load-balancer-source-ranges"
+	// ingressSourceRangeAnnotation is the annotation which restricts the source ranges of an ingress of the ingress-nginx controller.
+	ingressSourceRangeAnnotation = "nginx.ingress.kubernetes.io/whitelist-source-range"
+)
+
+// ExposureOptions are the rule options of the rules which check the exposure of services and ingresses.
+type ExposureOptions struct {
+	AllowedExposures []AllowedExposure `json:"allowedExposures" yaml:"allowedExposures"`
+}
+
+// AllowedExposure allows services or ingresses of the selected namespaces to be exposed.
+type AllowedExposure struct {
+	NamespaceMatchLabels map[string]string `json:"namespaceMatchLabels" yaml:"namespaceMatchLabels"`
+	// Types are the allowed types of services, i.e. LoadBalancer and NodePort.
+	// All types are allowed when it is empty. The types are not used for ingresses.
+	Types []corev1.ServiceType `json:"types" yaml:"types"`
+	// Ports are the allowed ports. All ports are allowed when it is empty.
+	Ports []int32 `json:"ports" yaml:"ports"`
+	// SourceRanges are the CIDRs from which services and ingresses are allowed to be reachable.
+	// All sources are allowed when it is empty.
+	SourceRanges []string `json:"sourceRanges" yaml:"sourceRanges"`
+}
+
+func (o *ExposureOptions) validate() error {
+	for _, exposure := range o.AllowedExposures {
+		for _, sourceRange := range exposure.SourceRanges {
+			if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+				return fmt.Errorf("invalid source range %s: %w", sourceRange, err)
+			}
+		}
+		for _, t := range exposure.Types {
+			if t != corev1.ServiceTypeLoadBalancer && t != corev1.ServiceTypeNodePort {
+				return fmt.Errorf("invalid type %s, supported types are %s and %s", t, corev1.ServiceTypeLoadBalancer, corev1.ServiceTypeNodePort)
+			}
+		}
+	}
+	return nil
+}
+
+// exposure is a service or an ingress which is reachable from outside of the cluster.
+type exposure struct {
+	subject      string
+	serviceType  corev1.ServiceType
+	ports        []int32
+	sourceRanges []string
+}
+
+// check returns the check result of an exposure in a namespace with the given labels.
+func (o *ExposureOptions) check(e exposure, namespaceLabels map[string]string, target rule.Target) rule.CheckResult {
+	var (
+		matched    bool
+		violations []string
+	)
+	if o != nil {
+		for _, allowed := range o.AllowedExposures {
+			if !utils.MatchLabels(namespaceLabels, allowed.NamespaceMatchLabels) {
+				continue
+			}
+
+			v := allowed.violations(e)
+			if len(v) == 0 {
+				return rule.PassedCheckResult(fmt.Sprintf("%s is exposed as allowed.", e.subject), target)
+			}
+			if !matched {
+				matched, violations = true, v
+			}
+		}
+	}
+
+	if !matched {
+		return rule.FailedCheckResult(fmt.Sprintf("%s is exposed in a namespace which does not allow exposure.", e.subject), target)
+	}
+	return rule.FailedCheckResult(fmt.Sprintf("%s is not exposed as allowed.", e.subject), target.With("details", strings.Join(violations, "; ")))
+}
+
+func (a AllowedExposure) violations(e exposure) []string {
+	var violations []string
+	if e.serviceType != "" && len(a.Types) > 0 && !slices.Contains(a.Types, e.serviceType) {
+		violations = append(violations, fmt.Sprintf("type %s is not allowed", e.serviceType))
+	}
+
+	if len(a.Ports) > 0 {
+		for _, port := range e.ports {
+			if !slices.Contains(a.Ports, port) {
+				violations = append(violations, fmt.Sprintf("port %d is not allowed", port))
+			}
+		}
+	}
+
+	if len(a.SourceRanges) > 0 {
+		for _, sourceRange := range e.sourceRanges {
+			if !containedCIDR(sourceRange, a.SourceRanges) {
+				violations = append(violations, fmt.Sprintf("source range %s is not allowed", sourceRange))
+			}
+		}
+	}
+	return violations
+}
+
+// containedCIDR returns whether the CIDR is contained in one of the allowed CIDRs.
+func containedCIDR(cidr string, allowedCIDRs []string) bool {
+	_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return false
+	}
+	ones, bits := network.Mask.Size()
+
+	for _, allowedCIDR := range allowedCIDRs {
+		_, allowedNetwork, err := net.ParseCIDR(allowedCIDR)
+		if err != nil {
+			continue
+		}
+		allowedOnes, allowedBits := allowedNetwork.Mask.Size()
+		if bits == allowedBits && allowedOnes <= ones && allowedNetwork.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceRanges splits a comma separated list of source ranges. Resources without
+// source ranges are reachable from everywhere.
+func sourceRanges(sourceRanges []string, annotation string) []string {
+	if len(sourceRanges) > 0 {
+		return sourceRanges
+	}
+
+	var result []string
+	for _, sourceRange := range strings.Split(annotation, ",") {
+		if sourceRange = strings.TrimSpace(sourceRange); sourceRange != "" {
+			result = append(result, sourceRange)
+		}
+	}
+	if len(result) == 0 {
+		return []string{"0.0.0.0/0"}
+	}
+	return result
+}
+
+var _ rule.Rule = &ServiceExposureRule{}
+
+// ServiceExposureRule checks that services of type LoadBalancer and NodePort are only exposed
+// in the namespaces, with the ports and to the source ranges which are allowed by the rule options.
+type ServiceExposureRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *ExposureOptions
+}
+
+// ID returns the id of the rule.
+func (r *ServiceExposureRule) ID() string {
+	return "net-003"
+}
+
+// Name returns the name of the rule.
+func (r *ServiceExposureRule) Name() string {
+	return "Services of type LoadBalancer and NodePort must only be exposed where allowed (HIGH net-003)"
+}
+
+// Run checks the exposure of all services of type LoadBalancer and NodePort.
+func (r *ServiceExposureRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	namespaces, err := kubeutils.GetNamespaces(ctx, r.Client)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "namespaceList"))), nil
+	}
+	services, err := kubeutils.GetServices(ctx, r.Client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "serviceList"))), nil
+	}
+
+	var checkResults []rule.CheckResult
+	for _, service := range services {
+		e := exposure{
+			subject:     fmt.Sprintf("Service of type %s", service.Spec.Type),
+			serviceType: service.Spec.Type,
+		}
+		switch service.Spec.Type {
+		case corev1.ServiceTypeLoadBalancer:
+			for _, port := range service.Spec.Ports {
+				e.ports = append(e.ports, port.Port)
+			}
+			e.sourceRanges = sourceRanges(service.Spec.LoadBalancerSourceRanges, service.Annotations[loadBalancerSourceRangesAnnotation])
+		case corev1.ServiceTypeNodePort:
+			// node ports are reachable from wherever the nodes are reachable
+			for _, port := range service.Spec.Ports {
+				e.ports = append(e.ports, port.NodePort)
+			}
+		default:
+			continue
+		}
+
+		target := r.Target.With("kind", "service", "name", service.Name, "namespace", service.Namespace)
+		checkResults = append(checkResults, r.Options.check(e, namespaces[service.Namespace].Labels, target))
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any services of type LoadBalancer or NodePort.", r.Target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+var _ rule.Rule = &IngressExposureRule{}
+
+// IngressExposureRule checks that ingresses are only exposed in the namespaces, with
+// the ports and to the source ranges which are allowed by the rule options.
+type IngressExposureRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *ExposureOptions
+}
+
+// ID returns the id of the rule.
+func (r *IngressExposureRule) ID() string {
+	return "net-004"
+}
+
+// Name returns the name of the rule.
+func (r *IngressExposureRule) Name() string {
+	return "Ingresses must only be exposed where allowed (MEDIUM net-004)"
+}
+
+// Run checks the exposure of all ingresses. Ingresses are exposed on port 80
+// and additionally on port 443 when TLS is configured.
+func (r *IngressExposureRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	namespaces, err := kubeutils.GetNamespaces(ctx, r.Client)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "namespaceList"))), nil
+	}
+	ingresses, err := kubeutils.GetIngresses(ctx, r.Client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "ingressList"))), nil
+	}
+
+	var checkResults []rule.CheckResult
+	for _, ingress := range ingresses {
+		e := exposure{
+			subject:      "Ingress",
+			ports:        []int32{80},
+			sourceRanges: sourceRanges(nil, ingress.Annotations[ingressSourceRangeAnnotation]),
+		}
+		if len(ingress.Spec.TLS) > 0 {
+			e.ports = append(e.ports, 443)
+		}
+
+		target := r.Target.With("kind", "ingress", "name", ingress.Name, "namespace", ingress.Namespace)
+		checkResults = append(checkResults, r.Options.check(e, namespaces[ingress.Namespace].Labels, target))
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any ingresses.", r.Target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
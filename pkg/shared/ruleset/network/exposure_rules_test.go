@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package network_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/network"
+)
+
+var _ = Describe("exposure rules", func() {
+	var (
+		fakeClient     client.Client
+		ctx            = context.TODO()
+		clusterTarget  = rule.NewTarget()
+		serviceOptions = &network.ExposureOptions{
+			AllowedExposures: []network.AllowedExposure{
+				{
+					NamespaceMatchLabels: map[string]string{"exposed": "true"},
+					Types:                []corev1.ServiceType{corev1.ServiceTypeLoadBalancer},
+					Ports:                []int32{443},
+					SourceRanges:         []string{"10.0.0.0/8"},
+				},
+			},
+		}
+		ingressOptions = &network.ExposureOptions{
+			AllowedExposures: []network.AllowedExposure{
+				{
+					NamespaceMatchLabels: map[string]string{"exposed": "true"},
+					Ports:                []int32{80, 443},
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		for _, obj := range []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "public", Labels: map[string]string{"exposed": "true"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "private"}},
+		} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+	})
+
+	Describe("#ServiceExposureRule", func() {
+		It("should pass when there are no exposed services", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "private"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+			})).To(Succeed())
+
+			r := &network.ServiceExposureRule{Client: fakeClient, Target: clusterTarget, Options: serviceOptions}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.PassedCheckResult("The cluster does not have any services of type LoadBalancer or NodePort.", clusterTarget),
+			}))
+		})
+
+		It("should check the exposure of LoadBalancer and NodePort services", func() {
+			for _, obj := range []client.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "allowed", Namespace: "public"},
+					Spec: corev1.ServiceSpec{
+						Type:                     corev1.ServiceTypeLoadBalancer,
+						Ports:                    []corev1.ServicePort{{Port: 443}},
+						LoadBalancerSourceRanges: []string{"10.1.0.0/16"},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "open", Namespace: "public"},
+					Spec: corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeLoadBalancer,
+						Ports: []corev1.ServicePort{{Port: 443}, {Port: 22}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "annotated", Namespace: "public", Annotations: map[string]string{"service.beta.kubernetes.io/load-balancer-source-ranges": "10.0.0.0/24, 10.2.0.0/16"}},
+					Spec: corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeLoadBalancer,
+						Ports: []corev1.ServicePort{{Port: 443}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-port", Namespace: "public"},
+					Spec: corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeNodePort,
+						Ports: []corev1.ServicePort{{Port: 443, NodePort: 30443}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "private", Namespace: "private"},
+					Spec: corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeLoadBalancer,
+						Ports: []corev1.ServicePort{{Port: 443}},
+					},
+				},
+			} {
+				Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+			}
+
+			target := func(name, namespace string) rule.Target {
+				return clusterTarget.With("kind", "service", "name", name, "namespace", namespace)
+			}
+			r := &network.ServiceExposureRule{Client: fakeClient, Target: clusterTarget, Options: serviceOptions}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Service of type LoadBalancer is exposed in a namespace which does not allow exposure.", target("private", "private")),
+				rule.PassedCheckResult("Service of type LoadBalancer is exposed as allowed.", target("allowed", "public")),
+				rule.PassedCheckResult("Service of type LoadBalancer is exposed as allowed.", target("annotated", "public")),
+				rule.FailedCheckResult("Service of type NodePort is not exposed as allowed.", target("node-port", "public").With("details", "type NodePort is not allowed; port 30443 is not allowed")),
+				rule.FailedCheckResult("Service of type LoadBalancer is not exposed as allowed.", target("open", "public").With("details", "port 22 is not allowed; source range 0.0.0.0/0 is not allowed")),
+			}))
+		})
+
+		It("should fail all exposed services without rule options", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "allowed", Namespace: "public"},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{{Port: 443}},
+				},
+			})).To(Succeed())
+
+			r := &network.ServiceExposureRule{Client: fakeClient, Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Service of type LoadBalancer is exposed in a namespace which does not allow exposure.", clusterTarget.With("kind", "service", "name", "allowed", "namespace", "public")),
+			}))
+		})
+
+		It("should return an error for invalid rule options", func() {
+			_, err := network.Rules(fakeClient, clusterTarget, map[string]config.RuleOptionsConfig{
+				"net-003": {Args: map[string]any{"allowedExposures": []map[string]any{{"sourceRanges": []string{"foo"}}}}},
+			})
+			Expect(err).To(MatchError("invalid options of rule net-003: invalid source range foo: invalid CIDR address: foo"))
+		})
+	})
+
+	Describe("#IngressExposureRule", func() {
+		It("should check the exposure of ingresses", func() {
+			for _, obj := range []client.Object{
+				&networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "public"},
+					Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{Hosts: []string{"foo.example.com"}}}},
+				},
+				&networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Name: "private", Namespace: "private"},
+				},
+			} {
+				Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+			}
+
+			r := &network.IngressExposureRule{Client: fakeClient, Target: clusterTarget, Options: ingressOptions}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Ingress is exposed in a namespace which does not allow exposure.", clusterTarget.With("kind", "ingress", "name", "private", "namespace", "private")),
+				rule.PassedCheckResult("Ingress is exposed as allowed.", clusterTarget.With("kind", "ingress", "name", "tls", "namespace", "public")),
+			}))
+		})
+
+		It("should pass when there are no ingresses", func() {
+			r := &network.IngressExposureRule{Client: fakeClient, Target: clusterTarget, Options: ingressOptions}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.PassedCheckResult("The cluster does not have any ingresses.", clusterTarget),
+			}))
+		})
+	})
+})
@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/internal/utils"
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// NamespaceOptions are the rule options of the rules which are checked per namespace.
+type NamespaceOptions struct {
+	AcceptedNamespaces []AcceptedNamespaces `json:"acceptedNamespaces" yaml:"acceptedNamespaces"`
+}
+
+// AcceptedNamespaces selects namespaces which are accepted to violate a rule.
+type AcceptedNamespaces struct {
+	NamespaceMatchLabels map[string]string `json:"namespaceMatchLabels" yaml:"namespaceMatchLabels"`
+	Justification        string            `json:"justification" yaml:"justification"`
+}
+
+func (o *NamespaceOptions) accepted(namespace corev1.Namespace) (bool, string) {
+	if o == nil {
+		return false, ""
+	}
+
+	for _, acceptedNamespace := range o.AcceptedNamespaces {
+		if utils.MatchLabels(namespace.Labels, acceptedNamespace.NamespaceMatchLabels) {
+			return true, acceptedNamespace.Justification
+		}
+	}
+	return false, ""
+}
+
+// systemNamespaces are the namespaces of Kubernetes system components which are not checked.
+var systemNamespaces = map[string]bool{
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// checkedNamespaces returns the names of all namespaces which are not system namespaces.
+func checkedNamespaces(namespaces map[string]corev1.Namespace) []string {
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		if !systemNamespaces[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+var _ rule.Rule = &DefaultDenyRule{}
+
+// DefaultDenyRule checks that every namespace which is not a system namespace has
+// network policies which deny all ingress and all egress traffic by default.
+type DefaultDenyRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *NamespaceOptions
+}
+
+// ID returns the id of the rule.
+func (r *DefaultDenyRule) ID() string {
+	return "net-001"
+}
+
+// Name returns the name of the rule.
+func (r *DefaultDenyRule) Name() string {
+	return "Namespaces must be covered by default deny ingress and egress network policies (HIGH net-001)"
+}
+
+// Run checks the network policies of all namespaces.
+func (r *DefaultDenyRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	namespaces, err := kubeutils.GetNamespaces(ctx, r.Client)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "namespaceList"))), nil
+	}
+	networkPolicies, err := kubeutils.GetNetworkPolicies(ctx, r.Client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "networkPolicyList"))), nil
+	}
+
+	var (
+		denyIngress = map[string]bool{}
+		denyEgress  = map[string]bool{}
+	)
+	for _, networkPolicy := range networkPolicies {
+		ingress, egress := kubeutils.DefaultDenyNetworkPolicy(networkPolicy)
+		denyIngress[networkPolicy.Namespace] = denyIngress[networkPolicy.Namespace] || ingress
+		denyEgress[networkPolicy.Namespace] = denyEgress[networkPolicy.Namespace] || egress
+	}
+
+	var checkResults []rule.CheckResult
+	for _, name := range checkedNamespaces(namespaces) {
+		target := r.Target.With("kind", "namespace", "name", name)
+
+		var missing []string
+		if !denyIngress[name] {
+			missing = append(missing, "ingress")
+		}
+		if !denyEgress[name] {
+			missing = append(missing, "egress")
+		}
+
+		if len(missing) == 0 {
+			checkResults = append(checkResults, rule.PassedCheckResult("Namespace has default deny ingress and egress network policies.", target))
+			continue
+		}
+
+		if accepted, justification := r.Options.accepted(namespaces[name]); accepted {
+			msg := "Namespace accepted to not have default deny network policies."
+			if justification != "" {
+				msg = justification
+			}
+			checkResults = append(checkResults, rule.AcceptedCheckResult(msg, target))
+			continue
+		}
+		checkResults = append(checkResults, rule.FailedCheckResult(fmt.Sprintf("Namespace does not have a default deny %s network policy.", strings.Join(missing, " and ")), target))
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any non-system namespaces.", r.Target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+var _ rule.Rule = &PodSelectionRule{}
+
+// PodSelectionRule checks that every pod of a namespace which is not
+// a system namespace is selected by at least one network policy.
+type PodSelectionRule struct {
+	Client  client.Client
+	Target  rule.Target
+	Options *NamespaceOptions
+}
+
+// ID returns the id of the rule.
+func (r *PodSelectionRule) ID() string {
+	return "net-002"
+}
+
+// Name returns the name of the rule.
+func (r *PodSelectionRule) Name() string {
+	return "Pods must be selected by at least one network policy (MEDIUM net-002)"
+}
+
+// Run checks that the pods of all namespaces are selected by network policies.
+// Pods in the host network are not affected by network policies and are not checked.
+func (r *PodSelectionRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	namespaces, err := kubeutils.GetNamespaces(ctx, r.Client)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "namespaceList"))), nil
+	}
+	networkPolicies, err := kubeutils.GetNetworkPolicies(ctx, r.Client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "networkPolicyList"))), nil
+	}
+	pods, err := kubeutils.GetPods(ctx, r.Client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "podList"))), nil
+	}
+
+	selectors := map[string][]labels.Selector{}
+	for _, networkPolicy := range networkPolicies {
+		selector, err := metav1.LabelSelectorAsSelector(&networkPolicy.Spec.PodSelector)
+		if err != nil {
+			target := r.Target.With("kind", "networkPolicy", "name", networkPolicy.Name, "namespace", networkPolicy.Namespace)
+			return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), target)), nil
+		}
+		selectors[networkPolicy.Namespace] = append(selectors[networkPolicy.Namespace], selector)
+	}
+
+	unselected := map[string][]string{}
+	hasPods := map[string]bool{}
+	for _, pod := range pods {
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		hasPods[pod.Namespace] = true
+
+		selected := false
+		for _, selector := range selectors[pod.Namespace] {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				selected = true
+				break
+			}
+		}
+		if !selected {
+			unselected[pod.Namespace] = append(unselected[pod.Namespace], pod.Name)
+		}
+	}
+
+	var checkResults []rule.CheckResult
+	for _, name := range checkedNamespaces(namespaces) {
+		if !hasPods[name] {
+			continue
+		}
+		target := r.Target.With("kind", "namespace", "name", name)
+
+		pods := unselected[name]
+		if len(pods) == 0 {
+			checkResults = append(checkResults, rule.PassedCheckResult("All pods of the namespace are selected by network policies.", target))
+			continue
+		}
+
+		if accepted, justification := r.Options.accepted(namespaces[name]); accepted {
+			msg := "Namespace accepted to have pods which are not selected by network policies."
+			if justification != "" {
+				msg = justification
+			}
+			checkResults = append(checkResults, rule.AcceptedCheckResult(msg, target))
+			continue
+		}
+
+		sort.Strings(pods)
+		checkResults = append(checkResults, rule.FailedCheckResult("Namespace has pods which are not selected by any network policy.", target.With("details", "pods: "+strings.Join(pods, ", "))))
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any pods in non-system namespaces.", r.Target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package network_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/network"
+)
+
+var _ = Describe("namespace rules", func() {
+	var (
+		fakeClient    client.Client
+		ctx           = context.TODO()
+		clusterTarget = rule.NewTarget("cluster", "shoot")
+		barTarget     = clusterTarget.With("kind", "namespace", "name", "bar")
+		fooTarget     = clusterTarget.With("kind", "namespace", "name", "foo")
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		for _, obj := range []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bar", Labels: map[string]string{"team": "bar"}}},
+			&networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "foo"},
+				Spec: networkingv1.NetworkPolicySpec{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+				},
+			},
+			&networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "allow-app", Namespace: "bar"},
+				Spec: networkingv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}},
+				},
+			},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "foo"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "bar", Labels: map[string]string{"app": "app"}}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "bar", Labels: map[string]string{"app": "db"}}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "bar"}, Spec: corev1.PodSpec{HostNetwork: true}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"}},
+		} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+	})
+
+	Describe("#DefaultDenyRule", func() {
+		It("should check the default deny network policies of all non-system namespaces", func() {
+			r := &network.DefaultDenyRule{Client: fakeClient, Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Namespace does not have a default deny ingress and egress network policy.", barTarget),
+				rule.PassedCheckResult("Namespace has default deny ingress and egress network policies.", fooTarget),
+			}))
+		})
+
+		It("should accept namespaces selected by the rule options", func() {
+			options := &network.NamespaceOptions{
+				AcceptedNamespaces: []network.AcceptedNamespaces{{NamespaceMatchLabels: map[string]string{"team": "bar"}, Justification: "bar is public"}},
+			}
+			r := &network.DefaultDenyRule{Client: fakeClient, Target: clusterTarget, Options: options}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.AcceptedCheckResult("bar is public", barTarget),
+				rule.PassedCheckResult("Namespace has default deny ingress and egress network policies.", fooTarget),
+			}))
+		})
+	})
+
+	Describe("#PodSelectionRule", func() {
+		It("should check that the pods of all non-system namespaces are selected by network policies", func() {
+			r := &network.PodSelectionRule{Client: fakeClient, Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Namespace has pods which are not selected by any network policy.", barTarget.With("details", "pods: db")),
+				rule.PassedCheckResult("All pods of the namespace are selected by network policies.", fooTarget),
+			}))
+		})
+
+		It("should accept namespaces selected by the rule options", func() {
+			options := &network.NamespaceOptions{
+				AcceptedNamespaces: []network.AcceptedNamespaces{{NamespaceMatchLabels: map[string]string{"team": "bar"}}},
+			}
+			r := &network.PodSelectionRule{Client: fakeClient, Target: clusterTarget, Options: options}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.AcceptedCheckResult("Namespace accepted to have pods which are not selected by network policies.", barTarget),
+				rule.PassedCheckResult("All pods of the namespace are selected by network policies.", fooTarget),
+			}))
+		})
+
+		It("should pass when there are no pods in non-system namespaces", func() {
+			fakeClient = fakeclient.NewClientBuilder().Build()
+			Expect(fakeClient.Create(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"}})).To(Succeed())
+
+			r := &network.PodSelectionRule{Client: fakeClient, Target: clusterTarget}
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.PassedCheckResult("The cluster does not have any pods in non-system namespaces.", clusterTarget),
+			}))
+		})
+	})
+})
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of a Network Policies and Service Exposure Ruleset
+	RulesetID = "network-exposure"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset checks the network policy coverage of the namespaces and pods
+// of a cluster and the exposure of its services and ingresses.
+type Ruleset struct {
+	version    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "Network Policies and Service Exposure"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules check the
+// objects of the cluster of clusterConfig which is described by clusterTarget.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, clusterConfig *rest.Config, clusterTarget rule.Target) (*Ruleset, error) {
+	ruleset, err := New(WithVersion(rulesetConfig.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	c, err := client.New(clusterConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := Rules(c, clusterTarget, ruleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// Rules creates the rules of the ruleset which check the objects listed by the client.
+func Rules(c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	namespaceOptions := map[string]*NamespaceOptions{}
+	for _, id := range []string{"net-001", "net-002"} {
+		options, err := parseOptions[NamespaceOptions](ruleOptions[id].Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse options of rule %s: %w", id, err)
+		}
+		namespaceOptions[id] = options
+	}
+
+	exposureOptions := map[string]*ExposureOptions{}
+	for _, id := range []string{"net-003", "net-004"} {
+		options, err := parseOptions[ExposureOptions](ruleOptions[id].Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse options of rule %s: %w", id, err)
+		}
+		if options != nil {
+			if err := options.validate(); err != nil {
+				return nil, fmt.Errorf("invalid options of rule %s: %w", id, err)
+			}
+		}
+		exposureOptions[id] = options
+	}
+
+	return []rule.Rule{
+		&DefaultDenyRule{Client: c, Target: clusterTarget, Options: namespaceOptions["net-001"]},
+		&PodSelectionRule{Client: c, Target: clusterTarget, Options: namespaceOptions["net-002"]},
+		&ServiceExposureRule{Client: c, Target: clusterTarget, Options: exposureOptions["net-003"]},
+		&IngressExposureRule{Client: c, Target: clusterTarget, Options: exposureOptions["net-004"]},
+	}, nil
+}
+
+func parseOptions[O any](args any) (*O, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options O
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// defaultNodesPerWorkerGroup is the default number of sampled nodes per worker group.
+// It is the smallest number of nodes which has a majority when one node differs.
+const defaultNodesPerWorkerGroup = 3
+
+// Args are the arguments of a Node Configuration Drift [Ruleset].
+type Args struct {
+	// NodesPerWorkerGroup is the number of nodes which are sampled per worker group.
+	// All nodes of the worker groups are compared if it is set to 0. Defaults to 3.
+	NodesPerWorkerGroup *int `json:"nodesPerWorkerGroup,omitempty" yaml:"nodesPerWorkerGroup,omitempty"`
+}
+
+// ParseArgs parses the generic ruleset arguments into [Args].
+func ParseArgs(args any) (*Args, error) {
+	parsedArgs := Args{}
+	if args != nil {
+		argsByte, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(argsByte, &parsedArgs); err != nil {
+			return nil, fmt.Errorf("failed to parse ruleset args: %w", err)
+		}
+	}
+
+	if parsedArgs.NodesPerWorkerGroup != nil && *parsedArgs.NodesPerWorkerGroup < 0 {
+		return nil, errors.New("nodesPerWorkerGroup must not be negative")
+	}
+	return &parsedArgs, nil
+}
+
+// nodesPerWorkerGroup returns the number of nodes which are sampled per worker group.
+func (a *Args) nodesPerWorkerGroup() int {
+	if a.NodesPerWorkerGroup == nil {
+		return defaultNodesPerWorkerGroup
+	}
+	return *a.NodesPerWorkerGroup
+}
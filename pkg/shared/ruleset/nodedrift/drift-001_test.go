@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fakepod "github.com/gardener/diki/pkg/kubernetes/pod/fake"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+)
+
+var _ = Describe("#drift-001", func() {
+	const (
+		kubeletConfig = `authentication:
+  anonymous:
+    enabled: false
+readOnlyPort: 0
+featureGates:
+  Foo: true
+`
+		files = "600 0 0 /var/lib/kubelet/config/kubelet\n"
+		sshd  = "active=inactive\n"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		conformant = node{kubeletConfig: kubeletConfig, files: files, sshd: sshd}
+	)
+
+	It("should pass when the kubelet configs of the nodes do not differ", func() {
+		r := &nodedrift.KubeletConfigRule{Nodes: newNodes(conformant, conformant, conformant)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("drift-001"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{passed("node1, node2, node3")}))
+	})
+
+	It("should report nodes whose kubelet config differs from the majority", func() {
+		r := &nodedrift.KubeletConfigRule{Nodes: newNodes(
+			conformant,
+			node{kubeletConfig: kubeletConfig + "maxPods: 200\n", files: files, sshd: sshd},
+			node{kubeletConfig: "authentication:\n  anonymous:\n    enabled: true\nreadOnlyPort: 0\nfeatureGates:\n  Foo: true\n", files: files, sshd: sshd},
+		)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Node differs from the majority of the nodes of the worker group.", workerGroupTarget("worker1").With("node", "node2", "details", "maxPods: 200 (majority: <not set>)")),
+			rule.FailedCheckResult("Node differs from the majority of the nodes of the worker group.", workerGroupTarget("worker1").With("node", "node3", "details", "authentication.anonymous.enabled: true (majority: false)")),
+		}))
+	})
+
+	It("should not compare the ignored keys of the rule options", func() {
+		r := &nodedrift.KubeletConfigRule{Nodes: newNodes(
+			conformant,
+			conformant,
+			node{kubeletConfig: "authentication:\n  anonymous:\n    enabled: false\nreadOnlyPort: 0\nfeatureGates:\n  Foo: false\n  Bar: true\n", files: files, sshd: sshd},
+		), Options: &nodedrift.IgnoreOptions{IgnoredKeys: []string{"featureGates.*"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{passed("node1, node2, node3")}))
+	})
+
+	It("should warn when the nodes do not have a majority value", func() {
+		r := &nodedrift.KubeletConfigRule{Nodes: newNodes(
+			conformant,
+			node{kubeletConfig: "authentication:\n  anonymous:\n    enabled: false\nreadOnlyPort: 10255\nfeatureGates:\n  Foo: true\n", files: files, sshd: sshd},
+		)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.WarningCheckResult("Nodes of the worker group do not have a majority value.", workerGroupTarget("worker1").With("details", "readOnlyPort: node1=0 node2=10255")),
+		}))
+	})
+
+	It("should pass worker groups with a single node", func() {
+		r := &nodedrift.KubeletConfigRule{Nodes: newNodes(conformant)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Worker group does not have multiple nodes to compare.", workerGroupTarget("worker1").With("details", "compared nodes: node1")),
+		}))
+	})
+
+	It("should compare the configured number of sampled nodes", func() {
+		r := &nodedrift.KubeletConfigRule{Nodes: newNodes(conformant, conformant, conformant), NodesPerWorkerGroup: 2}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(BeElementOf(
+			[]rule.CheckResult{passed("node1, node2")},
+			[]rule.CheckResult{passed("node1, node3")},
+			[]rule.CheckResult{passed("node2, node3")},
+		))
+	})
+
+	It("should warn when the cluster does not have worker groups", func() {
+		nodes := newNodes()
+		nodes.WorkerGroups = func(context.Context) ([]nodedrift.WorkerGroup, error) { return nil, nil }
+		r := &nodedrift.KubeletConfigRule{Nodes: nodes}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.WarningCheckResult("Cluster does not have worker groups.", clusterTarget),
+		}))
+	})
+
+	It("should warn for worker groups without nodes which can be checked", func() {
+		nodes := newNodes(conformant, conformant)
+		nodes.WorkerGroups = func(context.Context) ([]nodedrift.WorkerGroup, error) {
+			return []nodedrift.WorkerGroup{{Name: "worker1", NodeNames: []string{"node1", "node2"}}, {Name: "worker2"}}, nil
+		}
+		r := &nodedrift.KubeletConfigRule{Nodes: nodes}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			passed("node1, node2"),
+			rule.WarningCheckResult("There are no ready nodes with at least 1 allocatable spot for worker group.", workerGroupTarget("worker2")),
+		}))
+	})
+
+	It("should return errored results for nodes whose facts cannot be read", func() {
+		nodes := newNodes(conformant, conformant, conformant, conformant)
+		nodes.PodContext = fakepod.NewFakeSimplePodContext(
+			[][]string{
+				conformant.output(),
+				{"", ""},
+				{"/opt/bin/kubelet --kubeconfig=/var/lib/kubelet/kubeconfig-real"},
+				{kubeletCommand, "### kubelet-config\n" + kubeletConfig},
+			},
+			[][]error{{nil, nil}, {errors.New("foo"), nil}, {nil}, {nil, nil}},
+		)
+		r := &nodedrift.KubeletConfigRule{Nodes: nodes}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("foo", podTarget("diki-node-drift-bbbbbbbbbb")),
+			rule.ErroredCheckResult("kubelet config file is not set exactly once", podTarget("diki-node-drift-cccccccccc")),
+			rule.ErroredCheckResult("output of facts script is incomplete", podTarget("diki-node-drift-dddddddddd")),
+			rule.PassedCheckResult("Worker group does not have multiple nodes to compare.", workerGroupTarget("worker1").With("details", "compared nodes: node1")),
+		}))
+	})
+})
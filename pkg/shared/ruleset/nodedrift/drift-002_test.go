@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift_test
+
+import (
+	"context"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+)
+
+var _ = Describe("#drift-002", func() {
+	const (
+		kubeletConfig = "readOnlyPort: 0\n"
+		files         = `600 0 0 /var/lib/kubelet/config/kubelet
+600 0 0 /var/lib/kubelet/kubeconfig-real
+644 0 0 /etc/systemd/system/kubelet.service
+755 0 0 /var/lib/kubelet/pki
+600 0 0 /var/lib/kubelet/pki/kubelet-server-current.pem
+`
+		sshd = "active=inactive\n"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		conformant = node{kubeletConfig: kubeletConfig, files: files, sshd: sshd}
+	)
+
+	It("should pass when the kubelet files of the nodes do not differ", func() {
+		r := &nodedrift.FileRule{Nodes: newNodes(conformant, conformant, conformant)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("drift-002"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{passed("node1, node2, node3")}))
+	})
+
+	It("should report nodes whose file permissions and owners differ from the majority", func() {
+		r := &nodedrift.FileRule{Nodes: newNodes(
+			conformant,
+			node{kubeletConfig: kubeletConfig, files: `600 0 0 /var/lib/kubelet/config/kubelet
+644 1000 0 /var/lib/kubelet/kubeconfig-real
+644 0 0 /etc/systemd/system/kubelet.service
+755 0 0 /var/lib/kubelet/pki
+`, sshd: sshd},
+			conformant,
+		)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Node differs from the majority of the nodes of the worker group.", workerGroupTarget("worker1").With("node", "node2",
+				"details", "/var/lib/kubelet/kubeconfig-real: permissions 644, user 1000, group 0 (majority: permissions 600, user 0, group 0); /var/lib/kubelet/pki/kubelet-server-current.pem: <not set> (majority: permissions 600, user 0, group 0)")),
+		}))
+	})
+
+	It("should not compare the files of the ignored keys of the rule options", func() {
+		r := &nodedrift.FileRule{Nodes: newNodes(
+			conformant,
+			conformant,
+			node{kubeletConfig: kubeletConfig, files: `600 0 0 /var/lib/kubelet/config/kubelet
+600 0 0 /var/lib/kubelet/kubeconfig-real
+644 0 0 /etc/systemd/system/kubelet.service
+700 0 0 /var/lib/kubelet/pki
+`, sshd: sshd},
+		), Options: &nodedrift.IgnoreOptions{IgnoredKeys: []string{"/var/lib/kubelet/pki", "/var/lib/kubelet/pki/*"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{passed("node1, node2, node3")}))
+	})
+
+	It("should error when the ignored keys of the rule options are invalid", func() {
+		r := &nodedrift.FileRule{Nodes: newNodes(conformant), Options: &nodedrift.IgnoreOptions{IgnoredKeys: []string{"[foo"}}}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("pattern [foo: syntax error in pattern", clusterTarget),
+		}))
+
+		_, err = nodedrift.Rules(nodedrift.Nodes{}, 0, map[string]config.RuleOptionsConfig{"drift-002": {Args: map[string]any{"ignoredKeys": []string{"[foo"}}}}, slog.Default)
+		Expect(err).To(MatchError("invalid options of rule drift-002: pattern [foo: syntax error in pattern"))
+	})
+})
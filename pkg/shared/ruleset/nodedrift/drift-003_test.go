@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+)
+
+var _ = Describe("#drift-003", func() {
+	const (
+		kubeletConfig = "readOnlyPort: 0\n"
+		files         = "600 0 0 /var/lib/kubelet/config/kubelet\n"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		inactive = node{kubeletConfig: kubeletConfig, files: files, sshd: "active=inactive\nenabled=disabled\nlistening=false\n"}
+	)
+
+	It("should pass when the sshd states of the nodes do not differ", func() {
+		r := &nodedrift.SshdRule{Nodes: newNodes(inactive, inactive, inactive)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("drift-003"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{passed("node1, node2, node3")}))
+	})
+
+	It("should report nodes whose sshd state differs from the majority", func() {
+		r := &nodedrift.SshdRule{Nodes: newNodes(
+			node{kubeletConfig: kubeletConfig, files: files, sshd: "active=active\nenabled=enabled\nlistening=true\n"},
+			inactive,
+			inactive,
+		)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Node differs from the majority of the nodes of the worker group.", workerGroupTarget("worker1").With("node", "node1",
+				"details", "active: active (majority: inactive); enabled: enabled (majority: disabled); listening: true (majority: false)")),
+		}))
+	})
+
+	It("should report sshd which is listening on nodes where it is not active", func() {
+		r := &nodedrift.SshdRule{Nodes: newNodes(
+			inactive,
+			node{kubeletConfig: kubeletConfig, files: files, sshd: "active=inactive\nenabled=disabled\nlistening=true\n"},
+			inactive,
+		)}
+
+		ruleResult, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Node differs from the majority of the nodes of the worker group.", workerGroupTarget("worker1").With("node", "node2", "details", "listening: true (majority: false)")),
+		}))
+	})
+})
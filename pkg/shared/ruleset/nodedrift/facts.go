@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const sectionPrefix = "### "
+
+const (
+	sectionKubeletConfig = "kubelet-config"
+	sectionFiles         = "files"
+	sectionSSHD          = "sshd"
+)
+
+const (
+	// kubeletPKIDirectory is the default certificate directory of the kubelet. The current certificates
+	// are compared by their symlinks because the names of the certificate files contain timestamps.
+	kubeletPKIDirectory = "/var/lib/kubelet/pki"
+	sshdScript          = `echo "active=$(systemctl is-active sshd 2>/dev/null)"
+echo "enabled=$(systemctl is-enabled sshd 2>/dev/null)"
+if ss -tln 2>/dev/null | grep -qE ':22(\s|$)'; then echo "listening=true"; else echo "listening=false"; fi`
+)
+
+// factsScript returns the script which collects the facts of a node. Every section of the output starts
+// with a line with the section prefix and the name of the section. The commands must not fail the script.
+func factsScript(kubeletConfigPath, kubeconfigPath string) string {
+	files := []string{
+		kubeletConfigPath,
+		kubeconfigPath,
+		"$(systemctl show -P FragmentPath kubelet.service)",
+		kubeletPKIDirectory,
+		kubeletPKIDirectory + "/*-current.pem",
+	}
+	return strings.Join([]string{
+		section(sectionKubeletConfig, fmt.Sprintf(`cat %s 2>/dev/null`, kubeletConfigPath)),
+		section(sectionFiles, fmt.Sprintf(`stat -Lc "%%a %%u %%g %%n" %s 2>/dev/null`, strings.Join(files, " "))),
+		section(sectionSSHD, sshdScript),
+		"true",
+	}, "\n")
+}
+
+func section(name, command string) string {
+	return fmt.Sprintf("echo '%s%s'\n%s", sectionPrefix, name, command)
+}
+
+// facts are the facts of a node which are compared between the nodes of a worker group.
+// Every kind of facts maps the keys of the compared settings to their values.
+type facts struct {
+	kubeletConfig map[string]string
+	files         map[string]string
+	sshd          map[string]string
+}
+
+// parseFacts parses the output of the facts script.
+func parseFacts(output string) (*facts, error) {
+	sections := map[string][]string{}
+	current := ""
+	for _, line := range strings.Split(output, "\n") {
+		if name, ok := strings.CutPrefix(line, sectionPrefix); ok {
+			current = name
+			sections[current] = []string{}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+	if _, ok := sections[sectionSSHD]; !ok {
+		return nil, errors.New("output of facts script is incomplete")
+	}
+
+	f := &facts{
+		kubeletConfig: map[string]string{},
+		files:         map[string]string{},
+		sshd:          map[string]string{},
+	}
+
+	rawKubeletConfig := strings.Join(sections[sectionKubeletConfig], "\n")
+	if len(strings.TrimSpace(rawKubeletConfig)) == 0 {
+		return nil, errors.New("kubelet config is empty")
+	}
+	kubeletConfig := map[string]any{}
+	if err := yaml.Unmarshal([]byte(rawKubeletConfig), &kubeletConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse kubelet config: %w", err)
+	}
+	flatten("", kubeletConfig, f.kubeletConfig)
+
+	for _, line := range sections[sectionFiles] {
+		// the fields are permissions, user, group and the name of the file
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		f.files[fields[3]] = fmt.Sprintf("permissions %s, user %s, group %s", fields[0], fields[1], fields[2])
+	}
+
+	for _, line := range sections[sectionSSHD] {
+		if key, value, ok := strings.Cut(strings.TrimSpace(line), "="); ok {
+			f.sshd[key] = value
+		}
+	}
+	return f, nil
+}
+
+// flatten adds the values of the nested maps of m to result. The keys are the
+// paths to the values separated by dots, lists and scalars are encoded as JSON.
+func flatten(prefix string, m map[string]any, result map[string]string) {
+	for key, value := range m {
+		if len(prefix) > 0 {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flatten(key, nested, result)
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprint(value))
+		}
+		result[key] = string(encoded)
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fakestrgen "github.com/gardener/diki/pkg/internal/stringgen/fake"
+	fakepod "github.com/gardener/diki/pkg/kubernetes/pod/fake"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+)
+
+func TestNodeDrift(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Node Configuration Drift Ruleset Test Suite")
+}
+
+const kubeletCommand = "/opt/bin/kubelet --config=/var/lib/kubelet/config/kubelet --kubeconfig=/var/lib/kubelet/kubeconfig-real"
+
+var clusterTarget = rule.NewTarget("cluster", "shoot")
+
+// node is the faked output of the facts script of a node.
+type node struct {
+	kubeletConfig string
+	files         string
+	sshd          string
+}
+
+// output returns the faked outputs of the kubelet command and of the facts script of the node.
+func (n node) output() []string {
+	return []string{kubeletCommand, "### kubelet-config\n" + n.kubeletConfig + "### files\n" + n.files + "### sshd\n" + n.sshd}
+}
+
+// newNodes creates nodes of a single worker group worker1 which return the facts of the nodes.
+// The pod name generator is reset, the pods which read the facts are named diki-node-drift-aaaaaaaaaa,
+// diki-node-drift-bbbbbbbbbb and so on in the order of the nodes.
+func newNodes(nodes ...node) nodedrift.Nodes {
+	nodedrift.Generator = &fakestrgen.FakeRandString{Rune: 'a'}
+
+	var (
+		nodeNames = make([]string, 0, len(nodes))
+		outputs   = make([][]string, 0, len(nodes))
+		errs      = make([][]error, 0, len(nodes))
+	)
+	for i, n := range nodes {
+		nodeNames = append(nodeNames, "node"+string(rune('1'+i)))
+		outputs = append(outputs, n.output())
+		errs = append(errs, []error{nil, nil})
+	}
+	return nodedrift.Nodes{
+		PodContext: fakepod.NewFakeSimplePodContext(outputs, errs),
+		WorkerGroups: func(context.Context) ([]nodedrift.WorkerGroup, error) {
+			return []nodedrift.WorkerGroup{{Name: "worker1", NodeNames: nodeNames}}, nil
+		},
+		InstanceID: "1",
+		Target:     clusterTarget,
+	}
+}
+
+func workerGroupTarget(name string) rule.Target {
+	return clusterTarget.With("kind", "workerGroup", "name", name)
+}
+
+func podTarget(name string) rule.Target {
+	return clusterTarget.With("kind", "pod", "namespace", "kube-system", "name", name)
+}
+
+// passed returns the check result of a worker group whose compared nodes do not differ.
+func passed(comparedNodes string) rule.CheckResult {
+	return rule.PassedCheckResult("Nodes of the worker group do not differ.", workerGroupTarget("worker1").With("details", "compared nodes: "+comparedNodes))
+}
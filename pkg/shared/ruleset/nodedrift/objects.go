@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/component-base/version"
+
+	"github.com/gardener/diki/imagevector"
+	"github.com/gardener/diki/pkg/kubernetes/pod"
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/images"
+)
+
+// WorkerGroup is a group of nodes of a cluster which are expected to share the same configuration.
+type WorkerGroup struct {
+	// Name is the name of the worker group.
+	Name string
+	// NodeNames are the names of the nodes of the worker group which can be checked.
+	NodeNames []string
+}
+
+// Nodes are the nodes of a cluster which are compared by the rules of the ruleset.
+type Nodes struct {
+	// PodContext creates the privileged pods which read the facts of the nodes.
+	PodContext pod.PodContext
+	// WorkerGroups returns the worker groups of the cluster.
+	WorkerGroups func(ctx context.Context) ([]WorkerGroup, error)
+	// InstanceID is the id of the ruleset instance which is added as label to the privileged pods.
+	InstanceID string
+	// Target describes the cluster.
+	Target rule.Target
+}
+
+// node is the result of reading the facts of a node.
+type node struct {
+	name  string
+	facts *facts
+	// err is set if the facts of the node cannot be read. The
+	// check results of the error are reported for errTarget.
+	err       error
+	errTarget rule.Target
+}
+
+// workerGroup contains the sampled nodes of a worker group.
+type workerGroup struct {
+	name  string
+	nodes []node
+}
+
+// target returns the target of the worker group.
+func (wg workerGroup) target(clusterTarget rule.Target) rule.Target {
+	return clusterTarget.With("kind", "workerGroup", "name", wg.name)
+}
+
+// loader reads the facts of the sampled nodes once and shares them between all rules of a ruleset.
+type loader struct {
+	nodes               Nodes
+	nodesPerWorkerGroup int
+	logger              func() *slog.Logger
+
+	once   sync.Once
+	result []workerGroup
+	err    error
+}
+
+func newLoader(nodes Nodes, nodesPerWorkerGroup int, logger func() *slog.Logger) *loader {
+	return &loader{nodes: nodes, nodesPerWorkerGroup: nodesPerWorkerGroup, logger: logger}
+}
+
+// get returns the sampled nodes of all worker groups.
+func (l *loader) get(ctx context.Context) ([]workerGroup, error) {
+	l.once.Do(func() {
+		l.result, l.err = l.read(ctx)
+	})
+	return l.result, l.err
+}
+
+func (l *loader) read(ctx context.Context) ([]workerGroup, error) {
+	workerGroups, err := l.nodes.WorkerGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := imagevector.ImageVector().FindImage(images.DikiOpsImageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find image version for %s: %w", images.DikiOpsImageName, err)
+	}
+	image.WithOptionalTag(version.Get().GitVersion)
+
+	result := make([]workerGroup, 0, len(workerGroups))
+	for _, wg := range workerGroups {
+		sampled := sample(wg.NodeNames, l.nodesPerWorkerGroup)
+		nodes := make([]node, 0, len(sampled))
+		for _, nodeName := range sampled {
+			n := node{name: nodeName}
+			n.facts, n.errTarget, n.err = l.readFacts(ctx, nodeName, image.String())
+			nodes = append(nodes, n)
+		}
+		result = append(result, workerGroup{name: wg.Name, nodes: nodes})
+	}
+	return result, nil
+}
+
+// sample returns n random node names sorted by name. All node names are returned if n is 0.
+func sample(nodeNames []string, n int) []string {
+	sampled := slices.Clone(nodeNames)
+	if n > 0 && len(sampled) > n {
+		rand.Shuffle(len(sampled), func(i, j int) {
+			sampled[i], sampled[j] = sampled[j], sampled[i]
+		})
+		sampled = sampled[:n]
+	}
+	slices.Sort(sampled)
+	return sampled
+}
+
+func (l *loader) readFacts(ctx context.Context, nodeName, image string) (*facts, rule.Target, error) {
+	podName := fmt.Sprintf("diki-%s-%s", RulesetID, Generator.Generate(10))
+	podTarget := l.nodes.Target.With("kind", "pod", "namespace", "kube-system", "name", podName)
+
+	defer func() {
+		if err := l.nodes.PodContext.Delete(ctx, podName, "kube-system"); err != nil {
+			l.logger().Error(err.Error())
+		}
+	}()
+
+	additionalLabels := map[string]string{pod.LabelInstanceID: l.nodes.InstanceID}
+	podExecutor, err := l.nodes.PodContext.Create(ctx, pod.NewPrivilegedPod(podName, "kube-system", image, nodeName, additionalLabels))
+	if err != nil {
+		return nil, podTarget, err
+	}
+
+	rawKubeletCommand, err := kubeutils.GetKubeletCommand(ctx, podExecutor)
+	if err != nil {
+		return nil, podTarget, err
+	}
+
+	kubeletCommand := strings.Split(strings.TrimSpace(rawKubeletCommand), " ")
+	kubeletConfigPaths := kubeutils.FindFlagValueRaw(kubeletCommand, "config")
+	if len(kubeletConfigPaths) != 1 || len(kubeletConfigPaths[0]) == 0 {
+		return nil, podTarget, errors.New("kubelet config file is not set exactly once")
+	}
+	kubeconfigPaths := kubeutils.FindFlagValueRaw(kubeletCommand, "kubeconfig")
+	if len(kubeconfigPaths) != 1 || len(kubeconfigPaths[0]) == 0 {
+		return nil, podTarget, errors.New("kubelet kubeconfig file is not set exactly once")
+	}
+
+	output, err := podExecutor.Execute(ctx, "/bin/sh", factsScript(kubeletConfigPaths[0], kubeconfigPaths[0]))
+	if err != nil {
+		return nil, podTarget, err
+	}
+
+	f, err := parseFacts(output)
+	if err != nil {
+		return nil, podTarget, err
+	}
+	return f, rule.Target{}, nil
+}
@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// notSet is the value of settings which are not set on a node.
+const notSet = "<not set>"
+
+// IgnoreOptions are the rule options of the rules of the ruleset.
+type IgnoreOptions struct {
+	// IgnoredKeys are shell file name patterns of the keys of settings which are not compared,
+	// e.g. settings of the kubelet config which differ between the nodes of a worker group by design.
+	IgnoredKeys []string `json:"ignoredKeys" yaml:"ignoredKeys"`
+}
+
+// ignoredKeys returns the validated ignored keys.
+func (o *IgnoreOptions) ignoredKeys() ([]string, error) {
+	if o == nil {
+		return nil, nil
+	}
+	for _, pattern := range o.IgnoredKeys {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("pattern %s: %w", pattern, err)
+		}
+	}
+	return o.IgnoredKeys, nil
+}
+
+// nodeLoader returns the shared loader of the rule or a new one which reads the facts of the nodes for the rule only.
+func nodeLoader(shared *loader, nodes Nodes, nodesPerWorkerGroup int, logger func() *slog.Logger) *loader {
+	if shared != nil {
+		return shared
+	}
+	if logger == nil {
+		logger = slog.Default
+	}
+	return newLoader(nodes, nodesPerWorkerGroup, logger)
+}
+
+// compareNodes compares the settings of the sampled nodes of all worker groups.
+// settings returns the compared settings of a node.
+func compareNodes(ctx context.Context, r rule.Rule, l *loader, settings func(*facts) map[string]string, options *IgnoreOptions) rule.RuleResult {
+	clusterTarget := l.nodes.Target
+	ignoredKeys, err := options.ignoredKeys()
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), clusterTarget))
+	}
+
+	workerGroups, err := l.get(ctx)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), clusterTarget))
+	}
+	if len(workerGroups) == 0 {
+		return rule.SingleCheckResult(r, rule.WarningCheckResult("Cluster does not have worker groups.", clusterTarget))
+	}
+
+	var checkResults []rule.CheckResult
+	for _, wg := range workerGroups {
+		checkResults = append(checkResults, checkWorkerGroup(wg, wg.target(clusterTarget), settings, ignoredKeys)...)
+	}
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}
+}
+
+var _ rule.Rule = &KubeletConfigRule{}
+
+// KubeletConfigRule reports nodes whose kubelet config differs from the majority of the nodes of their worker group.
+type KubeletConfigRule struct {
+	Nodes Nodes
+	// NodesPerWorkerGroup is the number of sampled nodes of every worker group. All nodes are compared if it is 0.
+	NodesPerWorkerGroup int
+	Options             *IgnoreOptions
+	Logger              func() *slog.Logger
+	// loader shares the facts of the nodes between the rules created by [Rules].
+	loader *loader
+}
+
+// ID returns the id of the rule.
+func (r *KubeletConfigRule) ID() string {
+	return "drift-001"
+}
+
+// Name returns the name of the rule.
+func (r *KubeletConfigRule) Name() string {
+	return "Kubelet config must not differ between the nodes of a worker group (HIGH drift-001)"
+}
+
+// Run compares the kubelet configs of the nodes of all worker groups.
+func (r *KubeletConfigRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	l := nodeLoader(r.loader, r.Nodes, r.NodesPerWorkerGroup, r.Logger)
+	return compareNodes(ctx, r, l, kubeletConfigSettings, r.Options), nil
+}
+
+var _ rule.Rule = &FileRule{}
+
+// FileRule reports nodes whose kubelet file permissions and owners differ from the majority of the nodes of their worker group.
+type FileRule struct {
+	Nodes Nodes
+	// NodesPerWorkerGroup is the number of sampled nodes of every worker group. All nodes are compared if it is 0.
+	NodesPerWorkerGroup int
+	Options             *IgnoreOptions
+	Logger              func() *slog.Logger
+	// loader shares the facts of the nodes between the rules created by [Rules].
+	loader *loader
+}
+
+// ID returns the id of the rule.
+func (r *FileRule) ID() string {
+	return "drift-002"
+}
+
+// Name returns the name of the rule.
+func (r *FileRule) Name() string {
+	return "Kubelet file permissions and owners must not differ between the nodes of a worker group (MEDIUM drift-002)"
+}
+
+// Run compares the kubelet files of the nodes of all worker groups.
+func (r *FileRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	l := nodeLoader(r.loader, r.Nodes, r.NodesPerWorkerGroup, r.Logger)
+	return compareNodes(ctx, r, l, fileSettings, r.Options), nil
+}
+
+var _ rule.Rule = &SshdRule{}
+
+// SshdRule reports nodes whose sshd state differs from the majority of the nodes of their worker group.
+type SshdRule struct {
+	Nodes Nodes
+	// NodesPerWorkerGroup is the number of sampled nodes of every worker group. All nodes are compared if it is 0.
+	NodesPerWorkerGroup int
+	Options             *IgnoreOptions
+	Logger              func() *slog.Logger
+	// loader shares the facts of the nodes between the rules created by [Rules].
+	loader *loader
+}
+
+// ID returns the id of the rule.
+func (r *SshdRule) ID() string {
+	return "drift-003"
+}
+
+// Name returns the name of the rule.
+func (r *SshdRule) Name() string {
+	return "Sshd state must not differ between the nodes of a worker group (MEDIUM drift-003)"
+}
+
+// Run compares the sshd states of the nodes of all worker groups.
+func (r *SshdRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	l := nodeLoader(r.loader, r.Nodes, r.NodesPerWorkerGroup, r.Logger)
+	return compareNodes(ctx, r, l, sshdSettings, r.Options), nil
+}
+
+func checkWorkerGroup(wg workerGroup, target rule.Target, settings func(*facts) map[string]string, ignoredKeys []string) []rule.CheckResult {
+	if len(wg.nodes) == 0 {
+		return []rule.CheckResult{rule.WarningCheckResult("There are no ready nodes with at least 1 allocatable spot for worker group.", target)}
+	}
+
+	var (
+		checkResults []rule.CheckResult
+		nodeNames    []string
+		// nodeSettings contains the settings of the nodes whose facts could be read
+		nodeSettings = map[string]map[string]string{}
+		keys         []string
+	)
+	for _, n := range wg.nodes {
+		if n.err != nil {
+			checkResults = append(checkResults, rule.ErroredCheckResult(n.err.Error(), n.errTarget))
+			continue
+		}
+		nodeNames = append(nodeNames, n.name)
+		nodeSettings[n.name] = settings(n.facts)
+		for key := range nodeSettings[n.name] {
+			if !slices.Contains(keys, key) && !ignored(ignoredKeys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	slices.Sort(keys)
+
+	switch len(nodeNames) {
+	case 0:
+		return checkResults
+	case 1:
+		return append(checkResults, rule.PassedCheckResult("Worker group does not have multiple nodes to compare.", target.With("details", "compared nodes: "+nodeNames[0])))
+	}
+
+	var (
+		// differences maps the names of the nodes to the settings which differ from the majority
+		differences = map[string][]string{}
+		noMajority  []string
+	)
+	for _, key := range keys {
+		values := make(map[string]string, len(nodeNames))
+		for _, nodeName := range nodeNames {
+			value, ok := nodeSettings[nodeName][key]
+			if !ok {
+				value = notSet
+			}
+			values[nodeName] = value
+		}
+
+		majorityValue, ok := majority(nodeNames, values)
+		if !ok {
+			nodeValues := make([]string, 0, len(nodeNames))
+			for _, nodeName := range nodeNames {
+				nodeValues = append(nodeValues, fmt.Sprintf("%s=%s", nodeName, values[nodeName]))
+			}
+			noMajority = append(noMajority, fmt.Sprintf("%s: %s", key, strings.Join(nodeValues, " ")))
+			continue
+		}
+		for _, nodeName := range nodeNames {
+			if values[nodeName] != majorityValue {
+				differences[nodeName] = append(differences[nodeName], fmt.Sprintf("%s: %s (majority: %s)", key, values[nodeName], majorityValue))
+			}
+		}
+	}
+
+	for _, nodeName := range nodeNames {
+		if len(differences[nodeName]) > 0 {
+			checkResults = append(checkResults, rule.FailedCheckResult("Node differs from the majority of the nodes of the worker group.", target.With("node", nodeName, "details", strings.Join(differences[nodeName], "; "))))
+		}
+	}
+	if len(noMajority) > 0 {
+		checkResults = append(checkResults, rule.WarningCheckResult("Nodes of the worker group do not have a majority value.", target.With("details", strings.Join(noMajority, "; "))))
+	}
+	if len(differences) == 0 && len(noMajority) == 0 {
+		checkResults = append(checkResults, rule.PassedCheckResult("Nodes of the worker group do not differ.", target.With("details", "compared nodes: "+strings.Join(nodeNames, ", "))))
+	}
+	return checkResults
+}
+
+func ignored(ignoredKeys []string, key string) bool {
+	return slices.ContainsFunc(ignoredKeys, func(pattern string) bool {
+		ok, _ := path.Match(pattern, key)
+		return ok
+	})
+}
+
+// majority returns the value which more than half of the nodes have.
+func majority(nodeNames []string, values map[string]string) (string, bool) {
+	counts := map[string]int{}
+	for _, nodeName := range nodeNames {
+		counts[values[nodeName]]++
+	}
+	for value, count := range counts {
+		if 2*count > len(nodeNames) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func kubeletConfigSettings(f *facts) map[string]string {
+	return f.kubeletConfig
+}
+
+func fileSettings(f *facts) map[string]string {
+	return f.files
+}
+
+func sshdSettings(f *facts) map[string]string {
+	return f.sshd
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nodedrift_test
+
+import (
+	"context"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nodedrift"
+)
+
+var _ = Describe("#Rules", func() {
+	It("should read the facts of the nodes once for all rules", func() {
+		n := node{kubeletConfig: "readOnlyPort: 0\n", files: "600 0 0 /var/lib/kubelet/config/kubelet\n", sshd: "active=inactive\n"}
+		// the fake pod context returns the facts of every node only once
+		rules, err := nodedrift.Rules(newNodes(n, n), 0, nil, slog.Default)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(3))
+
+		for _, r := range rules {
+			ruleResult, err := r.Run(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{passed("node1, node2")}), r.ID())
+		}
+	})
+})
+
+var _ = Describe("#ParseArgs", func() {
+	It("should default the number of nodes per worker group", func() {
+		args, err := nodedrift.ParseArgs(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args.NodesPerWorkerGroup).To(BeNil())
+	})
+
+	It("should return error for a negative number of nodes per worker group", func() {
+		_, err := nodedrift.ParseArgs(map[string]any{"nodesPerWorkerGroup": -1})
+		Expect(err).To(MatchError("nodesPerWorkerGroup must not be negative"))
+	})
+})
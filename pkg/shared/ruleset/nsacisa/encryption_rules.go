@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+)
+
+// EncryptionOptions are the rule options of the recommendation which requires secrets to be
+// encrypted at rest. They are passed to all encryption rules, which use only their own fields.
+type EncryptionOptions struct {
+	encryption.ResourceOptions
+	encryption.ProviderOptions
+	encryption.RotationOptions
+}
+
+// EncryptionRules returns the rule of the recommendation which requires secrets to be encrypted at rest. The
+// recommendation is checked by the rules of the encryption ruleset, which report a warning when apiServer is nil.
+// The rule options of the recommendation are the rule options of all encryption rules.
+func EncryptionRules(recommendations []Recommendation, apiServer *encryption.APIServer, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	recommendation, ok := recommendationByID(recommendations, "secrets-encryption")
+	if !ok {
+		return nil, nil
+	}
+
+	encryptionRuleOptions := map[string]config.RuleOptionsConfig{}
+	if opt, ok := ruleOptions[recommendation.ID]; ok {
+		for _, id := range encryption.RuleIDs {
+			encryptionRuleOptions[id] = config.RuleOptionsConfig{RuleID: id, Args: opt.Args}
+		}
+	}
+
+	encryptionRules, err := encryption.Rules(apiServer, encryptionRuleOptions)
+	if err != nil {
+		return nil, err
+	}
+	return []rule.Rule{NewRule(recommendation, encryptionRules...)}, nil
+}
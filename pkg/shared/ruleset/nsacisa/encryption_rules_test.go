@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/encryption"
+	"github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+var _ = Describe("EncryptionRules", func() {
+	var (
+		ctx    = context.TODO()
+		target = rule.NewTarget("cluster", "seed", "kind", "deployment", "name", "kube-apiserver", "namespace", "foo")
+	)
+
+	It("should check the recommendation with the encryption rules", func() {
+		fakeClient := fakeclient.NewClientBuilder().Build()
+		Expect(fakeClient.Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver", Namespace: "foo"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "kube-apiserver", Command: []string{"--foo=bar"}}},
+					},
+				},
+			},
+		})).To(Succeed())
+
+		rules, err := nsacisa.EncryptionRules(nsacisa.V12Recommendations, &encryption.APIServer{
+			Client:         fakeClient,
+			Namespace:      "foo",
+			DeploymentName: "kube-apiserver",
+			ContainerName:  "kube-apiserver",
+			Target:         rule.NewTarget("cluster", "seed"),
+		}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+
+		ruleResult, err := rules[0].Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("secrets-encryption"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Option encryption-provider-config has not been set.", target),
+			rule.FailedCheckResult("Option encryption-provider-config has not been set.", target),
+			rule.FailedCheckResult("Option encryption-provider-config has not been set.", target),
+			rule.FailedCheckResult("Option encryption-provider-config has not been set.", target),
+		}))
+	})
+
+	It("should warn when the kube-apiserver is not accessible", func() {
+		rules, err := nsacisa.EncryptionRules(nsacisa.V12Recommendations, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+
+		ruleResult, err := rules[0].Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(HaveLen(4))
+		for _, checkResult := range ruleResult.CheckResults {
+			Expect(checkResult.Status).To(Equal(rule.Warning))
+			Expect(checkResult.Message).To(Equal("The encryption configuration of the kube-apiserver is not visible for this cluster."))
+		}
+	})
+
+	It("should pass the rule options of the recommendation to the encryption rules", func() {
+		ruleOptions := map[string]config.RuleOptionsConfig{
+			"secrets-encryption": {RuleID: "secrets-encryption", Args: map[string]any{"allowedProviders": []string{"foo"}}},
+		}
+
+		_, err := nsacisa.EncryptionRules(nsacisa.V12Recommendations, nil, ruleOptions)
+
+		Expect(err).To(MatchError("invalid options of rule enc-003: unknown provider foo"))
+	})
+
+	It("should not return rules without the recommendation", func() {
+		Expect(nsacisa.EncryptionRules(nil, nil, nil)).To(BeEmpty())
+	})
+})
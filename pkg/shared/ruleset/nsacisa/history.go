@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// History contains the recommendations of all NSA/CISA Kubernetes Hardening Guidance versions.
+var History = history.MustNew(
+	RulesetID,
+	history.Version{
+		Version: "v1.2",
+		Rules:   recommendationIDs(V12Recommendations),
+	},
+)
+
+func recommendationIDs(recommendations []Recommendation) []string {
+	ids := make([]string, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		ids = append(ids, recommendation.ID)
+	}
+	return ids
+}
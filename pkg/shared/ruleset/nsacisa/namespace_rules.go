@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/internal/utils"
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// NamespaceOptions are the rule options of recommendations which are checked per namespace.
+type NamespaceOptions struct {
+	AcceptedNamespaces []AcceptedNamespaces `json:"acceptedNamespaces" yaml:"acceptedNamespaces"`
+}
+
+// AcceptedNamespaces selects namespaces which are accepted to violate a recommendation.
+type AcceptedNamespaces struct {
+	NamespaceMatchLabels map[string]string `json:"namespaceMatchLabels" yaml:"namespaceMatchLabels"`
+	Justification        string            `json:"justification" yaml:"justification"`
+}
+
+// accepted returns whether the namespace is accepted to violate the recommendation and the justification.
+func (o *NamespaceOptions) accepted(namespace corev1.Namespace) (bool, string) {
+	if o == nil {
+		return false, ""
+	}
+
+	for _, acceptedNamespace := range o.AcceptedNamespaces {
+		if utils.MatchLabels(namespace.Labels, acceptedNamespace.NamespaceMatchLabels) {
+			return true, acceptedNamespace.Justification
+		}
+	}
+	return false, ""
+}
+
+var _ rule.Rule = &NetworkPolicyRule{}
+
+// NetworkPolicyRule checks that every namespace has network policies
+// which deny all ingress and egress traffic by default.
+type NetworkPolicyRule struct {
+	Recommendation Recommendation
+	Client         client.Client
+	Target         rule.Target
+	Options        *NamespaceOptions
+}
+
+// ID returns the id of the checked recommendation.
+func (r *NetworkPolicyRule) ID() string {
+	return r.Recommendation.ID
+}
+
+// Name returns the name of the checked recommendation.
+func (r *NetworkPolicyRule) Name() string {
+	return r.Recommendation.Name()
+}
+
+// Run checks the network policies of all namespaces.
+func (r *NetworkPolicyRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	namespaces, err := kubeutils.GetNamespaces(ctx, r.Client)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "namespaceList"))), nil
+	}
+
+	networkPolicies, err := kubeutils.GetNetworkPolicies(ctx, r.Client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "networkPolicyList"))), nil
+	}
+
+	var (
+		denyIngress = map[string]bool{}
+		denyEgress  = map[string]bool{}
+	)
+	for _, networkPolicy := range networkPolicies {
+		ingress, egress := kubeutils.DefaultDenyNetworkPolicy(networkPolicy)
+		denyIngress[networkPolicy.Namespace] = denyIngress[networkPolicy.Namespace] || ingress
+		denyEgress[networkPolicy.Namespace] = denyEgress[networkPolicy.Namespace] || egress
+	}
+
+	checkResults := make([]rule.CheckResult, 0, len(namespaces))
+	for _, name := range sortedNames(namespaces) {
+		namespace := namespaces[name]
+		target := r.Target.With("kind", "namespace", "name", name)
+
+		var missing []string
+		if !denyIngress[name] {
+			missing = append(missing, "ingress")
+		}
+		if !denyEgress[name] {
+			missing = append(missing, "egress")
+		}
+
+		if len(missing) == 0 {
+			checkResults = append(checkResults, rule.PassedCheckResult("Namespace has default deny ingress and egress network policies.", target))
+			continue
+		}
+
+		if accepted, justification := r.Options.accepted(namespace); accepted {
+			msg := "Namespace accepted to not have default deny network policies."
+			if justification != "" {
+				msg = justification
+			}
+			checkResults = append(checkResults, rule.AcceptedCheckResult(msg, target))
+			continue
+		}
+		checkResults = append(checkResults, rule.FailedCheckResult(fmt.Sprintf("Namespace does not have a default deny %s network policy.", strings.Join(missing, " and ")), target))
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+var _ rule.Rule = &ResourceQuotaRule{}
+
+// ResourceQuotaRule checks that every namespace has a resource quota.
+type ResourceQuotaRule struct {
+	Recommendation Recommendation
+	Client         client.Client
+	Target         rule.Target
+	Options        *NamespaceOptions
+}
+
+// ID returns the id of the checked recommendation.
+func (r *ResourceQuotaRule) ID() string {
+	return r.Recommendation.ID
+}
+
+// Name returns the name of the checked recommendation.
+func (r *ResourceQuotaRule) Name() string {
+	return r.Recommendation.Name()
+}
+
+// Run checks the resource quotas of all namespaces.
+func (r *ResourceQuotaRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	namespaces, err := kubeutils.GetNamespaces(ctx, r.Client)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "namespaceList"))), nil
+	}
+
+	resourceQuotas, err := kubeutils.GetResourceQuotas(ctx, r.Client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.Target.With("kind", "resourceQuotaList"))), nil
+	}
+
+	hasQuota := map[string]bool{}
+	for _, resourceQuota := range resourceQuotas {
+		if len(resourceQuota.Spec.Hard) > 0 {
+			hasQuota[resourceQuota.Namespace] = true
+		}
+	}
+
+	checkResults := make([]rule.CheckResult, 0, len(namespaces))
+	for _, name := range sortedNames(namespaces) {
+		namespace := namespaces[name]
+		target := r.Target.With("kind", "namespace", "name", name)
+
+		if hasQuota[name] {
+			checkResults = append(checkResults, rule.PassedCheckResult("Namespace has a resource quota.", target))
+			continue
+		}
+
+		if accepted, justification := r.Options.accepted(namespace); accepted {
+			msg := "Namespace accepted to not have a resource quota."
+			if justification != "" {
+				msg = justification
+			}
+			checkResults = append(checkResults, rule.AcceptedCheckResult(msg, target))
+			continue
+		}
+		checkResults = append(checkResults, rule.FailedCheckResult("Namespace does not have a resource quota.", target))
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+func sortedNames(namespaces map[string]corev1.Namespace) []string {
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NamespaceRules returns the rules of the recommendations which are
+// checked for every namespace of the cluster of the client.
+func NamespaceRules(recommendations []Recommendation, c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	newRules := map[string]func(Recommendation, *NamespaceOptions) rule.Rule{
+		"default-deny-network-policies": func(recommendation Recommendation, options *NamespaceOptions) rule.Rule {
+			return &NetworkPolicyRule{Recommendation: recommendation, Client: c, Target: clusterTarget, Options: options}
+		},
+		"resource-quotas": func(recommendation Recommendation, options *NamespaceOptions) rule.Rule {
+			return &ResourceQuotaRule{Recommendation: recommendation, Client: c, Target: clusterTarget, Options: options}
+		},
+	}
+
+	var rules []rule.Rule
+	for _, recommendation := range recommendations {
+		newRule, ok := newRules[recommendation.ID]
+		if !ok {
+			continue
+		}
+
+		options, err := parseNamespaceOptions(ruleOptions[recommendation.ID].Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse options of rule %s: %w", recommendation.ID, err)
+		}
+		rules = append(rules, newRule(recommendation, options))
+	}
+	return rules, nil
+}
+
+func parseNamespaceOptions(args any) (*NamespaceOptions, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options NamespaceOptions
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
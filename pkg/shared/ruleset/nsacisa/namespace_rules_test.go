@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+var _ = Describe("NamespaceRules", func() {
+	var (
+		fakeClient    client.Client
+		ctx           = context.TODO()
+		clusterTarget = rule.NewTarget("cluster", "shoot")
+		barTarget     = clusterTarget.With("kind", "namespace", "name", "bar")
+		bazTarget     = clusterTarget.With("kind", "namespace", "name", "baz")
+		fooTarget     = clusterTarget.With("kind", "namespace", "name", "foo")
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bar"}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "baz", Labels: map[string]string{"system": "true"}}})).To(Succeed())
+	})
+
+	run := func(id string, ruleOptions map[string]config.RuleOptionsConfig) rule.RuleResult {
+		rules, err := nsacisa.NamespaceRules(nsacisa.V12Recommendations, fakeClient, clusterTarget, ruleOptions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(2))
+
+		for _, r := range rules {
+			if r.ID() == id {
+				ruleResult, err := r.Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				return ruleResult
+			}
+		}
+		Fail("rule " + id + " not found")
+		return rule.RuleResult{}
+	}
+
+	Describe("#NetworkPolicyRule", func() {
+		BeforeEach(func() {
+			Expect(fakeClient.Create(ctx, &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "foo"},
+				Spec: networkingv1.NetworkPolicySpec{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+				},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-ingress", Namespace: "bar"},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-egress-app", Namespace: "bar"},
+				Spec: networkingv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				},
+			})).To(Succeed())
+		})
+
+		It("should check the default deny network policies of all namespaces", func() {
+			Expect(run("default-deny-network-policies", nil).CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Namespace does not have a default deny egress network policy.", barTarget),
+				rule.FailedCheckResult("Namespace does not have a default deny ingress and egress network policy.", bazTarget),
+				rule.PassedCheckResult("Namespace has default deny ingress and egress network policies.", fooTarget),
+			}))
+		})
+
+		It("should accept namespaces selected by the rule options", func() {
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"default-deny-network-policies": {Args: map[string]any{
+					"acceptedNamespaces": []map[string]any{
+						{"namespaceMatchLabels": map[string]string{"system": "true"}, "justification": "system namespace"},
+					},
+				}},
+			}
+
+			Expect(run("default-deny-network-policies", ruleOptions).CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Namespace does not have a default deny egress network policy.", barTarget),
+				rule.AcceptedCheckResult("system namespace", bazTarget),
+				rule.PassedCheckResult("Namespace has default deny ingress and egress network policies.", fooTarget),
+			}))
+		})
+	})
+
+	Describe("#ResourceQuotaRule", func() {
+		It("should check the resource quotas of all namespaces", func() {
+			Expect(fakeClient.Create(ctx, &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "foo"},
+				Spec: corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+				},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "bar"},
+			})).To(Succeed())
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"resource-quotas": {Args: map[string]any{
+					"acceptedNamespaces": []map[string]any{
+						{"namespaceMatchLabels": map[string]string{"system": "true"}},
+					},
+				}},
+			}
+
+			Expect(run("resource-quotas", ruleOptions).CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Namespace does not have a resource quota.", barTarget),
+				rule.AcceptedCheckResult("Namespace accepted to not have a resource quota.", bazTarget),
+				rule.PassedCheckResult("Namespace has a resource quota.", fooTarget),
+			}))
+		})
+	})
+})
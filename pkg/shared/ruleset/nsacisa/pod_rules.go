@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/pss"
+)
+
+// ReadOnlyRootFilesystemCheck is a check which requires all containers
+// of a pod to set securityContext.readOnlyRootFilesystem to true.
+var ReadOnlyRootFilesystemCheck = pss.Check{
+	ID:       "readOnlyRootFilesystem",
+	CheckPod: checkReadOnlyRootFilesystem,
+}
+
+func checkReadOnlyRootFilesystem(_ *metav1.ObjectMeta, podSpec *corev1.PodSpec) policy.CheckResult {
+	var badContainers []string
+	check := func(name string, securityContext *corev1.SecurityContext) {
+		if securityContext == nil || securityContext.ReadOnlyRootFilesystem == nil || !*securityContext.ReadOnlyRootFilesystem {
+			badContainers = append(badContainers, fmt.Sprintf("%q", name))
+		}
+	}
+	for _, c := range podSpec.InitContainers {
+		check(c.Name, c.SecurityContext)
+	}
+	for _, c := range podSpec.Containers {
+		check(c.Name, c.SecurityContext)
+	}
+	for _, c := range podSpec.EphemeralContainers {
+		check(c.Name, c.SecurityContext)
+	}
+
+	if len(badContainers) == 0 {
+		return policy.CheckResult{Allowed: true}
+	}
+
+	containers := "container"
+	if len(badContainers) > 1 {
+		containers = "containers"
+	}
+	return policy.CheckResult{
+		Allowed:         false,
+		ForbiddenReason: "readOnlyRootFilesystem != true",
+		ForbiddenDetail: fmt.Sprintf("%s %s must set securityContext.readOnlyRootFilesystem=true", containers, strings.Join(badContainers, ", ")),
+	}
+}
+
+// PodRules returns the rules of the recommendations which are checked by evaluating the
+// pods and pod templates of the cluster of the client with Pod Security Standards checks.
+// The rule options of the recommendations are the rule options of Pod Security Standards controls.
+func PodRules(recommendations []Recommendation, c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	pssChecks := map[policy.CheckID]pss.Check{}
+	for _, check := range pss.Checks(api.LevelRestricted, api.LatestVersion()) {
+		pssChecks[check.ID] = check
+	}
+
+	recommendationChecks := map[string]pss.Check{
+		"non-root-containers":             pssChecks["runAsNonRoot"],
+		"immutable-container-filesystems": ReadOnlyRootFilesystemCheck,
+		"privileged-containers":           pssChecks["privileged"],
+	}
+
+	var (
+		checks               []pss.Check
+		checkRecommendations []Recommendation
+		pssRuleOptions       = map[string]config.RuleOptionsConfig{}
+	)
+	for _, recommendation := range recommendations {
+		check, ok := recommendationChecks[recommendation.ID]
+		if !ok {
+			continue
+		}
+
+		checks = append(checks, check)
+		checkRecommendations = append(checkRecommendations, recommendation)
+		if opt, ok := ruleOptions[recommendation.ID]; ok {
+			pssRuleOptions[string(check.ID)] = opt
+		}
+	}
+
+	pssRules, err := pss.Rules(checks, c, clusterTarget, pssRuleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]rule.Rule, 0, len(pssRules))
+	for i, r := range pssRules {
+		rules = append(rules, NewRule(checkRecommendations[i], r))
+	}
+	return rules, nil
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+var _ = Describe("PodRules", func() {
+	It("should check the recommendations for all pods", func() {
+		var (
+			ctx           = context.TODO()
+			fakeClient    = fakeclient.NewClientBuilder().Build()
+			readOnly      = true
+			nonRoot       = true
+			clusterTarget = rule.NewTarget("cluster", "shoot")
+			podTarget     = clusterTarget.With("kind", "pod", "name", "foo", "namespace", "foo")
+		)
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "foo"},
+			Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: &nonRoot},
+				Containers: []corev1.Container{
+					{Name: "app", SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &readOnly}},
+					{Name: "sidecar"},
+				},
+			},
+		})).To(Succeed())
+
+		rules, err := nsacisa.PodRules(nsacisa.V12Recommendations, fakeClient, clusterTarget, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(3))
+
+		results := map[string][]rule.CheckResult{}
+		for _, r := range rules {
+			ruleResult, err := r.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ruleResult.RuleName).To(Equal(r.Name()))
+			results[ruleResult.RuleID] = ruleResult.CheckResults
+		}
+
+		Expect(results).To(Equal(map[string][]rule.CheckResult{
+			"non-root-containers": {
+				rule.PassedCheckResult("Pod satisfies control.", podTarget),
+			},
+			"immutable-container-filesystems": {
+				rule.FailedCheckResult(`Pod violates control: readOnlyRootFilesystem != true (container "sidecar" must set securityContext.readOnlyRootFilesystem=true).`, podTarget.With("container", "sidecar")),
+			},
+			"privileged-containers": {
+				rule.PassedCheckResult("Pod satisfies control.", podTarget),
+			},
+		}))
+	})
+})
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/rbac"
+)
+
+// RBACRules returns the rule of the recommendation which requires subjects to be granted least privilege.
+// The recommendation is checked by the rules of the RBAC ruleset with the RBAC objects listed by the client.
+// The rule options of the recommendation are the rule options of all RBAC rules.
+func RBACRules(recommendations []Recommendation, c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	recommendation, ok := recommendationByID(recommendations, "rbac-least-privilege")
+	if !ok {
+		return nil, nil
+	}
+
+	rbacRuleOptions := map[string]config.RuleOptionsConfig{}
+	if opt, ok := ruleOptions[recommendation.ID]; ok {
+		for _, id := range rbac.RuleIDs {
+			rbacRuleOptions[id] = config.RuleOptionsConfig{RuleID: id, Args: opt.Args}
+		}
+	}
+
+	rbacRules, err := rbac.Rules(c, clusterTarget, rbacRuleOptions)
+	if err != nil {
+		return nil, err
+	}
+	return []rule.Rule{NewRule(recommendation, rbacRules...)}, nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+var _ = Describe("RBACRules", func() {
+	var (
+		ctx           = context.TODO()
+		clusterTarget = rule.NewTarget("cluster", "shoot")
+		aliceTarget   = clusterTarget.With("kind", "user", "name", "alice", "details", `ClusterRoleBinding admin binds ClusterRole admin which grants verbs [*] on resources [*] of api groups ["*"] cluster-wide`)
+		fakeClient    client.Client
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		for _, obj := range []client.Object{
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			},
+		} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+	})
+
+	It("should check the recommendation with the RBAC rules", func() {
+		rules, err := nsacisa.RBACRules(nsacisa.V12Recommendations, fakeClient, clusterTarget, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+
+		ruleResult, err := rules[0].Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.RuleID).To(Equal("rbac-least-privilege"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("User is granted wildcard verbs or resources.", aliceTarget),
+			rule.FailedCheckResult("User is granted the escalate, bind or impersonate verbs.", aliceTarget),
+			rule.FailedCheckResult("User is granted read access to secrets cluster-wide.", aliceTarget),
+			rule.FailedCheckResult("User is granted access to pods/exec.", aliceTarget),
+			rule.FailedCheckResult("User is granted access to nodes/proxy.", aliceTarget),
+			rule.PassedCheckResult("No binding grants permissions to system:anonymous or system:authenticated.", clusterTarget),
+			rule.PassedCheckResult("No unused service account is granted powerful permissions.", clusterTarget),
+		}))
+	})
+
+	It("should pass the rule options of the recommendation to the RBAC rules", func() {
+		ruleOptions := map[string]config.RuleOptionsConfig{
+			"rbac-least-privilege": {RuleID: "rbac-least-privilege", Args: map[string]any{"acceptedSubjects": []map[string]any{{"kind": "User", "name": "alice"}}}},
+		}
+		acceptedTarget := clusterTarget.With("kind", "user", "name", "alice")
+		rules, err := nsacisa.RBACRules(nsacisa.V12Recommendations, fakeClient, clusterTarget, ruleOptions)
+		Expect(err).ToNot(HaveOccurred())
+
+		ruleResult, err := rules[0].Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("User is granted wildcard verbs or resources and is accepted.", acceptedTarget),
+			rule.AcceptedCheckResult("User is granted the escalate, bind or impersonate verbs and is accepted.", acceptedTarget),
+			rule.AcceptedCheckResult("User is granted read access to secrets cluster-wide and is accepted.", acceptedTarget),
+			rule.AcceptedCheckResult("User is granted access to pods/exec and is accepted.", acceptedTarget),
+			rule.AcceptedCheckResult("User is granted access to nodes/proxy and is accepted.", acceptedTarget),
+			rule.PassedCheckResult("No binding grants permissions to system:anonymous or system:authenticated.", clusterTarget),
+			rule.PassedCheckResult("No unused service account is granted powerful permissions.", clusterTarget),
+		}))
+	})
+})
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"fmt"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+)
+
+// Recommendation is a hardening recommendation of the NSA/CISA Kubernetes Hardening Guidance.
+type Recommendation struct {
+	// ID is the id of the recommendation, e.g. non-root-containers.
+	ID string
+	// Title is the title of the recommendation.
+	Title string
+	// Section is the section of the guide which contains the recommendation.
+	Section string
+	// DISARuleIDs are the ids of the DISA Kubernetes STIG rules which check the same requirements.
+	DISARuleIDs []string
+}
+
+// Name returns the rule name of the recommendation.
+func (r Recommendation) Name() string {
+	return fmt.Sprintf("%s (%s)", r.Title, r.Section)
+}
+
+func (r Recommendation) requirement() catalog.Requirement {
+	return catalog.Requirement{ID: r.ID, Name: r.Name(), DISARuleIDs: r.DISARuleIDs}
+}
+
+// DISAVersion is the version of the DISA Kubernetes STIG whose rules are reused by the recommendations.
+const DISAVersion = "v1r11"
+
+const (
+	sectionPodSecurity     = "Kubernetes Pod security"
+	sectionNetwork         = "Network separation and hardening"
+	sectionAuthentication  = "Authentication and authorization"
+	sectionAuditLogging    = "Audit logging and threat detection"
+	sectionUpgradePractice = "Upgrading and application security practices"
+)
+
+// V12Recommendations are the recommendations of the NSA/CISA Kubernetes Hardening Guidance v1.2.
+var V12Recommendations = []Recommendation{
+	{ID: "non-root-containers", Title: "Containers must run as non-root users", Section: sectionPodSecurity},
+	{ID: "immutable-container-filesystems", Title: "Containers must run with an immutable root file system", Section: sectionPodSecurity},
+	{ID: "privileged-containers", Title: "Containers must not run as privileged", Section: sectionPodSecurity},
+	{ID: "secure-container-images", Title: "Container images must be scanned for vulnerabilities and misconfigurations", Section: sectionPodSecurity},
+	{ID: "default-deny-network-policies", Title: "Namespaces must have default deny network policies", Section: sectionNetwork},
+	{ID: "resource-quotas", Title: "Namespaces must have resource quotas", Section: sectionNetwork},
+	{ID: "secrets-encryption", Title: "Secrets must be encrypted at rest in etcd", Section: sectionNetwork},
+	{ID: "anonymous-authentication", Title: "Anonymous authentication must be disabled", Section: sectionAuthentication, DISARuleIDs: []string{"242390", "242391"}},
+	{ID: "rbac-authorization", Title: "Role-based access control must be enabled", Section: sectionAuthentication, DISARuleIDs: []string{"242382"}},
+	{ID: "rbac-least-privilege", Title: "Subjects must be granted least privilege with role-based access control", Section: sectionAuthentication},
+	{ID: "audit-logging", Title: "Kubernetes audit logging must be enabled", Section: sectionAuditLogging, DISARuleIDs: []string{"242402", "242403", "242461"}},
+	{ID: "threat-detection", Title: "Logs must be forwarded to a threat detection system", Section: sectionAuditLogging},
+	{ID: "upgrades", Title: "Kubernetes and its components must be kept up to date", Section: sectionUpgradePractice},
+}
+
+// Recommendations returns the recommendations of a NSA/CISA Kubernetes Hardening Guidance version.
+func Recommendations(version string) ([]Recommendation, error) {
+	switch version {
+	case "v1.2":
+		return V12Recommendations, nil
+	default:
+		return nil, fmt.Errorf("unknown ruleset %s version: %s", RulesetID, version)
+	}
+}
+
+func recommendationByID(recommendations []Recommendation, id string) (Recommendation, bool) {
+	for _, r := range recommendations {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Recommendation{}, false
+}
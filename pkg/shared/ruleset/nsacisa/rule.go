@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa
+
+import (
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/catalog"
+)
+
+// NewRule creates a rule which checks a recommendation with the given rules
+// of other rulesets which check the same requirements.
+func NewRule(recommendation Recommendation, rules ...rule.Rule) *rule.CompositeRule {
+	return catalog.NewRule(recommendation.requirement(), rules...)
+}
+
+// Rules returns the rules for the recommendations. Recommendations with an implementation
+// in rules are checked by it, recommendations overlapping with DISA Kubernetes STIG rules
+// are checked by the rules of disaRules and all other recommendations are not implemented.
+func Rules(recommendations []Recommendation, disaRules sharedruleset.RuleGetter, rules ...rule.Rule) ([]rule.Rule, error) {
+	return guide(recommendations).Rules(disaRules, rules...)
+}
+
+// DISARuleOptions translates the rule options of recommendations to the rule options of the
+// DISA Kubernetes STIG rules which check the recommendations. Only the rule args are translated,
+// skipped recommendations are handled by the NSA/CISA ruleset.
+func DISARuleOptions(recommendations []Recommendation, ruleOptions []config.RuleOptionsConfig) ([]config.RuleOptionsConfig, error) {
+	return guide(recommendations).DISARuleOptions(ruleOptions)
+}
+
+// guide returns the catalog of the recommendations.
+func guide(recommendations []Recommendation) catalog.Catalog {
+	requirements := make([]catalog.Requirement, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		requirements = append(requirements, recommendation.requirement())
+	}
+	return catalog.Catalog{Kind: "recommendation", Document: "guide", Requirements: requirements}
+}
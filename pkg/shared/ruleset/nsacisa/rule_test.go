@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nsacisa_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/nsacisa"
+)
+
+type fakeRule struct {
+	id string
+}
+
+func (r *fakeRule) ID() string   { return r.id }
+func (r *fakeRule) Name() string { return "fake " + r.id }
+func (r *fakeRule) Run(context.Context) (rule.RuleResult, error) {
+	return rule.RuleResult{RuleID: r.id, RuleName: r.Name()}, nil
+}
+
+type fakeRuleGetter map[string]rule.Rule
+
+func (g fakeRuleGetter) Rule(id string) (rule.Rule, bool) {
+	r, ok := g[id]
+	return r, ok
+}
+
+var _ = Describe("rule", func() {
+	var (
+		recommendations = []nsacisa.Recommendation{
+			{ID: "foo", Title: "Foo", Section: "Section", DISARuleIDs: []string{"1", "2"}},
+			{ID: "bar", Title: "Bar", Section: "Section"},
+			{ID: "baz", Title: "Baz", Section: "Section"},
+		}
+		disaRules = fakeRuleGetter{
+			"1": &fakeRule{id: "1"},
+			"2": &fakeRule{id: "2"},
+		}
+	)
+
+	Describe("#Rules", func() {
+		It("should return the rules of all recommendations", func() {
+			implemented := &fakeRule{id: "baz"}
+			rules, err := nsacisa.Rules(recommendations, disaRules, implemented)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rules).To(Equal([]rule.Rule{
+				nsacisa.NewRule(recommendations[0], disaRules["1"], disaRules["2"]),
+				rule.NewSkipRule("bar", "Bar (Section)", "", rule.NotImplemented),
+				implemented,
+			}))
+		})
+
+		It("should return an error for missing DISA rules and unknown recommendations", func() {
+			_, err := nsacisa.Rules(recommendations, fakeRuleGetter{}, &fakeRule{id: "qux"})
+			Expect(err).To(MatchError(And(
+				ContainSubstring("rule qux is not a recommendation of the guide"),
+				ContainSubstring("rule 1 of recommendation foo is not registered in the DISA Kubernetes STIG ruleset"),
+			)))
+		})
+	})
+
+	Describe("#V12Recommendations", func() {
+		It("should have unique ids", func() {
+			ids := map[string]struct{}{}
+			for _, recommendation := range nsacisa.V12Recommendations {
+				Expect(ids).ToNot(HaveKey(recommendation.ID))
+				ids[recommendation.ID] = struct{}{}
+			}
+			Expect(nsacisa.History.RuleIDs("v1.2")).To(HaveLen(len(nsacisa.V12Recommendations)))
+		})
+	})
+})
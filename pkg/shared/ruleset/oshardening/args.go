@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oshardening
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Args are the arguments of a Worker Node OS Hardening [Ruleset].
+type Args struct {
+	// Profile is the name of the profile which all nodes are checked against.
+	// The profile of a node is detected by the ID of its operating system if no profile is set.
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+}
+
+// ParseArgs parses the generic ruleset arguments into [Args].
+func ParseArgs(args any) (*Args, error) {
+	parsedArgs := Args{}
+	if args != nil {
+		argsByte, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(argsByte, &parsedArgs); err != nil {
+			return nil, fmt.Errorf("failed to parse ruleset args: %w", err)
+		}
+	}
+
+	if len(parsedArgs.Profile) > 0 {
+		if _, ok := profiles()[parsedArgs.Profile]; !ok {
+			return nil, fmt.Errorf("profile %s is not supported", parsedArgs.Profile)
+		}
+	}
+	return &parsedArgs, nil
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oshardening
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const sectionPrefix = "### "
+
+const (
+	sectionOSRelease        = "os-release"
+	sectionSysctl           = "sysctl"
+	sectionModules          = "modules"
+	sectionMounts           = "mounts"
+	sectionWorldWritable    = "world-writable"
+	sectionSUID             = "suid"
+	sectionAuditd           = "auditd"
+	sectionJournald         = "journald"
+	sectionJournalDirectory = "journal-directory"
+	sectionContainerdFiles  = "containerd-files"
+	sectionContainerdConfig = "containerd-config"
+)
+
+const (
+	containerdSocket = "/run/containerd/containerd.sock"
+	containerdConfig = "/etc/containerd/config.toml"
+	journalDirectory = "/var/log/journal"
+	// journald reads the drop-ins after the main configuration file
+	journaldConfiguration = `cat /etc/systemd/journald.conf /usr/lib/systemd/journald.conf.d/*.conf /etc/systemd/journald.conf.d/*.conf 2>/dev/null`
+)
+
+// factsScript collects the facts of a node. Every section of the output starts with a line with the
+// section prefix and the name of the section. The commands of the sections must not fail the script.
+var factsScript = strings.Join([]string{
+	section(sectionOSRelease, `cat /etc/os-release 2>/dev/null`),
+	section(sectionSysctl, `sysctl -a 2>/dev/null`),
+	section(sectionModules, `cut -d ' ' -f 1 /proc/modules 2>/dev/null`),
+	section(sectionMounts, `cat /proc/1/mounts 2>/dev/null`),
+	section(sectionWorldWritable, `find /etc -xdev -type f -perm -0002 2>/dev/null`),
+	section(sectionSUID, `find / /usr -xdev -type f -perm -4000 2>/dev/null | sort -u`),
+	section(sectionAuditd, `systemctl is-active auditd 2>/dev/null`),
+	section(sectionJournald, journaldConfiguration),
+	section(sectionJournalDirectory, fmt.Sprintf(`test -d %s && echo %s`, journalDirectory, journalDirectory)),
+	section(sectionContainerdFiles, fmt.Sprintf(`stat -Lc "%%a %%u %%g %%n" %s %s 2>/dev/null`, containerdSocket, containerdConfig)),
+	section(sectionContainerdConfig, fmt.Sprintf(`cat %s 2>/dev/null`, containerdConfig)),
+	"true",
+}, "\n")
+
+func section(name, command string) string {
+	return fmt.Sprintf("echo '%s%s'\n%s", sectionPrefix, name, command)
+}
+
+// fileStats are the stats of a file.
+type fileStats struct {
+	permissions string
+	owner       string
+	group       string
+}
+
+// facts are the facts of the operating system of a node.
+type facts struct {
+	osID             string
+	sysctls          map[string]string
+	modules          []string
+	mounts           map[string][]string
+	worldWritable    []string
+	suidBinaries     []string
+	auditd           string
+	journald         map[string]string
+	journalDirectory bool
+	containerdFiles  map[string]fileStats
+	containerdConfig string
+}
+
+// parseFacts parses the output of the facts script.
+func parseFacts(output string) (*facts, error) {
+	sections := map[string][]string{}
+	current := ""
+	for _, line := range strings.Split(output, "\n") {
+		if name, ok := strings.CutPrefix(line, sectionPrefix); ok {
+			current = name
+			sections[current] = []string{}
+			continue
+		}
+		if current == "" || strings.TrimSpace(line) == "" {
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+	if _, ok := sections[sectionContainerdConfig]; !ok {
+		return nil, errors.New("output of facts script is incomplete")
+	}
+
+	f := &facts{
+		sysctls:          map[string]string{},
+		modules:          sections[sectionModules],
+		mounts:           map[string][]string{},
+		worldWritable:    sections[sectionWorldWritable],
+		suidBinaries:     sections[sectionSUID],
+		journald:         map[string]string{},
+		journalDirectory: len(sections[sectionJournalDirectory]) > 0,
+		containerdFiles:  map[string]fileStats{},
+		containerdConfig: strings.Join(sections[sectionContainerdConfig], "\n"),
+	}
+
+	for _, line := range sections[sectionOSRelease] {
+		if value, ok := strings.CutPrefix(line, "ID="); ok {
+			f.osID = strings.Trim(value, `"'`)
+		}
+	}
+	for _, line := range sections[sectionSysctl] {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			f.sysctls[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	for _, line := range sections[sectionMounts] {
+		// the fields of a mount are device, mount point, type, options, dump and pass
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		// the last mount of a mount point is visible
+		f.mounts[fields[1]] = strings.Split(fields[3], ",")
+	}
+	if len(sections[sectionAuditd]) > 0 {
+		f.auditd = strings.TrimSpace(sections[sectionAuditd][0])
+	}
+	for _, line := range sections[sectionJournald] {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		// later configuration files override earlier ones
+		if key, value, ok := strings.Cut(line, "="); ok {
+			f.journald[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	for _, line := range sections[sectionContainerdFiles] {
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			continue
+		}
+		f.containerdFiles[fields[3]] = fileStats{permissions: fields[0], owner: fields[1], group: fields[2]}
+	}
+
+	slices.Sort(f.modules)
+	return f, nil
+}
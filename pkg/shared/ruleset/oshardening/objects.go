@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oshardening
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"k8s.io/component-base/version"
+
+	"github.com/gardener/diki/imagevector"
+	"github.com/gardener/diki/pkg/kubernetes/pod"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/images"
+)
+
+// WorkerGroup is a group of nodes of a cluster which share the same configuration.
+type WorkerGroup struct {
+	// Name is the name of the worker group.
+	Name string
+	// NodeName is the name of the node of the worker group which is checked.
+	// It is empty if the worker group does not have a node which can be checked.
+	NodeName string
+}
+
+// Nodes are the nodes of a cluster which are checked by the rules of the ruleset.
+type Nodes struct {
+	// PodContext creates the privileged pods which read the facts of the nodes.
+	PodContext pod.PodContext
+	// WorkerGroups returns the worker groups of the cluster.
+	WorkerGroups func(ctx context.Context) ([]WorkerGroup, error)
+	// InstanceID is the id of the ruleset instance which is added as label to the privileged pods.
+	InstanceID string
+	// Target describes the cluster.
+	Target rule.Target
+}
+
+// node is the result of reading the facts of the node of a worker group.
+type node struct {
+	workerGroup WorkerGroup
+	facts       *facts
+	// err is set if the facts of the node cannot be read. The
+	// check results of the error are reported for errTarget.
+	err       error
+	errTarget rule.Target
+}
+
+// target returns the target of the worker group of the node.
+func (n node) target(clusterTarget rule.Target) rule.Target {
+	return clusterTarget.With("kind", "workerGroup", "name", n.workerGroup.Name, "node", n.workerGroup.NodeName)
+}
+
+// loader reads the facts of the nodes once and shares them between all rules of a ruleset.
+type loader struct {
+	nodes  Nodes
+	logger func() *slog.Logger
+
+	once   sync.Once
+	result []node
+	err    error
+}
+
+func newLoader(nodes Nodes, logger func() *slog.Logger) *loader {
+	return &loader{nodes: nodes, logger: logger}
+}
+
+// get returns the nodes of all worker groups.
+func (l *loader) get(ctx context.Context) ([]node, error) {
+	l.once.Do(func() {
+		l.result, l.err = l.read(ctx)
+	})
+	return l.result, l.err
+}
+
+func (l *loader) read(ctx context.Context) ([]node, error) {
+	workerGroups, err := l.nodes.WorkerGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := imagevector.ImageVector().FindImage(images.DikiOpsImageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find image version for %s: %w", images.DikiOpsImageName, err)
+	}
+	image.WithOptionalTag(version.Get().GitVersion)
+
+	nodes := make([]node, 0, len(workerGroups))
+	for _, workerGroup := range workerGroups {
+		n := node{workerGroup: workerGroup}
+		if len(workerGroup.NodeName) > 0 {
+			n.facts, n.errTarget, n.err = l.readFacts(ctx, workerGroup.NodeName, image.String())
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+func (l *loader) readFacts(ctx context.Context, nodeName, image string) (*facts, rule.Target, error) {
+	podName := fmt.Sprintf("diki-%s-%s", RulesetID, Generator.Generate(10))
+	podTarget := l.nodes.Target.With("kind", "pod", "namespace", "kube-system", "name", podName)
+
+	defer func() {
+		if err := l.nodes.PodContext.Delete(ctx, podName, "kube-system"); err != nil {
+			l.logger().Error(err.Error())
+		}
+	}()
+
+	additionalLabels := map[string]string{pod.LabelInstanceID: l.nodes.InstanceID}
+	podExecutor, err := l.nodes.PodContext.Create(ctx, pod.NewPrivilegedPod(podName, "kube-system", image, nodeName, additionalLabels))
+	if err != nil {
+		return nil, podTarget, err
+	}
+
+	output, err := podExecutor.Execute(ctx, "/bin/sh", factsScript)
+	if err != nil {
+		return nil, podTarget, err
+	}
+
+	f, err := parseFacts(output)
+	if err != nil {
+		return nil, podTarget, err
+	}
+	return f, rule.Target{}, nil
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oshardening
+
+import (
+	"maps"
+	"slices"
+)
+
+const (
+	// ProfileGardenLinux is the profile of Garden Linux nodes.
+	ProfileGardenLinux = "gardenlinux"
+	// ProfileUbuntu is the profile of Ubuntu nodes.
+	ProfileUbuntu = "ubuntu"
+	// ProfileFlatcar is the profile of Flatcar Container Linux nodes.
+	ProfileFlatcar = "flatcar"
+)
+
+// Profile contains the expected configuration of the operating system of a node.
+type Profile struct {
+	// Name is the name of the profile. It equals the ID of the operating system in /etc/os-release.
+	Name string
+	// Sysctls maps kernel parameters to their allowed values.
+	Sysctls map[string][]string
+	// DeniedModules are the kernel modules which must not be loaded.
+	DeniedModules []string
+	// MountOptions maps mount points to the options they must be mounted with.
+	MountOptions map[string][]string
+	// AllowedSUIDBinaries are the binaries which are allowed to have the SUID bit set.
+	AllowedSUIDBinaries []string
+	// Auditd is whether the auditd service must be active.
+	Auditd bool
+}
+
+var (
+	defaultSysctls = map[string][]string{
+		"kernel.kptr_restrict":                       {"1", "2"},
+		"kernel.dmesg_restrict":                      {"1"},
+		"kernel.randomize_va_space":                  {"2"},
+		"kernel.unprivileged_bpf_disabled":           {"1", "2"},
+		"fs.protected_hardlinks":                     {"1"},
+		"fs.protected_symlinks":                      {"1"},
+		"fs.suid_dumpable":                           {"0"},
+		"net.ipv4.conf.all.rp_filter":                {"1", "2"},
+		"net.ipv4.conf.all.accept_redirects":         {"0"},
+		"net.ipv4.conf.all.send_redirects":           {"0"},
+		"net.ipv4.conf.all.accept_source_route":      {"0"},
+		"net.ipv4.icmp_echo_ignore_broadcasts":       {"1"},
+		"net.ipv4.icmp_ignore_bogus_error_responses": {"1"},
+	}
+
+	defaultDeniedModules = []string{"cramfs", "freevxfs", "jffs2", "hfs", "hfsplus", "udf", "dccp", "rds", "tipc", "usb_storage"}
+
+	defaultMountOptions = map[string][]string{
+		"/tmp": {"nodev", "nosuid"},
+		"/var": {"nodev"},
+	}
+
+	debianSUIDBinaries = []string{
+		"/usr/bin/chfn",
+		"/usr/bin/chsh",
+		"/usr/bin/gpasswd",
+		"/usr/bin/mount",
+		"/usr/bin/newgrp",
+		"/usr/bin/passwd",
+		"/usr/bin/su",
+		"/usr/bin/sudo",
+		"/usr/bin/umount",
+		"/usr/lib/dbus-1.0/dbus-daemon-launch-helper",
+		"/usr/lib/openssh/ssh-keysign",
+	}
+)
+
+// profiles returns the profiles of the supported operating systems.
+func profiles() map[string]Profile {
+	return map[string]Profile{
+		ProfileGardenLinux: {
+			Name:                ProfileGardenLinux,
+			Sysctls:             maps.Clone(defaultSysctls),
+			DeniedModules:       slices.Clone(defaultDeniedModules),
+			MountOptions:        maps.Clone(defaultMountOptions),
+			AllowedSUIDBinaries: slices.Clone(debianSUIDBinaries),
+			Auditd:              true,
+		},
+		ProfileUbuntu: {
+			Name:          ProfileUbuntu,
+			Sysctls:       maps.Clone(defaultSysctls),
+			DeniedModules: slices.Clone(defaultDeniedModules),
+			MountOptions:  maps.Clone(defaultMountOptions),
+			AllowedSUIDBinaries: append(slices.Clone(debianSUIDBinaries),
+				"/usr/bin/fusermount3",
+				"/usr/bin/pkexec",
+				"/usr/lib/eject/dmcrypt-get-device",
+				"/usr/lib/policykit-1/polkit-agent-helper-1",
+				"/usr/lib/snapd/snap-confine",
+				"/usr/libexec/polkit-agent-helper-1",
+			),
+			Auditd: true,
+		},
+		// Flatcar mounts /var on the root partition and logs audit events to the journal instead of running auditd.
+		ProfileFlatcar: {
+			Name:          ProfileFlatcar,
+			Sysctls:       maps.Clone(defaultSysctls),
+			DeniedModules: slices.Clone(defaultDeniedModules),
+			MountOptions:  map[string][]string{"/tmp": {"nodev", "nosuid"}},
+			AllowedSUIDBinaries: []string{
+				"/usr/bin/chage",
+				"/usr/bin/chfn",
+				"/usr/bin/chsh",
+				"/usr/bin/expiry",
+				"/usr/bin/fusermount",
+				"/usr/bin/fusermount3",
+				"/usr/bin/gpasswd",
+				"/usr/bin/mount",
+				"/usr/bin/newgidmap",
+				"/usr/bin/newgrp",
+				"/usr/bin/newuidmap",
+				"/usr/bin/passwd",
+				"/usr/bin/pkexec",
+				"/usr/bin/su",
+				"/usr/bin/sudo",
+				"/usr/bin/umount",
+				"/usr/lib/polkit-1/polkit-agent-helper-1",
+			},
+		},
+	}
+}
@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oshardening
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	intutils "github.com/gardener/diki/pkg/internal/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// SysctlOptions are the rule options of the rule which checks the kernel parameters.
+type SysctlOptions struct {
+	// Sysctls maps kernel parameters to their allowed values. They are added to the kernel parameters of the profile.
+	Sysctls map[string][]string `json:"sysctls" yaml:"sysctls"`
+}
+
+// ModuleOptions are the rule options of the rule which checks the loaded kernel modules.
+type ModuleOptions struct {
+	// DeniedModules replace the denied kernel modules of the profile.
+	DeniedModules []string `json:"deniedModules" yaml:"deniedModules"`
+}
+
+// MountPointOptions are the rule options of the rule which checks the mount options.
+type MountPointOptions struct {
+	// MountOptions replace the mount points and options of the profile.
+	MountOptions map[string][]string `json:"mountOptions" yaml:"mountOptions"`
+}
+
+// FileOptions are the rule options of the rules which check files.
+type FileOptions struct {
+	// AcceptedFiles are shell file name patterns of files which are accepted to violate the rule.
+	AcceptedFiles []string `json:"acceptedFiles" yaml:"acceptedFiles"`
+}
+
+// SUIDOptions are the rule options of the rule which checks the SUID binaries.
+type SUIDOptions struct {
+	// AllowedSUIDBinaries are shell file name patterns of binaries which are allowed
+	// to have the SUID bit set. They are added to the binaries of the profile.
+	AllowedSUIDBinaries []string `json:"allowedSUIDBinaries" yaml:"allowedSUIDBinaries"`
+}
+
+// finding is the result of the check of a node.
+type finding struct {
+	status  rule.Status
+	message string
+	details []string
+}
+
+func passed(message string) finding {
+	return finding{status: rule.Passed, message: message}
+}
+
+func failed(message string, details []string) finding {
+	return finding{status: rule.Failed, message: message, details: details}
+}
+
+var _ rule.Rule = &OSRule{}
+
+// OSRule checks the operating system of one node per worker group against the profile of the operating system.
+type OSRule struct {
+	id   string
+	name string
+	// profileName is the name of the profile of all nodes. The profile is
+	// detected by the operating system of the nodes if profileName is empty.
+	profileName string
+	// customize applies the rule options to the profile.
+	customize func(*Profile)
+	check     func(*facts, Profile) finding
+	loader    *loader
+}
+
+// ID returns the id of the rule.
+func (r *OSRule) ID() string {
+	return r.id
+}
+
+// Name returns the name of the rule.
+func (r *OSRule) Name() string {
+	return r.name
+}
+
+// Run checks the nodes of all worker groups.
+func (r *OSRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	clusterTarget := r.loader.nodes.Target
+	nodes, err := r.loader.get(ctx)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), clusterTarget)), nil
+	}
+	if len(nodes) == 0 {
+		return rule.SingleCheckResult(r, rule.WarningCheckResult("Cluster does not have worker groups.", clusterTarget)), nil
+	}
+
+	checkResults := make([]rule.CheckResult, 0, len(nodes))
+	for _, n := range nodes {
+		checkResults = append(checkResults, r.checkNode(n, clusterTarget))
+	}
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+func (r *OSRule) checkNode(n node, clusterTarget rule.Target) rule.CheckResult {
+	target := n.target(clusterTarget)
+	switch {
+	case len(n.workerGroup.NodeName) == 0:
+		return rule.WarningCheckResult("There are no ready nodes with at least 1 allocatable spot for worker group.", target)
+	case n.err != nil:
+		return rule.ErroredCheckResult(n.err.Error(), n.errTarget)
+	}
+
+	profileName := r.profileName
+	if len(profileName) == 0 {
+		profileName = n.facts.osID
+	}
+	profile, ok := profiles()[profileName]
+	if !ok {
+		return rule.WarningCheckResult(fmt.Sprintf("Operating system %s is not supported by a profile.", n.facts.osID), target)
+	}
+	if r.customize != nil {
+		r.customize(&profile)
+	}
+
+	f := r.check(n.facts, profile)
+	if len(f.details) > 0 {
+		target = target.With("details", strings.Join(f.details, ", "))
+	}
+	return rule.CheckResult{Status: f.status, Message: f.message, Target: target}
+}
+
+func matchesAny(patterns []string, file string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		ok, _ := path.Match(pattern, file)
+		return ok
+	})
+}
+
+func sysctlCheck(f *facts, profile Profile) finding {
+	var details []string
+	for _, key := range sortedKeys(profile.Sysctls) {
+		value, ok := f.sysctls[key]
+		switch {
+		case !ok:
+			details = append(details, fmt.Sprintf("%s is not set", key))
+		case !slices.Contains(profile.Sysctls[key], value):
+			details = append(details, fmt.Sprintf("%s is %s instead of %s", key, value, strings.Join(profile.Sysctls[key], " or ")))
+		}
+	}
+	if len(details) > 0 {
+		return failed("Kernel parameters are not set to allowed values.", details)
+	}
+	return passed("Kernel parameters are set to allowed values.")
+}
+
+func moduleCheck(f *facts, profile Profile) finding {
+	var loaded []string
+	for _, module := range f.modules {
+		if slices.Contains(profile.DeniedModules, module) {
+			loaded = append(loaded, module)
+		}
+	}
+	if len(loaded) > 0 {
+		return failed("Denied kernel modules are loaded.", loaded)
+	}
+	return passed("Denied kernel modules are not loaded.")
+}
+
+func mountCheck(f *facts, profile Profile) finding {
+	var (
+		details     []string
+		notSeparate []string
+	)
+	for _, mountPoint := range sortedKeys(profile.MountOptions) {
+		options, ok := f.mounts[mountPoint]
+		if !ok {
+			notSeparate = append(notSeparate, mountPoint)
+			continue
+		}
+		for _, option := range profile.MountOptions[mountPoint] {
+			if !slices.Contains(options, option) {
+				details = append(details, fmt.Sprintf("%s is not mounted with %s", mountPoint, option))
+			}
+		}
+	}
+
+	switch {
+	case len(details) > 0:
+		for _, mountPoint := range notSeparate {
+			details = append(details, fmt.Sprintf("%s is not a separate mount", mountPoint))
+		}
+		return failed("Mount points are not mounted with required options.", details)
+	case len(notSeparate) > 0:
+		return finding{status: rule.Warning, message: "Mount points are not separate mounts.", details: notSeparate}
+	default:
+		return passed("Mount points are mounted with required options.")
+	}
+}
+
+func worldWritableCheck(acceptedFiles []string) func(*facts, Profile) finding {
+	return func(f *facts, _ Profile) finding {
+		var files []string
+		for _, file := range f.worldWritable {
+			if !matchesAny(acceptedFiles, file) {
+				files = append(files, file)
+			}
+		}
+		if len(files) > 0 {
+			return failed("Files under /etc are world-writable.", files)
+		}
+		return passed("Files under /etc are not world-writable.")
+	}
+}
+
+func suidCheck(f *facts, profile Profile) finding {
+	var binaries []string
+	for _, binary := range f.suidBinaries {
+		if !matchesAny(profile.AllowedSUIDBinaries, binary) {
+			binaries = append(binaries, binary)
+		}
+	}
+	if len(binaries) > 0 {
+		return failed("Binaries which are not allowed have the SUID bit set.", binaries)
+	}
+	return passed("Only allowed binaries have the SUID bit set.")
+}
+
+func loggingCheck(f *facts, profile Profile) finding {
+	var details []string
+	if profile.Auditd && f.auditd != "active" {
+		details = append(details, "auditd is not active")
+	}
+
+	// journald stores the journal persistently with the default storage auto if the journal directory exists
+	switch storage := f.journald["Storage"]; storage {
+	case "persistent":
+	case "", "auto":
+		if !f.journalDirectory {
+			details = append(details, fmt.Sprintf("journald does not store the journal persistently because %s does not exist", journalDirectory))
+		}
+	default:
+		details = append(details, fmt.Sprintf("journald storage is %s", storage))
+	}
+
+	if len(details) > 0 {
+		return failed("Audit and journal logging is not configured.", details)
+	}
+	return passed("Audit and journal logging is configured.")
+}
+
+// the settings of the containerd configuration are matched by line to not depend on a TOML parser
+var (
+	containerdDisableAppArmor = regexp.MustCompile(`(?m)^\s*disable_apparmor\s*=\s*true`)
+	containerdTCPService      = regexp.MustCompile(`(?m)^\s*disable_tcp_service\s*=\s*false`)
+)
+
+func containerRuntimeCheck(f *facts, _ Profile) finding {
+	var details []string
+	for _, file := range []struct {
+		path, maxPermissions string
+	}{
+		{containerdSocket, "660"},
+		{containerdConfig, "644"},
+	} {
+		stats, ok := f.containerdFiles[file.path]
+		if !ok {
+			if file.path == containerdSocket {
+				details = append(details, fmt.Sprintf("%s does not exist", file.path))
+			}
+			continue
+		}
+		if exceed, err := intutils.ExceedFilePermissions(stats.permissions, file.maxPermissions); err != nil || exceed {
+			details = append(details, fmt.Sprintf("%s has permissions %s instead of at most %s", file.path, stats.permissions, file.maxPermissions))
+		}
+		if stats.owner != "0" {
+			details = append(details, fmt.Sprintf("%s is owned by user %s instead of root", file.path, stats.owner))
+		}
+	}
+
+	if containerdDisableAppArmor.MatchString(f.containerdConfig) {
+		details = append(details, "AppArmor is disabled")
+	}
+	if containerdTCPService.MatchString(f.containerdConfig) {
+		details = append(details, "TCP service of the CRI plugin is enabled")
+	}
+
+	if len(details) > 0 {
+		return failed("Container runtime is not configured securely.", details)
+	}
+	return passed("Container runtime is configured securely.")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
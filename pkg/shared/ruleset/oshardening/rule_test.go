@@ -0,0 +1,360 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oshardening_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	fakestrgen "github.com/gardener/diki/pkg/internal/stringgen/fake"
+	fakepod "github.com/gardener/diki/pkg/kubernetes/pod/fake"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/oshardening"
+)
+
+var _ = Describe("oshardening rules", func() {
+	const (
+		osRelease = `### os-release
+NAME="Garden Linux"
+ID=gardenlinux
+`
+		sysctls = `### sysctl
+kernel.kptr_restrict = 1
+kernel.dmesg_restrict = 1
+kernel.randomize_va_space = 2
+kernel.unprivileged_bpf_disabled = 2
+fs.protected_hardlinks = 1
+fs.protected_symlinks = 1
+fs.suid_dumpable = 0
+net.ipv4.conf.all.rp_filter = 1
+net.ipv4.conf.all.accept_redirects = 0
+net.ipv4.conf.all.send_redirects = 0
+net.ipv4.conf.all.accept_source_route = 0
+net.ipv4.icmp_echo_ignore_broadcasts = 1
+net.ipv4.icmp_ignore_bogus_error_responses = 1
+`
+		modules = `### modules
+overlay
+br_netfilter
+`
+		mounts = `### mounts
+/dev/sda1 / ext4 rw,relatime 0 0
+tmpfs /tmp tmpfs rw,nosuid,nodev 0 0
+/dev/sda2 /var ext4 rw,nodev,relatime 0 0
+`
+		worldWritable = `### world-writable
+`
+		suid = `### suid
+/usr/bin/passwd
+/usr/bin/su
+`
+		auditd = `### auditd
+active
+`
+		journald = `### journald
+[Journal]
+#Storage=auto
+Storage=persistent
+`
+		journalDirectory = `### journal-directory
+/var/log/journal
+`
+		containerdFiles = `### containerd-files
+660 0 0 /run/containerd/containerd.sock
+644 0 0 /etc/containerd/config.toml
+`
+		containerdConfig = `### containerd-config
+version = 2
+[plugins."io.containerd.grpc.v1.cri"]
+  disable_tcp_service = true
+`
+	)
+
+	var (
+		ctx           = context.TODO()
+		clusterTarget = rule.NewTarget("cluster", "shoot")
+		workerGroups  []oshardening.WorkerGroup
+		sections      map[string]string
+	)
+
+	BeforeEach(func() {
+		oshardening.Generator = &fakestrgen.FakeRandString{Rune: 'a'}
+		workerGroups = []oshardening.WorkerGroup{{Name: "worker1", NodeName: "node1"}}
+		sections = map[string]string{
+			"os-release":        osRelease,
+			"sysctl":            sysctls,
+			"modules":           modules,
+			"mounts":            mounts,
+			"world-writable":    worldWritable,
+			"suid":              suid,
+			"auditd":            auditd,
+			"journald":          journald,
+			"journal-directory": journalDirectory,
+			"containerd-files":  containerdFiles,
+			"containerd-config": containerdConfig,
+		}
+	})
+
+	output := func() string {
+		var sb strings.Builder
+		for _, name := range []string{"os-release", "sysctl", "modules", "mounts", "world-writable", "suid", "auditd", "journald", "journal-directory", "containerd-files", "containerd-config"} {
+			sb.WriteString(sections[name])
+		}
+		return sb.String()
+	}
+
+	workerGroupTarget := func(name, nodeName string) rule.Target {
+		return clusterTarget.With("kind", "workerGroup", "name", name, "node", nodeName)
+	}
+
+	runWith := func(id, profile string, ruleOptions map[string]config.RuleOptionsConfig, executeReturnString [][]string, executeReturnError [][]error) rule.RuleResult {
+		nodes := oshardening.Nodes{
+			PodContext: fakepod.NewFakeSimplePodContext(executeReturnString, executeReturnError),
+			WorkerGroups: func(context.Context) ([]oshardening.WorkerGroup, error) {
+				return workerGroups, nil
+			},
+			InstanceID: "1",
+			Target:     clusterTarget,
+		}
+		rules, err := oshardening.Rules(nodes, profile, ruleOptions, slog.Default)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, r := range rules {
+			if r.ID() == id {
+				ruleResult, err := r.Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				return ruleResult
+			}
+		}
+		Fail("rule " + id + " not found")
+		return rule.RuleResult{}
+	}
+
+	run := func(id string, ruleOptions map[string]config.RuleOptionsConfig) rule.RuleResult {
+		return runWith(id, "", ruleOptions, [][]string{{output()}}, [][]error{{nil}})
+	}
+
+	It("should pass all rules for a hardened node", func() {
+		for _, id := range []string{"os-001", "os-002", "os-003", "os-004", "os-005", "os-006", "os-007"} {
+			ruleResult := run(id, nil)
+			Expect(ruleResult.CheckResults).To(HaveLen(1))
+			Expect(ruleResult.CheckResults[0].Status).To(Equal(rule.Passed), id)
+			Expect(ruleResult.CheckResults[0].Target).To(Equal(workerGroupTarget("worker1", "node1")))
+		}
+	})
+
+	It("should warn for worker groups without a node which can be checked", func() {
+		workerGroups = append(workerGroups, oshardening.WorkerGroup{Name: "worker2"})
+
+		ruleResult := run("os-001", nil)
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Kernel parameters are set to allowed values.", workerGroupTarget("worker1", "node1")),
+			rule.WarningCheckResult("There are no ready nodes with at least 1 allocatable spot for worker group.", workerGroupTarget("worker2", "")),
+		}))
+	})
+
+	It("should return errored result when the facts cannot be read", func() {
+		ruleResult := runWith("os-001", "", nil, [][]string{{""}}, [][]error{{errors.New("foo")}})
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("foo", clusterTarget.With("kind", "pod", "namespace", "kube-system", "name", "diki-os-hardening-aaaaaaaaaa")),
+		}))
+	})
+
+	It("should return errored result when the output of the facts script is incomplete", func() {
+		ruleResult := runWith("os-001", "", nil, [][]string{{osRelease + sysctls}}, [][]error{{nil}})
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.ErroredCheckResult("output of facts script is incomplete", clusterTarget.With("kind", "pod", "namespace", "kube-system", "name", "diki-os-hardening-aaaaaaaaaa")),
+		}))
+	})
+
+	It("should warn for operating systems without a profile", func() {
+		sections["os-release"] = "### os-release\nID=\"fedora\"\n"
+
+		ruleResult := run("os-001", nil)
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.WarningCheckResult("Operating system fedora is not supported by a profile.", workerGroupTarget("worker1", "node1")),
+		}))
+	})
+
+	It("should use the profile of the ruleset args instead of the detected one", func() {
+		sections["os-release"] = "### os-release\nID=\"fedora\"\n"
+		sections["auditd"] = "### auditd\ninactive\n"
+
+		ruleResult := runWith("os-006", oshardening.ProfileFlatcar, nil, [][]string{{output()}}, [][]error{{nil}})
+
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Audit and journal logging is configured.", workerGroupTarget("worker1", "node1")),
+		}))
+	})
+
+	Describe("#os-001", func() {
+		It("should fail for kernel parameters which are not set or have other values", func() {
+			sections["sysctl"] = strings.NewReplacer("kernel.kptr_restrict = 1\n", "kernel.kptr_restrict = 0\n", "fs.suid_dumpable = 0\n", "").Replace(sysctls)
+
+			ruleResult := run("os-001", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Kernel parameters are not set to allowed values.", workerGroupTarget("worker1", "node1").With("details", "fs.suid_dumpable is not set, kernel.kptr_restrict is 0 instead of 1 or 2")),
+			}))
+		})
+
+		It("should check the kernel parameters of the rule options", func() {
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"os-001": {Args: map[string]any{"sysctls": map[string]any{"kernel.kptr_restrict": []string{"0", "1"}, "net.ipv4.ip_forward": []string{"0"}}}},
+			}
+
+			ruleResult := run("os-001", ruleOptions)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Kernel parameters are not set to allowed values.", workerGroupTarget("worker1", "node1").With("details", "net.ipv4.ip_forward is not set")),
+			}))
+		})
+	})
+
+	Describe("#os-002", func() {
+		It("should fail for loaded denied modules", func() {
+			sections["modules"] = modules + "usb_storage\ncramfs\n"
+
+			ruleResult := run("os-002", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Denied kernel modules are loaded.", workerGroupTarget("worker1", "node1").With("details", "cramfs, usb_storage")),
+			}))
+		})
+
+		It("should replace the denied modules with the ones of the rule options", func() {
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"os-002": {Args: map[string]any{"deniedModules": []string{"overlay"}}},
+			}
+			sections["modules"] = modules + "usb_storage\n"
+
+			ruleResult := run("os-002", ruleOptions)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Denied kernel modules are loaded.", workerGroupTarget("worker1", "node1").With("details", "overlay")),
+			}))
+		})
+	})
+
+	Describe("#os-003", func() {
+		It("should fail for mount points without required options", func() {
+			sections["mounts"] = "### mounts\n/dev/sda1 / ext4 rw 0 0\ntmpfs /tmp tmpfs rw,nodev 0 0\n"
+
+			ruleResult := run("os-003", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Mount points are not mounted with required options.", workerGroupTarget("worker1", "node1").With("details", "/tmp is not mounted with nosuid, /var is not a separate mount")),
+			}))
+		})
+
+		It("should warn for mount points which are not separate mounts", func() {
+			sections["mounts"] = "### mounts\n/dev/sda1 / ext4 rw 0 0\n"
+
+			ruleResult := run("os-003", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.WarningCheckResult("Mount points are not separate mounts.", workerGroupTarget("worker1", "node1").With("details", "/tmp, /var")),
+			}))
+		})
+
+		It("should use the last mount of a mount point", func() {
+			sections["mounts"] = mounts + "tmpfs /tmp tmpfs rw 0 0\n"
+
+			ruleResult := run("os-003", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Mount points are not mounted with required options.", workerGroupTarget("worker1", "node1").With("details", "/tmp is not mounted with nodev, /tmp is not mounted with nosuid")),
+			}))
+		})
+	})
+
+	Describe("#os-004", func() {
+		It("should fail for world-writable files which are not accepted", func() {
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"os-004": {Args: map[string]any{"acceptedFiles": []string{"/etc/foo/*"}}},
+			}
+			sections["world-writable"] = "### world-writable\n/etc/foo/bar\n/etc/shadow\n"
+
+			ruleResult := run("os-004", ruleOptions)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Files under /etc are world-writable.", workerGroupTarget("worker1", "node1").With("details", "/etc/shadow")),
+			}))
+		})
+	})
+
+	Describe("#os-005", func() {
+		It("should fail for SUID binaries which are not allowed", func() {
+			ruleOptions := map[string]config.RuleOptionsConfig{
+				"os-005": {Args: map[string]any{"allowedSUIDBinaries": []string{"/opt/bin/*"}}},
+			}
+			sections["suid"] = suid + "/opt/bin/foo\n/usr/bin/bar\n"
+
+			ruleResult := run("os-005", ruleOptions)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Binaries which are not allowed have the SUID bit set.", workerGroupTarget("worker1", "node1").With("details", "/usr/bin/bar")),
+			}))
+		})
+	})
+
+	Describe("#os-006", func() {
+		It("should fail when auditd is not active and the journal is not persistent", func() {
+			sections["auditd"] = "### auditd\ninactive\n"
+			sections["journald"] = "### journald\n[Journal]\nStorage=volatile\n"
+
+			ruleResult := run("os-006", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Audit and journal logging is not configured.", workerGroupTarget("worker1", "node1").With("details", "auditd is not active, journald storage is volatile")),
+			}))
+		})
+
+		It("should check the journal directory when the storage is auto", func() {
+			sections["journald"] = "### journald\n"
+			sections["journal-directory"] = "### journal-directory\n"
+
+			ruleResult := run("os-006", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Audit and journal logging is not configured.", workerGroupTarget("worker1", "node1").With("details", "journald does not store the journal persistently because /var/log/journal does not exist")),
+			}))
+		})
+	})
+
+	Describe("#os-007", func() {
+		It("should fail for insecure container runtime configuration", func() {
+			sections["containerd-files"] = "### containerd-files\n666 0 0 /run/containerd/containerd.sock\n644 1000 0 /etc/containerd/config.toml\n"
+			sections["containerd-config"] = "### containerd-config\n[plugins.\"io.containerd.grpc.v1.cri\"]\n  disable_apparmor = true\n  disable_tcp_service = false\n"
+
+			ruleResult := run("os-007", nil)
+
+			Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+				rule.FailedCheckResult("Container runtime is not configured securely.", workerGroupTarget("worker1", "node1").With("details", "/run/containerd/containerd.sock has permissions 666 instead of at most 660, /etc/containerd/config.toml is owned by user 1000 instead of root, AppArmor is disabled, TCP service of the CRI plugin is enabled")),
+			}))
+		})
+	})
+
+	It("should return error for invalid rule options", func() {
+		nodes := oshardening.Nodes{Target: clusterTarget}
+		ruleOptions := map[string]config.RuleOptionsConfig{
+			"os-001": {Args: map[string]any{"sysctls": map[string]any{"kernel.kptr_restrict": []string{}}}},
+		}
+
+		_, err := oshardening.Rules(nodes, "", ruleOptions, slog.Default)
+
+		Expect(err).To(MatchError("invalid options of rule os-001: kernel parameter kernel.kptr_restrict does not have allowed values"))
+	})
+})
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oshardening
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of a Worker Node OS Hardening Ruleset
+	RulesetID = "os-hardening"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset checks the operating system of the worker nodes of a cluster.
+type Ruleset struct {
+	version    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "Worker Node OS Hardening"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules check the nodes of the worker groups of nodes.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, nodes Nodes) (*Ruleset, error) {
+	args, err := ParseArgs(rulesetConfig.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset, err := New(WithVersion(rulesetConfig.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	rules, err := Rules(nodes, args.Profile, ruleOptions, ruleset.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// Rules creates the rules of the ruleset which check the nodes of the worker groups of nodes against the
+// profile with name profile. The profile of a node is detected by its operating system if profile is empty.
+func Rules(nodes Nodes, profile string, ruleOptions map[string]config.RuleOptionsConfig, logger func() *slog.Logger) ([]rule.Rule, error) {
+	l := newLoader(nodes, logger)
+
+	sysctlOptions, err := parseOptions[SysctlOptions](ruleOptions["os-001"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule os-001: %w", err)
+	}
+	var sysctls map[string][]string
+	if sysctlOptions != nil {
+		for key, values := range sysctlOptions.Sysctls {
+			if len(values) == 0 {
+				return nil, fmt.Errorf("invalid options of rule os-001: kernel parameter %s does not have allowed values", key)
+			}
+		}
+		sysctls = sysctlOptions.Sysctls
+	}
+
+	moduleOptions, err := parseOptions[ModuleOptions](ruleOptions["os-002"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule os-002: %w", err)
+	}
+
+	mountPointOptions, err := parseOptions[MountPointOptions](ruleOptions["os-003"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule os-003: %w", err)
+	}
+
+	fileOptions, err := parseOptions[FileOptions](ruleOptions["os-004"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule os-004: %w", err)
+	}
+	var acceptedFiles []string
+	if fileOptions != nil {
+		acceptedFiles = fileOptions.AcceptedFiles
+	}
+
+	suidOptions, err := parseOptions[SUIDOptions](ruleOptions["os-005"].Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options of rule os-005: %w", err)
+	}
+
+	return []rule.Rule{
+		&OSRule{
+			id:          "os-001",
+			name:        "Kernel parameters must be set to secure values (HIGH os-001)",
+			profileName: profile,
+			customize: func(p *Profile) {
+				maps.Copy(p.Sysctls, sysctls)
+			},
+			check:  sysctlCheck,
+			loader: l,
+		},
+		&OSRule{
+			id:          "os-002",
+			name:        "Unused file system and network protocol kernel modules must not be loaded (MEDIUM os-002)",
+			profileName: profile,
+			customize: func(p *Profile) {
+				if moduleOptions != nil && moduleOptions.DeniedModules != nil {
+					p.DeniedModules = moduleOptions.DeniedModules
+				}
+			},
+			check:  moduleCheck,
+			loader: l,
+		},
+		&OSRule{
+			id:          "os-003",
+			name:        "Temporary and variable data must be mounted with restrictive options (MEDIUM os-003)",
+			profileName: profile,
+			customize: func(p *Profile) {
+				if mountPointOptions != nil && mountPointOptions.MountOptions != nil {
+					p.MountOptions = mountPointOptions.MountOptions
+				}
+			},
+			check:  mountCheck,
+			loader: l,
+		},
+		&OSRule{
+			id:          "os-004",
+			name:        "Files under /etc must not be world-writable (MEDIUM os-004)",
+			profileName: profile,
+			check:       worldWritableCheck(acceptedFiles),
+			loader:      l,
+		},
+		&OSRule{
+			id:          "os-005",
+			name:        "Only expected binaries must have the SUID bit set (MEDIUM os-005)",
+			profileName: profile,
+			customize: func(p *Profile) {
+				if suidOptions != nil {
+					p.AllowedSUIDBinaries = append(p.AllowedSUIDBinaries, suidOptions.AllowedSUIDBinaries...)
+				}
+			},
+			check:  suidCheck,
+			loader: l,
+		},
+		&OSRule{
+			id:          "os-006",
+			name:        "Audit and journal logging must be enabled (MEDIUM os-006)",
+			profileName: profile,
+			check:       loggingCheck,
+			loader:      l,
+		},
+		&OSRule{
+			id:          "os-007",
+			name:        "Container runtime must be configured securely (HIGH os-007)",
+			profileName: profile,
+			check:       containerRuntimeCheck,
+			loader:      l,
+		},
+	}, nil
+}
+
+func parseOptions[O any](args any) (*O, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options O
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// maxStderrSize is the maximal number of bytes of the
+// standard error of a plugin which are kept for error messages.
+const maxStderrSize = 4096
+
+// invoke executes the plugin with the given request and calls handle for every message the plugin sends.
+// The plugin is killed when the timeout is exceeded or the context is cancelled.
+func invoke(ctx context.Context, path string, args []string, timeout time.Duration, request Request, handle func(Message) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = bytes.NewReader(requestBytes)
+	stderr := &tailBuffer{max: maxStderrSize}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start plugin %s: %w", path, err)
+	}
+
+	var handleErr error
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || handleErr != nil {
+			continue
+		}
+
+		var message Message
+		if err := json.Unmarshal(line, &message); err != nil {
+			handleErr = fmt.Errorf("plugin %s sent an invalid message: %w", path, err)
+			continue
+		}
+		handleErr = handle(message)
+	}
+	scanErr := scanner.Err()
+
+	waitErr := cmd.Wait()
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("plugin %s %s command timed out after %s", path, request.Command, timeout)
+	case waitErr != nil:
+		if msg := strings.TrimSpace(stderr.String()); len(msg) > 0 {
+			return fmt.Errorf("plugin %s %s command failed: %w: %s", path, request.Command, waitErr, msg)
+		}
+		return fmt.Errorf("plugin %s %s command failed: %w", path, request.Command, waitErr)
+	case scanErr != nil:
+		return fmt.Errorf("failed to read output of plugin %s: %w", path, scanErr)
+	}
+	return handleErr
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	max int
+	buf []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	return string(b.buf)
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"log/slog"
+	"time"
+)
+
+// CreateOption is a function that acts on a [Ruleset]
+// and is used to construct such objects.
+type CreateOption func(*Ruleset)
+
+// WithPath sets the path to the plugin executable of a [Ruleset].
+func WithPath(path string, args ...string) CreateOption {
+	return func(r *Ruleset) {
+		r.path = path
+		r.args = args
+	}
+}
+
+// WithTimeout sets the timeout of a single plugin invocation of a [Ruleset].
+func WithTimeout(timeout time.Duration) CreateOption {
+	return func(r *Ruleset) {
+		if timeout <= 0 {
+			panic("timeout should be a possitive duration")
+		}
+		r.timeout = timeout
+	}
+}
+
+// WithLogger the logger of a [Ruleset].
+func WithLogger(logger *slog.Logger) CreateOption {
+	return func(r *Ruleset) {
+		r.logger = logger
+	}
+}
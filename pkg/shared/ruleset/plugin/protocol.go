@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// ProtocolVersionV1 is the first version of the plugin protocol.
+//
+// Diki launches the plugin executable for every command and writes a single
+// JSON encoded [Request] to its standard input. The plugin answers by writing
+// newline delimited JSON encoded [Message]s to its standard output and exits
+// with a non-zero exit code when the command fails. The standard error of the
+// plugin is included in the error reported by diki.
+const ProtocolVersionV1 = "v1"
+
+// SupportedProtocolVersions returns the protocol versions supported by diki.
+func SupportedProtocolVersions() []string {
+	return []string{ProtocolVersionV1}
+}
+
+// Command is a command sent to a plugin.
+type Command string
+
+const (
+	// CommandDescribe requests a [Description] of the ruleset version. The plugin selects
+	// one of the protocol versions offered in the request and returns it in the description.
+	CommandDescribe Command = "describe"
+	// CommandRun requests the plugin to run rules and to stream back their results.
+	CommandRun Command = "run"
+)
+
+// Request is sent by diki to a plugin.
+type Request struct {
+	// Command is the command the plugin should execute.
+	Command Command `json:"command"`
+	// ProtocolVersions contains the protocol versions supported by diki.
+	// It is set for [CommandDescribe].
+	ProtocolVersions []string `json:"protocolVersions,omitempty"`
+	// ProtocolVersion is the protocol version selected by the plugin.
+	// It is set for all commands other than [CommandDescribe].
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+	// Provider contains the configuration of the provider, including
+	// its connection details, e.g. the paths to kubeconfig files.
+	Provider ProviderInfo `json:"provider"`
+	// Ruleset contains the configuration of the ruleset.
+	Ruleset RulesetInfo `json:"ruleset"`
+	// RuleOptions contain the arguments of the rules.
+	RuleOptions []RuleOption `json:"ruleOptions,omitempty"`
+	// RuleIDs contains the ids of the rules to run.
+	// It is set for [CommandRun].
+	RuleIDs []string `json:"ruleIDs,omitempty"`
+}
+
+// ProviderInfo describes the provider which runs a plugin.
+type ProviderInfo struct {
+	// ID is the id of the provider.
+	ID string `json:"id"`
+	// Name is the name of the provider.
+	Name string `json:"name,omitempty"`
+	// Metadata are the metadata of the provider.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Args are the provider specific arguments.
+	Args any `json:"args,omitempty"`
+}
+
+// RulesetInfo describes the ruleset implemented by a plugin.
+type RulesetInfo struct {
+	// ID is the id of the ruleset.
+	ID string `json:"id"`
+	// Version is the version of the ruleset.
+	Version string `json:"version"`
+	// Args are the ruleset specific arguments.
+	Args any `json:"args,omitempty"`
+}
+
+// RuleOption contains the arguments of a rule.
+type RuleOption struct {
+	// RuleID is the id of the rule.
+	RuleID string `json:"ruleID"`
+	// Args are the rule specific arguments.
+	Args any `json:"args,omitempty"`
+}
+
+// MessageType is the type of a [Message].
+type MessageType string
+
+const (
+	// MessageTypeDescription is the type of messages containing a [Description].
+	MessageTypeDescription MessageType = "description"
+	// MessageTypeRuleResult is the type of messages containing a [RuleResult].
+	MessageTypeRuleResult MessageType = "ruleResult"
+	// MessageTypeLog is the type of messages containing a [Log].
+	MessageTypeLog MessageType = "log"
+)
+
+// Message is sent by a plugin to diki.
+type Message struct {
+	// Type is the type of the message.
+	Type MessageType `json:"type"`
+	// Description is set for messages of type [MessageTypeDescription].
+	Description *Description `json:"description,omitempty"`
+	// RuleResult is set for messages of type [MessageTypeRuleResult].
+	RuleResult *RuleResult `json:"ruleResult,omitempty"`
+	// Log is set for messages of type [MessageTypeLog].
+	Log *Log `json:"log,omitempty"`
+}
+
+// Description describes a ruleset version implemented by a plugin.
+type Description struct {
+	// ProtocolVersion is the protocol version selected by the plugin.
+	ProtocolVersion string `json:"protocolVersion"`
+	// ID is the id of the ruleset.
+	ID string `json:"id"`
+	// Name is the user friendly name of the ruleset.
+	Name string `json:"name"`
+	// Version is the version of the ruleset.
+	Version string `json:"version"`
+	// Rules are the rules of the ruleset version.
+	Rules []RuleDescription `json:"rules"`
+}
+
+// RuleDescription describes a rule implemented by a plugin.
+type RuleDescription struct {
+	// ID is the id of the rule.
+	ID string `json:"id"`
+	// Name is the user friendly name of the rule.
+	Name string `json:"name"`
+}
+
+// RuleResult contains the results of a rule run.
+type RuleResult struct {
+	// RuleID is the id of the rule.
+	RuleID string `json:"ruleID"`
+	// RuleName is the name of the rule.
+	RuleName string `json:"ruleName"`
+	// CheckResults are the results of the rule checks.
+	CheckResults []CheckResult `json:"checkResults"`
+}
+
+// CheckResult contains the result of a single rule check.
+type CheckResult struct {
+	// Status is the status of the check.
+	Status rule.Status `json:"status"`
+	// Message is the message of the check.
+	Message string `json:"message"`
+	// Target describes the checked target.
+	Target rule.Target `json:"target,omitempty"`
+}
+
+// Log is a log message of a plugin which is logged by diki.
+type Log struct {
+	// Level is the level of the log message. One of: debug, info, warn, error.
+	Level string `json:"level"`
+	// Message is the log message.
+	Message string `json:"message"`
+}
+
+// NewRuleResult converts a [rule.RuleResult] into a protocol [RuleResult].
+func NewRuleResult(ruleResult rule.RuleResult) *RuleResult {
+	result := &RuleResult{
+		RuleID:       ruleResult.RuleID,
+		RuleName:     ruleResult.RuleName,
+		CheckResults: make([]CheckResult, 0, len(ruleResult.CheckResults)),
+	}
+	for _, checkResult := range ruleResult.CheckResults {
+		result.CheckResults = append(result.CheckResults, CheckResult{
+			Status:  checkResult.Status,
+			Message: checkResult.Message,
+			Target:  checkResult.Target,
+		})
+	}
+	return result
+}
+
+// ToRuleResult converts the protocol [RuleResult] into a [rule.RuleResult].
+func (r *RuleResult) ToRuleResult() rule.RuleResult {
+	result := rule.RuleResult{
+		RuleID:       r.RuleID,
+		RuleName:     r.RuleName,
+		CheckResults: make([]rule.CheckResult, 0, len(r.CheckResults)),
+	}
+	for _, checkResult := range r.CheckResults {
+		result.CheckResults = append(result.CheckResults, rule.CheckResult{
+			Status:  checkResult.Status,
+			Message: checkResult.Message,
+			Target:  checkResult.Target,
+		})
+	}
+	return result
+}
@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+)
+
+// DefaultTimeout is the default timeout of a single plugin invocation.
+const DefaultTimeout = 10 * time.Minute
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset is a ruleset which is implemented by an external plugin executable.
+type Ruleset struct {
+	path        string
+	args        []string
+	timeout     time.Duration
+	request     Request
+	description Description
+	skipRules   map[string]rule.Rule
+	logger      *slog.Logger
+}
+
+// New creates a new Ruleset for the ruleset version described in the request. It
+// negotiates the protocol version with the plugin and retrieves the plugin's rules.
+func New(ctx context.Context, request Request, options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		timeout:   DefaultTimeout,
+		skipRules: map[string]rule.Rule{},
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	if len(r.path) == 0 {
+		return nil, fmt.Errorf("plugin path of ruleset %s is not set", request.Ruleset.ID)
+	}
+
+	r.request = request
+	if err := r.describe(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The plugin
+// receives the configuration of the provider which runs the ruleset.
+func FromGenericConfig(ctx context.Context, rulesetConfig config.RulesetConfig, providerConfig config.ProviderConfig) (*Ruleset, error) {
+	if rulesetConfig.Plugin == nil {
+		return nil, fmt.Errorf("ruleset %s is not a plugin", rulesetConfig.ID)
+	}
+
+	options := []CreateOption{WithPath(rulesetConfig.Plugin.Path, rulesetConfig.Plugin.Args...)}
+	if len(rulesetConfig.Plugin.Timeout) > 0 {
+		timeout, err := time.ParseDuration(rulesetConfig.Plugin.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid plugin timeout of ruleset %s: %w", rulesetConfig.ID, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("plugin timeout of ruleset %s must be positive", rulesetConfig.ID)
+		}
+		options = append(options, WithTimeout(timeout))
+	}
+
+	request := Request{
+		Provider: ProviderInfo{
+			ID:       providerConfig.ID,
+			Name:     providerConfig.Name,
+			Metadata: providerConfig.Metadata,
+			Args:     providerConfig.Args,
+		},
+		Ruleset: RulesetInfo{
+			ID:      rulesetConfig.ID,
+			Version: rulesetConfig.Version,
+			Args:    rulesetConfig.Args,
+		},
+	}
+
+	skipOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if slices.ContainsFunc(request.RuleOptions, func(ruleOption RuleOption) bool {
+			return ruleOption.RuleID == opt.RuleID
+		}) {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		request.RuleOptions = append(request.RuleOptions, RuleOption{RuleID: opt.RuleID, Args: opt.Args})
+		if opt.Skip != nil && opt.Skip.Enabled {
+			skipOptions[opt.RuleID] = opt
+		}
+	}
+
+	r, err := New(ctx, request, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ruleDescription := range r.description.Rules {
+		if opt, ok := skipOptions[ruleDescription.ID]; ok {
+			r.skipRules[ruleDescription.ID] = rule.NewSkipRule(ruleDescription.ID, ruleDescription.Name, opt.Skip.Justification, rule.Accepted)
+		}
+	}
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return r.request.Ruleset.ID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return r.description.Name
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.request.Ruleset.Version
+}
+
+// Rules returns the rules of the Ruleset.
+func (r *Ruleset) Rules() []RuleDescription {
+	return slices.Clone(r.description.Rules)
+}
+
+// describe retrieves the description of the ruleset version and
+// validates that the plugin supports the negotiated protocol version.
+func (r *Ruleset) describe(ctx context.Context) error {
+	request := r.request
+	request.Command = CommandDescribe
+	request.ProtocolVersions = SupportedProtocolVersions()
+
+	var description *Description
+	if err := invoke(ctx, r.path, r.args, r.timeout, request, func(message Message) error {
+		switch message.Type {
+		case MessageTypeDescription:
+			if message.Description == nil {
+				return errors.New("description message does not contain a description")
+			}
+			description = message.Description
+		case MessageTypeLog:
+			r.log(message.Log)
+		default:
+			return fmt.Errorf("unexpected message of type %s", message.Type)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	switch {
+	case description == nil:
+		return fmt.Errorf("plugin %s did not describe ruleset %s", r.path, r.ID())
+	case !slices.Contains(SupportedProtocolVersions(), description.ProtocolVersion):
+		return fmt.Errorf("plugin %s uses unsupported protocol version %s, supported versions are %v", r.path, description.ProtocolVersion, SupportedProtocolVersions())
+	case description.ID != r.ID() || description.Version != r.Version():
+		return fmt.Errorf("plugin %s implements ruleset %s version %s instead of ruleset %s version %s", r.path, description.ID, description.Version, r.ID(), r.Version())
+	case len(description.Rules) == 0:
+		return fmt.Errorf("plugin %s does not have any rules for ruleset %s version %s", r.path, r.ID(), r.Version())
+	}
+
+	r.description = *description
+	r.request.ProtocolVersion = description.ProtocolVersion
+	return nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	if !slices.ContainsFunc(r.description.Rules, func(ruleDescription RuleDescription) bool {
+		return ruleDescription.ID == id
+	}) {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	if skipRule, ok := r.skipRules[id]; ok {
+		return skipRule.Run(ctx)
+	}
+
+	ruleResults, err := r.run(ctx, []string{id})
+	if err != nil {
+		return rule.RuleResult{}, err
+	}
+	return ruleResults[0], nil
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	result := ruleset.RulesetResult{
+		RulesetName:    r.Name(),
+		RulesetID:      r.ID(),
+		RulesetVersion: r.Version(),
+		RuleResults:    make([]rule.RuleResult, 0, len(r.description.Rules)),
+	}
+
+	ruleIDs := make([]string, 0, len(r.description.Rules))
+	for _, ruleDescription := range r.description.Rules {
+		skipRule, ok := r.skipRules[ruleDescription.ID]
+		if !ok {
+			ruleIDs = append(ruleIDs, ruleDescription.ID)
+			continue
+		}
+
+		res, err := skipRule.Run(ctx)
+		if err != nil {
+			return ruleset.RulesetResult{}, err
+		}
+		result.RuleResults = append(result.RuleResults, res)
+	}
+
+	if len(ruleIDs) > 0 {
+		r.Logger().Info(fmt.Sprintf("plugin will run %d rules", len(ruleIDs)))
+		ruleResults, err := r.run(ctx, ruleIDs)
+		if err != nil {
+			return ruleset.RulesetResult{}, err
+		}
+		result.RuleResults = append(result.RuleResults, ruleResults...)
+	}
+	return result, nil
+}
+
+// run executes the rules with the given ids with the plugin. It returns an
+// error when the plugin does not send exactly one result for each of the rules.
+func (r *Ruleset) run(ctx context.Context, ruleIDs []string) ([]rule.RuleResult, error) {
+	request := r.request
+	request.Command = CommandRun
+	request.RuleIDs = ruleIDs
+
+	ruleResults := make(map[string]rule.RuleResult, len(ruleIDs))
+	if err := invoke(ctx, r.path, r.args, r.timeout, request, func(message Message) error {
+		switch message.Type {
+		case MessageTypeRuleResult:
+			if message.RuleResult == nil {
+				return errors.New("rule result message does not contain a rule result")
+			}
+			ruleResult := message.RuleResult.ToRuleResult()
+			if !slices.Contains(ruleIDs, ruleResult.RuleID) {
+				return fmt.Errorf("plugin sent result of rule %s which was not requested", ruleResult.RuleID)
+			}
+			if _, ok := ruleResults[ruleResult.RuleID]; ok {
+				return fmt.Errorf("plugin sent more than one result of rule %s", ruleResult.RuleID)
+			}
+			ruleResults[ruleResult.RuleID] = ruleResult
+			r.Logger().Info(fmt.Sprintf("finished rule %s run (%d remaining)", ruleResult.RuleID, len(ruleIDs)-len(ruleResults)))
+		case MessageTypeLog:
+			r.log(message.Log)
+		default:
+			return fmt.Errorf("unexpected message of type %s", message.Type)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	results := make([]rule.RuleResult, 0, len(ruleIDs))
+	var err error
+	for _, ruleID := range ruleIDs {
+		ruleResult, ok := ruleResults[ruleID]
+		if !ok {
+			err = errors.Join(err, fmt.Errorf("plugin did not send a result of rule %s", ruleID))
+			continue
+		}
+		if len(ruleResult.RuleName) == 0 {
+			ruleResult.RuleName = r.ruleName(ruleID)
+		}
+		results = append(results, ruleResult)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *Ruleset) ruleName(id string) string {
+	for _, ruleDescription := range r.description.Rules {
+		if ruleDescription.ID == id {
+			return ruleDescription.Name
+		}
+	}
+	return ""
+}
+
+// log logs a log message of the plugin with the Ruleset's logger.
+func (r *Ruleset) log(log *Log) {
+	if log == nil {
+		return
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(log.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+	r.Logger().Log(context.Background(), level, log.Message, "plugin", r.path)
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
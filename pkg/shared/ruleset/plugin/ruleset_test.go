@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	"github.com/gardener/diki/pkg/shared/ruleset/plugin"
+)
+
+const (
+	describeOutput = `{"type":"log","log":{"level":"debug","message":"describing"}}
+{"type":"description","description":{"protocolVersion":"v1","id":"foo","name":"Foo","version":"v1","rules":[{"id":"1","name":"One"},{"id":"2","name":"Two"}]}}`
+	runOutput = `{"type":"ruleResult","ruleResult":{"ruleID":"1","ruleName":"One","checkResults":[{"status":"Passed","message":"foo","target":{"name":"bar"}}]}}`
+)
+
+// writePlugin writes a plugin executable which prints the given outputs for
+// the describe and run commands and saves the run request to request.json.
+func writePlugin(dir, describe, run string) string {
+	path := filepath.Join(dir, "diki-ruleset-foo")
+	script := `#!/bin/sh
+request=$(cat)
+case "$request" in
+*'"command":"describe"'*)
+cat <<'END'
+` + describe + `
+END
+;;
+*)
+echo "$request" > ` + filepath.Join(dir, "request.json") + `
+cat <<'END'
+` + run + `
+END
+;;
+esac
+`
+	Expect(os.WriteFile(path, []byte(script), 0700)).To(Succeed())
+	return path
+}
+
+var _ = Describe("ruleset", func() {
+	var (
+		ctx            = context.TODO()
+		dir            string
+		rulesetConfig  config.RulesetConfig
+		providerConfig config.ProviderConfig
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		rulesetConfig = config.RulesetConfig{
+			ID:      "foo",
+			Version: "v1",
+			Args:    map[string]any{"foo": "bar"},
+			RuleOptions: []config.RuleOptionsConfig{
+				{RuleID: "1", Args: map[string]any{"bar": "baz"}},
+				{RuleID: "2", Skip: &config.RuleOptionSkipConfig{Enabled: true, Justification: "not relevant"}},
+			},
+		}
+		providerConfig = config.ProviderConfig{
+			ID:   "managedk8s",
+			Args: map[string]any{"kubeconfigPath": "/tmp/kubeconfig"},
+		}
+	})
+
+	It("should run the rules with the plugin", func() {
+		rulesetConfig.Plugin = &config.PluginConfig{Path: writePlugin(dir, describeOutput, runOutput)}
+
+		r, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.Name()).To(Equal("Foo"))
+		Expect(r.Rules()).To(Equal([]plugin.RuleDescription{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}}))
+
+		result, err := r.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ruleset.RulesetResult{
+			RulesetID:      "foo",
+			RulesetName:    "Foo",
+			RulesetVersion: "v1",
+			RuleResults: []rule.RuleResult{
+				{
+					RuleID:       "2",
+					RuleName:     "Two",
+					CheckResults: []rule.CheckResult{{Status: rule.Accepted, Message: "not relevant"}},
+				},
+				{
+					RuleID:       "1",
+					RuleName:     "One",
+					CheckResults: []rule.CheckResult{rule.PassedCheckResult("foo", rule.NewTarget("name", "bar"))},
+				},
+			},
+		}))
+
+		request, err := os.ReadFile(filepath.Join(dir, "request.json"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(request).To(MatchJSON(`{
+			"command": "run",
+			"protocolVersion": "v1",
+			"provider": {"id": "managedk8s", "args": {"kubeconfigPath": "/tmp/kubeconfig"}},
+			"ruleset": {"id": "foo", "version": "v1", "args": {"foo": "bar"}},
+			"ruleOptions": [{"ruleID": "1", "args": {"bar": "baz"}}, {"ruleID": "2"}],
+			"ruleIDs": ["1"]
+		}`))
+	})
+
+	It("should run a single rule with the plugin", func() {
+		rulesetConfig.Plugin = &config.PluginConfig{Path: writePlugin(dir, describeOutput, runOutput)}
+
+		r, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := r.RunRule(ctx, "1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.CheckResults).To(Equal([]rule.CheckResult{rule.PassedCheckResult("foo", rule.NewTarget("name", "bar"))}))
+
+		_, err = r.RunRule(ctx, "3")
+		Expect(err).To(MatchError("rule with id 3 is not registered in the ruleset"))
+	})
+
+	It("should return an error when the plugin does not send a result of a rule", func() {
+		rulesetConfig.RuleOptions = nil
+		rulesetConfig.Plugin = &config.PluginConfig{Path: writePlugin(dir, describeOutput, runOutput)}
+
+		r, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = r.Run(ctx)
+		Expect(err).To(MatchError("plugin did not send a result of rule 2"))
+	})
+
+	It("should return an error when the plugin uses an unsupported protocol version", func() {
+		describe := `{"type":"description","description":{"protocolVersion":"v0","id":"foo","name":"Foo","version":"v1","rules":[{"id":"1","name":"One"}]}}`
+		rulesetConfig.Plugin = &config.PluginConfig{Path: writePlugin(dir, describe, runOutput)}
+
+		_, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).To(MatchError(ContainSubstring("uses unsupported protocol version v0, supported versions are [v1]")))
+	})
+
+	It("should return an error when the plugin implements another ruleset version", func() {
+		rulesetConfig.Version = "v2"
+		rulesetConfig.Plugin = &config.PluginConfig{Path: writePlugin(dir, describeOutput, runOutput)}
+
+		_, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).To(MatchError(ContainSubstring("implements ruleset foo version v1 instead of ruleset foo version v2")))
+	})
+
+	It("should return the standard error of a failing plugin", func() {
+		path := filepath.Join(dir, "diki-ruleset-foo")
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\necho 'unknown version' >&2\nexit 1\n"), 0700)).To(Succeed())
+		rulesetConfig.Plugin = &config.PluginConfig{Path: path}
+
+		_, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).To(MatchError(ContainSubstring("describe command failed: exit status 1: unknown version")))
+	})
+
+	It("should kill plugins which exceed the timeout", func() {
+		path := filepath.Join(dir, "diki-ruleset-foo")
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 10\n"), 0700)).To(Succeed())
+		rulesetConfig.Plugin = &config.PluginConfig{Path: path, Timeout: "100ms"}
+
+		_, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).To(MatchError(ContainSubstring("describe command timed out after 100ms")))
+	})
+
+	It("should return an error for an invalid timeout", func() {
+		rulesetConfig.Plugin = &config.PluginConfig{Path: "foo", Timeout: "-1s"}
+
+		_, err := plugin.FromGenericConfig(ctx, rulesetConfig, providerConfig)
+		Expect(err).To(MatchError("plugin timeout of ruleset foo must be positive"))
+	})
+})
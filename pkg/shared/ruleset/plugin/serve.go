@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// Plugin is a ruleset implementation which can be served as plugin executable with [Serve].
+type Plugin interface {
+	// Describe returns the description of the requested ruleset version.
+	// The protocol version of the description is set by [Serve].
+	Describe(ctx context.Context, request Request) (Description, error)
+	// Run runs the requested rules and calls emit with the result of every rule.
+	Run(ctx context.Context, request Request, emit func(rule.RuleResult) error) error
+}
+
+// Serve reads a single request from in, executes it with the plugin and writes the resulting messages to out.
+// It is meant to be called from the main function of a plugin executable with the standard input and output.
+func Serve(ctx context.Context, in io.Reader, out io.Writer, p Plugin) error {
+	var request Request
+	if err := json.NewDecoder(in).Decode(&request); err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	encoder := json.NewEncoder(out)
+	switch request.Command {
+	case CommandDescribe:
+		protocolVersion, err := negotiateProtocolVersion(request.ProtocolVersions)
+		if err != nil {
+			return err
+		}
+
+		description, err := p.Describe(ctx, request)
+		if err != nil {
+			return err
+		}
+		description.ProtocolVersion = protocolVersion
+		return encoder.Encode(Message{Type: MessageTypeDescription, Description: &description})
+	case CommandRun:
+		if !slices.Contains(SupportedProtocolVersions(), request.ProtocolVersion) {
+			return fmt.Errorf("unsupported protocol version %s", request.ProtocolVersion)
+		}
+
+		return p.Run(ctx, request, func(ruleResult rule.RuleResult) error {
+			return encoder.Encode(Message{Type: MessageTypeRuleResult, RuleResult: NewRuleResult(ruleResult)})
+		})
+	default:
+		return fmt.Errorf("unknown command %s", request.Command)
+	}
+}
+
+// negotiateProtocolVersion returns the latest protocol version
+// which is supported by both the plugin and diki.
+func negotiateProtocolVersion(protocolVersions []string) (string, error) {
+	supportedProtocolVersions := SupportedProtocolVersions()
+	for i := len(supportedProtocolVersions) - 1; i >= 0; i-- {
+		if slices.Contains(protocolVersions, supportedProtocolVersions[i]) {
+			return supportedProtocolVersions[i], nil
+		}
+	}
+	return "", fmt.Errorf("none of the protocol versions %v is supported, supported versions are %v", protocolVersions, supportedProtocolVersions)
+}
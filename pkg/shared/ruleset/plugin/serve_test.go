@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/plugin"
+)
+
+type fakePlugin struct{}
+
+func (fakePlugin) Describe(_ context.Context, request plugin.Request) (plugin.Description, error) {
+	return plugin.Description{
+		ID:      request.Ruleset.ID,
+		Name:    "Foo",
+		Version: request.Ruleset.Version,
+		Rules:   []plugin.RuleDescription{{ID: "1", Name: "One"}},
+	}, nil
+}
+
+func (fakePlugin) Run(_ context.Context, request plugin.Request, emit func(rule.RuleResult) error) error {
+	for _, ruleID := range request.RuleIDs {
+		if err := emit(rule.RuleResult{
+			RuleID:       ruleID,
+			RuleName:     "One",
+			CheckResults: []rule.CheckResult{rule.FailedCheckResult("foo", rule.NewTarget("name", "bar"))},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ = Describe("serve", func() {
+	var (
+		ctx = context.TODO()
+		out *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+	})
+
+	It("should describe the ruleset with the negotiated protocol version", func() {
+		in := strings.NewReader(`{"command":"describe","protocolVersions":["v1","v2"],"ruleset":{"id":"foo","version":"v1"}}`)
+
+		Expect(plugin.Serve(ctx, in, out, fakePlugin{})).To(Succeed())
+		Expect(out.String()).To(MatchJSON(`{"type":"description","description":{"protocolVersion":"v1","id":"foo","name":"Foo","version":"v1","rules":[{"id":"1","name":"One"}]}}`))
+	})
+
+	It("should return an error when no protocol version is supported", func() {
+		in := strings.NewReader(`{"command":"describe","protocolVersions":["v2"],"ruleset":{"id":"foo","version":"v1"}}`)
+
+		Expect(plugin.Serve(ctx, in, out, fakePlugin{})).To(MatchError("none of the protocol versions [v2] is supported, supported versions are [v1]"))
+	})
+
+	It("should stream the rule results", func() {
+		in := strings.NewReader(`{"command":"run","protocolVersion":"v1","ruleset":{"id":"foo","version":"v1"},"ruleIDs":["1"]}`)
+
+		Expect(plugin.Serve(ctx, in, out, fakePlugin{})).To(Succeed())
+		Expect(out.String()).To(MatchJSON(`{"type":"ruleResult","ruleResult":{"ruleID":"1","ruleName":"One","checkResults":[{"status":"Failed","message":"foo","target":{"name":"bar"}}]}}`))
+	})
+})
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/pod-security-admission/api"
+)
+
+// Args are the arguments of a Pod Security Standards [Ruleset].
+type Args struct {
+	// Level is the Pod Security Standards level which workloads are evaluated against.
+	// Can be `baseline` or `restricted`, defaults to `baseline`.
+	Level api.Level `json:"level,omitempty" yaml:"level,omitempty"`
+}
+
+// ParseArgs parses the generic ruleset arguments into [Args].
+func ParseArgs(args any) (*Args, error) {
+	parsedArgs := Args{}
+	if args != nil {
+		argsByte, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(argsByte, &parsedArgs); err != nil {
+			return nil, fmt.Errorf("failed to parse ruleset args: %w", err)
+		}
+	}
+
+	switch parsedArgs.Level {
+	case "":
+		parsedArgs.Level = api.LevelBaseline
+	case api.LevelBaseline, api.LevelRestricted:
+	default:
+		return nil, fmt.Errorf("unknown level %s, supported levels are %s and %s", parsedArgs.Level, api.LevelBaseline, api.LevelRestricted)
+	}
+	return &parsedArgs, nil
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss
+
+import (
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// Check is a Pod Security Standards control at a specific version.
+type Check struct {
+	// ID is the id of the control, e.g. privileged.
+	ID policy.CheckID
+	// Level is the level which introduces the control.
+	Level api.Level
+	// CheckPod evaluates the control for a pod.
+	CheckPod policy.CheckPodFn
+}
+
+// RuleIDs are the ids of the controls of all levels and versions.
+var RuleIDs = ruleIDs()
+
+func ruleIDs() []string {
+	var ids []string
+	for _, c := range policy.DefaultChecks() {
+		ids = append(ids, string(c.ID))
+	}
+	return ids
+}
+
+// Checks returns the controls of a Pod Security Standards level at a version in the same
+// way as the PodSecurity admission plugin. The restricted level contains the baseline
+// controls which are not overridden by restricted controls.
+func Checks(level api.Level, version api.Version) []Check {
+	var (
+		checks    []Check
+		overrides = map[policy.CheckID]bool{}
+	)
+	for _, c := range policy.DefaultChecks() {
+		if c.Level == api.LevelRestricted && level != api.LevelRestricted {
+			continue
+		}
+
+		versionedCheck, ok := versionedCheck(c, version)
+		if !ok {
+			continue
+		}
+
+		for _, id := range versionedCheck.OverrideCheckIDs {
+			overrides[id] = true
+		}
+		checks = append(checks, Check{ID: c.ID, Level: c.Level, CheckPod: versionedCheck.CheckPod})
+	}
+
+	result := make([]Check, 0, len(checks))
+	for _, c := range checks {
+		if !overrides[c.ID] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// versionedCheck returns the latest versioned check which is not newer than version.
+func versionedCheck(check policy.Check, version api.Version) (policy.VersionedCheck, bool) {
+	for i := len(check.Versions) - 1; i >= 0; i-- {
+		if !version.Older(check.Versions[i].MinimumVersion) {
+			return check.Versions[i], true
+		}
+	}
+	return policy.VersionedCheck{}, false
+}
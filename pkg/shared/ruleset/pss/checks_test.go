@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/pss"
+)
+
+var _ = Describe("checks", func() {
+	ids := func(checks []pss.Check) []policy.CheckID {
+		result := make([]policy.CheckID, 0, len(checks))
+		for _, c := range checks {
+			result = append(result, c.ID)
+		}
+		return result
+	}
+
+	It("should only return baseline checks for the baseline level", func() {
+		checks := pss.Checks(api.LevelBaseline, api.LatestVersion())
+
+		Expect(ids(checks)).To(ContainElements(policy.CheckID("privileged"), policy.CheckID("hostNamespaces"), policy.CheckID("capabilities_baseline")))
+		for _, c := range checks {
+			Expect(c.Level).To(Equal(api.LevelBaseline))
+		}
+	})
+
+	It("should replace overridden baseline checks for the restricted level", func() {
+		checks := pss.Checks(api.LevelRestricted, api.LatestVersion())
+
+		Expect(ids(checks)).To(ContainElements(policy.CheckID("privileged"), policy.CheckID("capabilities_restricted"), policy.CheckID("runAsNonRoot")))
+		Expect(ids(checks)).ToNot(ContainElement(policy.CheckID("capabilities_baseline")))
+	})
+
+	It("should only return checks which exist in the version", func() {
+		Expect(ids(pss.Checks(api.LevelRestricted, api.MajorMinorVersion(1, 0)))).ToNot(ContainElement(policy.CheckID("seccompProfile_restricted")))
+		Expect(ids(pss.Checks(api.LevelRestricted, api.MajorMinorVersion(1, 19)))).To(ContainElement(policy.CheckID("seccompProfile_restricted")))
+	})
+})
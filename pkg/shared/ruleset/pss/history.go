@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss
+
+import (
+	"fmt"
+
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+
+	"github.com/gardener/diki/pkg/ruleset/history"
+)
+
+// LatestMinorVersion is the latest Kubernetes minor version known to the vendored checks of the
+// PodSecurity admission plugin (k8s.io/pod-security-admission v0.28). It has to be raised when
+// the checks are updated, since later Pod Security Standards versions are not supported before.
+const LatestMinorVersion = 28
+
+// Versions are the supported versions of the ruleset, latest and v1.0 to v1.[LatestMinorVersion].
+var Versions = versions()
+
+// History contains the controls of all Pod Security Standards versions. A control
+// is changed in the versions which introduce a new version of its check.
+var History = history.MustNew(RulesetID, historyVersions()...)
+
+func versions() []string {
+	versions := []string{"latest"}
+	for minor := 0; minor <= LatestMinorVersion; minor++ {
+		versions = append(versions, fmt.Sprintf("v1.%d", minor))
+	}
+	return versions
+}
+
+func historyVersions() []history.Version {
+	var (
+		versions []history.Version
+		base     string
+	)
+	for minor := 0; minor <= LatestMinorVersion; minor++ {
+		v := history.Version{Version: fmt.Sprintf("v1.%d", minor), Base: base}
+		for _, check := range policy.DefaultChecks() {
+			for i, versionedCheck := range check.Versions {
+				if versionedCheck.MinimumVersion != api.MajorMinorVersion(1, minor) {
+					continue
+				}
+
+				switch {
+				case len(base) == 0:
+					v.Rules = append(v.Rules, string(check.ID))
+				case i == 0:
+					v.Added = append(v.Added, string(check.ID))
+				default:
+					v.Changed = append(v.Changed, string(check.ID))
+				}
+			}
+		}
+		versions = append(versions, v)
+		base = v.Version
+	}
+	// latest evaluates the checks of the latest known version
+	return append(versions, history.Version{Version: "latest", Base: base})
+}
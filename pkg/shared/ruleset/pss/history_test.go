@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/pss"
+)
+
+var _ = Describe("History", func() {
+	It("should contain all supported versions", func() {
+		var versions []string
+		for _, v := range pss.History.Versions() {
+			versions = append(versions, v.Version)
+		}
+		Expect(versions).To(ConsistOf(pss.Versions))
+		Expect(pss.Versions).To(ContainElements("latest", "v1.0", "v1.28"))
+		Expect(pss.Versions).ToNot(ContainElement("v1.29"))
+	})
+
+	It("should not know checks newer than the latest minor version", func() {
+		for _, check := range policy.DefaultChecks() {
+			for _, versionedCheck := range check.Versions {
+				Expect(versionedCheck.MinimumVersion.Older(api.MajorMinorVersion(1, pss.LatestMinorVersion+1))).To(BeTrue(), "check %s", check.ID)
+			}
+		}
+	})
+
+	It("should contain all checks in the latest version", func() {
+		latest, err := pss.History.RuleIDs("latest")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(latest).To(ConsistOf(pss.RuleIDs))
+	})
+
+	It("should report the checks which were added or changed", func() {
+		diff, err := pss.History.Diff("v1.0", "v1.28")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(diff.Added).To(Equal([]string{"allowPrivilegeEscalation", "capabilities_restricted", "runAsUser", "seccompProfile_restricted"}))
+		Expect(diff.Removed).To(BeEmpty())
+		Expect(diff.Changed).To(Equal([]string{"seccompProfile_baseline", "sysctls"}))
+
+		diff, err = pss.History.Diff("v1.28", "latest")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Added).To(BeEmpty())
+		Expect(diff.Changed).To(BeEmpty())
+	})
+})
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/pod-security-admission/policy"
+
+	"github.com/gardener/diki/pkg/internal/utils"
+	"github.com/gardener/diki/pkg/rule"
+)
+
+var _ rule.Rule = &Rule{}
+
+// Rule evaluates a Pod Security Standards control for all workloads of a cluster.
+type Rule struct {
+	check     Check
+	workloads *workloads
+	target    rule.Target
+	options   *Options
+}
+
+// Options are the rule options of a Pod Security Standards control.
+type Options struct {
+	AcceptedPods []AcceptedPods `json:"acceptedPods" yaml:"acceptedPods"`
+}
+
+// AcceptedPods selects pods and pod templates which are accepted to violate a control.
+type AcceptedPods struct {
+	PodMatchLabels       map[string]string `json:"podMatchLabels" yaml:"podMatchLabels"`
+	NamespaceMatchLabels map[string]string `json:"namespaceMatchLabels" yaml:"namespaceMatchLabels"`
+	Justification        string            `json:"justification" yaml:"justification"`
+}
+
+// ID returns the id of the control.
+func (r *Rule) ID() string {
+	return string(r.check.ID)
+}
+
+// Name returns the name of the control.
+func (r *Rule) Name() string {
+	return fmt.Sprintf("Pods must satisfy the Pod Security Standards control %s (%s)", r.check.ID, r.check.Level)
+}
+
+// Run evaluates the control for all pods and pod templates.
+func (r *Rule) Run(ctx context.Context) (rule.RuleResult, error) {
+	workloads, namespaces, errKind, err := r.workloads.get(ctx)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.target.With("kind", errKind))), nil
+	}
+
+	checkResults := []rule.CheckResult{}
+	for _, w := range workloads {
+		checkResults = append(checkResults, r.checkWorkload(w, namespaces[w.namespace])...)
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("The cluster does not have any pods.", r.target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+func (r *Rule) checkWorkload(w workload, namespace corev1.Namespace) []rule.CheckResult {
+	target := r.target.With("kind", w.kind, "name", w.name, "namespace", w.namespace)
+	subject := "Pod template"
+	if w.kind == "pod" {
+		subject = "Pod"
+	}
+
+	result := r.check.CheckPod(&w.podMetadata, &w.podSpec)
+	if result.Allowed {
+		return []rule.CheckResult{rule.PassedCheckResult(fmt.Sprintf("%s satisfies control.", subject), target)}
+	}
+
+	accepted, justification := r.accepted(w.podMetadata.Labels, namespace)
+	violation := func(result policy.CheckResult, target rule.Target) rule.CheckResult {
+		if accepted {
+			msg := fmt.Sprintf("%s accepted to violate control.", subject)
+			if justification != "" {
+				msg = justification
+			}
+			return rule.AcceptedCheckResult(msg, target)
+		}
+		return rule.FailedCheckResult(fmt.Sprintf("%s violates control: %s.", subject, forbidden(result)), target)
+	}
+
+	// the control is violated by the pod spec itself and not by single containers
+	podSpec := *w.podSpec.DeepCopy()
+	podSpec.InitContainers, podSpec.Containers, podSpec.EphemeralContainers = nil, nil, nil
+	if podResult := r.check.CheckPod(&w.podMetadata, &podSpec); !podResult.Allowed {
+		return []rule.CheckResult{violation(result, target)}
+	}
+
+	var checkResults []rule.CheckResult
+	for _, c := range containers(w.podSpec) {
+		if containerResult := r.check.CheckPod(&w.podMetadata, &c.podSpec); !containerResult.Allowed {
+			checkResults = append(checkResults, violation(containerResult, target.With("container", c.name)))
+		}
+	}
+
+	// the control is only violated by a combination of containers
+	if len(checkResults) == 0 {
+		return []rule.CheckResult{violation(result, target)}
+	}
+	return checkResults
+}
+
+func (r *Rule) accepted(podLabels map[string]string, namespace corev1.Namespace) (bool, string) {
+	if r.options == nil {
+		return false, ""
+	}
+
+	for _, acceptedPod := range r.options.AcceptedPods {
+		if utils.MatchLabels(podLabels, acceptedPod.PodMatchLabels) &&
+			utils.MatchLabels(namespace.Labels, acceptedPod.NamespaceMatchLabels) {
+			return true, acceptedPod.Justification
+		}
+	}
+
+	return false, ""
+}
+
+type container struct {
+	name    string
+	podSpec corev1.PodSpec
+}
+
+// containers returns a pod spec for every container of a pod spec which only contains this container.
+func containers(podSpec corev1.PodSpec) []container {
+	var result []container
+	for _, c := range podSpec.InitContainers {
+		spec := *podSpec.DeepCopy()
+		spec.InitContainers, spec.Containers, spec.EphemeralContainers = []corev1.Container{c}, nil, nil
+		result = append(result, container{name: c.Name, podSpec: spec})
+	}
+	for _, c := range podSpec.Containers {
+		spec := *podSpec.DeepCopy()
+		spec.InitContainers, spec.Containers, spec.EphemeralContainers = nil, []corev1.Container{c}, nil
+		result = append(result, container{name: c.Name, podSpec: spec})
+	}
+	for _, c := range podSpec.EphemeralContainers {
+		spec := *podSpec.DeepCopy()
+		spec.InitContainers, spec.Containers, spec.EphemeralContainers = nil, nil, []corev1.EphemeralContainer{c}
+		result = append(result, container{name: c.Name, podSpec: spec})
+	}
+	return result
+}
+
+func forbidden(result policy.CheckResult) string {
+	reason := result.ForbiddenReason
+	if len(reason) == 0 {
+		reason = policy.UnknownForbiddenReason
+	}
+	if len(result.ForbiddenDetail) == 0 {
+		return reason
+	}
+	return fmt.Sprintf("%s (%s)", reason, result.ForbiddenDetail)
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/pss"
+)
+
+var _ = Describe("Rule", func() {
+	var (
+		fakeClient    client.Client
+		ctx           = context.TODO()
+		privileged    = true
+		clusterTarget = rule.NewTarget("cluster", "shoot")
+		podTarget     = clusterTarget.With("kind", "pod", "namespace", "foo")
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: map[string]string{"team": "foo"}}})).To(Succeed())
+
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "compliant", Namespace: "foo"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app"}},
+			},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "privileged", Namespace: "foo", Labels: map[string]string{"app": "privileged"}},
+			Spec: corev1.PodSpec{
+				HostNetwork: true,
+				Containers: []corev1.Container{
+					{Name: "app"},
+					{Name: "sidecar", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+				},
+				InitContainers: []corev1.Container{
+					{Name: "init", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+				},
+			},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "foo"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "app", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+						},
+					},
+				},
+			},
+		})).To(Succeed())
+	})
+
+	run := func(id string, ruleOptions map[string]config.RuleOptionsConfig) rule.RuleResult {
+		rules, err := pss.Rules(pss.Checks(api.LevelBaseline, api.LatestVersion()), fakeClient, clusterTarget, ruleOptions)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, r := range rules {
+			if r.ID() == id {
+				ruleResult, err := r.Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				return ruleResult
+			}
+		}
+		Fail("rule " + id + " not found")
+		return rule.RuleResult{}
+	}
+
+	It("should name the violating containers", func() {
+		ruleResult := run("privileged", nil)
+
+		Expect(ruleResult.RuleName).To(Equal("Pods must satisfy the Pod Security Standards control privileged (baseline)"))
+		Expect(ruleResult.CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("Pod satisfies control.", podTarget.With("name", "compliant")),
+			rule.FailedCheckResult(`Pod violates control: privileged (container "init" must not set securityContext.privileged=true).`, podTarget.With("name", "privileged", "container", "init")),
+			rule.FailedCheckResult(`Pod violates control: privileged (container "sidecar" must not set securityContext.privileged=true).`, podTarget.With("name", "privileged", "container", "sidecar")),
+			rule.FailedCheckResult(`Pod template violates control: privileged (container "app" must not set securityContext.privileged=true).`, clusterTarget.With("kind", "deployment", "name", "deployment", "namespace", "foo", "container", "app")),
+		}))
+	})
+
+	It("should not name containers for violations of the pod spec", func() {
+		ruleResult := run("hostNamespaces", nil)
+
+		Expect(ruleResult.CheckResults).To(ContainElement(
+			rule.FailedCheckResult("Pod violates control: host namespaces (hostNetwork=true).", podTarget.With("name", "privileged")),
+		))
+	})
+
+	It("should accept violations of selected pods", func() {
+		ruleResult := run("privileged", map[string]config.RuleOptionsConfig{
+			"privileged": {
+				RuleID: "privileged",
+				Args: map[string]any{
+					"acceptedPods": []map[string]any{
+						{
+							"podMatchLabels":       map[string]any{"app": "privileged"},
+							"namespaceMatchLabels": map[string]any{"team": "foo"},
+							"justification":        "privileged by design",
+						},
+					},
+				},
+			},
+		})
+
+		Expect(ruleResult.CheckResults).To(ContainElements(
+			rule.AcceptedCheckResult("privileged by design", podTarget.With("name", "privileged", "container", "init")),
+			rule.AcceptedCheckResult("privileged by design", podTarget.With("name", "privileged", "container", "sidecar")),
+		))
+	})
+})
+
+var _ = Describe("ParseArgs", func() {
+	It("should default to the baseline level", func() {
+		args, err := pss.ParseArgs(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args.Level).To(Equal(api.LevelBaseline))
+	})
+
+	It("should not allow the privileged level", func() {
+		_, err := pss.ParseArgs(map[string]any{"level": "privileged"})
+		Expect(err).To(MatchError("unknown level privileged, supported levels are baseline and restricted"))
+	})
+})
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/pod-security-admission/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of a Pod Security Standards Ruleset
+	RulesetID = "pod-security-standards"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset evaluates the pods and pod templates of a cluster against
+// the controls of a Pod Security Standards level. The version of the
+// ruleset is the version of the Pod Security Standards, e.g. v1.28 or latest.
+type Ruleset struct {
+	version    string
+	level      api.Level
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		level:      api.LevelBaseline,
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "Pod Security Standards"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// Level returns the Pod Security Standards level of the Ruleset.
+func (r *Ruleset) Level() api.Level {
+	return r.level
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules evaluate
+// the workloads of the cluster of clusterConfig which is described by clusterTarget.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, clusterConfig *rest.Config, clusterTarget rule.Target) (*Ruleset, error) {
+	version, err := api.ParseVersion(rulesetConfig.Version)
+	if err != nil {
+		return nil, fmt.Errorf("unknown ruleset %s version: %s", rulesetConfig.ID, rulesetConfig.Version)
+	}
+
+	args, err := ParseArgs(rulesetConfig.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset, err := New(
+		WithVersion(rulesetConfig.Version),
+		WithLevel(args.Level),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	c, err := client.New(clusterConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := Rules(Checks(args.Level, version), c, clusterTarget, ruleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// Rules creates a rule for every check which evaluates the workloads listed by the client.
+func Rules(checks []Check, c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	w := newWorkloads(c)
+	rules := make([]rule.Rule, 0, len(checks))
+	for _, check := range checks {
+		options, err := parseOptions(ruleOptions[string(check.ID)].Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse options of rule %s: %w", check.ID, err)
+		}
+
+		rules = append(rules, &Rule{check: check, workloads: w, target: clusterTarget, options: options})
+	}
+	return rules, nil
+}
+
+func parseOptions(args any) (*Options, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options Options
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
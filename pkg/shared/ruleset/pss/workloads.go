@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pss
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+)
+
+// workload is a pod or the pod template of a controller.
+type workload struct {
+	kind, name, namespace string
+	podMetadata           metav1.ObjectMeta
+	podSpec               corev1.PodSpec
+}
+
+// workloads lists the workloads of a cluster once and shares them between all rules of a ruleset.
+type workloads struct {
+	client client.Client
+
+	once       sync.Once
+	workloads  []workload
+	namespaces map[string]corev1.Namespace
+	errKind    string
+	err        error
+}
+
+func newWorkloads(c client.Client) *workloads {
+	return &workloads{client: c}
+}
+
+// get returns all pods and the pod templates of all controllers which are not owned by another controller.
+// On error the kind of the objects which could not be listed is returned as well.
+func (w *workloads) get(ctx context.Context) ([]workload, map[string]corev1.Namespace, string, error) {
+	w.once.Do(func() {
+		w.workloads, w.namespaces, w.errKind, w.err = w.list(ctx)
+	})
+	return w.workloads, w.namespaces, w.errKind, w.err
+}
+
+func (w *workloads) list(ctx context.Context) ([]workload, map[string]corev1.Namespace, string, error) {
+	const limit = 300
+	var (
+		result   []workload
+		selector = labels.NewSelector()
+	)
+
+	template := func(kind string, obj metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec) workload {
+		podMetadata := *podTemplate.ObjectMeta.DeepCopy()
+		podMetadata.Namespace = obj.Namespace
+		return workload{kind: kind, name: obj.Name, namespace: obj.Namespace, podMetadata: podMetadata, podSpec: podTemplate.Spec}
+	}
+
+	pods, err := kubeutils.GetPods(ctx, w.client, "", selector, limit)
+	if err != nil {
+		return nil, nil, "podList", err
+	}
+	for _, pod := range pods {
+		result = append(result, workload{kind: "pod", name: pod.Name, namespace: pod.Namespace, podMetadata: pod.ObjectMeta, podSpec: pod.Spec})
+	}
+
+	deployments, err := kubeutils.GetDeployments(ctx, w.client, "", selector, limit)
+	if err != nil {
+		return nil, nil, "deploymentList", err
+	}
+	for _, deployment := range deployments {
+		result = append(result, template("deployment", deployment.ObjectMeta, deployment.Spec.Template))
+	}
+
+	replicaSets, err := kubeutils.GetReplicaSets(ctx, w.client, "", selector, limit)
+	if err != nil {
+		return nil, nil, "replicaSetList", err
+	}
+	for _, replicaSet := range replicaSets {
+		if len(replicaSet.OwnerReferences) == 0 {
+			result = append(result, template("replicaSet", replicaSet.ObjectMeta, replicaSet.Spec.Template))
+		}
+	}
+
+	statefulSets, err := kubeutils.GetStatefulSets(ctx, w.client, "", selector, limit)
+	if err != nil {
+		return nil, nil, "statefulSetList", err
+	}
+	for _, statefulSet := range statefulSets {
+		result = append(result, template("statefulSet", statefulSet.ObjectMeta, statefulSet.Spec.Template))
+	}
+
+	daemonSets, err := kubeutils.GetDaemonSets(ctx, w.client, "", selector, limit)
+	if err != nil {
+		return nil, nil, "daemonSetList", err
+	}
+	for _, daemonSet := range daemonSets {
+		result = append(result, template("daemonSet", daemonSet.ObjectMeta, daemonSet.Spec.Template))
+	}
+
+	jobs, err := kubeutils.GetJobs(ctx, w.client, "", selector, limit)
+	if err != nil {
+		return nil, nil, "jobList", err
+	}
+	for _, job := range jobs {
+		if len(job.OwnerReferences) == 0 {
+			result = append(result, template("job", job.ObjectMeta, job.Spec.Template))
+		}
+	}
+
+	cronJobs, err := kubeutils.GetCronJobs(ctx, w.client, "", selector, limit)
+	if err != nil {
+		return nil, nil, "cronJobList", err
+	}
+	for _, cronJob := range cronJobs {
+		result = append(result, template("cronJob", cronJob.ObjectMeta, cronJob.Spec.JobTemplate.Spec.Template))
+	}
+
+	namespaces, err := kubeutils.GetNamespaces(ctx, w.client)
+	if err != nil {
+		return nil, nil, "namespaceList", err
+	}
+	return result, namespaces, "", nil
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutils "github.com/gardener/diki/pkg/kubernetes/utils"
+)
+
+// objects are the RBAC objects of a cluster together with the
+// service accounts and the service accounts used by pods.
+type objects struct {
+	grants          []Grant
+	bindings        []Binding
+	serviceAccounts []corev1.ServiceAccount
+	// usedServiceAccounts contains the namespaced names of the service accounts used by pods.
+	usedServiceAccounts map[string]bool
+}
+
+// loader lists the objects of a cluster once and shares them between all rules of a ruleset.
+type loader struct {
+	client client.Client
+
+	once    sync.Once
+	objects objects
+	errKind string
+	err     error
+}
+
+func newLoader(c client.Client) *loader {
+	return &loader{client: c}
+}
+
+// get returns the objects of the cluster. On error the kind of
+// the objects which could not be listed is returned as well.
+func (l *loader) get(ctx context.Context) (objects, string, error) {
+	l.once.Do(func() {
+		l.objects, l.errKind, l.err = l.list(ctx)
+	})
+	return l.objects, l.errKind, l.err
+}
+
+func (l *loader) list(ctx context.Context) (objects, string, error) {
+	clusterRoles := &rbacv1.ClusterRoleList{}
+	if err := l.client.List(ctx, clusterRoles); err != nil {
+		return objects{}, "clusterRoleList", err
+	}
+
+	roles := &rbacv1.RoleList{}
+	if err := l.client.List(ctx, roles); err != nil {
+		return objects{}, "roleList", err
+	}
+
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := l.client.List(ctx, clusterRoleBindings); err != nil {
+		return objects{}, "clusterRoleBindingList", err
+	}
+
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := l.client.List(ctx, roleBindings); err != nil {
+		return objects{}, "roleBindingList", err
+	}
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := l.client.List(ctx, serviceAccounts); err != nil {
+		return objects{}, "serviceAccountList", err
+	}
+
+	pods, err := kubeutils.GetPods(ctx, l.client, "", labels.NewSelector(), 300)
+	if err != nil {
+		return objects{}, "podList", err
+	}
+
+	var bindings []Binding
+	for _, b := range clusterRoleBindings.Items {
+		bindings = append(bindings, Binding{Kind: "ClusterRoleBinding", Name: b.Name, Labels: b.Labels, RoleRef: b.RoleRef, Subjects: b.Subjects})
+	}
+	for _, b := range roleBindings.Items {
+		bindings = append(bindings, Binding{Kind: "RoleBinding", Name: b.Name, Namespace: b.Namespace, Labels: b.Labels, RoleRef: b.RoleRef, Subjects: b.Subjects})
+	}
+
+	usedServiceAccounts := map[string]bool{}
+	for _, pod := range pods {
+		name := pod.Spec.ServiceAccountName
+		if name == "" {
+			name = "default"
+		}
+		usedServiceAccounts[pod.Namespace+"/"+name] = true
+	}
+
+	return objects{
+		grants:              Grants(bindings, clusterRoles.Items, roles.Items),
+		bindings:            bindings,
+		serviceAccounts:     serviceAccounts.Items,
+		usedServiceAccounts: usedServiceAccounts,
+	}, "", nil
+}
@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	// bootstrappingLabel is the label of the roles and bindings which are created by the kube-apiserver.
+	bootstrappingLabel = "kubernetes.io/bootstrapping"
+	// bootstrappingValue is the value of the bootstrapping label of default roles and bindings.
+	bootstrappingValue = "rbac-defaults"
+)
+
+// defaultBinding is the role and the subjects of a default binding which is created by the kube-apiserver.
+type defaultBinding struct {
+	roleName string
+	subjects []rbacv1.Subject
+}
+
+// defaultBindings maps the default bindings which are created by the kube-apiserver to their roles and
+// subjects. ClusterRoleBindings reference ClusterRoles and RoleBindings reference Roles. The bindings
+// are the bootstrap policy of Kubernetes, see plugin/pkg/auth/authorizer/rbac/bootstrappolicy.
+var defaultBindings = map[string]defaultBinding{
+	"ClusterRoleBinding cluster-admin":                           {"cluster-admin", []rbacv1.Subject{group("system:masters")}},
+	"ClusterRoleBinding system:basic-user":                       {"system:basic-user", []rbacv1.Subject{group("system:authenticated")}},
+	"ClusterRoleBinding system:discovery":                        {"system:discovery", []rbacv1.Subject{group("system:authenticated")}},
+	"ClusterRoleBinding system:kube-controller-manager":          {"system:kube-controller-manager", []rbacv1.Subject{user("system:kube-controller-manager")}},
+	"ClusterRoleBinding system:kube-dns":                         {"system:kube-dns", []rbacv1.Subject{serviceAccount("kube-system", "kube-dns")}},
+	"ClusterRoleBinding system:kube-scheduler":                   {"system:kube-scheduler", []rbacv1.Subject{user("system:kube-scheduler")}},
+	"ClusterRoleBinding system:monitoring":                       {"system:monitoring", []rbacv1.Subject{group("system:monitoring")}},
+	"ClusterRoleBinding system:node":                             {"system:node", nil},
+	"ClusterRoleBinding system:node-proxier":                     {"system:node-proxier", []rbacv1.Subject{user("system:kube-proxy")}},
+	"ClusterRoleBinding system:public-info-viewer":               {"system:public-info-viewer", []rbacv1.Subject{group("system:authenticated"), group("system:unauthenticated")}},
+	"ClusterRoleBinding system:service-account-issuer-discovery": {"system:service-account-issuer-discovery", []rbacv1.Subject{group("system:serviceaccounts")}},
+	"ClusterRoleBinding system:volume-scheduler":                 {"system:volume-scheduler", []rbacv1.Subject{user("system:kube-scheduler")}},
+	"RoleBinding kube-public/system:controller:bootstrap-signer": {"system:controller:bootstrap-signer", []rbacv1.Subject{serviceAccount("kube-system", "bootstrap-signer")}},
+	"RoleBinding kube-system/system:controller:bootstrap-signer": {"system:controller:bootstrap-signer", []rbacv1.Subject{serviceAccount("kube-system", "bootstrap-signer")}},
+	"RoleBinding kube-system/system:controller:cloud-provider":   {"system:controller:cloud-provider", []rbacv1.Subject{serviceAccount("kube-system", "cloud-provider")}},
+	"RoleBinding kube-system/system:controller:token-cleaner":    {"system:controller:token-cleaner", []rbacv1.Subject{serviceAccount("kube-system", "token-cleaner")}},
+	"RoleBinding kube-system/system::extension-apiserver-authentication-reader": {"extension-apiserver-authentication-reader",
+		[]rbacv1.Subject{user("system:kube-controller-manager"), user("system:kube-scheduler")}},
+	"RoleBinding kube-system/system::leader-locking-kube-controller-manager": {"system::leader-locking-kube-controller-manager",
+		[]rbacv1.Subject{user("system:kube-controller-manager"), serviceAccount("kube-system", "kube-controller-manager")}},
+	"RoleBinding kube-system/system::leader-locking-kube-scheduler": {"system::leader-locking-kube-scheduler",
+		[]rbacv1.Subject{user("system:kube-scheduler"), serviceAccount("kube-system", "kube-scheduler")}},
+}
+
+// defaultControllerBindingPrefix is the prefix of the names of the default ClusterRoleBindings
+// of the controllers of the kube-controller-manager. They bind the ClusterRole of the same name
+// to the service account of the controller in the kube-system namespace.
+const defaultControllerBindingPrefix = "system:controller:"
+
+// defaultControllers are the controllers of the kube-controller-manager which have a default ClusterRoleBinding.
+var defaultControllers = []string{
+	"attachdetach-controller",
+	"certificate-controller",
+	"clusterrole-aggregation-controller",
+	"cronjob-controller",
+	"daemon-set-controller",
+	"deployment-controller",
+	"disruption-controller",
+	"endpoint-controller",
+	"endpointslice-controller",
+	"endpointslicemirroring-controller",
+	"ephemeral-volume-controller",
+	"expand-controller",
+	"generic-garbage-collector",
+	"horizontal-pod-autoscaler",
+	"job-controller",
+	"legacy-service-account-token-cleaner",
+	"namespace-controller",
+	"node-controller",
+	"persistent-volume-binder",
+	"pod-garbage-collector",
+	"pv-protection-controller",
+	"pvc-protection-controller",
+	"replicaset-controller",
+	"replication-controller",
+	"resource-claim-controller",
+	"resourcequota-controller",
+	"root-ca-cert-publisher",
+	"route-controller",
+	"service-account-controller",
+	"service-cidrs-controller",
+	"service-controller",
+	"statefulset-controller",
+	"storage-version-migrator-controller",
+	"ttl-after-finished-controller",
+	"ttl-controller",
+	"validatingadmissionpolicy-status-controller",
+}
+
+func user(name string) rbacv1.Subject {
+	return rbacv1.Subject{Kind: rbacv1.UserKind, Name: name}
+}
+
+func group(name string) rbacv1.Subject {
+	return rbacv1.Subject{Kind: rbacv1.GroupKind, Name: name}
+}
+
+func serviceAccount(namespace, name string) rbacv1.Subject {
+	return rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: namespace, Name: name}
+}
+
+// Binding is a ClusterRoleBinding or a RoleBinding.
+type Binding struct {
+	// Kind is either ClusterRoleBinding or RoleBinding.
+	Kind      string
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	RoleRef   rbacv1.RoleRef
+	Subjects  []rbacv1.Subject
+}
+
+// Default returns whether the binding is a default binding created by the kube-apiserver, i.e. it has the
+// bootstrapping label, the name of a default binding, references the role of this default binding and
+// binds only the subjects of this default binding.
+func (b Binding) Default() bool {
+	if _, ok := b.defaultBinding(); !ok {
+		return false
+	}
+	for _, subject := range b.Subjects {
+		if !b.DefaultSubject(subject) {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultSubject returns whether the subject is bound by the default binding created by the kube-apiserver.
+// Subjects which are added to a default binding are not default subjects.
+func (b Binding) DefaultSubject(subject rbacv1.Subject) bool {
+	binding, ok := b.defaultBinding()
+	if !ok {
+		return false
+	}
+	if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
+		subject.Namespace = b.Namespace
+	}
+	return slices.ContainsFunc(binding.subjects, func(s rbacv1.Subject) bool {
+		return s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace
+	})
+}
+
+// defaultBinding returns the default binding of the bootstrap policy with the name and the role of the binding.
+func (b Binding) defaultBinding() (defaultBinding, bool) {
+	if !b.labeledDefault() {
+		return defaultBinding{}, false
+	}
+
+	roleKind := "ClusterRole"
+	if b.Kind == "RoleBinding" {
+		roleKind = "Role"
+	}
+	if b.RoleRef.Kind != roleKind {
+		return defaultBinding{}, false
+	}
+	if binding, ok := defaultBindings[b.String()]; ok {
+		return binding, b.RoleRef.Name == binding.roleName
+	}
+
+	controller, ok := strings.CutPrefix(b.Name, defaultControllerBindingPrefix)
+	if b.Kind != "ClusterRoleBinding" || !ok || !slices.Contains(defaultControllers, controller) || b.RoleRef.Name != b.Name {
+		return defaultBinding{}, false
+	}
+	return defaultBinding{roleName: b.Name, subjects: []rbacv1.Subject{serviceAccount("kube-system", controller)}}, true
+}
+
+// labeledDefault returns whether the binding has the bootstrapping label of default bindings.
+func (b Binding) labeledDefault() bool {
+	return b.Labels[bootstrappingLabel] == bootstrappingValue
+}
+
+// Details describes the role of the binding. Bindings with the bootstrapping label which are not
+// default bindings or which bind additional subjects are pointed out because they disguise themselves
+// as default bindings.
+func (b Binding) Details() string {
+	return fmt.Sprintf("%s binds %s %s", b, b.RoleRef.Kind, b.RoleRef.Name) + b.disguiseDetails()
+}
+
+func (b Binding) disguiseDetails() string {
+	if !b.labeledDefault() || b.Default() {
+		return ""
+	}
+	if _, ok := b.defaultBinding(); ok {
+		return ", the binding is a default binding of Kubernetes with additional subjects"
+	}
+	return fmt.Sprintf(", the binding has the label %s=%s but is not a default binding of Kubernetes", bootstrappingLabel, bootstrappingValue)
+}
+
+// String returns the kind and the namespaced name of the binding.
+func (b Binding) String() string {
+	if b.Namespace == "" {
+		return fmt.Sprintf("%s %s", b.Kind, b.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", b.Kind, b.Namespace, b.Name)
+}
+
+// Grant is a policy rule which is granted to a subject by a binding.
+type Grant struct {
+	Subject rbacv1.Subject
+	Binding Binding
+	Rule    rbacv1.PolicyRule
+}
+
+// ClusterWide returns whether the policy rule is granted in all namespaces.
+func (g Grant) ClusterWide() bool {
+	return g.Binding.Kind == "ClusterRoleBinding"
+}
+
+// Explain returns a description of the effective permission of the grant.
+func (g Grant) Explain() string {
+	var permission []string
+	if len(g.Rule.NonResourceURLs) > 0 {
+		permission = append(permission, fmt.Sprintf("verbs [%s] on non-resource urls [%s]", strings.Join(g.Rule.Verbs, ", "), strings.Join(g.Rule.NonResourceURLs, ", ")))
+	} else {
+		permission = append(permission, fmt.Sprintf("verbs [%s] on resources [%s] of api groups [%s]", strings.Join(g.Rule.Verbs, ", "), strings.Join(g.Rule.Resources, ", "), strings.Join(quote(g.Rule.APIGroups), ", ")))
+	}
+	if len(g.Rule.ResourceNames) > 0 {
+		permission = append(permission, fmt.Sprintf("with resource names [%s]", strings.Join(g.Rule.ResourceNames, ", ")))
+	}
+
+	scope := "cluster-wide"
+	if !g.ClusterWide() {
+		scope = "in namespace " + g.Binding.Namespace
+	}
+	return fmt.Sprintf("%s binds %s %s which grants %s %s%s", g.Binding, g.Binding.RoleRef.Kind, g.Binding.RoleRef.Name, strings.Join(permission, " "), scope, g.Binding.disguiseDetails())
+}
+
+func quote(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return quoted
+}
+
+// Allows returns whether the policy rule of the grant allows one of the verbs on the resource of the api group.
+// Resources can contain a subresource, e.g. pods/exec. The rule is matched in the same way as by the RBAC authorizer.
+func (g Grant) Allows(apiGroup, resource string, verbs ...string) bool {
+	r := g.Rule
+	if !slices.Contains(r.APIGroups, rbacv1.APIGroupAll) && !slices.Contains(r.APIGroups, apiGroup) {
+		return false
+	}
+
+	resourceMatches := false
+	for _, ruleResource := range r.Resources {
+		if ruleResource == rbacv1.ResourceAll || ruleResource == resource {
+			resourceMatches = true
+			break
+		}
+		// a rule for pods/* matches all subresources of pods
+		if prefix, ok := strings.CutSuffix(ruleResource, "/*"); ok && strings.HasPrefix(resource, prefix+"/") {
+			resourceMatches = true
+			break
+		}
+	}
+	if !resourceMatches {
+		return false
+	}
+
+	if slices.Contains(r.Verbs, rbacv1.VerbAll) {
+		return true
+	}
+	for _, verb := range verbs {
+		if slices.Contains(r.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// Grants resolves the roles of the bindings and returns the policy rules which are granted to the subjects of
+// the bindings. Bindings which reference roles that do not exist do not grant any permissions. The rules of
+// aggregated ClusterRoles are expected to be aggregated by the kube-controller-manager already.
+func Grants(bindings []Binding, clusterRoles []rbacv1.ClusterRole, roles []rbacv1.Role) []Grant {
+	clusterRoleRules := make(map[string][]rbacv1.PolicyRule, len(clusterRoles))
+	for _, clusterRole := range clusterRoles {
+		clusterRoleRules[clusterRole.Name] = clusterRole.Rules
+	}
+	roleRules := make(map[string][]rbacv1.PolicyRule, len(roles))
+	for _, role := range roles {
+		roleRules[role.Namespace+"/"+role.Name] = role.Rules
+	}
+
+	var grants []Grant
+	for _, binding := range bindings {
+		var rules []rbacv1.PolicyRule
+		switch binding.RoleRef.Kind {
+		case "ClusterRole":
+			rules = clusterRoleRules[binding.RoleRef.Name]
+		case "Role":
+			rules = roleRules[binding.Namespace+"/"+binding.RoleRef.Name]
+		}
+
+		for _, subject := range binding.Subjects {
+			if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
+				subject.Namespace = binding.Namespace
+			}
+			for _, r := range rules {
+				grants = append(grants, Grant{Subject: subject, Binding: binding, Rule: r})
+			}
+		}
+	}
+	return grants
+}
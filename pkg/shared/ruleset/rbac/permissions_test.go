@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/diki/pkg/shared/ruleset/rbac"
+)
+
+var _ = Describe("permissions", func() {
+	Describe("#Allows", func() {
+		DescribeTable("Run cases",
+			func(policyRule rbacv1.PolicyRule, apiGroup, resource string, verbs []string, expected bool) {
+				Expect(rbac.Grant{Rule: policyRule}.Allows(apiGroup, resource, verbs...)).To(Equal(expected))
+			},
+			Entry("should match exact rules",
+				rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}, "", "secrets", []string{"get", "list"}, true),
+			Entry("should match wildcards",
+				rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}, "", "pods/exec", []string{"create"}, true),
+			Entry("should match all subresources",
+				rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/*"}, Verbs: []string{"create"}}, "", "pods/exec", []string{"create"}, true),
+			Entry("should not match other verbs",
+				rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"create"}}, "", "secrets", []string{"get"}, false),
+			Entry("should not match resources of other api groups",
+				rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"*"}, Verbs: []string{"*"}}, "", "secrets", []string{"get"}, false),
+			Entry("should not match the resource of a subresource",
+				rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"*"}}, "", "pods/exec", []string{"create"}, false),
+		)
+	})
+
+	Describe("#Default", func() {
+		defaultLabels := map[string]string{"kubernetes.io/bootstrapping": "rbac-defaults"}
+
+		DescribeTable("Run cases",
+			func(binding rbac.Binding, expected bool) {
+				Expect(binding.Default()).To(Equal(expected))
+			},
+			Entry("should be true for default ClusterRoleBindings",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:discovery", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:discovery"}}, true),
+			Entry("should be true for default ClusterRoleBindings of controllers",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:controller:job-controller", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:controller:job-controller"}}, true),
+			Entry("should be true for default RoleBindings",
+				rbac.Binding{Kind: "RoleBinding", Name: "system::extension-apiserver-authentication-reader", Namespace: "kube-system", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "extension-apiserver-authentication-reader"}}, true),
+			Entry("should be false for bindings without the bootstrapping label",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:discovery", RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:discovery"}}, false),
+			Entry("should be false for bindings with unknown names",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "foo", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "foo"}}, false),
+			Entry("should be false for default bindings which reference other roles",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:controller:job-controller", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"}}, false),
+			Entry("should be false for default RoleBindings in other namespaces",
+				rbac.Binding{Kind: "RoleBinding", Name: "system:controller:token-cleaner", Namespace: "foo", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "system:controller:token-cleaner"}}, false),
+			Entry("should be true for default bindings with their default subjects",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "cluster-admin", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+					Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}}}, true),
+			Entry("should be false for default bindings with additional subjects",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "cluster-admin", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+					Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}, {Kind: rbacv1.UserKind, Name: "alice"}}}, false),
+			Entry("should be false for bindings of unknown controllers",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:controller:backdoor", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:controller:backdoor"}}, false),
+		)
+	})
+
+	Describe("#DefaultSubject", func() {
+		defaultLabels := map[string]string{"kubernetes.io/bootstrapping": "rbac-defaults"}
+
+		DescribeTable("Run cases",
+			func(binding rbac.Binding, subject rbacv1.Subject, expected bool) {
+				Expect(binding.DefaultSubject(subject)).To(Equal(expected))
+			},
+			Entry("should be true for the subjects of default bindings",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:public-info-viewer", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:public-info-viewer"}},
+				rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "system:unauthenticated"}, true),
+			Entry("should be true for the service accounts of default controller bindings",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:controller:job-controller", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:controller:job-controller"}},
+				rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "job-controller", Namespace: "kube-system"}, true),
+			Entry("should be true for service accounts without namespace of default RoleBindings",
+				rbac.Binding{Kind: "RoleBinding", Name: "system:controller:token-cleaner", Namespace: "kube-system", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "system:controller:token-cleaner"}},
+				rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "token-cleaner"}, true),
+			Entry("should be false for other subjects of default bindings",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:controller:job-controller", Labels: defaultLabels, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:controller:job-controller"}},
+				rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "job-controller", Namespace: "foo"}, false),
+			Entry("should be false for the subjects of bindings which are not default bindings",
+				rbac.Binding{Kind: "ClusterRoleBinding", Name: "system:public-info-viewer", RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:public-info-viewer"}},
+				rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "system:unauthenticated"}, false),
+		)
+	})
+
+	Describe("#Grants", func() {
+		It("should resolve the roles of the bindings", func() {
+			secretsRule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}
+			podsRule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list"}}
+			clusterRoles := []rbacv1.ClusterRole{{ObjectMeta: metav1.ObjectMeta{Name: "secrets"}, Rules: []rbacv1.PolicyRule{secretsRule}}}
+			roles := []rbacv1.Role{{ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: "foo"}, Rules: []rbacv1.PolicyRule{podsRule}}}
+			sa := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "bar"}
+			bindings := []rbac.Binding{
+				{Kind: "ClusterRoleBinding", Name: "secrets", RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "secrets"}, Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}}},
+				{Kind: "RoleBinding", Name: "pods", Namespace: "foo", RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "pods"}, Subjects: []rbacv1.Subject{sa}},
+				{Kind: "RoleBinding", Name: "missing", Namespace: "foo", RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "missing"}, Subjects: []rbacv1.Subject{sa}},
+			}
+
+			grants := rbac.Grants(bindings, clusterRoles, roles)
+
+			Expect(grants).To(Equal([]rbac.Grant{
+				{Subject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"}, Binding: bindings[0], Rule: secretsRule},
+				{Subject: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "bar", Namespace: "foo"}, Binding: bindings[1], Rule: podsRule},
+			}))
+			Expect(grants[0].ClusterWide()).To(BeTrue())
+			Expect(grants[0].Explain()).To(Equal(`ClusterRoleBinding secrets binds ClusterRole secrets which grants verbs [get] on resources [secrets] of api groups [""] cluster-wide`))
+			Expect(grants[1].ClusterWide()).To(BeFalse())
+			Expect(grants[1].Explain()).To(Equal(`RoleBinding foo/pods binds Role pods which grants verbs [list] on resources [pods] of api groups [""] in namespace foo`))
+		})
+	})
+})
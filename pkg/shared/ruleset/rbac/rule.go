@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/gardener/diki/pkg/rule"
+)
+
+// Options are the rule options of the RBAC rules.
+type Options struct {
+	AcceptedSubjects []AcceptedSubject `json:"acceptedSubjects" yaml:"acceptedSubjects"`
+}
+
+// AcceptedSubject selects subjects which are accepted to violate a rule.
+// Name and Namespace are shell file name patterns, e.g. system:*.
+type AcceptedSubject struct {
+	// Kind is the kind of the subject, either User, Group or ServiceAccount.
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+	// Namespace is the namespace of ServiceAccount subjects. All namespaces are matched when it is empty.
+	Namespace     string `json:"namespace" yaml:"namespace"`
+	Justification string `json:"justification" yaml:"justification"`
+}
+
+func (o *Options) accepted(subject rbacv1.Subject) (bool, string) {
+	if o == nil {
+		return false, ""
+	}
+
+	for _, s := range o.AcceptedSubjects {
+		if s.Kind != subject.Kind {
+			continue
+		}
+		if ok, _ := path.Match(s.Name, subject.Name); !ok {
+			continue
+		}
+		if ok, _ := path.Match(s.Namespace, subject.Namespace); s.Namespace != "" && !ok {
+			continue
+		}
+		return true, s.Justification
+	}
+	return false, ""
+}
+
+var _ rule.Rule = &SubjectRule{}
+
+// SubjectRule checks that no subject is granted a permission by any binding.
+type SubjectRule struct {
+	id         string
+	name       string
+	permission string
+	matches    func(Grant) bool
+	loader     *loader
+	target     rule.Target
+	options    *Options
+}
+
+// ID returns the id of the rule.
+func (r *SubjectRule) ID() string {
+	return r.id
+}
+
+// Name returns the name of the rule.
+func (r *SubjectRule) Name() string {
+	return r.name
+}
+
+// Run checks the effective permissions of all subjects.
+func (r *SubjectRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	objects, errKind, err := r.loader.get(ctx)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.target.With("kind", errKind))), nil
+	}
+
+	var grants []Grant
+	for _, g := range objects.grants {
+		if r.matches(g) {
+			grants = append(grants, g)
+		}
+	}
+
+	checkResults := checkSubjects(grants, r.target, r.options, func(subject string) string {
+		return fmt.Sprintf("%s is granted %s.", subject, r.permission)
+	})
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult(fmt.Sprintf("No subject is granted %s.", r.permission), r.target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+// checkSubjects returns a check result for every subject of the grants. Subjects which are only granted the
+// permissions as subjects of default bindings or which are accepted by the options are accepted, all other
+// subjects fail. Subjects added to default bindings are not accepted as default subjects. The details of
+// failed check results explain the effective permissions of the subject.
+func checkSubjects(grants []Grant, target rule.Target, options *Options, violation func(subject string) string) []rule.CheckResult {
+	var (
+		keys            []string
+		subjects        = map[string]rbacv1.Subject{}
+		subjectGrants   = map[string][]Grant{}
+		explanationSeen = map[string]bool{}
+	)
+	for _, g := range grants {
+		key := subjectKey(g.Subject)
+		if _, ok := subjects[key]; !ok {
+			keys = append(keys, key)
+			subjects[key] = g.Subject
+		}
+		if g.Binding.DefaultSubject(g.Subject) {
+			continue
+		}
+		// multiple rules of the same role can match, the binding is explained once
+		explanation := key + "|" + g.Explain()
+		if explanationSeen[explanation] {
+			continue
+		}
+		explanationSeen[explanation] = true
+		subjectGrants[key] = append(subjectGrants[key], g)
+	}
+	sort.Strings(keys)
+
+	checkResults := make([]rule.CheckResult, 0, len(keys))
+	for _, key := range keys {
+		subject := subjects[key]
+		subjectTarget := target.With(subjectTargetKeyValues(subject)...)
+		msg := violation(subjectKindName(subject))
+
+		if len(subjectGrants[key]) == 0 {
+			checkResults = append(checkResults, rule.AcceptedCheckResult(strings.TrimSuffix(msg, ".")+" by default bindings of Kubernetes.", subjectTarget))
+			continue
+		}
+
+		if accepted, justification := options.accepted(subject); accepted {
+			if justification == "" {
+				justification = strings.TrimSuffix(msg, ".") + " and is accepted."
+			}
+			checkResults = append(checkResults, rule.AcceptedCheckResult(justification, subjectTarget))
+			continue
+		}
+
+		explanations := make([]string, 0, len(subjectGrants[key]))
+		for _, g := range subjectGrants[key] {
+			explanations = append(explanations, g.Explain())
+		}
+		checkResults = append(checkResults, rule.FailedCheckResult(msg, subjectTarget.With("details", strings.Join(explanations, "; "))))
+	}
+	return checkResults
+}
+
+func subjectKey(subject rbacv1.Subject) string {
+	return subject.Kind + "/" + subject.Namespace + "/" + subject.Name
+}
+
+func subjectKindName(subject rbacv1.Subject) string {
+	switch subject.Kind {
+	case rbacv1.ServiceAccountKind:
+		return "Service account"
+	case rbacv1.GroupKind:
+		return "Group"
+	default:
+		return "User"
+	}
+}
+
+func subjectTargetKeyValues(subject rbacv1.Subject) []string {
+	switch subject.Kind {
+	case rbacv1.ServiceAccountKind:
+		return []string{"kind", "serviceAccount", "name", subject.Name, "namespace", subject.Namespace}
+	case rbacv1.GroupKind:
+		return []string{"kind", "group", "name", subject.Name}
+	default:
+		return []string{"kind", "user", "name", subject.Name}
+	}
+}
+
+var _ rule.Rule = &BindingRule{}
+
+// BindingRule checks that no binding grants permissions to the anonymous user or to all users.
+type BindingRule struct {
+	id      string
+	name    string
+	loader  *loader
+	target  rule.Target
+	options *Options
+}
+
+// ID returns the id of the rule.
+func (r *BindingRule) ID() string {
+	return r.id
+}
+
+// Name returns the name of the rule.
+func (r *BindingRule) Name() string {
+	return r.name
+}
+
+// Run checks the subjects of all bindings.
+func (r *BindingRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	objects, errKind, err := r.loader.get(ctx)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.target.With("kind", errKind))), nil
+	}
+
+	var checkResults []rule.CheckResult
+	for _, binding := range objects.bindings {
+		var subjects []rbacv1.Subject
+		for _, subject := range binding.Subjects {
+			if publicSubject(subject) {
+				subjects = append(subjects, subject)
+			}
+		}
+		if len(subjects) == 0 {
+			continue
+		}
+
+		kind := "clusterRoleBinding"
+		if binding.Kind == "RoleBinding" {
+			kind = "roleBinding"
+		}
+		target := r.target.With("kind", kind, "name", binding.Name)
+		if binding.Namespace != "" {
+			target = target.With("namespace", binding.Namespace)
+		}
+
+		names := make([]string, 0, len(subjects))
+		accepted, justification, defaultSubjects := true, "", true
+		for _, subject := range subjects {
+			defaultSubjects = defaultSubjects && binding.DefaultSubject(subject)
+			names = append(names, subject.Name)
+			subjectAccepted, subjectJustification := r.options.accepted(subject)
+			accepted = accepted && subjectAccepted
+			if subjectJustification != "" {
+				justification = subjectJustification
+			}
+		}
+		msg := fmt.Sprintf("Binding grants permissions to %s.", strings.Join(names, " and "))
+
+		switch {
+		case defaultSubjects:
+			checkResults = append(checkResults, rule.AcceptedCheckResult(strings.TrimSuffix(msg, ".")+" and is a default binding of Kubernetes.", target))
+		case accepted && justification != "":
+			checkResults = append(checkResults, rule.AcceptedCheckResult(justification, target))
+		case accepted:
+			checkResults = append(checkResults, rule.AcceptedCheckResult(strings.TrimSuffix(msg, ".")+" and is accepted.", target))
+		default:
+			checkResults = append(checkResults, rule.FailedCheckResult(msg, target.With("details", binding.Details())))
+		}
+	}
+
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("No binding grants permissions to system:anonymous or system:authenticated.", r.target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
+
+// publicSubject returns whether the subject is the anonymous user or a group which contains all users.
+func publicSubject(subject rbacv1.Subject) bool {
+	switch {
+	case subject.Kind == rbacv1.UserKind && subject.Name == "system:anonymous":
+		return true
+	case subject.Kind == rbacv1.GroupKind && (subject.Name == "system:authenticated" || subject.Name == "system:unauthenticated"):
+		return true
+	default:
+		return false
+	}
+}
+
+var _ rule.Rule = &UnusedServiceAccountRule{}
+
+// UnusedServiceAccountRule checks that service accounts which are not used
+// by any pod are not granted any of the permissions of the powerful rules.
+type UnusedServiceAccountRule struct {
+	id       string
+	name     string
+	powerful []func(Grant) bool
+	loader   *loader
+	target   rule.Target
+	options  *Options
+}
+
+// ID returns the id of the rule.
+func (r *UnusedServiceAccountRule) ID() string {
+	return r.id
+}
+
+// Name returns the name of the rule.
+func (r *UnusedServiceAccountRule) Name() string {
+	return r.name
+}
+
+// Run checks the permissions of all service accounts which are not used by any pod.
+func (r *UnusedServiceAccountRule) Run(ctx context.Context) (rule.RuleResult, error) {
+	objects, errKind, err := r.loader.get(ctx)
+	if err != nil {
+		return rule.SingleCheckResult(r, rule.ErroredCheckResult(err.Error(), r.target.With("kind", errKind))), nil
+	}
+
+	unused := map[string]bool{}
+	for _, serviceAccount := range objects.serviceAccounts {
+		if !objects.usedServiceAccounts[serviceAccount.Namespace+"/"+serviceAccount.Name] {
+			unused[serviceAccount.Namespace+"/"+serviceAccount.Name] = true
+		}
+	}
+
+	var grants []Grant
+	for _, g := range objects.grants {
+		if g.Subject.Kind != rbacv1.ServiceAccountKind || !unused[g.Subject.Namespace+"/"+g.Subject.Name] {
+			continue
+		}
+		for _, powerful := range r.powerful {
+			if powerful(g) {
+				grants = append(grants, g)
+				break
+			}
+		}
+	}
+
+	checkResults := checkSubjects(grants, r.target, r.options, func(string) string {
+		return "Service account is not used by any pod and is granted powerful permissions."
+	})
+	if len(checkResults) == 0 {
+		return rule.SingleCheckResult(r, rule.PassedCheckResult("No unused service account is granted powerful permissions.", r.target)), nil
+	}
+
+	return rule.RuleResult{
+		RuleID:       r.ID(),
+		RuleName:     r.Name(),
+		CheckResults: checkResults,
+	}, nil
+}
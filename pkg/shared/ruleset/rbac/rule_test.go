@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/shared/ruleset/rbac"
+)
+
+var _ = Describe("Rule", func() {
+	var (
+		fakeClient    client.Client
+		ctx           = context.TODO()
+		clusterTarget = rule.NewTarget("cluster", "shoot")
+		defaultLabels = map[string]string{"kubernetes.io/bootstrapping": "rbac-defaults"}
+		aliceTarget   = clusterTarget.With("kind", "user", "name", "alice")
+		mastersTarget = clusterTarget.With("kind", "group", "name", "system:masters")
+		robotTarget   = clusterTarget.With("kind", "serviceAccount", "name", "robot", "namespace", "foo")
+		appTarget     = clusterTarget.With("kind", "serviceAccount", "name", "app", "namespace", "foo")
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		for _, obj := range []client.Object{
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin", Labels: defaultLabels},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin", Labels: defaultLabels},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}},
+			},
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret-reader"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}}},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret-reader"},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "secret-reader"},
+				Subjects: []rbacv1.Subject{
+					{Kind: rbacv1.UserKind, Name: "alice"},
+					{Kind: rbacv1.ServiceAccountKind, Name: "robot", Namespace: "foo"},
+				},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret-reader", Namespace: "foo"},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "secret-reader"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "app"}},
+			},
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "exec", Namespace: "foo"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "exec", Namespace: "foo"},
+				RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "exec"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "app"}, {Kind: rbacv1.GroupKind, Name: "system:authenticated"}},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:discovery", Labels: defaultLabels},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:discovery"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:authenticated"}},
+			},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "robot", Namespace: "foo"}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "foo"}},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "foo"},
+				Spec:       corev1.PodSpec{ServiceAccountName: "app"},
+			},
+		} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+	})
+
+	run := func(id string, ruleOptions map[string]config.RuleOptionsConfig) rule.RuleResult {
+		rules, err := rbac.Rules(fakeClient, clusterTarget, ruleOptions)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, r := range rules {
+			if r.ID() == id {
+				ruleResult, err := r.Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				return ruleResult
+			}
+		}
+		Fail("rule " + id + " not found")
+		return rule.RuleResult{}
+	}
+
+	It("should accept subjects which are only granted permissions by default bindings", func() {
+		Expect(run("rbac-001", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("Group is granted wildcard verbs or resources by default bindings of Kubernetes.", mastersTarget),
+		}))
+	})
+
+	It("should pass when no subject is granted the permissions", func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+
+		Expect(run("rbac-002", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("No subject is granted the escalate, bind or impersonate verbs.", clusterTarget),
+		}))
+		Expect(run("rbac-006", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.PassedCheckResult("No binding grants permissions to system:anonymous or system:authenticated.", clusterTarget),
+		}))
+	})
+
+	It("should explain the effective permissions of subjects which read secrets cluster-wide", func() {
+		secretReader := `ClusterRoleBinding secret-reader binds ClusterRole secret-reader which grants verbs [get, list] on resources [secrets] of api groups [""] cluster-wide`
+		ruleOptions := map[string]config.RuleOptionsConfig{
+			"rbac-003": {Args: map[string]any{
+				"acceptedSubjects": []map[string]any{
+					{"kind": "ServiceAccount", "name": "rob*", "namespace": "foo", "justification": "robot syncs secrets"},
+				},
+			}},
+		}
+
+		Expect(run("rbac-003", ruleOptions).CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("Group is granted read access to secrets cluster-wide by default bindings of Kubernetes.", mastersTarget),
+			rule.AcceptedCheckResult("robot syncs secrets", robotTarget),
+			rule.FailedCheckResult("User is granted read access to secrets cluster-wide.", aliceTarget.With("details", secretReader)),
+		}))
+	})
+
+	It("should report subjects which can exec into pods", func() {
+		Expect(run("rbac-004", nil).CheckResults).To(ContainElements(
+			rule.FailedCheckResult("Service account is granted access to pods/exec.", appTarget.With("details", `RoleBinding foo/exec binds Role exec which grants verbs [create] on resources [pods/exec] of api groups [""] in namespace foo`)),
+			rule.FailedCheckResult("Group is granted access to pods/exec.", clusterTarget.With("kind", "group", "name", "system:authenticated", "details", `RoleBinding foo/exec binds Role exec which grants verbs [create] on resources [pods/exec] of api groups [""] in namespace foo`)),
+		))
+	})
+
+	It("should report bindings to system:anonymous and system:authenticated", func() {
+		Expect(run("rbac-006", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("Binding grants permissions to system:authenticated and is a default binding of Kubernetes.", clusterTarget.With("kind", "clusterRoleBinding", "name", "system:discovery")),
+			rule.FailedCheckResult("Binding grants permissions to system:authenticated.", clusterTarget.With("kind", "roleBinding", "name", "exec", "namespace", "foo", "details", "RoleBinding foo/exec binds Role exec")),
+		}))
+	})
+
+	It("should report bindings with the bootstrapping label which are not default bindings", func() {
+		for _, obj := range []client.Object{
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:backdoor", Labels: defaultLabels},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:basic-user", Labels: defaultLabels},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:authenticated"}},
+			},
+		} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+
+		Expect(run("rbac-001", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Group is granted wildcard verbs or resources.", clusterTarget.With("kind", "group", "name", "system:authenticated", "details", `ClusterRoleBinding system:basic-user binds ClusterRole cluster-admin which grants verbs [*] on resources [*] of api groups ["*"] cluster-wide, the binding has the label kubernetes.io/bootstrapping=rbac-defaults but is not a default binding of Kubernetes`)),
+			rule.AcceptedCheckResult("Group is granted wildcard verbs or resources by default bindings of Kubernetes.", mastersTarget),
+			rule.FailedCheckResult("User is granted wildcard verbs or resources.", aliceTarget.With("details", `ClusterRoleBinding system:backdoor binds ClusterRole cluster-admin which grants verbs [*] on resources [*] of api groups ["*"] cluster-wide, the binding has the label kubernetes.io/bootstrapping=rbac-defaults but is not a default binding of Kubernetes`)),
+		}))
+		Expect(run("rbac-006", nil).CheckResults).To(ContainElement(
+			rule.FailedCheckResult("Binding grants permissions to system:authenticated.", clusterTarget.With("kind", "clusterRoleBinding", "name", "system:basic-user", "details", "ClusterRoleBinding system:basic-user binds ClusterRole cluster-admin, the binding has the label kubernetes.io/bootstrapping=rbac-defaults but is not a default binding of Kubernetes")),
+		))
+	})
+
+	It("should report subjects which are added to default bindings", func() {
+		for _, obj := range []client.Object{
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:discovery"},
+			},
+		} {
+			Expect(fakeClient.Delete(ctx, obj)).To(Succeed())
+		}
+		for _, obj := range []client.Object{
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin", Labels: defaultLabels},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}, {Kind: rbacv1.UserKind, Name: "alice"}},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:discovery", Labels: defaultLabels},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:discovery"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:authenticated"}, {Kind: rbacv1.UserKind, Name: "system:anonymous"}},
+			},
+		} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+
+		Expect(run("rbac-001", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("Group is granted wildcard verbs or resources by default bindings of Kubernetes.", mastersTarget),
+			rule.FailedCheckResult("User is granted wildcard verbs or resources.", aliceTarget.With("details", `ClusterRoleBinding cluster-admin binds ClusterRole cluster-admin which grants verbs [*] on resources [*] of api groups ["*"] cluster-wide, the binding is a default binding of Kubernetes with additional subjects`)),
+		}))
+		Expect(run("rbac-006", nil).CheckResults).To(ContainElement(
+			rule.FailedCheckResult("Binding grants permissions to system:authenticated and system:anonymous.", clusterTarget.With("kind", "clusterRoleBinding", "name", "system:discovery", "details", "ClusterRoleBinding system:discovery binds ClusterRole system:discovery, the binding is a default binding of Kubernetes with additional subjects")),
+		))
+	})
+
+	It("should report controller bindings which are not default bindings", func() {
+		for _, obj := range []client.Object{
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:controller:backdoor", Labels: defaultLabels},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:controller:backdoor", Labels: defaultLabels},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:controller:backdoor"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "backdoor", Namespace: "kube-system"}},
+			},
+		} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+
+		Expect(run("rbac-001", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.AcceptedCheckResult("Group is granted wildcard verbs or resources by default bindings of Kubernetes.", mastersTarget),
+			rule.FailedCheckResult("Service account is granted wildcard verbs or resources.", clusterTarget.With("kind", "serviceAccount", "name", "backdoor", "namespace", "kube-system", "details", `ClusterRoleBinding system:controller:backdoor binds ClusterRole system:controller:backdoor which grants verbs [*] on resources [*] of api groups ["*"] cluster-wide, the binding has the label kubernetes.io/bootstrapping=rbac-defaults but is not a default binding of Kubernetes`)),
+		}))
+	})
+
+	It("should report unused service accounts with powerful permissions", func() {
+		Expect(run("rbac-007", nil).CheckResults).To(Equal([]rule.CheckResult{
+			rule.FailedCheckResult("Service account is not used by any pod and is granted powerful permissions.", robotTarget.With("details", `ClusterRoleBinding secret-reader binds ClusterRole secret-reader which grants verbs [get, list] on resources [secrets] of api groups [""] cluster-wide`)),
+		}))
+	})
+})
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/rule"
+	"github.com/gardener/diki/pkg/ruleset"
+	sharedruleset "github.com/gardener/diki/pkg/shared/ruleset"
+)
+
+const (
+	// RulesetID is a constant containing the id of a RBAC Analysis Ruleset
+	RulesetID = "rbac-analysis"
+)
+
+var _ ruleset.Ruleset = &Ruleset{}
+
+// Ruleset analyses the ClusterRoles, Roles and bindings of a cluster
+// and reports subjects which are granted risky permissions.
+type Ruleset struct {
+	version    string
+	rules      map[string]rule.Rule
+	numWorkers int
+	logger     *slog.Logger
+}
+
+// New creates a new Ruleset.
+func New(options ...CreateOption) (*Ruleset, error) {
+	r := &Ruleset{
+		rules:      map[string]rule.Rule{},
+		numWorkers: 5,
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r, nil
+}
+
+// ID returns the id of the Ruleset.
+func (r *Ruleset) ID() string {
+	return RulesetID
+}
+
+// Name returns the name of the Ruleset.
+func (r *Ruleset) Name() string {
+	return "RBAC Analysis"
+}
+
+// Version returns the version of the Ruleset.
+func (r *Ruleset) Version() string {
+	return r.version
+}
+
+// FromGenericConfig creates a Ruleset from a RulesetConfig. The rules analyse
+// the RBAC objects of the cluster of clusterConfig which is described by clusterTarget.
+func FromGenericConfig(rulesetConfig config.RulesetConfig, clusterConfig *rest.Config, clusterTarget rule.Target) (*Ruleset, error) {
+	ruleset, err := New(WithVersion(rulesetConfig.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOptions := map[string]config.RuleOptionsConfig{}
+	for _, opt := range rulesetConfig.RuleOptions {
+		if _, ok := ruleOptions[opt.RuleID]; ok {
+			return nil, fmt.Errorf("rule option for rule id: %s is already registered", opt.RuleID)
+		}
+
+		ruleOptions[opt.RuleID] = opt
+	}
+
+	c, err := client.New(clusterConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := Rules(c, clusterTarget, ruleOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ruleset.AddRules(sharedruleset.SkipRules(rules, ruleOptions)...); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+var (
+	wildcard = func(g Grant) bool {
+		return slices.Contains(g.Rule.Verbs, rbacv1.VerbAll) || slices.Contains(g.Rule.Resources, rbacv1.ResourceAll)
+	}
+	privilegeEscalation = func(g Grant) bool {
+		return g.Allows(rbacv1.GroupName, "clusterroles", "escalate", "bind") ||
+			g.Allows(rbacv1.GroupName, "roles", "escalate", "bind") ||
+			g.Allows("", "users", "impersonate") ||
+			g.Allows("", "groups", "impersonate") ||
+			g.Allows("", "serviceaccounts", "impersonate")
+	}
+	clusterWideSecretsRead = func(g Grant) bool {
+		return g.ClusterWide() && len(g.Rule.ResourceNames) == 0 && g.Allows("", "secrets", "get", "list", "watch")
+	}
+	podsExec = func(g Grant) bool {
+		return g.Allows("", "pods/exec", "create", "get")
+	}
+	nodesProxy = func(g Grant) bool {
+		return g.Allows("", "nodes/proxy", "get", "list", "watch", "create", "update", "patch", "delete")
+	}
+)
+
+// RuleIDs are the ids of the rules of the ruleset.
+var RuleIDs = []string{"rbac-001", "rbac-002", "rbac-003", "rbac-004", "rbac-005", "rbac-006", "rbac-007"}
+
+// Rules creates the rules of the ruleset which analyse the RBAC objects listed by the client.
+func Rules(c client.Client, clusterTarget rule.Target, ruleOptions map[string]config.RuleOptionsConfig) ([]rule.Rule, error) {
+	l := newLoader(c)
+	options := map[string]*Options{}
+	for _, id := range RuleIDs {
+		o, err := parseOptions(ruleOptions[id].Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse options of rule %s: %w", id, err)
+		}
+		options[id] = o
+	}
+
+	subjectRule := func(id, name, permission string, matches func(Grant) bool) rule.Rule {
+		return &SubjectRule{id: id, name: name, permission: permission, matches: matches, loader: l, target: clusterTarget, options: options[id]}
+	}
+
+	return []rule.Rule{
+		subjectRule("rbac-001", "Subjects must not be granted wildcard verbs or resources (HIGH rbac-001)", "wildcard verbs or resources", wildcard),
+		subjectRule("rbac-002", "Subjects must not be granted the escalate, bind or impersonate verbs (HIGH rbac-002)", "the escalate, bind or impersonate verbs", privilegeEscalation),
+		subjectRule("rbac-003", "Subjects must not be able to read secrets cluster-wide (HIGH rbac-003)", "read access to secrets cluster-wide", clusterWideSecretsRead),
+		subjectRule("rbac-004", "Subjects must not be able to execute commands in pods (MEDIUM rbac-004)", "access to pods/exec", podsExec),
+		subjectRule("rbac-005", "Subjects must not be able to access the kubelet API via nodes/proxy (HIGH rbac-005)", "access to nodes/proxy", nodesProxy),
+		&BindingRule{
+			id:      "rbac-006",
+			name:    "Bindings must not grant permissions to system:anonymous or system:authenticated (HIGH rbac-006)",
+			loader:  l,
+			target:  clusterTarget,
+			options: options["rbac-006"],
+		},
+		&UnusedServiceAccountRule{
+			id:       "rbac-007",
+			name:     "Service accounts which are not used by any pod must not be granted powerful permissions (MEDIUM rbac-007)",
+			powerful: []func(Grant) bool{wildcard, privilegeEscalation, clusterWideSecretsRead, podsExec, nodesProxy},
+			loader:   l,
+			target:   clusterTarget,
+			options:  options["rbac-007"],
+		},
+	}, nil
+}
+
+func parseOptions(args any) (*Options, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	argsByte, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var options Options
+	if err := json.Unmarshal(argsByte, &options); err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// RunRule executes specific known Rule of the Ruleset.
+func (r *Ruleset) RunRule(ctx context.Context, id string) (rule.RuleResult, error) {
+	rr, ok := r.rules[id]
+	if !ok {
+		return rule.RuleResult{}, fmt.Errorf("rule with id %s is not registered in the ruleset", id)
+	}
+
+	return rr.Run(ctx)
+}
+
+// Run executes all known Rules of the Ruleset.
+func (r *Ruleset) Run(ctx context.Context) (ruleset.RulesetResult, error) {
+	return sharedruleset.Run(ctx, r, r.rules, r.numWorkers, r.Logger())
+}
+
+// AddRules adds Rules to the Ruleset.
+func (r *Ruleset) AddRules(rules ...rule.Rule) error {
+	for _, rr := range rules {
+		if _, ok := r.rules[rr.ID()]; ok {
+			return fmt.Errorf("rule with id %s already exists", rr.ID())
+		}
+		r.rules[rr.ID()] = rr
+	}
+	return nil
+}
+
+// Logger returns the Ruleset's logger.
+// If not set it set it to slog.Default().With("ruleset", r.ID(), "version", r.Version() then return it.
+func (r *Ruleset) Logger() *slog.Logger {
+	if r.logger == nil {
+		r.logger = slog.Default().With("ruleset", r.ID(), "version", r.Version())
+	}
+	return r.logger
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/rule"
 	"github.com/gardener/diki/pkg/ruleset"
 	"github.com/gardener/diki/pkg/shared/provider"
@@ -38,6 +39,9 @@ func Run(
 		RulesetVersion: r.Version(),
 		RuleResults:    make([]rule.RuleResult, 0, len(rules)),
 	}
+	if tailored, ok := r.(ruleset.Tailored); ok {
+		result.Tailoring = tailored.Tailoring()
+	}
 
 	type run struct {
 		result rule.RuleResult
@@ -94,3 +98,22 @@ func Run(
 	}
 	return result, nil
 }
+
+// SkipRules replaces the rules which are skipped by their rule options with rules
+// that are accepted with the justification of the rule options.
+func SkipRules(rules []rule.Rule, ruleOptions map[string]config.RuleOptionsConfig) []rule.Rule {
+	result := make([]rule.Rule, 0, len(rules))
+	for _, r := range rules {
+		opt, found := ruleOptions[r.ID()]
+		if found && opt.Skip != nil && opt.Skip.Enabled {
+			r = rule.NewSkipRule(r.ID(), r.Name(), opt.Skip.Justification, rule.Accepted)
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// RuleGetter returns the rules of a ruleset by their id.
+type RuleGetter interface {
+	Rule(id string) (rule.Rule, bool)
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Advisory is a vulnerability advisory in the OSV format.
+// Only the fields which are used for offline matching are decoded.
+type Advisory struct {
+	ID               string           `json:"id"`
+	Aliases          []string         `json:"aliases"`
+	Withdrawn        string           `json:"withdrawn"`
+	Severity         []SeverityScore  `json:"severity"`
+	Affected         []Affected       `json:"affected"`
+	DatabaseSpecific DatabaseSpecific `json:"database_specific"`
+}
+
+// SeverityScore is a severity score of an advisory, e.g. a CVSS_V3 vector.
+type SeverityScore struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// DatabaseSpecific contains the database specific fields of an advisory.
+type DatabaseSpecific struct {
+	// Severity is the qualitative severity assigned by the database, e.g. CRITICAL.
+	Severity string `json:"severity"`
+}
+
+// Affected describes the affected versions of a package.
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []Range  `json:"ranges"`
+	Versions []string `json:"versions"`
+}
+
+// Package identifies a package of an ecosystem.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	PURL      string `json:"purl"`
+}
+
+// Range is a range of affected versions described by events.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is an event of a range. Exactly one of its fields is set.
+type Event struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+	Limit        string `json:"limit"`
+}
+
+// ReadAdvisories reads the OSV advisories of a file or of all JSON files of a directory.
+// A file contains a single advisory or a list of advisories.
+func ReadAdvisories(path string) ([]Advisory, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files = files[:0]
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), ".json") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	var advisories []Advisory
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+
+		fileAdvisories, err := decodeAdvisories(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode advisories of file %s: %w", file, err)
+		}
+		advisories = append(advisories, fileAdvisories...)
+	}
+	return advisories, nil
+}
+
+func decodeAdvisories(data []byte) ([]Advisory, error) {
+	var advisories []Advisory
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &advisories); err != nil {
+			return nil, err
+		}
+		return advisories, nil
+	}
+
+	var advisory Advisory
+	if err := json.Unmarshal(data, &advisory); err != nil {
+		return nil, err
+	}
+	return append(advisories, advisory), nil
+}
+
+// affects returns whether the version of a package is affected.
+func (a Affected) affects(version string) bool {
+	for _, v := range a.Versions {
+		if CompareVersions(v, version) == 0 {
+			return true
+		}
+	}
+
+	for _, r := range a.Ranges {
+		// git ranges describe commits which cannot be matched against package versions
+		if r.Type != "SEMVER" && r.Type != "ECOSYSTEM" {
+			continue
+		}
+		if r.affects(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// affects evaluates the events of the range in ascending order of their versions.
+// The version is affected when the last event which is not greater than the
+// version introduces the vulnerability.
+func (r Range) affects(version string) bool {
+	var (
+		affected bool
+		events   = make([]Event, len(r.Events))
+	)
+	copy(events, r.Events)
+	sortEvents(events)
+
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || CompareVersions(e.Introduced, version) <= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if CompareVersions(e.Fixed, version) <= 0 {
+				affected = false
+			}
+		case e.Limit != "":
+			if CompareVersions(e.Limit, version) <= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if CompareVersions(e.LastAffected, version) < 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+func (e Event) version() string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	case e.LastAffected != "":
+		return e.LastAffected
+	default:
+		return e.Limit
+	}
+}
+
+func sortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Introduced == "0" || b.Introduced == "0" {
+			return a.Introduced == "0" && b.Introduced != "0"
+		}
+		return CompareVersions(a.version(), b.version()) < 0
+	})
+}
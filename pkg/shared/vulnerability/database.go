@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability
+
+import (
+	"sort"
+	"strings"
+)
+
+// packageKey identifies a package across the releases of its ecosystem.
+type packageKey struct {
+	ecosystem string
+	name      string
+}
+
+// affectedPackage is a package which is affected by an advisory.
+type affectedPackage struct {
+	advisory *Advisory
+	affected Affected
+	// release is the release of the ecosystem, e.g. 12 for the Debian:12 ecosystem.
+	release string
+}
+
+// Database matches components against OSV advisories offline.
+type Database struct {
+	packages map[packageKey][]affectedPackage
+}
+
+// Finding is a component which is affected by an advisory.
+type Finding struct {
+	Advisory  Advisory
+	Component Component
+	Severity  Severity
+}
+
+// NewDatabase creates a Database of the advisories. Withdrawn advisories
+// and packages of unknown ecosystems are ignored.
+func NewDatabase(advisories []Advisory) *Database {
+	d := &Database{packages: map[packageKey][]affectedPackage{}}
+	for i := range advisories {
+		advisory := &advisories[i]
+		if advisory.Withdrawn != "" {
+			continue
+		}
+
+		for _, affected := range advisory.Affected {
+			key, release, ok := affectedKey(affected.Package)
+			if !ok {
+				continue
+			}
+			d.packages[key] = append(d.packages[key], affectedPackage{advisory: advisory, affected: affected, release: release})
+		}
+	}
+	return d
+}
+
+// affectedKey returns the key and the release of an affected package. The ecosystem and the name
+// of the package are used when they are set, otherwise the ones of the package URL.
+func affectedKey(p Package) (packageKey, string, bool) {
+	if p.Ecosystem != "" && p.Name != "" {
+		ecosystem, release, _ := strings.Cut(p.Ecosystem, ":")
+		release, _, _ = strings.Cut(release, ":")
+		return packageKey{ecosystem: ecosystem, name: p.Name}, strings.TrimPrefix(release, "v"), true
+	}
+
+	c, err := ParsePURL(p.PURL)
+	if err != nil || c.ecosystem() == "" {
+		return packageKey{}, "", false
+	}
+	return packageKey{ecosystem: c.ecosystem(), name: c.packageName()}, c.release(), true
+}
+
+// Match returns the findings of the components sorted by the id of the advisories.
+func (d *Database) Match(components []Component) []Finding {
+	var (
+		findings []Finding
+		found    = map[string]bool{}
+	)
+	for _, c := range components {
+		ecosystem := c.ecosystem()
+		if ecosystem == "" {
+			continue
+		}
+
+		for _, p := range d.packages[packageKey{ecosystem: ecosystem, name: c.packageName()}] {
+			if !sameRelease(p.release, c.release()) || !p.affected.affects(c.Version) {
+				continue
+			}
+
+			key := p.advisory.ID + "|" + c.String()
+			if found[key] {
+				continue
+			}
+			found[key] = true
+			findings = append(findings, Finding{Advisory: *p.advisory, Component: c, Severity: AdvisorySeverity(*p.advisory)})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Advisory.ID != findings[j].Advisory.ID {
+			return findings[i].Advisory.ID < findings[j].Advisory.ID
+		}
+		return findings[i].Component.String() < findings[j].Component.String()
+	})
+	return findings
+}
+
+// sameRelease returns whether the release of an advisory matches the release of a component.
+// Releases are only compared when both are versions, e.g. 3.19 matches 3.19.1, but codenames
+// like bookworm cannot be compared and match every release.
+func sameRelease(advisoryRelease, componentRelease string) bool {
+	if !startsWithDigit(advisoryRelease) || !startsWithDigit(componentRelease) {
+		return true
+	}
+	return componentRelease == advisoryRelease || strings.HasPrefix(componentRelease, advisoryRelease+".")
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/shared/vulnerability"
+)
+
+var _ = Describe("Database", func() {
+	const (
+		advisories = `[
+  {
+    "id": "DSA-0001",
+    "database_specific": {"severity": "CRITICAL"},
+    "affected": [{
+      "package": {"ecosystem": "Debian:12", "name": "openssl"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.11-1~deb12u2"}]}]
+    }]
+  },
+  {
+    "id": "DSA-0002",
+    "database_specific": {"severity": "HIGH"},
+    "affected": [{
+      "package": {"ecosystem": "Debian:11", "name": "openssl"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "9.9.9"}]}]
+    }]
+  },
+  {
+    "id": "GO-0001",
+    "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"}],
+    "affected": [{
+      "package": {"purl": "pkg:golang/golang.org/x/net"},
+      "ranges": [{"type": "SEMVER", "events": [{"introduced": "0.10.0"}, {"fixed": "0.17.0"}, {"introduced": "0.18.0"}, {"last_affected": "0.19.0"}]}]
+    }]
+  },
+  {
+    "id": "GO-0002",
+    "withdrawn": "2024-01-01T00:00:00Z",
+    "affected": [{"package": {"ecosystem": "Go", "name": "golang.org/x/net"}, "versions": ["0.18.0"]}]
+  }
+]`
+		cycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"name": "openssl", "purl": "pkg:deb/debian/openssl@3.0.11-1~deb12u1?arch=amd64&distro=debian-12"},
+    {"name": "app", "components": [
+      {"name": "golang.org/x/net", "purl": "pkg:golang/golang.org/x/net@v0.18.0"},
+      {"name": "unversioned", "purl": "pkg:golang/example.com/foo"}
+    ]}
+  ]
+}`
+		spdx = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "net", "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/golang.org/x/net@v0.17.0"}]},
+    {"name": "openssl", "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:deb/debian/openssl@3.0.11-1~deb12u2?distro=debian-12"}]}
+  ]
+}`
+	)
+
+	var (
+		dir      string
+		database *vulnerability.Database
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "advisories.json"), []byte(advisories), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "cyclonedx.json"), []byte(cycloneDX), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "spdx.json"), []byte(spdx), 0600)).To(Succeed())
+
+		a, err := vulnerability.ReadAdvisories(filepath.Join(dir, "advisories.json"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(a).To(HaveLen(4))
+		database = vulnerability.NewDatabase(a)
+	})
+
+	It("should match the components of a CycloneDX SBOM", func() {
+		components, err := vulnerability.ReadSBOM(filepath.Join(dir, "cyclonedx.json"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(components).To(HaveLen(2))
+
+		findings := database.Match(components)
+		Expect(findings).To(HaveLen(2))
+		Expect(findings[0].Advisory.ID).To(Equal("DSA-0001"))
+		Expect(findings[0].Component.String()).To(Equal("openssl@3.0.11-1~deb12u1"))
+		Expect(findings[0].Severity).To(Equal(vulnerability.SeverityCritical))
+		Expect(findings[1].Advisory.ID).To(Equal("GO-0001"))
+		Expect(findings[1].Component.String()).To(Equal("golang.org/x/net@v0.18.0"))
+		Expect(findings[1].Severity).To(Equal(vulnerability.SeverityHigh))
+	})
+
+	It("should not match fixed versions of an SPDX SBOM", func() {
+		components, err := vulnerability.ReadSBOM(filepath.Join(dir, "spdx.json"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(components).To(HaveLen(2))
+		Expect(database.Match(components)).To(BeEmpty())
+	})
+
+	It("should read the advisories of a directory", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "single.json"), []byte(`{"id": "GHSA-0001"}`), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("foo"), 0600)).To(Succeed())
+		Expect(os.Remove(filepath.Join(dir, "cyclonedx.json"))).To(Succeed())
+		Expect(os.Remove(filepath.Join(dir, "spdx.json"))).To(Succeed())
+
+		a, err := vulnerability.ReadAdvisories(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(a).To(HaveLen(5))
+	})
+
+	It("should parse package URLs", func() {
+		Expect(vulnerability.ParsePURL("pkg:npm/%40angular/core@17.0.0")).To(Equal(vulnerability.Component{
+			Type: "npm", Namespace: "@angular", Name: "core", Version: "17.0.0", Qualifiers: map[string]string{},
+		}))
+		_, err := vulnerability.ParsePURL("npm/foo")
+		Expect(err).To(MatchError("package URL npm/foo does not have the pkg scheme"))
+	})
+})
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// purlEcosystems maps package URL types to OSV ecosystems. Types of Linux distributions
+// are mapped by the namespace of the package URL, i.e. the name of the distribution.
+var purlEcosystems = map[string]string{
+	"golang":   "Go",
+	"npm":      "npm",
+	"pypi":     "PyPI",
+	"maven":    "Maven",
+	"cargo":    "crates.io",
+	"gem":      "RubyGems",
+	"nuget":    "NuGet",
+	"composer": "Packagist",
+	"hex":      "Hex",
+	"pub":      "Pub",
+}
+
+// distributionEcosystems maps the Linux distributions of deb, apk and rpm package URLs to OSV ecosystems.
+var distributionEcosystems = map[string]string{
+	"debian":     "Debian",
+	"ubuntu":     "Ubuntu",
+	"alpine":     "Alpine",
+	"wolfi":      "Wolfi",
+	"chainguard": "Chainguard",
+	"opensuse":   "openSUSE",
+	"suse":       "SUSE",
+	"redhat":     "Red Hat",
+	"rocky":      "Rocky Linux",
+	"almalinux":  "AlmaLinux",
+}
+
+// Component is a versioned package of an SBOM identified by its package URL.
+type Component struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers map[string]string
+}
+
+// ParsePURL parses a package URL, e.g. pkg:deb/debian/openssl@3.0.11-1~deb12u2?distro=debian-12.
+func ParsePURL(purl string) (Component, error) {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return Component{}, fmt.Errorf("package URL %s does not have the pkg scheme", purl)
+	}
+	rest, _, _ = strings.Cut(rest, "#")
+
+	c := Component{Qualifiers: map[string]string{}}
+	rest, qualifiers, _ := strings.Cut(rest, "?")
+	for _, qualifier := range strings.Split(qualifiers, "&") {
+		if key, value, ok := strings.Cut(qualifier, "="); ok {
+			c.Qualifiers[strings.ToLower(key)], _ = url.QueryUnescape(value)
+		}
+	}
+
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest, c.Version = rest[:i], unescape(rest[i+1:])
+	}
+
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(segments) < 2 {
+		return Component{}, fmt.Errorf("package URL %s does not have a type and a name", purl)
+	}
+	for i := range segments {
+		segments[i] = unescape(segments[i])
+	}
+	c.Type = strings.ToLower(segments[0])
+	c.Name = segments[len(segments)-1]
+	c.Namespace = strings.Join(segments[1:len(segments)-1], "/")
+	return c, nil
+}
+
+func unescape(s string) string {
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
+
+// ecosystem returns the OSV ecosystem of the component or an empty string if it is unknown.
+func (c Component) ecosystem() string {
+	switch c.Type {
+	case "deb", "apk", "rpm":
+		return distributionEcosystems[strings.ToLower(c.Namespace)]
+	default:
+		return purlEcosystems[c.Type]
+	}
+}
+
+// packageName returns the name of the component in its OSV ecosystem.
+func (c Component) packageName() string {
+	switch {
+	case c.Namespace == "":
+		return c.Name
+	case c.Type == "golang" || c.Type == "npm" || c.Type == "composer":
+		return c.Namespace + "/" + c.Name
+	case c.Type == "maven":
+		return c.Namespace + ":" + c.Name
+	default:
+		return c.Name
+	}
+}
+
+// release returns the release of the Linux distribution of the component, e.g. 12 for debian-12.
+func (c Component) release() string {
+	distro := c.Qualifiers["distro"]
+	if i := strings.LastIndex(distro, "-"); i >= 0 {
+		return distro[i+1:]
+	}
+	return distro
+}
+
+// String returns the name and the version of the component.
+func (c Component) String() string {
+	return c.packageName() + "@" + c.Version
+}
+
+// sbom contains the fields of CycloneDX and SPDX JSON documents which reference package URLs.
+type sbom struct {
+	// Components are the components of a CycloneDX document.
+	Components []cycloneDXComponent `json:"components"`
+	// Packages are the packages of an SPDX document.
+	Packages []spdxPackage `json:"packages"`
+}
+
+type cycloneDXComponent struct {
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type spdxPackage struct {
+	ExternalRefs []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+// ReadSBOM reads the components of a CycloneDX or SPDX JSON document.
+// Components without a versioned package URL are ignored.
+func ReadSBOM(file string) ([]Component, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+
+	var doc sbom
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode SBOM %s: %w", file, err)
+	}
+
+	var purls []string
+	var collect func([]cycloneDXComponent)
+	collect = func(components []cycloneDXComponent) {
+		for _, c := range components {
+			purls = append(purls, c.PURL)
+			collect(c.Components)
+		}
+	}
+	collect(doc.Components)
+	for _, p := range doc.Packages {
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				purls = append(purls, ref.ReferenceLocator)
+			}
+		}
+	}
+
+	var components []Component
+	for _, purl := range purls {
+		c, err := ParsePURL(purl)
+		if err != nil || c.Version == "" {
+			continue
+		}
+		components = append(components, c)
+	}
+	return components, nil
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Severity is the qualitative severity of a vulnerability.
+type Severity int
+
+const (
+	// SeverityUnknown is the severity of advisories without a known severity.
+	SeverityUnknown Severity = iota
+	// SeverityLow is the LOW severity.
+	SeverityLow
+	// SeverityMedium is the MEDIUM severity.
+	SeverityMedium
+	// SeverityHigh is the HIGH severity.
+	SeverityHigh
+	// SeverityCritical is the CRITICAL severity.
+	SeverityCritical
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "LOW"
+	case SeverityMedium:
+		return "MEDIUM"
+	case SeverityHigh:
+		return "HIGH"
+	case SeverityCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseSeverity parses a severity name. MODERATE is parsed as MEDIUM.
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "LOW":
+		return SeverityLow, nil
+	case "MEDIUM", "MODERATE":
+		return SeverityMedium, nil
+	case "HIGH":
+		return SeverityHigh, nil
+	case "CRITICAL":
+		return SeverityCritical, nil
+	default:
+		return SeverityUnknown, fmt.Errorf("unknown severity %s", name)
+	}
+}
+
+// AdvisorySeverity returns the severity of an advisory. The severity assigned by the database is used
+// when it is set, otherwise the highest severity of the CVSS v3 base scores of the advisory.
+func AdvisorySeverity(a Advisory) Severity {
+	if s, err := ParseSeverity(a.DatabaseSpecific.Severity); err == nil {
+		return s
+	}
+
+	severity := SeverityUnknown
+	for _, score := range a.Severity {
+		if score.Type != "CVSS_V3" {
+			continue
+		}
+		baseScore, err := CVSS3BaseScore(score.Score)
+		if err != nil {
+			continue
+		}
+		if s := severityFromScore(baseScore); s > severity {
+			severity = s
+		}
+	}
+	return severity
+}
+
+func severityFromScore(score float64) Severity {
+	switch {
+	case score >= 9.0:
+		return SeverityCritical
+	case score >= 7.0:
+		return SeverityHigh
+	case score >= 4.0:
+		return SeverityMedium
+	case score > 0:
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
+
+// cvss3Weights are the weights of the base metric values of CVSS v3.
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"S":  {"U": 0, "C": 0},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// CVSS3BaseScore calculates the base score of a CVSS v3.0 or v3.1 vector,
+// e.g. CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H.
+func CVSS3BaseScore(vector string) (float64, error) {
+	parts := strings.Split(vector, "/")
+	if len(parts) == 0 || !strings.HasPrefix(parts[0], "CVSS:3.") {
+		return 0, fmt.Errorf("vector %s is not a CVSS v3 vector", vector)
+	}
+
+	metrics := map[string]string{}
+	for _, part := range parts[1:] {
+		metric, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return 0, fmt.Errorf("invalid metric %s of vector %s", part, vector)
+		}
+		metrics[metric] = value
+	}
+
+	weights := map[string]float64{}
+	for metric, values := range cvss3Weights {
+		weight, ok := values[metrics[metric]]
+		if !ok {
+			return 0, fmt.Errorf("vector %s has an invalid or missing base metric %s", vector, metric)
+		}
+		weights[metric] = weight
+	}
+
+	scopeChanged := metrics["S"] == "C"
+	if scopeChanged {
+		// privileges have a higher weight when the scope is changed
+		switch metrics["PR"] {
+		case "L":
+			weights["PR"] = 0.68
+		case "H":
+			weights["PR"] = 0.5
+		}
+	}
+
+	iss := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, nil
+	}
+
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * weights["PR"] * weights["UI"]
+	if scopeChanged {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// roundUp returns the smallest number with one decimal place which is equal to or higher than
+// the input, avoiding floating point errors like the CVSS v3.1 specification.
+func roundUp(f float64) float64 {
+	i := int(math.Round(f * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return float64(i/10000+1) / 10
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/shared/vulnerability"
+)
+
+var _ = Describe("severity", func() {
+	DescribeTable("#CVSS3BaseScore",
+		func(vector string, expected float64) {
+			Expect(vulnerability.CVSS3BaseScore(vector)).To(Equal(expected))
+		},
+		Entry("critical", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8),
+		Entry("changed scope", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10.0),
+		Entry("high", "CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:H/A:H", 7.8),
+		Entry("medium", "CVSS:3.0/AV:N/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N", 6.5),
+		Entry("medium with changed scope", "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1),
+		Entry("no impact", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0.0),
+	)
+
+	It("should return an error for invalid vectors", func() {
+		_, err := vulnerability.CVSS3BaseScore("CVSS:2.0/AV:N")
+		Expect(err).To(MatchError("vector CVSS:2.0/AV:N is not a CVSS v3 vector"))
+
+		_, err = vulnerability.CVSS3BaseScore("CVSS:3.1/AV:N/AC:L")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should determine the severity of advisories", func() {
+		Expect(vulnerability.AdvisorySeverity(vulnerability.Advisory{
+			DatabaseSpecific: vulnerability.DatabaseSpecific{Severity: "MODERATE"},
+			Severity:         []vulnerability.SeverityScore{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}},
+		})).To(Equal(vulnerability.SeverityMedium))
+		Expect(vulnerability.AdvisorySeverity(vulnerability.Advisory{
+			Severity: []vulnerability.SeverityScore{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}},
+		})).To(Equal(vulnerability.SeverityCritical))
+		Expect(vulnerability.AdvisorySeverity(vulnerability.Advisory{})).To(Equal(vulnerability.SeverityUnknown))
+	})
+})
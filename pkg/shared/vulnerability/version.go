@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability
+
+import (
+	"strings"
+)
+
+// CompareVersions compares two package versions and returns -1, 0 or 1 when a is lower than,
+// equal to or greater than b. The versions are compared segment by segment where numeric
+// segments are compared by their value and all other segments lexically. A tilde sorts
+// before everything, even the end of the version, like in Debian versions.
+// This approximates the version ordering of most ecosystems without knowing their rules.
+func CompareVersions(a, b string) int {
+	a, b = strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v")
+	for a != "" || b != "" {
+		var segA, segB string
+		segA, a = nextSegment(a)
+		segB, b = nextSegment(b)
+
+		if c := compareSegments(segA, segB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// nextSegment splits the first segment of digits, of tildes or of other characters from the version.
+func nextSegment(version string) (string, string) {
+	if version == "" {
+		return "", ""
+	}
+
+	class := segmentClass(version[0])
+	i := 1
+	for i < len(version) && segmentClass(version[i]) == class {
+		i++
+	}
+	return version[:i], version[i:]
+}
+
+func segmentClass(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return 0
+	case c == '~':
+		return 1
+	default:
+		return 2
+	}
+}
+
+func compareSegments(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~"):
+		// a tilde sorts before everything, even the end of the version
+		if strings.HasPrefix(a, "~") && strings.HasPrefix(b, "~") {
+			return compareInts(len(b), len(a))
+		}
+		if strings.HasPrefix(a, "~") {
+			return -1
+		}
+		return 1
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+
+	aNumeric, bNumeric := segmentClass(a[0]) == 0, segmentClass(b[0]) == 0
+	switch {
+	case aNumeric && bNumeric:
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if c := compareInts(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		// numeric segments are greater than other segments, e.g. 1.0.1 > 1.0-rc1
+		return 1
+	case bNumeric:
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
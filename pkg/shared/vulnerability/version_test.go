@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vulnerability_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki/pkg/shared/vulnerability"
+)
+
+var _ = Describe("#CompareVersions", func() {
+	DescribeTable("should compare versions",
+		func(a, b string, expected int) {
+			Expect(vulnerability.CompareVersions(a, b)).To(Equal(expected))
+			Expect(vulnerability.CompareVersions(b, a)).To(Equal(-expected))
+		},
+		Entry("equal versions", "1.2.3", "1.2.3", 0),
+		Entry("v prefix", "v1.2.3", "1.2.3", 0),
+		Entry("numeric segments", "1.10.0", "1.9.0", 1),
+		Entry("leading zeros", "1.02", "1.2", 0),
+		Entry("longer version", "1.2.3.1", "1.2.3", 1),
+		Entry("pre-release", "1.2.3-rc1", "1.2.3", 1),
+		Entry("numeric segment after pre-release", "1.2.3.1", "1.2.3-rc1", 1),
+		Entry("debian revisions", "3.0.11-1~deb12u2", "3.0.11-1~deb12u1", 1),
+		Entry("tilde before end of version", "3.0.11-1~deb12u2", "3.0.11-1", -1),
+		Entry("alpine revisions", "3.1.4-r5", "3.1.4-r6", -1),
+	)
+})